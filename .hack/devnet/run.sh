@@ -26,6 +26,16 @@ EXECUTION_NODES=$(docker ps -aq -f "label=enclave_uuid=$ENCLAVE_UUID" \
               -f "label=com.kurtosistech.app-id=kurtosis" \
               -f "label=com.kurtosistech.custom.ethereum-package.client-type=execution" | tac)
 
+## Generate endpoint files (picked up by dora's endpoint discovery, re-run this script after scaling the enclave)
+mkdir -p "${__dir}/generated-endpoints"
+cat <<EOF > "${__dir}/generated-endpoints/kurtosis.yaml.tmp"
+beaconapi:
+$(docker inspect -f "  - { name: {{ with index .Config.Labels \"com.kurtosistech.id\"}}{{.}}{{end}}, url: http://{{ with index .NetworkSettings.Networks \"kt-$ENCLAVE_NAME\"}}{{.IPAddress }}:4000{{end}} }" $BEACON_NODES)
+executionapi:
+$(docker inspect -f "  - { name: {{ with index .Config.Labels \"com.kurtosistech.id\"}}{{.}}{{end}}, url: http://{{ with index .NetworkSettings.Networks \"kt-$ENCLAVE_NAME\"}}{{.IPAddress }}:8545{{end}} }"  $EXECUTION_NODES)
+EOF
+mv "${__dir}/generated-endpoints/kurtosis.yaml.tmp" "${__dir}/generated-endpoints/kurtosis.yaml"
+
 cat <<EOF > "${__dir}/generated-dora-config.yaml"
 logging:
   outputLevel: "info"
@@ -47,12 +57,14 @@ beaconapi:
   localCacheSize: 10
   redisCacheAddr: ""
   redisCachePrefix: ""
-  endpoints:
-$(docker inspect -f "    - { name: {{ with index .Config.Labels \"com.kurtosistech.id\"}}{{.}}{{end}}, url: http://{{ with index .NetworkSettings.Networks \"kt-$ENCLAVE_NAME\"}}{{.IPAddress }}:4000{{end}} }" $BEACON_NODES)
 executionapi:
   depositLogBatchSize: 1000
-  endpoints:
-$(docker inspect -f "    - { name: {{ with index .Config.Labels \"com.kurtosistech.id\"}}{{.}}{{end}}, url: http://{{ with index .NetworkSettings.Networks \"kt-$ENCLAVE_NAME\"}}{{.IPAddress }}:8545{{end}} }"  $EXECUTION_NODES)
+endpointDiscovery:
+  refreshInterval: 10s
+  sources:
+    - name: "kurtosis"
+      type: "directory"
+      path: "${__dir}/generated-endpoints"
 indexer:
   inMemoryEpochs: 8
   cachePersistenceDelay: 8
@@ -68,6 +80,7 @@ EOF
 cat <<EOF
 ============================================================================================================
 Dora config at ${__dir}/generated-dora-config.yaml
+Endpoints at ${__dir}/generated-endpoints
 Chain config at ${__dir}/generated-chain-config.yaml
 Database at ${__dir}/generated-database.sqlite
 ============================================================================================================
@@ -0,0 +1,215 @@
+package clienthealth
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// number of recent requests used to calculate the error & timeout rates
+	requestWindowSize = 50
+	// weight of the latest request in the rolling average latency
+	latencySmoothing = 0.2
+	// number of consecutive failures that opens the circuit breaker
+	circuitFailureThreshold = 5
+	// time the circuit stays open after the first trip, doubled on each consecutive trip
+	circuitBaseCooldown = 15 * time.Second
+	circuitMaxCooldown  = 5 * time.Minute
+	// clients with a score below this threshold are demoted behind all healthy clients
+	DegradedScoreThreshold = 50
+)
+
+type requestResult uint8
+
+const (
+	requestSucceeded requestResult = iota
+	requestFailed
+	requestTimedOut
+)
+
+// Tracker keeps track of the request latency, error rate & timeouts of a client and implements a simple circuit breaker.
+type Tracker struct {
+	mutex               sync.Mutex
+	results             [requestWindowSize]requestResult
+	resultIndex         int
+	resultCount         int
+	avgLatency          time.Duration
+	consecutiveFailures int
+	circuitTrips        int
+	circuitOpenUntil    time.Time
+	lastRequest         time.Time
+}
+
+// Stats is a snapshot of the tracked client health.
+type Stats struct {
+	AvgLatency          time.Duration
+	Requests            int
+	ErrorRate           float64
+	TimeoutRate         float64
+	ConsecutiveFailures int
+	CircuitOpen         bool
+	CircuitOpenUntil    time.Time
+	LastRequest         time.Time
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// RecordRequest records the result of a request to the client.
+// requests that have been cancelled by the caller are ignored as they do not reflect the client health.
+func (t *Tracker) RecordRequest(duration time.Duration, err error) {
+	if err != nil && errors.Is(err, context.Canceled) {
+		return
+	}
+
+	result := requestSucceeded
+	if err != nil {
+		if IsTimeoutError(err) {
+			result = requestTimedOut
+		} else {
+			result = requestFailed
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.results[t.resultIndex] = result
+	t.resultIndex = (t.resultIndex + 1) % requestWindowSize
+	if t.resultCount < requestWindowSize {
+		t.resultCount++
+	}
+	t.lastRequest = time.Now()
+
+	if result != requestFailed {
+		// timeouts count with their full duration, so slow clients get penalized too
+		if t.avgLatency == 0 {
+			t.avgLatency = duration
+		} else {
+			t.avgLatency = time.Duration(float64(t.avgLatency)*(1-latencySmoothing) + float64(duration)*latencySmoothing)
+		}
+	}
+
+	if result == requestSucceeded {
+		t.consecutiveFailures = 0
+		t.circuitTrips = 0
+		t.circuitOpenUntil = time.Time{}
+		return
+	}
+
+	t.consecutiveFailures++
+	if t.consecutiveFailures >= circuitFailureThreshold && time.Now().After(t.circuitOpenUntil) {
+		cooldown := circuitBaseCooldown * time.Duration(math.Pow(2, float64(t.circuitTrips)))
+		if cooldown > circuitMaxCooldown {
+			cooldown = circuitMaxCooldown
+		}
+		t.circuitTrips++
+		t.circuitOpenUntil = time.Now().Add(cooldown)
+	}
+}
+
+// IsCircuitOpen returns true if the client failed too often recently and should not be used for requests.
+// the circuit closes again after a cooldown, the next request decides if it stays closed.
+func (t *Tracker) IsCircuitOpen() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return time.Now().Before(t.circuitOpenUntil)
+}
+
+// GetStats returns a snapshot of the tracked client health.
+func (t *Tracker) GetStats() Stats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	failedCount := 0
+	timeoutCount := 0
+	for i := 0; i < t.resultCount; i++ {
+		switch t.results[i] {
+		case requestFailed:
+			failedCount++
+		case requestTimedOut:
+			timeoutCount++
+		}
+	}
+
+	stats := Stats{
+		AvgLatency:          t.avgLatency,
+		Requests:            t.resultCount,
+		ConsecutiveFailures: t.consecutiveFailures,
+		CircuitOpen:         time.Now().Before(t.circuitOpenUntil),
+		CircuitOpenUntil:    t.circuitOpenUntil,
+		LastRequest:         t.lastRequest,
+	}
+	if t.resultCount > 0 {
+		stats.ErrorRate = float64(failedCount+timeoutCount) / float64(t.resultCount)
+		stats.TimeoutRate = float64(timeoutCount) / float64(t.resultCount)
+	}
+
+	return stats
+}
+
+// GetScore returns the health score of the client between 0 (unusable) and 100 (perfectly healthy).
+// the head lag is the distance of the client head to the highest head of all clients (in slots or blocks).
+func (t *Tracker) GetScore(headLag uint64) float64 {
+	stats := t.GetStats()
+	if stats.CircuitOpen {
+		return 0
+	}
+
+	score := 100.0
+
+	// latency above 100ms costs 1 point per 50ms
+	if latencyMs := float64(stats.AvgLatency.Milliseconds()); latencyMs > 100 {
+		score -= math.Min((latencyMs-100)/50, 30)
+	}
+
+	// errors cost up to 40 points, timeouts up to 20 more
+	score -= stats.ErrorRate * 40
+	score -= stats.TimeoutRate * 20
+
+	// each slot / block behind the best client costs 5 points
+	score -= math.Min(float64(headLag)*5, 30)
+
+	if score < 0 {
+		score = 0
+	}
+
+	return score
+}
+
+// IsTimeoutError checks if the error is caused by a request timeout.
+func IsTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	errStr := err.Error()
+	return strings.Contains(errStr, "deadline exceeded") || strings.Contains(errStr, "Client.Timeout") || strings.Contains(errStr, "i/o timeout")
+}
+
+// IsDegraded returns true if the score is too low for the client to be preferred by its static priority.
+func IsDegraded(score float64) bool {
+	return score < DegradedScoreThreshold
+}
+
+// CompareScores compares the health scores of two clients for sorting.
+// scores are compared in buckets of 10 points, so clients with similar health are treated as equal and load can be balanced between them.
+// returns -1 if a is healthier, 1 if b is healthier or 0 if both are in the same bucket.
+func CompareScores(a float64, b float64) int {
+	aBucket := int(a / 10)
+	bBucket := int(b / 10)
+	switch {
+	case aBucket > bBucket:
+		return -1
+	case aBucket < bBucket:
+		return 1
+	default:
+		return 0
+	}
+}
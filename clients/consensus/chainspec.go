@@ -16,40 +16,45 @@ type ForkVersion struct {
 
 // https://github.com/ethereum/consensus-specs/blob/dev/configs/mainnet.yaml
 type ChainSpec struct {
-	PresetBase                   string            `yaml:"PRESET_BASE"`
-	ConfigName                   string            `yaml:"CONFIG_NAME"`
-	MinGenesisTime               time.Time         `yaml:"MIN_GENESIS_TIME"`
-	GenesisForkVersion           phase0.Version    `yaml:"GENESIS_FORK_VERSION"`
-	AltairForkVersion            phase0.Version    `yaml:"ALTAIR_FORK_VERSION"`
-	AltairForkEpoch              *uint64           `yaml:"ALTAIR_FORK_EPOCH"`
-	BellatrixForkVersion         phase0.Version    `yaml:"BELLATRIX_FORK_VERSION"`
-	BellatrixForkEpoch           *uint64           `yaml:"BELLATRIX_FORK_EPOCH"`
-	CappellaForkVersion          phase0.Version    `yaml:"CAPELLA_FORK_VERSION"`
-	CappellaForkEpoch            *uint64           `yaml:"CAPELLA_FORK_EPOCH"`
-	DenebForkVersion             phase0.Version    `yaml:"DENEB_FORK_VERSION"`
-	DenebForkEpoch               *uint64           `yaml:"DENEB_FORK_EPOCH"`
-	ElectraForkVersion           phase0.Version    `yaml:"ELECTRA_FORK_VERSION"`
-	ElectraForkEpoch             *uint64           `yaml:"ELECTRA_FORK_EPOCH"`
-	Eip7594ForkVersion           phase0.Version    `yaml:"EIP7594_FORK_VERSION"`
-	Eip7594ForkEpoch             *uint64           `yaml:"EIP7594_FORK_EPOCH"`
-	SecondsPerSlot               time.Duration     `yaml:"SECONDS_PER_SLOT"`
-	SlotsPerEpoch                uint64            `yaml:"SLOTS_PER_EPOCH"`
-	EpochsPerHistoricalVector    uint64            `yaml:"EPOCHS_PER_HISTORICAL_VECTOR"`
-	EpochsPerSlashingVector      uint64            `yaml:"EPOCHS_PER_SLASHINGS_VECTOR"`
-	EpochsPerSyncCommitteePeriod uint64            `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
-	MinSeedLookahead             uint64            `yaml:"MIN_SEED_LOOKAHEAD"`
-	ShuffleRoundCount            uint64            `yaml:"SHUFFLE_ROUND_COUNT"`
-	MaxEffectiveBalance          uint64            `yaml:"MAX_EFFECTIVE_BALANCE"`
-	MaxEffectiveBalanceElectra   uint64            `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA"`
-	TargetCommitteeSize          uint64            `yaml:"TARGET_COMMITTEE_SIZE"`
-	MaxCommitteesPerSlot         uint64            `yaml:"MAX_COMMITTEES_PER_SLOT"`
-	MinPerEpochChurnLimit        uint64            `yaml:"MIN_PER_EPOCH_CHURN_LIMIT"`
-	ChurnLimitQuotient           uint64            `yaml:"CHURN_LIMIT_QUOTIENT"`
-	DomainBeaconProposer         phase0.DomainType `yaml:"DOMAIN_BEACON_PROPOSER"`
-	DomainBeaconAttester         phase0.DomainType `yaml:"DOMAIN_BEACON_ATTESTER"`
-	DomainSyncCommittee          phase0.DomainType `yaml:"DOMAIN_SYNC_COMMITTEE"`
-	SyncCommitteeSize            uint64            `yaml:"SYNC_COMMITTEE_SIZE"`
-	DepositContractAddress       []byte            `yaml:"DEPOSIT_CONTRACT_ADDRESS"`
+	PresetBase                         string            `yaml:"PRESET_BASE"`
+	ConfigName                         string            `yaml:"CONFIG_NAME"`
+	MinGenesisTime                     time.Time         `yaml:"MIN_GENESIS_TIME"`
+	GenesisForkVersion                 phase0.Version    `yaml:"GENESIS_FORK_VERSION"`
+	AltairForkVersion                  phase0.Version    `yaml:"ALTAIR_FORK_VERSION"`
+	AltairForkEpoch                    *uint64           `yaml:"ALTAIR_FORK_EPOCH"`
+	BellatrixForkVersion               phase0.Version    `yaml:"BELLATRIX_FORK_VERSION"`
+	BellatrixForkEpoch                 *uint64           `yaml:"BELLATRIX_FORK_EPOCH"`
+	CappellaForkVersion                phase0.Version    `yaml:"CAPELLA_FORK_VERSION"`
+	CappellaForkEpoch                  *uint64           `yaml:"CAPELLA_FORK_EPOCH"`
+	DenebForkVersion                   phase0.Version    `yaml:"DENEB_FORK_VERSION"`
+	DenebForkEpoch                     *uint64           `yaml:"DENEB_FORK_EPOCH"`
+	ElectraForkVersion                 phase0.Version    `yaml:"ELECTRA_FORK_VERSION"`
+	ElectraForkEpoch                   *uint64           `yaml:"ELECTRA_FORK_EPOCH"`
+	Eip7594ForkVersion                 phase0.Version    `yaml:"EIP7594_FORK_VERSION"`
+	Eip7594ForkEpoch                   *uint64           `yaml:"EIP7594_FORK_EPOCH"`
+	SecondsPerSlot                     time.Duration     `yaml:"SECONDS_PER_SLOT"`
+	SlotsPerEpoch                      uint64            `yaml:"SLOTS_PER_EPOCH"`
+	SlotsPerHistoricalRoot             uint64            `yaml:"SLOTS_PER_HISTORICAL_ROOT"`
+	EpochsPerHistoricalVector          uint64            `yaml:"EPOCHS_PER_HISTORICAL_VECTOR"`
+	EpochsPerSlashingVector            uint64            `yaml:"EPOCHS_PER_SLASHINGS_VECTOR"`
+	EpochsPerSyncCommitteePeriod       uint64            `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+	MinSeedLookahead                   uint64            `yaml:"MIN_SEED_LOOKAHEAD"`
+	ShuffleRoundCount                  uint64            `yaml:"SHUFFLE_ROUND_COUNT"`
+	MaxEffectiveBalance                uint64            `yaml:"MAX_EFFECTIVE_BALANCE"`
+	MaxEffectiveBalanceElectra         uint64            `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA"`
+	TargetCommitteeSize                uint64            `yaml:"TARGET_COMMITTEE_SIZE"`
+	MaxCommitteesPerSlot               uint64            `yaml:"MAX_COMMITTEES_PER_SLOT"`
+	MinPerEpochChurnLimit              uint64            `yaml:"MIN_PER_EPOCH_CHURN_LIMIT"`
+	ChurnLimitQuotient                 uint64            `yaml:"CHURN_LIMIT_QUOTIENT"`
+	DomainBeaconProposer               phase0.DomainType `yaml:"DOMAIN_BEACON_PROPOSER"`
+	DomainBeaconAttester               phase0.DomainType `yaml:"DOMAIN_BEACON_ATTESTER"`
+	DomainSyncCommittee                phase0.DomainType `yaml:"DOMAIN_SYNC_COMMITTEE"`
+	SyncCommitteeSize                  uint64            `yaml:"SYNC_COMMITTEE_SIZE"`
+	DepositContractAddress             []byte            `yaml:"DEPOSIT_CONTRACT_ADDRESS"`
+	MinEpochsToInactivityPenalty       uint64            `yaml:"MIN_EPOCHS_TO_INACTIVITY_PENALTY"`
+	InactivityPenaltyQuotient          uint64            `yaml:"INACTIVITY_PENALTY_QUOTIENT"`
+	InactivityPenaltyQuotientAltair    uint64            `yaml:"INACTIVITY_PENALTY_QUOTIENT_ALTAIR"`
+	InactivityPenaltyQuotientBellatrix uint64            `yaml:"INACTIVITY_PENALTY_QUOTIENT_BELLATRIX"`
 
 	// additional dora specific specs
 	WhiskForkEpoch *uint64
@@ -96,6 +101,28 @@ func (chain *ChainSpec) CheckMismatch(chain2 *ChainSpec) []string {
 	return mismatches
 }
 
+// GetInactivityPenaltyQuotient returns the inactivity penalty quotient of the fork active at the given epoch.
+// falls back to the mainnet preset values if the quotient is not provided by the client.
+func (chain *ChainSpec) GetInactivityPenaltyQuotient(epoch phase0.Epoch) uint64 {
+	switch {
+	case chain.BellatrixForkEpoch != nil && uint64(epoch) >= *chain.BellatrixForkEpoch:
+		if chain.InactivityPenaltyQuotientBellatrix > 0 {
+			return chain.InactivityPenaltyQuotientBellatrix
+		}
+		return 1 << 24
+	case chain.AltairForkEpoch != nil && uint64(epoch) >= *chain.AltairForkEpoch:
+		if chain.InactivityPenaltyQuotientAltair > 0 {
+			return chain.InactivityPenaltyQuotientAltair
+		}
+		return 3 * (1 << 24)
+	default:
+		if chain.InactivityPenaltyQuotient > 0 {
+			return chain.InactivityPenaltyQuotient
+		}
+		return 1 << 26
+	}
+}
+
 func (chain *ChainSpec) Clone() *ChainSpec {
 	res := &ChainSpec{}
 	chainT := reflect.ValueOf(chain).Elem()
@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestGetInactivityPenaltyQuotient(t *testing.T) {
+	altairEpoch := uint64(10)
+	bellatrixEpoch := uint64(20)
+	specs := &ChainSpec{
+		AltairForkEpoch:                    &altairEpoch,
+		BellatrixForkEpoch:                 &bellatrixEpoch,
+		InactivityPenaltyQuotient:          1 << 26,
+		InactivityPenaltyQuotientAltair:    3 * (1 << 24),
+		InactivityPenaltyQuotientBellatrix: 1 << 24,
+	}
+
+	tests := []struct {
+		epoch    phase0.Epoch
+		expected uint64
+	}{
+		{0, 1 << 26},
+		{9, 1 << 26},
+		{10, 3 * (1 << 24)},
+		{19, 3 * (1 << 24)},
+		{20, 1 << 24},
+		{1000, 1 << 24},
+	}
+
+	for _, test := range tests {
+		if quotient := specs.GetInactivityPenaltyQuotient(test.epoch); quotient != test.expected {
+			t.Errorf("quotient at epoch %v: expected %v, got %v", test.epoch, test.expected, quotient)
+		}
+	}
+
+	// mainnet preset values if the client does not provide the quotients
+	specs = &ChainSpec{
+		AltairForkEpoch:    &altairEpoch,
+		BellatrixForkEpoch: &bellatrixEpoch,
+	}
+	if quotient := specs.GetInactivityPenaltyQuotient(5); quotient != 1<<26 {
+		t.Errorf("expected phase0 default quotient, got %v", quotient)
+	}
+	if quotient := specs.GetInactivityPenaltyQuotient(15); quotient != 3*(1<<24) {
+		t.Errorf("expected altair default quotient, got %v", quotient)
+	}
+	if quotient := specs.GetInactivityPenaltyQuotient(25); quotient != 1<<24 {
+		t.Errorf("expected bellatrix default quotient, got %v", quotient)
+	}
+}
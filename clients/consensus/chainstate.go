@@ -18,10 +18,10 @@ type ChainState struct {
 	specMutex sync.RWMutex
 	specs     *ChainSpec
 
-	genesisMutex sync.Mutex
+	genesisMutex sync.RWMutex
 	genesis      *v1.Genesis
 
-	wallclockMutex sync.Mutex
+	wallclockMutex sync.RWMutex
 	wallclock      *ethwallclock.EthereumBeaconChain
 
 	finalityMutex sync.RWMutex
@@ -104,17 +104,39 @@ func (cs *ChainState) initWallclock() {
 		return
 	}
 
-	if cs.specs == nil || cs.genesis == nil {
+	specs := cs.GetSpecs()
+	genesis := cs.GetGenesis()
+	if specs == nil || genesis == nil {
 		return
 	}
 
-	cs.wallclock = ethwallclock.NewEthereumBeaconChain(cs.genesis.GenesisTime, cs.specs.SecondsPerSlot, cs.specs.SlotsPerEpoch)
-	cs.wallclock.OnEpochChanged(func(current ethwallclock.Epoch) {
-		cs.wallclockEpochDispatcher.Fire(&current)
-	})
-	cs.wallclock.OnSlotChanged(func(current ethwallclock.Slot) {
-		cs.wallclockSlotDispatcher.Fire(&current)
-	})
+	cs.wallclock = ethwallclock.NewEthereumBeaconChain(genesis.GenesisTime, specs.SecondsPerSlot, specs.SlotsPerEpoch)
+	go cs.runWallclockLoop(cs.wallclock, specs.SlotsPerEpoch)
+}
+
+// runWallclockLoop fires the wallclock slot & epoch events.
+// the callbacks of the wallclock are not used, as they can't be registered safely while its timers are already running.
+func (cs *ChainState) runWallclockLoop(wallclock *ethwallclock.EthereumBeaconChain, slotsPerEpoch uint64) {
+	slot := wallclock.Slots().Current()
+	for {
+		time.Sleep(time.Until(slot.TimeWindow().End()))
+
+		slot = wallclock.Slots().Current()
+		currentSlot := slot // the subscribers keep the pointer
+		cs.wallclockSlotDispatcher.Fire(&currentSlot)
+
+		if slot.Number()%slotsPerEpoch == 0 {
+			epoch := wallclock.Epochs().Current()
+			cs.wallclockEpochDispatcher.Fire(&epoch)
+		}
+	}
+}
+
+func (cs *ChainState) getWallclock() *ethwallclock.EthereumBeaconChain {
+	cs.wallclockMutex.RLock()
+	defer cs.wallclockMutex.RUnlock()
+
+	return cs.wallclock
 }
 
 func (cs *ChainState) setFinalizedCheckpoint(finality *v1.Finality) {
@@ -131,13 +153,26 @@ func (cs *ChainState) setFinalizedCheckpoint(finality *v1.Finality) {
 }
 
 func (cs *ChainState) GetSpecs() *ChainSpec {
+	cs.specMutex.RLock()
+	defer cs.specMutex.RUnlock()
+
 	return cs.specs
 }
 
 func (cs *ChainState) GetGenesis() *v1.Genesis {
+	cs.genesisMutex.RLock()
+	defer cs.genesisMutex.RUnlock()
+
 	return cs.genesis
 }
 
+func (cs *ChainState) GetFinality() *v1.Finality {
+	cs.finalityMutex.RLock()
+	defer cs.finalityMutex.RUnlock()
+
+	return cs.finality
+}
+
 func (cs *ChainState) GetFinalizedCheckpoint() (phase0.Epoch, phase0.Root) {
 	cs.finalityMutex.RLock()
 	defer cs.finalityMutex.RUnlock()
@@ -161,7 +196,8 @@ func (cs *ChainState) GetJustifiedCheckpoint() (phase0.Epoch, phase0.Root) {
 }
 
 func (cs *ChainState) GetFinalizedSlot() phase0.Slot {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
@@ -172,15 +208,16 @@ func (cs *ChainState) GetFinalizedSlot() phase0.Slot {
 		return 0
 	}
 
-	return phase0.Slot(cs.finality.Finalized.Epoch) * phase0.Slot(cs.specs.SlotsPerEpoch)
+	return phase0.Slot(cs.finality.Finalized.Epoch) * phase0.Slot(specs.SlotsPerEpoch)
 }
 
 func (cs *ChainState) CurrentSlot() phase0.Slot {
-	if cs.wallclock == nil {
+	wallclock := cs.getWallclock()
+	if wallclock == nil {
 		return 0
 	}
 
-	slot, _, err := cs.wallclock.Now()
+	slot, _, err := wallclock.Now()
 	if err != nil {
 		return 0
 	}
@@ -193,11 +230,12 @@ func (cs *ChainState) CurrentSlot() phase0.Slot {
 }
 
 func (cs *ChainState) CurrentEpoch() phase0.Epoch {
-	if cs.wallclock == nil {
+	wallclock := cs.getWallclock()
+	if wallclock == nil {
 		return 0
 	}
 
-	_, epoch, err := cs.wallclock.Now()
+	_, epoch, err := wallclock.Now()
 	if err != nil {
 		return 0
 	}
@@ -210,76 +248,87 @@ func (cs *ChainState) CurrentEpoch() phase0.Epoch {
 }
 
 func (cs *ChainState) EpochOfSlot(slot phase0.Slot) phase0.Epoch {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
-	return phase0.Epoch(slot / phase0.Slot(cs.specs.SlotsPerEpoch))
+	return phase0.Epoch(slot / phase0.Slot(specs.SlotsPerEpoch))
 }
 
 func (cs *ChainState) EpochToSlot(epoch phase0.Epoch) phase0.Slot {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
-	return phase0.Slot(epoch) * phase0.Slot(cs.specs.SlotsPerEpoch)
+	return phase0.Slot(epoch) * phase0.Slot(specs.SlotsPerEpoch)
 }
 
 func (cs *ChainState) SlotToTime(slot phase0.Slot) time.Time {
-	if cs.specs == nil || cs.genesis == nil {
+	specs := cs.GetSpecs()
+	genesis := cs.GetGenesis()
+	if specs == nil || genesis == nil {
 		return time.Time{}
 	}
 
-	return cs.genesis.GenesisTime.Add(time.Duration(slot) * cs.specs.SecondsPerSlot)
+	return genesis.GenesisTime.Add(time.Duration(slot) * specs.SecondsPerSlot)
 }
 
 func (cs *ChainState) EpochToTime(epoch phase0.Epoch) time.Time {
-	if cs.specs == nil || cs.genesis == nil {
+	specs := cs.GetSpecs()
+	genesis := cs.GetGenesis()
+	if specs == nil || genesis == nil {
 		return time.Time{}
 	}
 
-	return cs.genesis.GenesisTime.Add(time.Duration(cs.EpochToSlot(epoch)) * cs.specs.SecondsPerSlot)
+	return genesis.GenesisTime.Add(time.Duration(cs.EpochToSlot(epoch)) * specs.SecondsPerSlot)
 }
 
 func (cs *ChainState) TimeToSlot(timestamp time.Time) phase0.Slot {
-	if cs.specs == nil || cs.genesis == nil {
+	specs := cs.GetSpecs()
+	genesis := cs.GetGenesis()
+	if specs == nil || genesis == nil {
 		return 0
 	}
 
-	if cs.genesis.GenesisTime.Compare(timestamp) > 0 {
+	if genesis.GenesisTime.Compare(timestamp) > 0 {
 		return 0
 	}
 
-	return phase0.Slot(uint64((timestamp.Sub(cs.genesis.GenesisTime)).Seconds()) / uint64(cs.specs.SecondsPerSlot.Seconds()))
+	return phase0.Slot(uint64((timestamp.Sub(genesis.GenesisTime)).Seconds()) / uint64(specs.SecondsPerSlot.Seconds()))
 }
 
 func (cs *ChainState) SlotToSlotIndex(slot phase0.Slot) phase0.Slot {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
-	return slot % phase0.Slot(cs.specs.SlotsPerEpoch)
+	return slot % phase0.Slot(specs.SlotsPerEpoch)
 }
 
 func (cs *ChainState) EpochStartSlot(epoch phase0.Epoch) phase0.Slot {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
-	return phase0.Slot(epoch) * phase0.Slot(cs.specs.SlotsPerEpoch)
+	return phase0.Slot(epoch) * phase0.Slot(specs.SlotsPerEpoch)
 }
 
 func (cs *ChainState) GetValidatorChurnLimit(validatorCount uint64) uint64 {
-	if cs.specs == nil {
+	specs := cs.GetSpecs()
+	if specs == nil {
 		return 0
 	}
 
 	adaptable := uint64(0)
 	if validatorCount > 0 {
-		adaptable = validatorCount / cs.specs.ChurnLimitQuotient
+		adaptable = validatorCount / specs.ChurnLimitQuotient
 	}
 
-	min := cs.specs.MinPerEpochChurnLimit
+	min := specs.MinPerEpochChurnLimit
 	if min > adaptable {
 		return min
 	}
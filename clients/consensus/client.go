@@ -3,12 +3,14 @@ package consensus
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/dora/clients/clienthealth"
 	"github.com/ethpandaops/dora/clients/consensus/rpc"
 	"github.com/ethpandaops/dora/clients/sshtunnel"
 )
@@ -28,15 +30,17 @@ type Client struct {
 	clientCtxCancel         context.CancelFunc
 	rpcClient               *rpc.BeaconClient
 	logger                  *logrus.Entry
-	isOnline                bool
-	isSyncing               bool
-	isOptimistic            bool
+	isStopped               atomic.Bool
+	isOnline                atomic.Bool
+	isSyncing               atomic.Bool
+	isOptimistic            atomic.Bool
 	versionStr              string
 	peerId                  string
 	clientType              ClientType
 	lastEvent               time.Time
 	retryCounter            uint64
 	lastError               error
+	health                  *clienthealth.Tracker
 	headMutex               sync.RWMutex
 	headRoot                phase0.Root
 	headSlot                phase0.Slot
@@ -47,6 +51,7 @@ type Client struct {
 	lastFinalityUpdateEpoch phase0.Epoch
 	lastPeerUpdateEpoch     phase0.Epoch
 	lastSyncUpdateEpoch     phase0.Epoch
+	peersMutex              sync.RWMutex
 	peers                   []*v1.Peer
 	blockDispatcher         Dispatcher[*v1.BlockEvent]
 	headDispatcher          Dispatcher[*v1.HeadEvent]
@@ -67,6 +72,7 @@ func (pool *Pool) newPoolClient(clientIdx uint16, endpoint *ClientConfig) (*Clie
 		endpointConfig: endpoint,
 		rpcClient:      rpcClient,
 		logger:         logger,
+		health:         clienthealth.NewTracker(),
 	}
 	client.resetContext()
 
@@ -83,6 +89,12 @@ func (client *Client) resetContext() {
 	client.clientCtx, client.clientCtxCancel = context.WithCancel(client.pool.ctx)
 }
 
+// stopClient cancels the client context, which terminates the event processing loop and all pending requests.
+func (client *Client) stopClient() {
+	client.isStopped.Store(true)
+	client.clientCtxCancel()
+}
+
 func (client *Client) SubscribeBlockEvent(capacity int, blocking bool) *Subscription[*v1.BlockEvent] {
 	return client.blockDispatcher.Subscribe(capacity, blocking)
 }
@@ -112,6 +124,9 @@ func (client *Client) GetVersion() string {
 }
 
 func (client *Client) GetPeerID() string {
+	client.peersMutex.RLock()
+	defer client.peersMutex.RUnlock()
+
 	return client.peerId
 }
 
@@ -153,13 +168,38 @@ func (client *Client) GetFinalityCheckpoint() (finalitedEpoch phase0.Epoch, fina
 	return client.finalizedEpoch, client.finalizedRoot, client.justifiedEpoch, client.justifiedRoot
 }
 
+func (client *Client) GetHealth() *clienthealth.Tracker {
+	return client.health
+}
+
+// TrackRequest records the result of a request that has been started at the given time for the client health score.
+func (client *Client) TrackRequest(startTime time.Time, err error) {
+	client.health.RecordRequest(time.Since(startTime), err)
+}
+
+// GetHealthScore returns the health score of the client (0-100) based on the request latency, error rate and head lag.
+func (client *Client) GetHealthScore() float64 {
+	headSlot, _ := client.GetLastHead()
+	headLag := uint64(0)
+	if highestSlot := client.pool.getHighestHeadSlot(); highestSlot > headSlot {
+		headLag = uint64(highestSlot - headSlot)
+	}
+
+	return client.health.GetScore(headLag)
+}
+
+// IsAvailable returns true if the client is not blocked by the circuit breaker.
+func (client *Client) IsAvailable() bool {
+	return !client.health.IsCircuitOpen()
+}
+
 func (client *Client) GetStatus() ClientStatus {
 	switch {
-	case client.isSyncing:
+	case client.isSyncing.Load():
 		return ClientStatusSynchronizing
-	case client.isOptimistic:
+	case client.isOptimistic.Load():
 		return ClientStatusOptimistic
-	case client.isOnline:
+	case client.isOnline.Load():
 		return ClientStatusOnline
 	default:
 		return ClientStatusOffline
@@ -167,6 +207,9 @@ func (client *Client) GetStatus() ClientStatus {
 }
 
 func (client *Client) GetNodePeers() []*v1.Peer {
+	client.peersMutex.RLock()
+	defer client.peersMutex.RUnlock()
+
 	if client.peers == nil {
 		return []*v1.Peer{}
 	}
@@ -37,7 +37,7 @@ func (client *Client) runClientLoop() {
 			return
 		}
 
-		client.isOnline = false
+		client.isOnline.Store(false)
 		client.lastError = err
 		client.lastEvent = time.Now()
 		client.retryCounter++
@@ -48,8 +48,16 @@ func (client *Client) runClientLoop() {
 			waitTime = 60
 		}
 
+		if client.isStopped.Load() {
+			return
+		}
+
 		client.logger.Warnf("consensus client error: %v, retrying in %v sec...", err, waitTime)
-		time.Sleep(time.Duration(waitTime) * time.Second)
+		select {
+		case <-client.clientCtx.Done():
+			return
+		case <-time.After(time.Duration(waitTime) * time.Second):
+		}
 	}
 }
 
@@ -75,6 +83,7 @@ func (client *Client) checkClient() error {
 	if err = client.updateNodePeers(ctx); err != nil {
 		return fmt.Errorf("could not get node peers for %s: %v", client.endpointConfig.Name, err)
 	}
+	client.lastPeerUpdateEpoch = client.pool.chainState.CurrentEpoch()
 
 	// get & compare genesis
 	genesis, err := client.rpcClient.GetGenesis(ctx)
@@ -116,6 +125,7 @@ func (client *Client) checkClient() error {
 	if err != nil {
 		return err
 	}
+	client.lastFinalityUpdateEpoch = client.pool.chainState.CurrentEpoch()
 
 	return nil
 }
@@ -128,7 +138,7 @@ func (client *Client) runClientLogic() error {
 	}
 
 	// check latest header / sync status
-	if client.isSyncing {
+	if client.isSyncing.Load() {
 		return fmt.Errorf("beacon node is synchronizing")
 	}
 
@@ -176,8 +186,8 @@ func (client *Client) runClientLogic() error {
 			client.logger.Tracef("event (%v) processing time: %v ms", evt.Event, time.Since(now).Milliseconds())
 			client.lastEvent = time.Now()
 		case streamStatus := <-blockStream.ReadyChan:
-			if client.isOnline != streamStatus.Ready {
-				client.isOnline = streamStatus.Ready
+			if client.isOnline.Load() != streamStatus.Ready {
+				client.isOnline.Store(streamStatus.Ready)
 				if streamStatus.Ready {
 					client.logger.Debug("RPC event stream connected")
 					client.lastError = nil
@@ -191,7 +201,7 @@ func (client *Client) runClientLogic() error {
 
 			err := client.pollClientHead()
 			if err != nil {
-				client.isOnline = false
+				client.isOnline.Store(false)
 				return err
 			}
 
@@ -204,11 +214,11 @@ func (client *Client) runClientLogic() error {
 		if currentEpoch-client.lastSyncUpdateEpoch >= 1 {
 			// update sync status
 			if err = client.updateSynchronizationStatus(client.clientCtx); err != nil {
-				client.isOnline = false
+				client.isOnline.Store(false)
 				return fmt.Errorf("could not get synchronization status for %s: %v", client.endpointConfig.Name, err)
 			}
 
-			if client.isSyncing {
+			if client.isSyncing.Load() {
 				return fmt.Errorf("beacon node is synchronizing")
 			}
 		}
@@ -217,7 +227,7 @@ func (client *Client) runClientLogic() error {
 			client.lastFinalityUpdateEpoch = currentEpoch
 			go func() {
 				// update finality status
-				if _, err = client.updateFinalityCheckpoints(client.clientCtx); err != nil {
+				if _, err := client.updateFinalityCheckpoints(client.clientCtx); err != nil {
 					client.logger.Errorf("could not get finality checkpoint for %s: %v", client.endpointConfig.Name, err)
 				}
 			}()
@@ -227,10 +237,10 @@ func (client *Client) runClientLogic() error {
 			client.lastPeerUpdateEpoch = currentEpoch
 			go func() {
 				// update node peers
-				if err = client.updateNodePeers(client.clientCtx); err != nil {
+				if err := client.updateNodePeers(client.clientCtx); err != nil {
 					client.logger.Errorf("could not get node peers for %s: %v", client.endpointConfig.Name, err)
 				} else {
-					client.logger.WithFields(logrus.Fields{"epoch": currentEpoch, "peers": len(client.peers)}).Debug("updated consensus node peers")
+					client.logger.WithFields(logrus.Fields{"epoch": currentEpoch, "peers": len(client.GetNodePeers())}).Debug("updated consensus node peers")
 				}
 			}()
 		}
@@ -251,8 +261,8 @@ func (client *Client) updateSynchronizationStatus(ctx context.Context) error {
 		return fmt.Errorf("could not get synchronization status")
 	}
 
-	client.isSyncing = syncStatus.IsSyncing
-	client.isOptimistic = syncStatus.IsOptimistic
+	client.isSyncing.Store(syncStatus.IsSyncing)
+	client.isOptimistic.Store(syncStatus.IsOptimistic)
 	client.lastSyncUpdateEpoch = client.pool.chainState.CurrentEpoch()
 
 	return nil
@@ -263,8 +273,7 @@ func (client *Client) updateNodePeers(ctx context.Context) error {
 	ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	var err error
-	client.peerId, err = client.rpcClient.GetNodePeerId(ctx)
+	peerId, err := client.rpcClient.GetNodePeerId(ctx)
 	if err != nil {
 		return fmt.Errorf("could not get node peer id: %v", err)
 	}
@@ -273,8 +282,11 @@ func (client *Client) updateNodePeers(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("could not get peers: %v", err)
 	}
+
+	client.peersMutex.Lock()
+	client.peerId = peerId
 	client.peers = peers
-	client.lastPeerUpdateEpoch = client.pool.chainState.CurrentEpoch()
+	client.peersMutex.Unlock()
 
 	return nil
 }
@@ -289,8 +301,6 @@ func (client *Client) updateFinalityCheckpoints(ctx context.Context) (phase0.Roo
 		return NullRoot, err
 	}
 
-	client.lastFinalityUpdateEpoch = client.pool.chainState.CurrentEpoch()
-
 	client.headMutex.Lock()
 	if bytes.Equal(client.justifiedRoot[:], finalizedCheckpoints.Justified.Root[:]) {
 		client.headMutex.Unlock()
@@ -344,7 +354,8 @@ func (client *Client) processFinalizedEvent(evt *v1.FinalizedCheckpointEvent) er
 			time.Sleep(3 * time.Second)
 		}
 
-		client.logger.Debugf("processed finalization_checkpoint event: finalized %v [0x%x], justified %v [0x%x], retry: %v", client.finalizedEpoch, client.finalizedRoot, client.justifiedEpoch, client.justifiedRoot, retry)
+		finalizedEpoch, finalizedRoot, justifiedEpoch, justifiedRoot := client.GetFinalityCheckpoint()
+		client.logger.Debugf("processed finalization_checkpoint event: finalized %v [0x%x], justified %v [0x%x], retry: %v", finalizedEpoch, finalizedRoot, justifiedEpoch, justifiedRoot, retry)
 	}()
 
 	return nil
@@ -354,7 +365,9 @@ func (client *Client) pollClientHead() error {
 	ctx, cancel := context.WithTimeout(client.clientCtx, 10*time.Second)
 	defer cancel()
 
+	startTime := time.Now()
 	latestHeader, err := client.rpcClient.GetLatestBlockHead(ctx)
+	client.TrackRequest(startTime, err)
 	if err != nil {
 		return fmt.Errorf("could not get latest header: %v", err)
 	}
@@ -0,0 +1,233 @@
+package mockbeacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+)
+
+func (node *Node) newRouter() http.Handler {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/eth/v1/node/version", node.handleNodeVersion).Methods("GET")
+	router.HandleFunc("/eth/v1/node/syncing", node.handleNodeSyncing).Methods("GET")
+	router.HandleFunc("/eth/v1/node/identity", node.handleNodeIdentity).Methods("GET")
+	router.HandleFunc("/eth/v1/node/peers", node.handleNodePeers).Methods("GET")
+	router.HandleFunc("/eth/v1/beacon/genesis", node.handleGenesis).Methods("GET")
+	router.HandleFunc("/eth/v1/config/spec", node.handleSpec).Methods("GET")
+	router.HandleFunc("/eth/v1/beacon/headers/{block_id}", node.handleBlockHeader).Methods("GET")
+	router.HandleFunc("/eth/v2/beacon/blocks/{block_id}", node.handleBlock).Methods("GET")
+	router.HandleFunc("/eth/v2/debug/beacon/states/{state_id}", node.handleState).Methods("GET")
+	router.HandleFunc("/eth/v1/beacon/states/{state_id}/finality_checkpoints", node.handleFinalityCheckpoints).Methods("GET")
+	router.HandleFunc("/eth/v1/events", node.handleEvents).Methods("GET")
+
+	return router
+}
+
+func (node *Node) writeData(w http.ResponseWriter, data any, version string) {
+	w.Header().Set("Content-Type", "application/json")
+
+	response := map[string]any{
+		"data": data,
+	}
+	if version != "" {
+		w.Header().Set("Eth-Consensus-Version", version)
+		response["version"] = version
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		node.writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (node *Node) writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]any{
+		"code":    code,
+		"message": message,
+	})
+}
+
+func (node *Node) handleNodeVersion(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, map[string]string{
+		"version": "Mock/v0.0.0/dora",
+	}, "")
+}
+
+func (node *Node) handleNodeSyncing(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, &v1.SyncState{
+		HeadSlot: node.GetHead().Slot,
+	}, "")
+}
+
+func (node *Node) handleNodeIdentity(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, map[string]any{
+		"peer_id":             "16Uiu2HAmMockBeaconNode",
+		"enr":                 "",
+		"p2p_addresses":       []string{},
+		"discovery_addresses": []string{},
+		"metadata": map[string]string{
+			"seq_number": "0",
+			"attnets":    "0x0000000000000000",
+		},
+	}, "")
+}
+
+func (node *Node) handleNodePeers(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, []*v1.Peer{}, "")
+}
+
+func (node *Node) handleGenesis(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, &v1.Genesis{
+		GenesisTime:           node.config.GenesisTime,
+		GenesisValidatorsRoot: node.genesisValidatorsRoot,
+		GenesisForkVersion:    genesisForkVersion,
+	}, "")
+}
+
+func (node *Node) handleSpec(w http.ResponseWriter, r *http.Request) {
+	farFuture := fmt.Sprintf("%d", uint64(farFutureEpoch))
+
+	node.writeData(w, map[string]string{
+		"PRESET_BASE":                      "mainnet",
+		"CONFIG_NAME":                      "mocknet",
+		"MIN_GENESIS_TIME":                 fmt.Sprintf("%d", node.config.GenesisTime.Unix()),
+		"GENESIS_FORK_VERSION":             fmt.Sprintf("%#x", genesisForkVersion[:]),
+		"ALTAIR_FORK_VERSION":              fmt.Sprintf("%#x", altairForkVersion[:]),
+		"ALTAIR_FORK_EPOCH":                "0",
+		"BELLATRIX_FORK_VERSION":           "0x30000000",
+		"BELLATRIX_FORK_EPOCH":             farFuture,
+		"CAPELLA_FORK_VERSION":             "0x40000000",
+		"CAPELLA_FORK_EPOCH":               farFuture,
+		"DENEB_FORK_VERSION":               "0x50000000",
+		"DENEB_FORK_EPOCH":                 farFuture,
+		"ELECTRA_FORK_VERSION":             "0x60000000",
+		"ELECTRA_FORK_EPOCH":               farFuture,
+		"SECONDS_PER_SLOT":                 fmt.Sprintf("%d", node.config.SecondsPerSlot),
+		"SLOTS_PER_EPOCH":                  fmt.Sprintf("%d", node.config.SlotsPerEpoch),
+		"SLOTS_PER_HISTORICAL_ROOT":        fmt.Sprintf("%d", slotsPerHistoricalRoot),
+		"EPOCHS_PER_HISTORICAL_VECTOR":     fmt.Sprintf("%d", epochsPerHistoricalVector),
+		"EPOCHS_PER_SLASHINGS_VECTOR":      fmt.Sprintf("%d", epochsPerSlashingsVector),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": "8",
+		"MIN_SEED_LOOKAHEAD":               "1",
+		"SHUFFLE_ROUND_COUNT":              "10",
+		"MAX_EFFECTIVE_BALANCE":            fmt.Sprintf("%d", node.config.MaxEffectiveBalance),
+		"TARGET_COMMITTEE_SIZE":            "4",
+		"MAX_COMMITTEES_PER_SLOT":          "4",
+		"MIN_PER_EPOCH_CHURN_LIMIT":        "4",
+		"CHURN_LIMIT_QUOTIENT":             "65536",
+		"DOMAIN_BEACON_PROPOSER":           "0x00000000",
+		"DOMAIN_BEACON_ATTESTER":           "0x01000000",
+		"DOMAIN_SYNC_COMMITTEE":            "0x07000000",
+		"SYNC_COMMITTEE_SIZE":              fmt.Sprintf("%d", syncCommitteeSize),
+		"DEPOSIT_CONTRACT_ADDRESS":         "0x00000000219ab540356cbb839cbe05303d7705fa",
+	}, "")
+}
+
+// resolveBlockId returns the block for a block id (head, genesis, finalized, slot or root).
+// slot lookups only return blocks of the canonical chain.
+func (node *Node) resolveBlockId(blockId string) *Block {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	switch {
+	case blockId == "head":
+		return node.head
+	case blockId == "genesis":
+		return node.genesis
+	case blockId == "finalized":
+		if node.finalized.Epoch == 0 {
+			return node.genesis
+		}
+		return node.blocks[node.finalized.Root]
+	case strings.HasPrefix(blockId, "0x"):
+		rootBytes, err := hex.DecodeString(strings.TrimPrefix(blockId, "0x"))
+		if err != nil || len(rootBytes) != 32 {
+			return nil
+		}
+		return node.blocks[phase0.Root(rootBytes)]
+	default:
+		slot, err := strconv.ParseUint(blockId, 10, 64)
+		if err != nil {
+			return nil
+		}
+		block := node.head.getAncestorAtSlot(phase0.Slot(slot))
+		if block == nil || block.Slot != phase0.Slot(slot) {
+			return nil
+		}
+		return block
+	}
+}
+
+func (node *Node) handleBlockHeader(w http.ResponseWriter, r *http.Request) {
+	block := node.resolveBlockId(mux.Vars(r)["block_id"])
+	if block == nil {
+		node.writeError(w, http.StatusNotFound, "block not found")
+		return
+	}
+
+	node.writeData(w, &v1.BeaconBlockHeader{
+		Root:      block.Root,
+		Canonical: node.IsCanonical(block),
+		Header:    block.Header,
+	}, "")
+}
+
+func (node *Node) handleBlock(w http.ResponseWriter, r *http.Request) {
+	block := node.resolveBlockId(mux.Vars(r)["block_id"])
+	if block == nil {
+		node.writeError(w, http.StatusNotFound, "block not found")
+		return
+	}
+
+	node.writeData(w, block.Block, "altair")
+}
+
+func (node *Node) handleState(w http.ResponseWriter, r *http.Request) {
+	stateId := mux.Vars(r)["state_id"]
+
+	var block *Block
+	var stateSlot *phase0.Slot
+	if strings.HasPrefix(stateId, "0x") {
+		rootBytes, err := hex.DecodeString(strings.TrimPrefix(stateId, "0x"))
+		if err == nil && len(rootBytes) == 32 {
+			node.chainMutex.RLock()
+			block = node.stateBlocks[phase0.Root(rootBytes)]
+			node.chainMutex.RUnlock()
+		}
+	} else if slot, err := strconv.ParseUint(stateId, 10, 64); err == nil {
+		// states of empty slots are the state of the last block, advanced to the requested slot
+		node.chainMutex.RLock()
+		if phase0.Slot(slot) <= node.head.Slot {
+			block = node.head.getAncestorAtSlot(phase0.Slot(slot))
+		}
+		node.chainMutex.RUnlock()
+		stateSlot = (*phase0.Slot)(&slot)
+	} else {
+		block = node.resolveBlockId(stateId)
+	}
+
+	if block == nil {
+		node.writeError(w, http.StatusNotFound, "state not found")
+		return
+	}
+
+	state := node.buildState(block)
+	if stateSlot != nil {
+		state.Slot = *stateSlot
+	}
+
+	node.writeData(w, state, "altair")
+}
+
+func (node *Node) handleFinalityCheckpoints(w http.ResponseWriter, r *http.Request) {
+	node.writeData(w, node.getFinality(), "")
+}
@@ -0,0 +1,214 @@
+package mockbeacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// Block is a block of the simulated chain.
+type Block struct {
+	Root      phase0.Root
+	Slot      phase0.Slot
+	Label     string
+	Parent    *Block
+	StateRoot phase0.Root
+	Header    *phase0.SignedBeaconBlockHeader
+	Block     *altair.SignedBeaconBlock
+}
+
+// buildBlock creates a new block on top of the given parent.
+// the label is used as graffiti, so blocks with the same parent & slot get distinct roots.
+func (node *Node) buildBlock(parent *Block, slot phase0.Slot, label string) (*Block, error) {
+	parentRoot := phase0.Root{}
+	if parent != nil {
+		parentRoot = parent.Root
+		if slot <= parent.Slot {
+			return nil, fmt.Errorf("block slot %v not after parent slot %v", slot, parent.Slot)
+		}
+	}
+
+	block := &Block{
+		Slot:   slot,
+		Label:  label,
+		Parent: parent,
+	}
+
+	// the state is generated on demand, so the state root is just a unique identifier derived from the block position
+	block.StateRoot = hashRoot([]byte("state"), parentRoot[:], uint64Bytes(uint64(slot)), []byte(label))
+
+	graffiti := [32]byte{}
+	copy(graffiti[:], label)
+
+	block.Block = &altair.SignedBeaconBlock{
+		Message: &altair.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: phase0.ValidatorIndex(uint64(slot) % node.config.ValidatorCount),
+			ParentRoot:    parentRoot,
+			StateRoot:     block.StateRoot,
+			Body: &altair.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				Graffiti:          graffiti,
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
+			},
+		},
+	}
+
+	bodyRoot, err := block.Block.Message.Body.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed computing body root: %v", err)
+	}
+
+	block.Header = &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: block.Block.Message.ProposerIndex,
+			ParentRoot:    parentRoot,
+			StateRoot:     block.StateRoot,
+			BodyRoot:      bodyRoot,
+		},
+	}
+
+	block.Root, err = block.Header.Message.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed computing block root: %v", err)
+	}
+
+	return block, nil
+}
+
+// buildState creates the post state of the given block.
+// the state contains a static validator set, randao mixes derived from the block chain and no history.
+func (node *Node) buildState(block *Block) *altair.BeaconState {
+	validatorCount := node.config.ValidatorCount
+	validators := make([]*phase0.Validator, validatorCount)
+	balances := make([]phase0.Gwei, validatorCount)
+	participation := make([]altair.ParticipationFlags, validatorCount)
+	inactivityScores := make([]uint64, validatorCount)
+	for i := uint64(0); i < validatorCount; i++ {
+		validators[i] = &phase0.Validator{
+			PublicKey:                  validatorPubkey(i),
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           phase0.Gwei(node.config.MaxEffectiveBalance),
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  farFutureEpoch,
+			WithdrawableEpoch:          farFutureEpoch,
+		}
+		balances[i] = phase0.Gwei(node.config.MaxEffectiveBalance)
+	}
+
+	// randao mixes change with every block, so duties differ between forks
+	randaoMixes := make([]phase0.Root, epochsPerHistoricalVector)
+	for i := range randaoMixes {
+		randaoMixes[i] = hashRoot([]byte("randao"), block.Root[:], uint64Bytes(uint64(i)))
+	}
+
+	// historical block & state roots of the chain, empty slots repeat the roots of the previous block
+	blockRoots := make([]phase0.Root, slotsPerHistoricalRoot)
+	stateRoots := make([]phase0.Root, slotsPerHistoricalRoot)
+	for slot := block.Slot; slot > 0 && slot+slotsPerHistoricalRoot > block.Slot; slot-- {
+		ancestor := block.getAncestorAtSlot(slot - 1)
+		if ancestor == nil {
+			break
+		}
+		blockRoots[(slot-1)%slotsPerHistoricalRoot] = ancestor.Root
+		stateRoots[(slot-1)%slotsPerHistoricalRoot] = ancestor.StateRoot
+	}
+
+	syncCommittee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, syncCommitteeSize),
+	}
+	for i := range syncCommittee.Pubkeys {
+		syncCommittee.Pubkeys[i] = validatorPubkey(uint64(i) % validatorCount)
+	}
+
+	header := *block.Header.Message
+
+	return &altair.BeaconState{
+		GenesisTime:           uint64(node.config.GenesisTime.Unix()),
+		GenesisValidatorsRoot: node.genesisValidatorsRoot,
+		Slot:                  block.Slot,
+		Fork: &phase0.Fork{
+			PreviousVersion: genesisForkVersion,
+			CurrentVersion:  altairForkVersion,
+			Epoch:           0,
+		},
+		LatestBlockHeader: &header,
+		BlockRoots:        blockRoots,
+		StateRoots:        stateRoots,
+		HistoricalRoots:   []phase0.Root{},
+		ETH1Data: &phase0.ETH1Data{
+			BlockHash: make([]byte, 32),
+		},
+		ETH1DataVotes:               []*phase0.ETH1Data{},
+		ETH1DepositIndex:            node.config.DepositIndex,
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 randaoMixes,
+		Slashings:                   make([]phase0.Gwei, epochsPerSlashingsVector),
+		PreviousEpochParticipation:  participation,
+		CurrentEpochParticipation:   participation,
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+		InactivityScores:            inactivityScores,
+		CurrentSyncCommittee:        syncCommittee,
+		NextSyncCommittee:           syncCommittee,
+	}
+}
+
+// isAncestor checks if the given block is the block itself or an ancestor of the descendant.
+func (block *Block) isAncestor(descendant *Block) bool {
+	for descendant != nil && descendant.Slot >= block.Slot {
+		if descendant == block {
+			return true
+		}
+		descendant = descendant.Parent
+	}
+	return false
+}
+
+// getAncestorAtSlot returns the latest block of the chain with a slot lower or equal to the given slot.
+func (block *Block) getAncestorAtSlot(slot phase0.Slot) *Block {
+	for block != nil && block.Slot > slot {
+		block = block.Parent
+	}
+	return block
+}
+
+func validatorPubkey(index uint64) phase0.BLSPubKey {
+	pubkey := phase0.BLSPubKey{0xa0}
+	binary.BigEndian.PutUint64(pubkey[40:], index)
+	return pubkey
+}
+
+func hashRoot(data ...[]byte) phase0.Root {
+	hasher := sha256.New()
+	for _, d := range data {
+		hasher.Write(d)
+	}
+	return phase0.Root(hasher.Sum(nil))
+}
+
+func uint64Bytes(value uint64) []byte {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, value)
+	return data
+}
@@ -0,0 +1,94 @@
+package mockbeacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type eventSubscriber struct {
+	topics    map[string]bool
+	eventChan chan *streamEvent
+	doneChan  chan bool
+}
+
+type streamEvent struct {
+	topic string
+	data  []byte
+}
+
+// publishEvent sends the event to all subscribers of the topic.
+func (node *Node) publishEvent(topic string, data any) {
+	eventData, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	event := &streamEvent{
+		topic: topic,
+		data:  eventData,
+	}
+
+	node.subscriberMutex.Lock()
+	defer node.subscriberMutex.Unlock()
+
+	for subscriber := range node.subscribers {
+		if !subscriber.topics[topic] {
+			continue
+		}
+
+		select {
+		case subscriber.eventChan <- event:
+		case <-subscriber.doneChan:
+		}
+	}
+}
+
+// handleEvents serves the server-sent event stream for /eth/v1/events.
+func (node *Node) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		node.writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	subscriber := &eventSubscriber{
+		topics:    map[string]bool{},
+		eventChan: make(chan *streamEvent, 100),
+		doneChan:  make(chan bool),
+	}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		subscriber.topics[topic] = true
+	}
+
+	node.subscriberMutex.Lock()
+	node.subscribers[subscriber] = true
+	node.subscriberMutex.Unlock()
+
+	defer func() {
+		// unblock pending publishers before unregistering
+		close(subscriber.doneChan)
+
+		node.subscriberMutex.Lock()
+		delete(node.subscribers, subscriber)
+		node.subscriberMutex.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-node.closeChan:
+			return
+		case event := <-subscriber.eventChan:
+			fmt.Fprintf(w, "event: %v\ndata: %v\n\n", event.topic, string(event.data))
+			flusher.Flush()
+		}
+	}
+}
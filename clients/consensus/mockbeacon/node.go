@@ -0,0 +1,314 @@
+package mockbeacon
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// preset values of the simulated chain, chosen to keep the generated states small
+const (
+	slotsPerHistoricalRoot    = 64
+	epochsPerHistoricalVector = 64
+	epochsPerSlashingsVector  = 64
+	syncCommitteeSize         = 512
+)
+
+var (
+	genesisForkVersion = phase0.Version{0x10, 0x00, 0x00, 0x00}
+	altairForkVersion  = phase0.Version{0x20, 0x00, 0x00, 0x00}
+)
+
+// Config holds the chain parameters of the simulated beacon node.
+type Config struct {
+	GenesisTime         time.Time
+	SecondsPerSlot      uint64
+	SlotsPerEpoch       uint64
+	ValidatorCount      uint64
+	MaxEffectiveBalance uint64
+	DepositIndex        uint64 // eth1_deposit_index of all states (deposits processed before genesis)
+}
+
+// Node is an in-process beacon node serving a scripted chain via the beacon api.
+// the chain starts with the altair fork at genesis, as the indexer requires sync committees in the beacon states.
+// blocks, heads & finality checkpoints are controlled by the caller (directly or via Replay) and published via the event stream.
+type Node struct {
+	config                *Config
+	server                *httptest.Server
+	genesisValidatorsRoot phase0.Root
+
+	chainMutex    sync.RWMutex
+	genesis       *Block
+	head          *Block
+	blocks        map[phase0.Root]*Block
+	stateBlocks   map[phase0.Root]*Block
+	labels        map[string]*Block
+	justified     *phase0.Checkpoint
+	finalized     *phase0.Checkpoint
+	prevJustified *phase0.Checkpoint
+
+	subscriberMutex sync.Mutex
+	subscribers     map[*eventSubscriber]bool
+	closeChan       chan bool
+}
+
+// NewNode creates a new simulated beacon node with a genesis block and starts serving the beacon api.
+func NewNode(config *Config) (*Node, error) {
+	if config.SecondsPerSlot == 0 {
+		config.SecondsPerSlot = 1
+	}
+	if config.SlotsPerEpoch == 0 {
+		config.SlotsPerEpoch = 4
+	}
+	if config.ValidatorCount == 0 {
+		config.ValidatorCount = 64
+	}
+	if config.MaxEffectiveBalance == 0 {
+		config.MaxEffectiveBalance = 32000000000
+	}
+	if config.GenesisTime.IsZero() {
+		config.GenesisTime = time.Now()
+	}
+
+	node := &Node{
+		config:                config,
+		genesisValidatorsRoot: hashRoot([]byte("genesis-validators"), uint64Bytes(config.ValidatorCount)),
+		blocks:                map[phase0.Root]*Block{},
+		stateBlocks:           map[phase0.Root]*Block{},
+		labels:                map[string]*Block{},
+		justified:             &phase0.Checkpoint{},
+		finalized:             &phase0.Checkpoint{},
+		prevJustified:         &phase0.Checkpoint{},
+		subscribers:           map[*eventSubscriber]bool{},
+		closeChan:             make(chan bool),
+	}
+
+	genesis, err := node.buildBlock(nil, 0, "genesis")
+	if err != nil {
+		return nil, err
+	}
+
+	node.genesis = genesis
+	node.head = genesis
+	node.addBlock(genesis)
+
+	node.server = httptest.NewServer(node.newRouter())
+
+	return node, nil
+}
+
+// Close stops serving the beacon api and closes all event streams.
+func (node *Node) Close() {
+	close(node.closeChan)
+	node.server.Close()
+}
+
+// URL returns the beacon api endpoint of the node.
+func (node *Node) URL() string {
+	return node.server.URL
+}
+
+// GetConfig returns the chain parameters of the node.
+func (node *Node) GetConfig() *Config {
+	return node.config
+}
+
+// SlotTime returns the start time of the given slot.
+func (node *Node) SlotTime(slot phase0.Slot) time.Time {
+	return node.config.GenesisTime.Add(time.Duration(uint64(slot)*node.config.SecondsPerSlot) * time.Second)
+}
+
+// EpochOfSlot returns the epoch of the given slot.
+func (node *Node) EpochOfSlot(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / node.config.SlotsPerEpoch)
+}
+
+// EpochStartSlot returns the first slot of the given epoch.
+func (node *Node) EpochStartSlot(epoch phase0.Epoch) phase0.Slot {
+	return phase0.Slot(uint64(epoch) * node.config.SlotsPerEpoch)
+}
+
+// GetGenesis returns the genesis block.
+func (node *Node) GetGenesis() *Block {
+	return node.genesis
+}
+
+// GetHead returns the current head block.
+func (node *Node) GetHead() *Block {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return node.head
+}
+
+// GetBlock returns the block with the given label or nil if unknown.
+func (node *Node) GetBlock(label string) *Block {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return node.labels[label]
+}
+
+// GetBlockByRoot returns the block with the given root or nil if unknown.
+func (node *Node) GetBlockByRoot(root phase0.Root) *Block {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return node.blocks[root]
+}
+
+// IsCanonical checks if the block is part of the chain of the current head.
+func (node *Node) IsCanonical(block *Block) bool {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return block.isAncestor(node.head)
+}
+
+// GetFinalizedCheckpoint returns the current finalized checkpoint.
+func (node *Node) GetFinalizedCheckpoint() phase0.Checkpoint {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return *node.finalized
+}
+
+func (node *Node) addBlock(block *Block) {
+	node.blocks[block.Root] = block
+	node.stateBlocks[block.StateRoot] = block
+	if block.Label != "" {
+		node.labels[block.Label] = block
+	}
+}
+
+// AddBlock adds a new block on top of the given parent and publishes a block event.
+// the head is not changed, use SetHead to move the head to the new block.
+func (node *Node) AddBlock(parent *Block, slot phase0.Slot, label string) (*Block, error) {
+	node.chainMutex.Lock()
+	if label != "" && node.labels[label] != nil {
+		node.chainMutex.Unlock()
+		return nil, fmt.Errorf("duplicate block label %v", label)
+	}
+
+	block, err := node.buildBlock(parent, slot, label)
+	if err != nil {
+		node.chainMutex.Unlock()
+		return nil, err
+	}
+
+	node.addBlock(block)
+	node.chainMutex.Unlock()
+
+	node.publishEvent("block", &v1.BlockEvent{
+		Slot:  block.Slot,
+		Block: block.Root,
+	})
+
+	return block, nil
+}
+
+// SetHead moves the head to the given block and publishes a head event.
+// moving the head to a block that is not a descendant of the current head results in a reorg.
+func (node *Node) SetHead(block *Block) {
+	node.chainMutex.Lock()
+	prevHead := node.head
+	node.head = block
+
+	epoch := node.EpochOfSlot(block.Slot)
+	headEvent := &v1.HeadEvent{
+		Slot:                     block.Slot,
+		Block:                    block.Root,
+		State:                    block.StateRoot,
+		EpochTransition:          node.EpochOfSlot(prevHead.Slot) != epoch,
+		CurrentDutyDependentRoot: node.getDependentRoot(block, epoch),
+	}
+	if epoch > 0 {
+		headEvent.PreviousDutyDependentRoot = node.getDependentRoot(block, epoch-1)
+	} else {
+		headEvent.PreviousDutyDependentRoot = node.genesis.Root
+	}
+	node.chainMutex.Unlock()
+
+	node.publishEvent("head", headEvent)
+}
+
+// getDependentRoot returns the root of the last block before the given epoch on the chain of the given block.
+func (node *Node) getDependentRoot(block *Block, epoch phase0.Epoch) phase0.Root {
+	if epoch == 0 {
+		return node.genesis.Root
+	}
+
+	dependentBlock := block.getAncestorAtSlot(node.EpochStartSlot(epoch) - 1)
+	if dependentBlock == nil {
+		return node.genesis.Root
+	}
+
+	return dependentBlock.Root
+}
+
+// SetFinality updates the justified & finalized checkpoints based on the chain of the current head.
+// a finalized_checkpoint event is published if the finalized checkpoint changed.
+func (node *Node) SetFinality(justifiedEpoch phase0.Epoch, finalizedEpoch phase0.Epoch) error {
+	if finalizedEpoch > justifiedEpoch {
+		return fmt.Errorf("finalized epoch %v after justified epoch %v", finalizedEpoch, justifiedEpoch)
+	}
+
+	node.chainMutex.Lock()
+	if finalizedEpoch < node.finalized.Epoch {
+		node.chainMutex.Unlock()
+		return fmt.Errorf("finalized epoch %v before current finalized epoch %v", finalizedEpoch, node.finalized.Epoch)
+	}
+
+	node.prevJustified = node.justified
+	node.justified = node.getCheckpoint(justifiedEpoch)
+	finalizedChanged := finalizedEpoch != node.finalized.Epoch
+	node.finalized = node.getCheckpoint(finalizedEpoch)
+
+	var finalizedEvent *v1.FinalizedCheckpointEvent
+	if finalizedChanged {
+		finalizedEvent = &v1.FinalizedCheckpointEvent{
+			Block: node.finalized.Root,
+			Epoch: node.finalized.Epoch,
+		}
+		if block := node.blocks[node.finalized.Root]; block != nil {
+			finalizedEvent.State = block.StateRoot
+		}
+	}
+	node.chainMutex.Unlock()
+
+	if finalizedEvent != nil {
+		node.publishEvent("finalized_checkpoint", finalizedEvent)
+	}
+
+	return nil
+}
+
+// getCheckpoint returns the checkpoint of the given epoch on the chain of the current head.
+// like real beacon nodes, the checkpoint of epoch 0 refers to the zero root.
+func (node *Node) getCheckpoint(epoch phase0.Epoch) *phase0.Checkpoint {
+	if epoch == 0 {
+		return &phase0.Checkpoint{}
+	}
+
+	checkpointBlock := node.head.getAncestorAtSlot(node.EpochStartSlot(epoch))
+	return &phase0.Checkpoint{
+		Epoch: epoch,
+		Root:  checkpointBlock.Root,
+	}
+}
+
+// getFinality returns the current finality checkpoints.
+func (node *Node) getFinality() *v1.Finality {
+	node.chainMutex.RLock()
+	defer node.chainMutex.RUnlock()
+
+	return &v1.Finality{
+		Finalized:         node.finalized,
+		Justified:         node.justified,
+		PreviousJustified: node.prevJustified,
+	}
+}
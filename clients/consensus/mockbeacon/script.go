@@ -0,0 +1,125 @@
+package mockbeacon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Step is a single action of a chain script.
+// steps are replayed in order, each step is executed when the wallclock reaches the start of its slot.
+type Step struct {
+	Slot phase0.Slot
+	Run  func(node *Node) error
+}
+
+// Replay executes the steps of a chain script in real time.
+// steps with slots in the past are executed right away.
+func (node *Node) Replay(ctx context.Context, steps []Step) error {
+	for _, step := range steps {
+		if err := node.WaitForSlot(ctx, step.Slot); err != nil {
+			return err
+		}
+
+		if err := step.Run(node); err != nil {
+			return fmt.Errorf("step at slot %v failed: %v", step.Slot, err)
+		}
+	}
+
+	return nil
+}
+
+// WaitForSlot blocks until the wallclock reached the start of the given slot.
+func (node *Node) WaitForSlot(ctx context.Context, slot phase0.Slot) error {
+	select {
+	case <-time.After(time.Until(node.SlotTime(slot))):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Produce creates a step that builds a block on top of the current head and moves the head to it.
+func Produce(slot phase0.Slot, label string) Step {
+	return Step{
+		Slot: slot,
+		Run: func(node *Node) error {
+			block, err := node.AddBlock(node.GetHead(), slot, label)
+			if err != nil {
+				return err
+			}
+
+			node.SetHead(block)
+			return nil
+		},
+	}
+}
+
+// ProduceRange creates steps that build blocks for all slots in the given range on top of the current head.
+// the blocks are labeled with the prefix & slot number, the missed slots are skipped.
+func ProduceRange(firstSlot phase0.Slot, lastSlot phase0.Slot, labelPrefix string, missedSlots ...phase0.Slot) []Step {
+	missedMap := map[phase0.Slot]bool{}
+	for _, slot := range missedSlots {
+		missedMap[slot] = true
+	}
+
+	steps := []Step{}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if missedMap[slot] {
+			continue
+		}
+
+		steps = append(steps, Produce(slot, fmt.Sprintf("%v%v", labelPrefix, slot)))
+	}
+
+	return steps
+}
+
+// Fork creates a step that builds a block on top of the labeled parent block without changing the head.
+func Fork(slot phase0.Slot, parentLabel string, label string) Step {
+	return Step{
+		Slot: slot,
+		Run: func(node *Node) error {
+			parent := node.GetBlock(parentLabel)
+			if parent == nil {
+				return fmt.Errorf("unknown parent block %v", parentLabel)
+			}
+
+			_, err := node.AddBlock(parent, slot, label)
+			return err
+		},
+	}
+}
+
+// Reorg creates a step that builds a block on top of the labeled parent block and moves the head to it.
+func Reorg(slot phase0.Slot, parentLabel string, label string) Step {
+	return Step{
+		Slot: slot,
+		Run: func(node *Node) error {
+			parent := node.GetBlock(parentLabel)
+			if parent == nil {
+				return fmt.Errorf("unknown parent block %v", parentLabel)
+			}
+
+			block, err := node.AddBlock(parent, slot, label)
+			if err != nil {
+				return err
+			}
+
+			node.SetHead(block)
+			return nil
+		},
+	}
+}
+
+// Finalize creates a step that updates the justified & finalized checkpoints on the chain of the current head.
+func Finalize(slot phase0.Slot, justifiedEpoch phase0.Epoch, finalizedEpoch phase0.Epoch) Step {
+	return Step{
+		Slot: slot,
+		Run: func(node *Node) error {
+			return node.SetFinality(justifiedEpoch, finalizedEpoch)
+		},
+	}
+}
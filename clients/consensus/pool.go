@@ -2,19 +2,25 @@ package consensus
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/ethwallclock"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/rand"
+
+	"github.com/ethpandaops/dora/clients/clienthealth"
 )
 
 type Pool struct {
 	ctx           context.Context
 	logger        logrus.FieldLogger
+	clientsMutex  sync.Mutex
 	clientCounter uint16
-	clients       []*Client
+	clients       []*Client // copy-on-write, replaced instead of modified in place so snapshots can be iterated without lock
 	chainState    *ChainState
 }
 
@@ -44,6 +50,9 @@ func (pool *Pool) GetChainState() *ChainState {
 }
 
 func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
 	clientIdx := pool.clientCounter
 	pool.clientCounter++
 
@@ -57,15 +66,42 @@ func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
 	return client, nil
 }
 
+// RemoveEndpoint stops the given client and removes it from the pool.
+func (pool *Pool) RemoveEndpoint(client *Client) bool {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
+	clients := make([]*Client, 0, len(pool.clients))
+	found := false
+	for _, c := range pool.clients {
+		if c == client {
+			found = true
+			continue
+		}
+		clients = append(clients, c)
+	}
+
+	if !found {
+		return false
+	}
+
+	pool.clients = clients
+	client.stopClient()
+
+	return true
+}
+
 func (pool *Pool) GetAllEndpoints() []*Client {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
 	return pool.clients
 }
 
 func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
 	readyClients := []*Client{}
 
-	for _, client := range pool.clients {
-		if !client.isOnline {
+	for _, client := range pool.GetAllEndpoints() {
+		if !client.isOnline.Load() {
 			continue
 		}
 
@@ -80,9 +116,53 @@ func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
 		readyClients[i], readyClients[j] = readyClients[j], readyClients[i]
 	})
 
+	pool.sortClientsByHealth(readyClients)
+
+	if len(readyClients) == 0 {
+		return nil
+	}
+
 	return readyClients[0]
 }
 
+// getHighestHeadSlot returns the highest head slot of all online clients.
+func (pool *Pool) getHighestHeadSlot() phase0.Slot {
+	highestSlot := phase0.Slot(0)
+	for _, client := range pool.GetAllEndpoints() {
+		if !client.isOnline.Load() {
+			continue
+		}
+
+		headSlot, _ := client.GetLastHead()
+		if headSlot > highestSlot {
+			highestSlot = headSlot
+		}
+	}
+
+	return highestSlot
+}
+
+// sortClientsByHealth sorts the (shuffled) clients by their health.
+// clients with an open circuit breaker are moved to the end, clients with a similar health score keep their random order.
+func (pool *Pool) sortClientsByHealth(clients []*Client) {
+	scores := make(map[*Client]float64, len(clients))
+	for _, client := range clients {
+		scores[client] = client.GetHealthScore()
+	}
+
+	sort.SliceStable(clients, func(a, b int) bool {
+		clientA := clients[a]
+		clientB := clients[b]
+
+		availableA := clientA.IsAvailable()
+		if availableA != clientB.IsAvailable() {
+			return availableA
+		}
+
+		return clienthealth.CompareScores(scores[clientA], scores[clientB]) < 0
+	})
+}
+
 func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType) *Client {
 	for {
 		client := pool.GetReadyEndpoint(clientType)
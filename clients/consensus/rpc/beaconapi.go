@@ -218,6 +218,28 @@ func (bc *BeaconClient) postJSON(ctx context.Context, requrl string, postData, r
 	return nil
 }
 
+// proxyHttpClient is shared by all proxy requests, so connections to the beacon nodes are reused.
+var proxyHttpClient = &nethttp.Client{Timeout: time.Second * 300}
+
+// ProxyRequest forwards a raw GET request for the given api path to the beacon node.
+// The caller is responsible for closing the response body.
+func (bc *BeaconClient) ProxyRequest(ctx context.Context, path string, accept string) (*nethttp.Response, error) {
+	req, err := nethttp.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s", bc.endpoint, path), nethttp.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for headerKey, headerVal := range bc.headers {
+		req.Header.Set(headerKey, headerVal)
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	return proxyHttpClient.Do(req)
+}
+
 func (bc *BeaconClient) GetGenesis(ctx context.Context) (*v1.Genesis, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.GenesisProvider)
 	if !isProvider {
@@ -421,6 +443,45 @@ func (bc *BeaconClient) GetState(ctx context.Context, stateRef string) (*spec.Ve
 	return result.Data, nil
 }
 
+func (bc *BeaconClient) GetProposerDuties(ctx context.Context, epoch phase0.Epoch) ([]*v1.ProposerDuty, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get proposer duties not supported")
+	}
+
+	result, err := provider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (bc *BeaconClient) GetValidatorBalances(ctx context.Context, stateRef string, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.ValidatorBalancesProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get validator balances not supported")
+	}
+
+	result, err := provider.ValidatorBalances(ctx, &api.ValidatorBalancesOpts{
+		State:   stateRef,
+		Indices: indices,
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
 func (bc *BeaconClient) GetBlobSidecarsByBlockroot(ctx context.Context, blockroot []byte) ([]*deneb.BlobSidecar, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.BlobSidecarsProvider)
 	if !isProvider {
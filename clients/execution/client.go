@@ -3,12 +3,14 @@ package execution
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/dora/clients/clienthealth"
 	"github.com/ethpandaops/dora/clients/execution/rpc"
 	"github.com/ethpandaops/dora/clients/sshtunnel"
 )
@@ -28,6 +30,7 @@ type Client struct {
 	clientCtxCancel context.CancelFunc
 	rpcClient       *rpc.ExecutionClient
 	logger          *logrus.Entry
+	isStopped       atomic.Bool
 	isOnline        bool
 	isSyncing       bool
 	versionStr      string
@@ -38,6 +41,7 @@ type Client struct {
 	blockFilterId   rpc.BlockFilterId
 	retryCounter    uint64
 	lastError       error
+	health          *clienthealth.Tracker
 	headMutex       sync.RWMutex
 	headHash        common.Hash
 	headNumber      uint64
@@ -60,6 +64,7 @@ func (pool *Pool) newPoolClient(clientIdx uint16, endpoint *ClientConfig) (*Clie
 		endpointConfig: endpoint,
 		rpcClient:      rpcClient,
 		logger:         logger,
+		health:         clienthealth.NewTracker(),
 	}
 	client.resetContext()
 
@@ -76,6 +81,12 @@ func (client *Client) resetContext() {
 	client.clientCtx, client.clientCtxCancel = context.WithCancel(context.Background())
 }
 
+// stopClient cancels the client context, which terminates the event processing loop and all pending requests.
+func (client *Client) stopClient() {
+	client.isStopped.Store(true)
+	client.clientCtxCancel()
+}
+
 func (client *Client) GetIndex() uint16 {
 	return client.clientIdx
 }
@@ -115,6 +126,31 @@ func (client *Client) GetRPCClient() *rpc.ExecutionClient {
 	return client.rpcClient
 }
 
+func (client *Client) GetHealth() *clienthealth.Tracker {
+	return client.health
+}
+
+// TrackRequest records the result of a request that has been started at the given time for the client health score.
+func (client *Client) TrackRequest(startTime time.Time, err error) {
+	client.health.RecordRequest(time.Since(startTime), err)
+}
+
+// GetHealthScore returns the health score of the client (0-100) based on the request latency, error rate and head lag.
+func (client *Client) GetHealthScore() float64 {
+	headNumber, _ := client.GetLastHead()
+	headLag := uint64(0)
+	if highestNumber := client.pool.getHighestHeadNumber(); highestNumber > headNumber {
+		headLag = highestNumber - headNumber
+	}
+
+	return client.health.GetScore(headLag)
+}
+
+// IsAvailable returns true if the client is not blocked by the circuit breaker.
+func (client *Client) IsAvailable() bool {
+	return !client.health.IsCircuitOpen()
+}
+
 func (client *Client) GetStatus() ClientStatus {
 	switch {
 	case client.isSyncing:
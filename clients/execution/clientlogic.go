@@ -45,8 +45,16 @@ func (client *Client) runClientLoop() {
 			waitTime = 60
 		}
 
+		if client.isStopped.Load() {
+			return
+		}
+
 		client.logger.Warnf("execution client error: %v, retrying in %v sec...", err, waitTime)
-		time.Sleep(time.Duration(waitTime) * time.Second)
+		select {
+		case <-client.clientCtx.Done():
+			return
+		case <-time.After(time.Duration(waitTime) * time.Second):
+		}
 	}
 }
 
@@ -235,7 +243,9 @@ func (client *Client) pollClientHead() error {
 	ctx, cancel := context.WithTimeout(client.clientCtx, 10*time.Second)
 	defer cancel()
 
+	startTime := time.Now()
 	latestHeader, err := client.rpcClient.GetLatestHeader(ctx)
+	client.TrackRequest(startTime, err)
 	if err != nil {
 		return fmt.Errorf("could not get latest header: %v", err)
 	}
@@ -3,14 +3,19 @@ package execution
 import (
 	"context"
 	"math/rand/v2"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/clienthealth"
 )
 
 type Pool struct {
 	ctx           context.Context
 	logger        logrus.FieldLogger
+	clientsMutex  sync.Mutex
 	clientCounter uint16
 	clients       []*Client
 	chainState    *ChainState
@@ -30,6 +35,9 @@ func (pool *Pool) GetChainState() *ChainState {
 }
 
 func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
 	clientIdx := pool.clientCounter
 	pool.clientCounter++
 	client, err := pool.newPoolClient(clientIdx, endpoint)
@@ -43,14 +51,41 @@ func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
 	return client, nil
 }
 
+// RemoveEndpoint stops the given client and removes it from the pool.
+func (pool *Pool) RemoveEndpoint(client *Client) bool {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
+	clients := make([]*Client, 0, len(pool.clients))
+	found := false
+	for _, c := range pool.clients {
+		if c == client {
+			found = true
+			continue
+		}
+		clients = append(clients, c)
+	}
+
+	if !found {
+		return false
+	}
+
+	pool.clients = clients
+	client.stopClient()
+
+	return true
+}
+
 func (pool *Pool) GetAllEndpoints() []*Client {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
 	return pool.clients
 }
 
 func (pool *Pool) GetReadyEndpoints(clientType ClientType) []*Client {
 	readyClients := []*Client{}
 
-	for _, client := range pool.clients {
+	for _, client := range pool.GetAllEndpoints() {
 		if !client.isOnline {
 			continue
 		}
@@ -66,6 +101,8 @@ func (pool *Pool) GetReadyEndpoints(clientType ClientType) []*Client {
 		readyClients[i], readyClients[j] = readyClients[j], readyClients[i]
 	})
 
+	pool.sortClientsByHealth(readyClients)
+
 	return readyClients
 }
 
@@ -78,6 +115,44 @@ func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
 	return readyClients[0]
 }
 
+// getHighestHeadNumber returns the highest head block number of all online clients.
+func (pool *Pool) getHighestHeadNumber() uint64 {
+	highestNumber := uint64(0)
+	for _, client := range pool.GetAllEndpoints() {
+		if !client.isOnline {
+			continue
+		}
+
+		headNumber, _ := client.GetLastHead()
+		if headNumber > highestNumber {
+			highestNumber = headNumber
+		}
+	}
+
+	return highestNumber
+}
+
+// sortClientsByHealth sorts the (shuffled) clients by their health.
+// clients with an open circuit breaker are moved to the end, clients with a similar health score keep their random order.
+func (pool *Pool) sortClientsByHealth(clients []*Client) {
+	scores := make(map[*Client]float64, len(clients))
+	for _, client := range clients {
+		scores[client] = client.GetHealthScore()
+	}
+
+	sort.SliceStable(clients, func(a, b int) bool {
+		clientA := clients[a]
+		clientB := clients[b]
+
+		availableA := clientA.IsAvailable()
+		if availableA != clientB.IsAvailable() {
+			return availableA
+		}
+
+		return clienthealth.CompareScores(scores[clientA], scores[clientB]) < 0
+	})
+}
+
 func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType) *Client {
 	for {
 		client := pool.GetReadyEndpoint(clientType)
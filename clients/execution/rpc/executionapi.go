@@ -231,6 +231,10 @@ func (ec *ExecutionClient) GetBlockByHash(ctx context.Context, hash common.Hash)
 	return block, nil
 }
 
+func (ec *ExecutionClient) GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
+	return ec.ethClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(blockHash, false))
+}
+
 func (ec *ExecutionClient) GetNonceAt(ctx context.Context, wallet common.Address, blockNumber *big.Int) (uint64, error) {
 	return ec.ethClient.NonceAt(ctx, wallet, blockNumber)
 }
@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"flag"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -13,6 +16,8 @@ import (
 
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/handlers"
+	"github.com/ethpandaops/dora/handlers/adminapi"
+	"github.com/ethpandaops/dora/handlers/beaconapi"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/static"
 	"github.com/ethpandaops/dora/types"
@@ -41,6 +46,11 @@ func main() {
 		"release": utils.BuildRelease,
 	}).Printf("starting")
 
+	if len(cfg.Networks) > 0 {
+		runNetworkGateway(ctx, logger, cfg)
+		return
+	}
+
 	db.MustInitDB()
 	err = db.ApplyEmbeddedDbSchema(-2)
 	if err != nil {
@@ -52,11 +62,47 @@ func main() {
 		logger.Fatalf("error starting beacon service: %v", err)
 	}
 
+	if cfg.ClientCompare.ConsensusEnabled {
+		err = services.StartConsensusDivergenceChecker(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting consensus divergence checker: %v", err)
+		}
+	}
+
+	if cfg.ClientCompare.ExecutionEnabled {
+		err = services.StartExecutionDivergenceChecker(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting execution divergence checker: %v", err)
+		}
+	}
+
 	err = services.StartTxSignaturesService()
 	if err != nil {
 		logger.Fatalf("error starting tx signature service: %v", err)
 	}
 
+	if !cfg.Indexer.DisableIndexWriter {
+		err = services.StartRetentionService(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting retention service: %v", err)
+		}
+
+		err = services.StartClientDiversityService(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting client diversity service: %v", err)
+		}
+
+		err = services.StartChartRollupService(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting chart rollup service: %v", err)
+		}
+
+		err = services.StartFinalityMonitor(ctx, logger)
+		if err != nil {
+			logger.Fatalf("error starting finality monitor: %v", err)
+		}
+	}
+
 	if cfg.RateLimit.Enabled {
 		err = services.StartCallRateLimiter(cfg.RateLimit.ProxyCount, cfg.RateLimit.Rate, cfg.RateLimit.Burst)
 		if err != nil {
@@ -86,14 +132,22 @@ func startFrontend(logger logrus.FieldLogger) {
 	router.HandleFunc("/index/data", handlers.IndexData).Methods("GET")
 	router.HandleFunc("/clients/consensus", handlers.ClientsCL).Methods("GET")
 	router.HandleFunc("/clients/execution", handlers.ClientsEl).Methods("GET")
+	router.HandleFunc("/clients/execution/divergences", handlers.ClientsElDivergences).Methods("GET")
+	router.HandleFunc("/clients/diversity", handlers.ClientsDiversity).Methods("GET")
 	router.HandleFunc("/forks", handlers.Forks).Methods("GET")
 	router.HandleFunc("/epochs", handlers.Epochs).Methods("GET")
 	router.HandleFunc("/epoch/{epoch}", handlers.Epoch).Methods("GET")
+	router.HandleFunc("/charts", handlers.Charts).Methods("GET")
+	router.HandleFunc("/finality", handlers.Finality).Methods("GET")
+	router.HandleFunc("/finality/incident/{epoch}", handlers.FinalityIncident).Methods("GET")
 	router.HandleFunc("/slots", handlers.Slots).Methods("GET")
 	router.HandleFunc("/slots/filtered", handlers.SlotsFiltered).Methods("GET")
+	router.HandleFunc("/slots/late", handlers.SlotsLate).Methods("GET")
 	router.HandleFunc("/slot/{slotOrHash}", handlers.Slot).Methods("GET")
 	router.HandleFunc("/slot/{root}/blob/{commitment}", handlers.SlotBlob).Methods("GET")
 	router.HandleFunc("/mev/blocks", handlers.MevBlocks).Methods("GET")
+	router.HandleFunc("/mev/analytics", handlers.MevAnalytics).Methods("GET")
+	router.HandleFunc("/mev/builder/{pubkey}", handlers.MevBuilder).Methods("GET")
 
 	router.HandleFunc("/search", handlers.Search).Methods("GET")
 	router.HandleFunc("/search/{type}", handlers.SearchAhead).Methods("GET")
@@ -109,6 +163,35 @@ func startFrontend(logger logrus.FieldLogger) {
 
 	router.HandleFunc("/identicon", handlers.Identicon).Methods("GET")
 
+	if utils.Config.BeaconApiProxy.Enabled {
+		// beacon api compatible endpoints
+		router.HandleFunc("/eth/v1/beacon/headers/{block_id}", beaconapi.BlockHeader).Methods("GET")
+		router.HandleFunc("/eth/v2/beacon/blocks/{block_id}", beaconapi.Block).Methods("GET")
+		router.HandleFunc("/eth/v1/beacon/blob_sidecars/{block_id}", beaconapi.BlobSidecars).Methods("GET")
+		router.HandleFunc("/eth/v1/beacon/states/{state_id}/finality_checkpoints", beaconapi.FinalityCheckpoints).Methods("GET")
+		router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators", beaconapi.Validators).Methods("GET")
+		router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators/{validator_id}", beaconapi.Validator).Methods("GET")
+		router.HandleFunc("/eth/v1/validator/duties/proposer/{epoch}", beaconapi.ProposerDuties).Methods("GET")
+	}
+
+	if utils.Config.AdminApi.Enabled {
+		// admin api to manage endpoints at runtime
+		adminRouter := router.PathPrefix("/api/admin").Subrouter()
+		adminRouter.Use(adminapi.AuthMiddleware)
+		adminRouter.HandleFunc("/endpoints", adminapi.Endpoints).Methods("GET")
+		adminRouter.HandleFunc("/endpoints/{type}", adminapi.AddEndpoint).Methods("POST")
+		adminRouter.HandleFunc("/endpoints/{type}/{name}", adminapi.UpdateEndpoint).Methods("PATCH")
+		adminRouter.HandleFunc("/endpoints/{type}/{name}", adminapi.RemoveEndpoint).Methods("DELETE")
+		adminRouter.HandleFunc("/backfill", adminapi.Backfill).Methods("GET")
+		adminRouter.HandleFunc("/backfill", adminapi.StartBackfill).Methods("POST")
+		adminRouter.HandleFunc("/backfill", adminapi.StopBackfill).Methods("DELETE")
+		adminRouter.HandleFunc("/era", adminapi.EraFiles).Methods("GET")
+		adminRouter.HandleFunc("/era/{era}", adminapi.EraFile).Methods("GET")
+
+		// admin pages (authenticated via the admin api token)
+		router.HandleFunc("/admin/indexer", handlers.AdminIndexer).Methods("GET", "POST")
+	}
+
 	if utils.Config.Frontend.Pprof {
 		// add pprof handler
 		router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
@@ -132,7 +215,11 @@ func startFrontend(logger logrus.FieldLogger) {
 	n := negroni.New()
 	n.Use(negroni.NewRecovery())
 	//n.Use(gzip.Gzip(gzip.DefaultCompression))
-	n.UseHandler(router)
+	if basePath := utils.GetBasePath(); basePath != "" {
+		n.UseHandler(getBasePathHandler(basePath, router))
+	} else {
+		n.UseHandler(router)
+	}
 
 	if utils.Config.Frontend.HttpWriteTimeout == 0 {
 		utils.Config.Frontend.HttpWriteTimeout = time.Second * 15
@@ -151,6 +238,23 @@ func startFrontend(logger logrus.FieldLogger) {
 		Handler:      n,
 	}
 
+	if socketPath := utils.Config.Server.Socket; socketPath != "" {
+		// remove stale socket file from a previous run
+		os.Remove(socketPath)
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			logger.Fatalf("error listening on socket %v: %v", socketPath, err)
+		}
+
+		logger.Printf("http server listening on %v", socketPath)
+		go func() {
+			if err := srv.Serve(listener); err != nil {
+				logger.WithError(err).Fatal("Error serving frontend")
+			}
+		}()
+		return
+	}
+
 	logger.Printf("http server listening on %v", srv.Addr)
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
@@ -158,3 +262,19 @@ func startFrontend(logger logrus.FieldLogger) {
 		}
 	}()
 }
+
+// getBasePathHandler serves the router under the given base path, requests outside of the base path are not found.
+func getBasePathHandler(basePath string, router http.Handler) http.Handler {
+	prefixHandler := http.StripPrefix(basePath, router)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == basePath:
+			http.Redirect(w, r, basePath+"/", http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, basePath+"/"):
+			prefixHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
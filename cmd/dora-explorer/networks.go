@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/negroni"
+
+	"github.com/ethpandaops/dora/static"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// networkInstance is a dora explorer process serving a single network.
+// each instance runs with its own config file, so endpoints, database & caches are fully isolated.
+// the process serves its frontend on a unix socket, so there are no local ports to allocate.
+type networkInstance struct {
+	logger     logrus.FieldLogger
+	config     *types.NetworkConfig
+	socketPath string
+	basePath   string
+	proxy      *httputil.ReverseProxy
+
+	onlineMutex sync.RWMutex
+	online      bool
+}
+
+// runNetworkGateway starts a dora process for each configured network and serves all of them via a shared http server.
+func runNetworkGateway(ctx context.Context, logger logrus.FieldLogger, cfg *types.Config) {
+	executable, err := os.Executable()
+	if err != nil {
+		logger.Fatalf("error resolving executable path: %v", err)
+	}
+
+	socketDir, err := os.MkdirTemp("", "dora-networks-")
+	if err != nil {
+		logger.Fatalf("error creating socket directory: %v", err)
+	}
+	defer os.RemoveAll(socketDir)
+
+	instances := make([]*networkInstance, 0, len(cfg.Networks))
+	for idx := range cfg.Networks {
+		socketPath := filepath.Join(socketDir, fmt.Sprintf("network-%v.sock", idx))
+		instance := newNetworkInstance(logger, &cfg.Networks[idx], socketPath)
+		instances = append(instances, instance)
+		go instance.runProcessLoop(ctx, executable)
+		go instance.runHealthLoop(ctx)
+	}
+
+	startNetworkGateway(logger, instances)
+
+	utils.WaitForCtrlC()
+	logger.Println("exiting...")
+}
+
+func newNetworkInstance(logger logrus.FieldLogger, config *types.NetworkConfig, socketPath string) *networkInstance {
+	instance := &networkInstance{
+		logger:     logger.WithField("network", config.Name),
+		config:     config,
+		socketPath: socketPath,
+		proxy:      httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: "localhost"}),
+	}
+	if config.Host == "" {
+		// the network process serves its frontend under the path prefix, so requests are proxied unmodified
+		instance.basePath = strings.TrimSuffix(config.PathPrefix, "/")
+	}
+
+	instance.proxy.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	instance.proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		instance.logger.Debugf("proxy request failed: %v", err)
+		http.Error(w, fmt.Sprintf("network %v is currently unavailable", config.DisplayName), http.StatusServiceUnavailable)
+	}
+
+	return instance
+}
+
+// runProcessLoop runs the network process and restarts it with increasing backoff when it exits.
+func (instance *networkInstance) runProcessLoop(ctx context.Context, executable string) {
+	backoff := 5 * time.Second
+
+	for {
+		startTime := time.Now()
+		cmd := exec.CommandContext(ctx, executable, "-config", instance.config.ConfigPath)
+		cmd.Env = append(getNetworkProcessEnv(),
+			"FRONTEND_SERVER_SOCKET="+instance.socketPath,
+			"FRONTEND_BASE_PATH="+instance.basePath,
+		)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Cancel = func() error {
+			return cmd.Process.Signal(os.Interrupt)
+		}
+		cmd.WaitDelay = 30 * time.Second
+
+		instance.logger.Infof("starting network process (config: %v, socket: %v)", instance.config.ConfigPath, instance.socketPath)
+		err := cmd.Run()
+
+		instance.setOnline(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startTime) > 5*time.Minute {
+			backoff = 5 * time.Second
+		}
+		instance.logger.Warnf("network process exited (%v), restarting in %v", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff < 5*time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// getNetworkProcessEnv returns the environment for the network processes.
+// config overrides via environment variables are meant for the gateway, so they are not passed to the networks.
+func getNetworkProcessEnv() []string {
+	configEnvNames := map[string]bool{}
+	collectConfigEnvNames(reflect.TypeOf(types.Config{}), "", configEnvNames)
+
+	env := []string{}
+	for _, envVar := range os.Environ() {
+		envName, _, _ := strings.Cut(envVar, "=")
+		if configEnvNames[strings.ToUpper(envName)] {
+			continue
+		}
+		env = append(env, envVar)
+	}
+
+	return env
+}
+
+// collectConfigEnvNames collects the environment variable names processed by envconfig for the given config struct.
+// envconfig accepts both, the plain envconfig tag & the tag prefixed with the names of the parent structs.
+func collectConfigEnvNames(structType reflect.Type, prefix string, names map[string]bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		key := field.Name
+		if tag := field.Tag.Get("envconfig"); tag != "" {
+			key = tag
+			names[strings.ToUpper(tag)] = true
+		}
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		names[strings.ToUpper(key)] = true
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType.PkgPath() == "" {
+			collectConfigEnvNames(fieldType, key, names)
+		}
+	}
+}
+
+// runHealthLoop periodically checks if the network process accepts connections.
+func (instance *networkInstance) runHealthLoop(ctx context.Context) {
+	for {
+		conn, err := net.DialTimeout("unix", instance.socketPath, 2*time.Second)
+		if err == nil {
+			conn.Close()
+		}
+		instance.setOnline(err == nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+func (instance *networkInstance) setOnline(online bool) {
+	instance.onlineMutex.Lock()
+	defer instance.onlineMutex.Unlock()
+	instance.online = online
+}
+
+func (instance *networkInstance) isOnline() bool {
+	instance.onlineMutex.RLock()
+	defer instance.onlineMutex.RUnlock()
+	return instance.online
+}
+
+func (instance *networkInstance) getUrl() string {
+	if instance.config.Host != "" {
+		return "//" + instance.config.Host + "/"
+	}
+	return instance.basePath + "/"
+}
+
+// networkGateway routes requests to the network instances.
+// requests are matched by host first and then by path prefix.
+type networkGateway struct {
+	instances  []*networkInstance
+	fileServer http.Handler
+}
+
+func startNetworkGateway(logger logrus.FieldLogger, instances []*networkInstance) {
+	fileSys := http.FS(static.Files)
+	gateway := &networkGateway{
+		instances:  instances,
+		fileServer: http.FileServer(fileSys),
+	}
+
+	n := negroni.New()
+	n.Use(negroni.NewRecovery())
+	n.UseHandler(gateway)
+
+	if utils.Config.Frontend.HttpWriteTimeout == 0 {
+		utils.Config.Frontend.HttpWriteTimeout = time.Second * 15
+	}
+	if utils.Config.Frontend.HttpReadTimeout == 0 {
+		utils.Config.Frontend.HttpReadTimeout = time.Second * 15
+	}
+	if utils.Config.Frontend.HttpIdleTimeout == 0 {
+		utils.Config.Frontend.HttpIdleTimeout = time.Second * 60
+	}
+	srv := &http.Server{
+		Addr:         utils.Config.Server.Host + ":" + utils.Config.Server.Port,
+		WriteTimeout: utils.Config.Frontend.HttpWriteTimeout,
+		ReadTimeout:  utils.Config.Frontend.HttpReadTimeout,
+		IdleTimeout:  utils.Config.Frontend.HttpIdleTimeout,
+		Handler:      n,
+	}
+
+	logger.Printf("network gateway listening on %v", srv.Addr)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil {
+			logger.WithError(err).Fatal("Error serving network gateway")
+		}
+	}()
+}
+
+func (gateway *networkGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// host based routing
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, instance := range gateway.instances {
+		if instance.config.Host != "" && strings.EqualFold(instance.config.Host, host) {
+			instance.proxy.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	// path prefix based routing
+	if instance := gateway.getInstanceByPath(r.URL.Path); instance != nil {
+		instance.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	// shared static files
+	if r.URL.Path != "/" && gateway.isStaticFile(r.URL.Path) {
+		gateway.fileServer.ServeHTTP(w, r)
+		return
+	}
+
+	gateway.serveNetworksPage(w, r)
+}
+
+// getInstanceByPath returns the network instance with a base path matching the given path.
+func (gateway *networkGateway) getInstanceByPath(path string) *networkInstance {
+	for _, instance := range gateway.instances {
+		if instance.basePath == "" {
+			continue
+		}
+		if path == instance.basePath || strings.HasPrefix(path, instance.basePath+"/") {
+			return instance
+		}
+	}
+
+	return nil
+}
+
+func (gateway *networkGateway) isStaticFile(path string) bool {
+	f, err := static.Files.Open(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	return err == nil && !stat.IsDir()
+}
+
+func (gateway *networkGateway) serveNetworksPage(w http.ResponseWriter, r *http.Request) {
+	pageData := &models.NetworksPageData{
+		Networks: make([]*models.NetworksPageDataNetwork, len(gateway.instances)),
+	}
+	for idx, instance := range gateway.instances {
+		pageData.Networks[idx] = &models.NetworksPageDataNetwork{
+			Name:        instance.config.Name,
+			DisplayName: instance.config.DisplayName,
+			Url:         instance.getUrl(),
+			Online:      instance.isOnline(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if r.URL.Path != "/" && r.URL.Path != "/networks" {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	networksTemplate := templates.GetTemplate("networks/networks.html")
+	if err := networksTemplate.ExecuteTemplate(w, "networks", pageData); err != nil {
+		logrus.WithError(err).Errorf("error executing networks template for %v route", r.URL.String())
+	}
+}
@@ -13,3 +13,8 @@ var DefaultConfigYml string
 //
 //go:embed *.names.yml
 var ValidatorNamesYml embed.FS
+
+// bundled function & event signatures
+//
+//go:embed txsig.signatures.txt
+var TxSignaturesTxt string
@@ -5,11 +5,10 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertBlob(blob *dbtypes.Blob, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertBlob(blob *dbtypes.Blob, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO blobs (
 				commitment, proof, size, blob
@@ -29,8 +28,8 @@ func InsertBlob(blob *dbtypes.Blob, tx *sqlx.Tx) error {
 	return nil
 }
 
-func InsertBlobAssignment(blobAssignment *dbtypes.BlobAssignment, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertBlobAssignment(blobAssignment *dbtypes.BlobAssignment, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO blob_assignments (
 				root, commitment, slot
@@ -48,7 +47,7 @@ func InsertBlobAssignment(blobAssignment *dbtypes.BlobAssignment, tx *sqlx.Tx) e
 	return nil
 }
 
-func GetBlob(commitment []byte, withData bool) *dbtypes.Blob {
+func (store *sqlStore) GetBlob(commitment []byte, withData bool) *dbtypes.Blob {
 	blob := dbtypes.Blob{}
 	var sql strings.Builder
 	fmt.Fprintf(&sql, `SELECT commitment, proof, size`)
@@ -56,16 +55,16 @@ func GetBlob(commitment []byte, withData bool) *dbtypes.Blob {
 		fmt.Fprintf(&sql, `, blob`)
 	}
 	fmt.Fprintf(&sql, ` FROM blobs WHERE commitment = $1`)
-	err := ReaderDb.Get(&blob, sql.String(), commitment)
+	err := ReaderDb.GetContext(store.ctx, &blob, sql.String(), commitment)
 	if err != nil {
 		return nil
 	}
 	return &blob
 }
 
-func GetLatestBlobAssignment(commitment []byte) *dbtypes.BlobAssignment {
+func (store *sqlStore) GetLatestBlobAssignment(commitment []byte) *dbtypes.BlobAssignment {
 	blobAssignment := dbtypes.BlobAssignment{}
-	err := ReaderDb.Get(&blobAssignment, "SELECT root, commitment, slot FROM blob_assignments WHERE commitment = $1 ORDER BY slot DESC LIMIT 1", commitment)
+	err := ReaderDb.GetContext(store.ctx, &blobAssignment, "SELECT root, commitment, slot FROM blob_assignments WHERE commitment = $1 ORDER BY slot DESC LIMIT 1", commitment)
 	if err != nil {
 		return nil
 	}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func (store *sqlStore) InsertBlockBody(body *dbtypes.BlockBody, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO block_bodies (
+				root, slot, header_ver, header_ssz, block_ver, block_ssz
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (root) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO block_bodies (
+				root, slot, header_ver, header_ssz, block_ver, block_ssz
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
+	}),
+		body.Root, body.Slot, body.HeaderVer, body.HeaderSSZ, body.BlockVer, body.BlockSSZ)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *sqlStore) GetBlockBody(root []byte) *dbtypes.BlockBody {
+	body := dbtypes.BlockBody{}
+	err := ReaderDb.GetContext(store.ctx, &body, `
+	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz
+	FROM block_bodies
+	WHERE root = $1
+	`, root)
+	if err != nil {
+		return nil
+	}
+	return &body
+}
+
+// GetCanonicalBlockBodyBySlot returns the persisted body of the canonical block in the given slot.
+// bodies of blocks that turned out to be orphaned after they have been persisted are skipped.
+func (store *sqlStore) GetCanonicalBlockBodyBySlot(slot uint64) *dbtypes.BlockBody {
+	body := dbtypes.BlockBody{}
+	err := ReaderDb.GetContext(store.ctx, &body, `
+	SELECT block_bodies.root, block_bodies.slot, block_bodies.header_ver, block_bodies.header_ssz, block_bodies.block_ver, block_bodies.block_ssz
+	FROM block_bodies
+	JOIN slots ON slots.root = block_bodies.root
+	WHERE block_bodies.slot = $1 AND slots.status = 1
+	LIMIT 1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &body
+}
+
+func (store *sqlStore) DeleteBlockBodiesBefore(slot uint64, limit uint32, tx Tx) (int64, error) {
+	res, err := sqlTx(tx).Exec(`
+	DELETE FROM block_bodies
+	WHERE root IN (
+		SELECT root FROM block_bodies WHERE slot < $1 LIMIT $2
+	)`, slot, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
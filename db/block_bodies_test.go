@@ -0,0 +1,66 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestBlockBodies(t *testing.T) {
+	newTestSqliteDb(t)
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		for _, slot := range []*dbtypes.Slot{
+			{Slot: 10, Root: []byte{0x10}, Status: dbtypes.Canonical},
+			{Slot: 11, Root: []byte{0x11}, Status: dbtypes.Canonical},
+			{Slot: 11, Root: []byte{0x12}, Status: dbtypes.Orphaned},
+			{Slot: 12, Root: []byte{0x13}, Status: dbtypes.Canonical},
+		} {
+			if err := db.InsertSlot(slot, tx); err != nil {
+				return err
+			}
+			if err := db.InsertBlockBody(&dbtypes.BlockBody{
+				Root:      slot.Root,
+				Slot:      slot.Slot,
+				HeaderVer: 1,
+				HeaderSSZ: []byte{0x01},
+				BlockVer:  1,
+				BlockSSZ:  slot.Root,
+			}, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed inserting block bodies: %v", err)
+	}
+
+	if body := db.GetBlockBody([]byte{0x12}); body == nil || body.Slot != 11 {
+		t.Errorf("expected body of block 0x12, got %v", body)
+	}
+
+	// the body of the block that got orphaned after it has been persisted is skipped
+	if body := db.GetCanonicalBlockBodyBySlot(11); body == nil || body.Root[0] != 0x11 {
+		t.Errorf("expected the canonical body of slot 11, got %v", body)
+	}
+	if body := db.GetCanonicalBlockBodyBySlot(13); body != nil {
+		t.Errorf("expected no body for slot 13, got %v", body)
+	}
+
+	var deleted int64
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		deleted, err = db.DeleteBlockBodiesBefore(12, 100, tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed deleting block bodies: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected 3 deleted block bodies, got %v", deleted)
+	}
+	if db.GetBlockBody([]byte{0x11}) != nil || db.GetBlockBody([]byte{0x13}) == nil {
+		t.Errorf("expected the bodies before slot 12 to be deleted only")
+	}
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func (store *sqlStore) InsertBlockTimings(timings []*dbtypes.BlockTiming, tx Tx) error {
+	if len(timings) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO block_timings ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO block_timings ",
+		}),
+		"(root, slot, client_name, seen_delay, head_delay)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 5
+
+	args := make([]any, len(timings)*fieldCount)
+	for i, timing := range timings {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = timing.Root
+		args[argIdx+1] = timing.Slot
+		args[argIdx+2] = timing.ClientName
+		args[argIdx+3] = timing.SeenDelay
+		args[argIdx+4] = timing.HeadDelay
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (root, client_name) DO UPDATE SET seen_delay = excluded.seen_delay, head_delay = excluded.head_delay",
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *sqlStore) GetBlockTimingsByRoot(root []byte) []*dbtypes.BlockTiming {
+	timings := []*dbtypes.BlockTiming{}
+	err := ReaderDb.SelectContext(store.ctx, &timings, `
+	SELECT root, slot, client_name, seen_delay, head_delay
+	FROM block_timings
+	WHERE root = $1
+	ORDER BY seen_delay ASC`, root)
+	if err != nil {
+		logger.Errorf("Error while fetching block timings: %v", err)
+		return nil
+	}
+	return timings
+}
+
+// GetLateBlocks returns the blocks since the given slot that have not been seen by any client before minDelay milliseconds after the slot start.
+func (store *sqlStore) GetLateBlocks(firstSlot uint64, minDelay int64, offset uint64, limit uint32) ([]*dbtypes.LateBlock, uint64, error) {
+	var totalCount uint64
+	err := ReaderDb.GetContext(store.ctx, &totalCount, `
+	SELECT COUNT(*) FROM (
+		SELECT root
+		FROM block_timings
+		WHERE slot >= $1
+		GROUP BY root
+		HAVING MIN(seen_delay) >= $2
+	) AS late_blocks`, firstSlot, minDelay)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lateBlocks := []*dbtypes.LateBlock{}
+	err = ReaderDb.SelectContext(store.ctx, &lateBlocks, `
+	SELECT
+		block_timings.slot, block_timings.root, COALESCE(slots.proposer, 0) AS proposer, COALESCE(slots.status, 0) AS status,
+		COUNT(*) AS client_count, MIN(block_timings.seen_delay) AS min_seen_delay,
+		AVG(block_timings.seen_delay) AS avg_seen_delay, MAX(block_timings.seen_delay) AS max_seen_delay
+	FROM block_timings
+	LEFT JOIN slots ON slots.slot = block_timings.slot AND slots.root = block_timings.root
+	WHERE block_timings.slot >= $1
+	GROUP BY block_timings.slot, block_timings.root, slots.proposer, slots.status
+	HAVING MIN(block_timings.seen_delay) >= $2
+	ORDER BY block_timings.slot DESC
+	LIMIT $3 OFFSET $4`, firstSlot, minDelay, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching late blocks: %v", err)
+		return nil, 0, err
+	}
+
+	return lateBlocks, totalCount, nil
+}
+
+// GetBlockTimingClientStats returns the average arrival & head switch delays per client for blocks since the given slot.
+func (store *sqlStore) GetBlockTimingClientStats(firstSlot uint64, lateDelay int64) []*dbtypes.BlockTimingClientStats {
+	clientStats := []*dbtypes.BlockTimingClientStats{}
+	err := ReaderDb.SelectContext(store.ctx, &clientStats, `
+	SELECT
+		client_name, COUNT(*) AS block_count,
+		SUM(CASE WHEN seen_delay >= $2 THEN 1 ELSE 0 END) AS late_count,
+		AVG(seen_delay) AS avg_seen_delay, MAX(seen_delay) AS max_seen_delay,
+		COUNT(head_delay) AS head_count, COALESCE(AVG(head_delay - seen_delay), 0) AS avg_switch_delay
+	FROM block_timings
+	WHERE slot >= $1
+	GROUP BY client_name
+	ORDER BY client_name ASC`, firstSlot, lateDelay)
+	if err != nil {
+		logger.Errorf("Error while fetching block timing client stats: %v", err)
+		return nil
+	}
+	return clientStats
+}
@@ -0,0 +1,85 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func insertTestBlockTimings(t *testing.T, timings []*dbtypes.BlockTiming) {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		return db.InsertBlockTimings(timings, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed inserting block timings: %v", err)
+	}
+}
+
+func TestGetLateBlocks(t *testing.T) {
+	newTestSqliteDb(t)
+
+	headDelay := int64(4500)
+	insertTestBlockTimings(t, []*dbtypes.BlockTiming{
+		// late on all clients, but before the first slot
+		{Root: []byte{0x01}, Slot: 10, ClientName: "lighthouse", SeenDelay: 5000},
+		// late on all clients
+		{Root: []byte{0x02}, Slot: 20, ClientName: "lighthouse", SeenDelay: 4100, HeadDelay: &headDelay},
+		{Root: []byte{0x02}, Slot: 20, ClientName: "teku", SeenDelay: 4300},
+		// seen in time by one client
+		{Root: []byte{0x03}, Slot: 21, ClientName: "lighthouse", SeenDelay: 4100},
+		{Root: []byte{0x03}, Slot: 21, ClientName: "teku", SeenDelay: 1200},
+		// late on all clients
+		{Root: []byte{0x04}, Slot: 22, ClientName: "teku", SeenDelay: 4000},
+	})
+
+	lateBlocks, totalCount, err := db.GetLateBlocks(15, 4000, 0, 10)
+	if err != nil {
+		t.Fatalf("failed fetching late blocks: %v", err)
+	}
+	if totalCount != 2 || len(lateBlocks) != 2 {
+		t.Fatalf("expected 2 late blocks since slot 15, got %v (total %v)", len(lateBlocks), totalCount)
+	}
+	if lateBlocks[0].Slot != 22 || lateBlocks[1].Slot != 20 {
+		t.Errorf("expected late blocks 22 & 20, got %v & %v", lateBlocks[0].Slot, lateBlocks[1].Slot)
+	}
+	if lateBlocks[1].ClientCount != 2 || lateBlocks[1].MinSeenDelay != 4100 || lateBlocks[1].MaxSeenDelay != 4300 || lateBlocks[1].AvgSeenDelay != 4200 {
+		t.Errorf("unexpected stats for late block 20: %+v", lateBlocks[1])
+	}
+
+	lateBlocks, totalCount, err = db.GetLateBlocks(0, 4000, 1, 10)
+	if err != nil {
+		t.Fatalf("failed fetching late blocks: %v", err)
+	}
+	if totalCount != 3 || len(lateBlocks) != 2 || lateBlocks[1].Slot != 10 {
+		t.Errorf("expected the 2nd page to contain late blocks 20 & 10 (total 3), got %v (total %v)", len(lateBlocks), totalCount)
+	}
+}
+
+func TestGetBlockTimingClientStats(t *testing.T) {
+	newTestSqliteDb(t)
+
+	headDelay := int64(2500)
+	insertTestBlockTimings(t, []*dbtypes.BlockTiming{
+		{Root: []byte{0x01}, Slot: 10, ClientName: "lighthouse", SeenDelay: 9000},
+		{Root: []byte{0x02}, Slot: 20, ClientName: "lighthouse", SeenDelay: 2000, HeadDelay: &headDelay},
+		{Root: []byte{0x03}, Slot: 21, ClientName: "lighthouse", SeenDelay: 5000},
+		{Root: []byte{0x02}, Slot: 20, ClientName: "teku", SeenDelay: 1000},
+	})
+
+	clientStats := db.GetBlockTimingClientStats(15, 4000)
+	if len(clientStats) != 2 {
+		t.Fatalf("expected stats for 2 clients, got %v", len(clientStats))
+	}
+
+	lighthouse := clientStats[0]
+	if lighthouse.ClientName != "lighthouse" || lighthouse.BlockCount != 2 || lighthouse.LateCount != 1 {
+		t.Errorf("expected 2 lighthouse blocks with 1 late block, got %+v", lighthouse)
+	}
+	if lighthouse.AvgSeenDelay != 3500 || lighthouse.MaxSeenDelay != 5000 || lighthouse.HeadCount != 1 || lighthouse.AvgSwitchDelay != 500 {
+		t.Errorf("unexpected lighthouse delays: %+v", lighthouse)
+	}
+	if teku := clientStats[1]; teku.ClientName != "teku" || teku.BlockCount != 1 || teku.LateCount != 0 || teku.HeadCount != 0 {
+		t.Errorf("unexpected teku stats: %+v", teku)
+	}
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// ChartRollupStateKey is the explorer state key of the chart rollup progress (dbtypes.ChartRollupState).
+const ChartRollupStateKey = "chartrollups.state"
+
+// InvalidateChartRollups rewinds the chart rollup progress to the given epoch, so the rollups of its day are rebuilt.
+// needs to be called within the transaction that (re)writes an epoch that might have been rolled up already.
+func InvalidateChartRollups(epoch uint64, tx Tx) error {
+	rollupState := dbtypes.ChartRollupState{}
+	_, err := GetExplorerStateForUpdate(ChartRollupStateKey, &rollupState, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no rollups built yet
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if rollupState.Epoch <= epoch {
+		return nil
+	}
+
+	rollupState.Epoch = epoch
+	return SetExplorerState(ChartRollupStateKey, &rollupState, tx)
+}
+
+func (store *sqlStore) InsertChartRollups(rollups []*dbtypes.ChartRollup, tx Tx) error {
+	if len(rollups) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO chart_rollups ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO chart_rollups ",
+		}),
+		`(period, time, first_epoch, last_epoch, epoch_count, validator_count, validator_balance, target_participation,
+		head_participation, total_participation, sync_participation, block_count, orphaned_count, deposit_count, exit_count, blob_count)`,
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 16
+
+	args := make([]any, len(rollups)*fieldCount)
+	for i, rollup := range rollups {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = rollup.Period
+		args[argIdx+1] = rollup.Time
+		args[argIdx+2] = rollup.FirstEpoch
+		args[argIdx+3] = rollup.LastEpoch
+		args[argIdx+4] = rollup.EpochCount
+		args[argIdx+5] = rollup.ValidatorCount
+		args[argIdx+6] = rollup.ValidatorBalance
+		args[argIdx+7] = rollup.TargetParticipation
+		args[argIdx+8] = rollup.HeadParticipation
+		args[argIdx+9] = rollup.TotalParticipation
+		args[argIdx+10] = rollup.SyncParticipation
+		args[argIdx+11] = rollup.BlockCount
+		args[argIdx+12] = rollup.OrphanedCount
+		args[argIdx+13] = rollup.DepositCount
+		args[argIdx+14] = rollup.ExitCount
+		args[argIdx+15] = rollup.BlobCount
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: ` ON CONFLICT (period, time) DO UPDATE SET
+			first_epoch = excluded.first_epoch,
+			last_epoch = excluded.last_epoch,
+			epoch_count = excluded.epoch_count,
+			validator_count = excluded.validator_count,
+			validator_balance = excluded.validator_balance,
+			target_participation = excluded.target_participation,
+			head_participation = excluded.head_participation,
+			total_participation = excluded.total_participation,
+			sync_participation = excluded.sync_participation,
+			block_count = excluded.block_count,
+			orphaned_count = excluded.orphaned_count,
+			deposit_count = excluded.deposit_count,
+			exit_count = excluded.exit_count,
+			blob_count = excluded.blob_count`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetChartRollups returns the rollups of the given period (bucket size in seconds) with a bucket time in the given range.
+func (store *sqlStore) GetChartRollups(period uint32, firstTime uint64, lastTime uint64) []*dbtypes.ChartRollup {
+	rollups := []*dbtypes.ChartRollup{}
+	err := ReaderDb.SelectContext(store.ctx, &rollups, `
+	SELECT
+		period, time, first_epoch, last_epoch, epoch_count, validator_count, validator_balance, target_participation,
+		head_participation, total_participation, sync_participation, block_count, orphaned_count, deposit_count, exit_count, blob_count
+	FROM chart_rollups
+	WHERE period = $1 AND time >= $2 AND time <= $3
+	ORDER BY time ASC
+	`, period, firstTime, lastTime)
+	if err != nil {
+		logger.Errorf("Error while fetching chart rollups: %v", err)
+		return nil
+	}
+	return rollups
+}
@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestChartRollupsMigrationSchedulesBlobCountBackfill(t *testing.T) {
+	// schema right before the chart rollups migration
+	newTestSqliteDbAtVersion(t, 20241001120000)
+
+	for _, epoch := range []uint64{0, 1, 2, 150} {
+		if err := db.ExecWriterQuery(`INSERT INTO epochs (epoch, block_count) VALUES ($1, 32)`, epoch); err != nil {
+			t.Fatalf("failed inserting epoch %v: %v", epoch, err)
+		}
+	}
+
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{}
+	if _, err := db.GetExplorerState("chartrollups.blobbackfill", backfillState); err != nil {
+		t.Fatalf("expected the blob count backfill to be scheduled: %v", err)
+	}
+	if backfillState.NextEpoch != 0 || backfillState.EndEpoch != 151 {
+		t.Errorf("expected backfill of epochs 0 - 150, got %v - %v", backfillState.NextEpoch, backfillState.EndEpoch)
+	}
+
+	epochs := db.GetEpochs(150, 1)
+	if len(epochs) != 1 || epochs[0].BlobCount != 0 || epochs[0].BlockCount != 32 {
+		t.Errorf("expected the migrated epoch to be readable, got %v", epochs)
+	}
+}
+
+func TestChartRollupsMigrationWithoutEpochs(t *testing.T) {
+	newTestSqliteDb(t)
+
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{}
+	if _, err := db.GetExplorerState("chartrollups.blobbackfill", backfillState); err == nil {
+		t.Errorf("expected no blob count backfill for an empty db, got %v", backfillState)
+	}
+}
+
+func TestInvalidateChartRollups(t *testing.T) {
+	newTestSqliteDb(t)
+
+	invalidate := func(epoch uint64) {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			return db.InvalidateChartRollups(epoch, tx)
+		})
+		if err != nil {
+			t.Fatalf("failed invalidating chart rollups: %v", err)
+		}
+	}
+	getRollupEpoch := func() uint64 {
+		rollupState := &dbtypes.ChartRollupState{}
+		if _, err := db.GetExplorerState(db.ChartRollupStateKey, rollupState); err != nil {
+			return 0
+		}
+		return rollupState.Epoch
+	}
+
+	// nothing rolled up yet
+	invalidate(10)
+	if _, err := db.GetExplorerState(db.ChartRollupStateKey, &dbtypes.ChartRollupState{}); err == nil {
+		t.Errorf("expected no rollup state to be created")
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		return db.SetExplorerState(db.ChartRollupStateKey, &dbtypes.ChartRollupState{Epoch: 100}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed setting rollup state: %v", err)
+	}
+
+	// epochs that have not been rolled up yet don't rewind the state
+	invalidate(100)
+	if epoch := getRollupEpoch(); epoch != 100 {
+		t.Errorf("expected rollup state 100, got %v", epoch)
+	}
+
+	invalidate(40)
+	if epoch := getRollupEpoch(); epoch != 40 {
+		t.Errorf("expected rollup state to be rewound to 40, got %v", epoch)
+	}
+}
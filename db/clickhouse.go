@@ -0,0 +1,776 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+var clickhouseSchema = []string{
+	`CREATE TABLE IF NOT EXISTS epochs (
+		epoch UInt64,
+		validator_count UInt64,
+		validator_balance UInt64,
+		eligible UInt64,
+		voted_target UInt64,
+		voted_head UInt64,
+		voted_total UInt64,
+		block_count UInt16,
+		orphaned_count UInt16,
+		attestation_count UInt64,
+		deposit_count UInt64,
+		exit_count UInt64,
+		withdraw_count UInt64,
+		withdraw_amount UInt64,
+		attester_slashing_count UInt64,
+		proposer_slashing_count UInt64,
+		bls_change_count UInt64,
+		eth_transaction_count UInt64,
+		sync_participation Float32,
+		blob_count UInt64
+	) ENGINE = ReplacingMergeTree ORDER BY epoch`,
+	`ALTER TABLE epochs ADD COLUMN IF NOT EXISTS blob_count UInt64`,
+	`CREATE TABLE IF NOT EXISTS client_diversity (
+		epoch UInt64,
+		cl_client UInt8,
+		el_client UInt8,
+		block_count UInt64
+	) ENGINE = ReplacingMergeTree ORDER BY (epoch, cl_client, el_client)`,
+	`CREATE TABLE IF NOT EXISTS chart_rollups (
+		period UInt32,
+		time UInt64,
+		first_epoch UInt64,
+		last_epoch UInt64,
+		epoch_count UInt32,
+		validator_count UInt64,
+		validator_balance UInt64,
+		target_participation Float32,
+		head_participation Float32,
+		total_participation Float32,
+		sync_participation Float32,
+		block_count UInt32,
+		orphaned_count UInt32,
+		deposit_count UInt32,
+		exit_count UInt32,
+		blob_count UInt32
+	) ENGINE = ReplacingMergeTree ORDER BY (period, time)`,
+}
+
+const clickhouseEpochsFields = `epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
+	attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
+	proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation, blob_count`
+
+const clickhouseChartRollupsFields = `period, time, first_epoch, last_epoch, epoch_count, validator_count, validator_balance, target_participation,
+	head_participation, total_participation, sync_participation, block_count, orphaned_count, deposit_count, exit_count, blob_count`
+
+// number of epochs compared per query when copying missing aggregates to clickhouse
+const clickhouseSyncBatchSize = 1000
+
+// clickhouseEpoch is the json row representation of dbtypes.Epoch
+type clickhouseEpoch struct {
+	Epoch                 uint64  `json:"epoch"`
+	ValidatorCount        uint64  `json:"validator_count"`
+	ValidatorBalance      uint64  `json:"validator_balance"`
+	Eligible              uint64  `json:"eligible"`
+	VotedTarget           uint64  `json:"voted_target"`
+	VotedHead             uint64  `json:"voted_head"`
+	VotedTotal            uint64  `json:"voted_total"`
+	BlockCount            uint16  `json:"block_count"`
+	OrphanedCount         uint16  `json:"orphaned_count"`
+	AttestationCount      uint64  `json:"attestation_count"`
+	DepositCount          uint64  `json:"deposit_count"`
+	ExitCount             uint64  `json:"exit_count"`
+	WithdrawCount         uint64  `json:"withdraw_count"`
+	WithdrawAmount        uint64  `json:"withdraw_amount"`
+	AttesterSlashingCount uint64  `json:"attester_slashing_count"`
+	ProposerSlashingCount uint64  `json:"proposer_slashing_count"`
+	BLSChangeCount        uint64  `json:"bls_change_count"`
+	EthTransactionCount   uint64  `json:"eth_transaction_count"`
+	SyncParticipation     float32 `json:"sync_participation"`
+	BlobCount             uint64  `json:"blob_count"`
+}
+
+// clickhouseClientDiversity is the json row representation of dbtypes.ClientDiversity
+type clickhouseClientDiversity struct {
+	Epoch      uint64 `json:"epoch"`
+	ClClient   uint8  `json:"cl_client"`
+	ElClient   uint8  `json:"el_client"`
+	BlockCount uint64 `json:"block_count"`
+}
+
+// clickhouseChartRollup is the json row representation of dbtypes.ChartRollup
+type clickhouseChartRollup struct {
+	Period              uint32  `json:"period"`
+	Time                uint64  `json:"time"`
+	FirstEpoch          uint64  `json:"first_epoch"`
+	LastEpoch           uint64  `json:"last_epoch"`
+	EpochCount          uint32  `json:"epoch_count"`
+	ValidatorCount      uint64  `json:"validator_count"`
+	ValidatorBalance    uint64  `json:"validator_balance"`
+	TargetParticipation float32 `json:"target_participation"`
+	HeadParticipation   float32 `json:"head_participation"`
+	TotalParticipation  float32 `json:"total_participation"`
+	SyncParticipation   float32 `json:"sync_participation"`
+	BlockCount          uint32  `json:"block_count"`
+	OrphanedCount       uint32  `json:"orphaned_count"`
+	DepositCount        uint32  `json:"deposit_count"`
+	ExitCount           uint32  `json:"exit_count"`
+	BlobCount           uint32  `json:"blob_count"`
+}
+
+// clickhouseStore is an analytics store that keeps a copy of the aggregate tables (epochs, client diversity & chart rollups) in clickhouse.
+// writes go to the primary store first and are queued for clickhouse after the transaction has been committed, reads of the aggregates are served from clickhouse.
+// all other methods are forwarded to the primary store.
+//
+// clickhouse does not take part in the db transactions. the queued writes are flushed asynchronously by the mirror loop,
+// so the primary write path never waits for clickhouse. failed mirror writes stay queued and are retried periodically,
+// aggregates that are missing or differ in clickhouse (e.g. after a restart with queued writes) are copied again on startup.
+type clickhouseStore struct {
+	Store
+	ctx        context.Context
+	config     *types.ClickhouseDatabaseConfig
+	httpClient *http.Client
+	mirror     *clickhouseMirror
+}
+
+// clickhouseMirror collects the aggregate writes of the open transactions and the committed writes waiting for the mirror loop.
+// it is shared by all context bound copies of the store.
+type clickhouseMirror struct {
+	mutex      sync.Mutex
+	flushMutex sync.Mutex
+	pending    map[Tx]*clickhouseMirrorBatch
+	queue      *clickhouseMirrorBatch
+	notifyChan chan bool
+}
+
+// clickhouseMirrorBatch holds the aggregates to be mirrored to clickhouse.
+// the client diversity is rebuilt by range in the primary store, so the ranges are read back from the primary store when mirrored.
+type clickhouseMirrorBatch struct {
+	epochs          map[uint64]*dbtypes.Epoch
+	diversityRanges [][2]uint64
+	rollups         map[[2]uint64]*dbtypes.ChartRollup
+}
+
+func newClickhouseMirrorBatch() *clickhouseMirrorBatch {
+	return &clickhouseMirrorBatch{
+		epochs:  map[uint64]*dbtypes.Epoch{},
+		rollups: map[[2]uint64]*dbtypes.ChartRollup{},
+	}
+}
+
+func (batch *clickhouseMirrorBatch) isEmpty() bool {
+	return len(batch.epochs) == 0 && len(batch.diversityRanges) == 0 && len(batch.rollups) == 0
+}
+
+// merge adds the aggregates of the other batch, aggregates of the other batch replace older values of the same key.
+func (batch *clickhouseMirrorBatch) merge(other *clickhouseMirrorBatch) {
+	for key, epoch := range other.epochs {
+		batch.epochs[key] = epoch
+	}
+	batch.diversityRanges = append(batch.diversityRanges, other.diversityRanges...)
+	for key, rollup := range other.rollups {
+		batch.rollups[key] = rollup
+	}
+}
+
+func newClickhouseStore(primary Store, config *types.ClickhouseDatabaseConfig) (*clickhouseStore, error) {
+	store := &clickhouseStore{
+		Store:  primary,
+		ctx:    context.Background(),
+		config: config,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		mirror: &clickhouseMirror{
+			pending:    map[Tx]*clickhouseMirrorBatch{},
+			queue:      newClickhouseMirrorBatch(),
+			notifyChan: make(chan bool, 1),
+		},
+	}
+
+	for _, schemaQuery := range clickhouseSchema {
+		if err := store.exec(schemaQuery, nil); err != nil {
+			return nil, fmt.Errorf("failed applying clickhouse schema: %v", err)
+		}
+	}
+
+	return store, nil
+}
+
+// query runs a query via the clickhouse http interface and returns the response body.
+func (store *clickhouseStore) query(query string, data io.Reader) (io.ReadCloser, error) {
+	queryUrl, err := url.Parse(store.config.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clickhouse url: %v", err)
+	}
+
+	queryArgs := queryUrl.Query()
+	queryArgs.Set("query", query)
+	queryArgs.Set("output_format_json_quote_64bit_integers", "0")
+	if store.config.Database != "" {
+		queryArgs.Set("database", store.config.Database)
+	}
+	queryUrl.RawQuery = queryArgs.Encode()
+
+	if data == nil {
+		data = http.NoBody
+	}
+
+	req, err := http.NewRequestWithContext(store.ctx, "POST", queryUrl.String(), data)
+	if err != nil {
+		return nil, err
+	}
+	if store.config.Username != "" {
+		req.Header.Set("X-ClickHouse-User", store.config.Username)
+		req.Header.Set("X-ClickHouse-Key", store.config.Password)
+	}
+
+	resp, err := store.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		errorBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("clickhouse query failed with status %v: %v", resp.StatusCode, strings.TrimSpace(string(errorBody)))
+	}
+
+	return resp.Body, nil
+}
+
+func (store *clickhouseStore) exec(query string, data io.Reader) error {
+	body, err := store.query(query, data)
+	if err != nil {
+		return err
+	}
+
+	return body.Close()
+}
+
+// selectRows runs a query with JSONEachRow output and passes each row to the callback.
+func (store *clickhouseStore) selectRows(query string, cb func(row []byte) error) error {
+	body, err := store.query(query+" FORMAT JSONEachRow", nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		if err := cb(scanner.Bytes()); err != nil {
+			return fmt.Errorf("failed parsing clickhouse row: %v", err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// insertRows inserts the rows into the given table.
+func insertClickhouseRows[T any](store *clickhouseStore, table string, rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	data := &bytes.Buffer{}
+	encoder := json.NewEncoder(data)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+
+	return store.exec(fmt.Sprintf("INSERT INTO %v FORMAT JSONEachRow", table), data)
+}
+
+func (store *clickhouseStore) insertEpochs(epochs []*dbtypes.Epoch) error {
+	rows := make([]clickhouseEpoch, len(epochs))
+	for i, epoch := range epochs {
+		rows[i] = clickhouseEpoch(*epoch)
+	}
+
+	return insertClickhouseRows(store, "epochs", rows)
+}
+
+func (store *clickhouseStore) insertChartRollups(rollups []*dbtypes.ChartRollup) error {
+	rows := make([]clickhouseChartRollup, len(rollups))
+	for i, rollup := range rollups {
+		rows[i] = clickhouseChartRollup(*rollup)
+	}
+
+	return insertClickhouseRows(store, "chart_rollups", rows)
+}
+
+// copyClientDiversity replaces the client diversity aggregates of the given epoch range with the aggregates of the primary store.
+func (store *clickhouseStore) copyClientDiversity(firstEpoch uint64, lastEpoch uint64) error {
+	clientDiversity := store.Store.GetClientDiversity(firstEpoch, lastEpoch)
+	if clientDiversity == nil {
+		return fmt.Errorf("failed loading client diversity %v-%v from primary db", firstEpoch, lastEpoch)
+	}
+
+	if err := store.exec(fmt.Sprintf("DELETE FROM client_diversity WHERE epoch >= %d AND epoch <= %d", firstEpoch, lastEpoch), nil); err != nil {
+		return err
+	}
+
+	rows := make([]clickhouseClientDiversity, len(clientDiversity))
+	for i, aggregate := range clientDiversity {
+		rows[i] = clickhouseClientDiversity(*aggregate)
+	}
+
+	return insertClickhouseRows(store, "client_diversity", rows)
+}
+
+func (store *clickhouseStore) WithContext(ctx context.Context) Store {
+	return &clickhouseStore{
+		Store:      store.Store.WithContext(ctx),
+		ctx:        ctx,
+		config:     store.config,
+		httpClient: store.httpClient,
+		mirror:     store.mirror,
+	}
+}
+
+// RunTransaction runs the transaction on the primary store and queues the aggregates written by it for the mirror loop after the commit.
+func (store *clickhouseStore) RunTransaction(handler func(tx Tx) error) error {
+	var batch *clickhouseMirrorBatch
+	err := store.Store.RunTransaction(func(tx Tx) error {
+		batch = newClickhouseMirrorBatch()
+
+		store.mirror.mutex.Lock()
+		store.mirror.pending[tx] = batch
+		store.mirror.mutex.Unlock()
+
+		defer func() {
+			store.mirror.mutex.Lock()
+			delete(store.mirror.pending, tx)
+			store.mirror.mutex.Unlock()
+		}()
+
+		return handler(tx)
+	})
+
+	if err == nil && batch != nil && !batch.isEmpty() {
+		store.queueMirror(batch)
+	}
+
+	return err
+}
+
+// addMirrorWrite adds an aggregate write to the mirror batch of the transaction.
+// writes outside of RunTransaction are queued right away.
+func (store *clickhouseStore) addMirrorWrite(tx Tx, write func(batch *clickhouseMirrorBatch)) {
+	store.mirror.mutex.Lock()
+	batch := store.mirror.pending[tx]
+	if batch != nil {
+		write(batch)
+	}
+	store.mirror.mutex.Unlock()
+
+	if batch == nil {
+		batch = newClickhouseMirrorBatch()
+		write(batch)
+		store.queueMirror(batch)
+	}
+}
+
+// queueMirror adds the committed aggregates to the mirror queue and wakes up the mirror loop.
+func (store *clickhouseStore) queueMirror(batch *clickhouseMirrorBatch) {
+	store.mirror.mutex.Lock()
+	store.mirror.queue.merge(batch)
+	store.mirror.mutex.Unlock()
+
+	select {
+	case store.mirror.notifyChan <- true:
+	default:
+	}
+}
+
+// flushMirror writes all queued aggregates to clickhouse, failed writes stay queued for the next flush.
+// returns false if any write failed.
+func (store *clickhouseStore) flushMirror() bool {
+	store.mirror.flushMutex.Lock()
+	defer store.mirror.flushMutex.Unlock()
+
+	store.mirror.mutex.Lock()
+	flushBatch := store.mirror.queue
+	store.mirror.queue = newClickhouseMirrorBatch()
+	store.mirror.mutex.Unlock()
+
+	if flushBatch.isEmpty() {
+		return true
+	}
+
+	failedBatch := newClickhouseMirrorBatch()
+
+	epochs := make([]*dbtypes.Epoch, 0, len(flushBatch.epochs))
+	for _, epoch := range flushBatch.epochs {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i].Epoch < epochs[j].Epoch
+	})
+	if err := store.insertEpochs(epochs); err != nil {
+		logger.Warnf("failed mirroring %v epochs to clickhouse, queued for retry: %v", len(epochs), err)
+		failedBatch.epochs = flushBatch.epochs
+	}
+
+	for _, diversityRange := range flushBatch.diversityRanges {
+		if err := store.copyClientDiversity(diversityRange[0], diversityRange[1]); err != nil {
+			logger.Warnf("failed mirroring client diversity %v-%v to clickhouse, queued for retry: %v", diversityRange[0], diversityRange[1], err)
+			failedBatch.diversityRanges = append(failedBatch.diversityRanges, diversityRange)
+		}
+	}
+
+	rollups := make([]*dbtypes.ChartRollup, 0, len(flushBatch.rollups))
+	for _, rollup := range flushBatch.rollups {
+		rollups = append(rollups, rollup)
+	}
+	if err := store.insertChartRollups(rollups); err != nil {
+		logger.Warnf("failed mirroring %v chart rollups to clickhouse, queued for retry: %v", len(rollups), err)
+		failedBatch.rollups = flushBatch.rollups
+	}
+
+	if failedBatch.isEmpty() {
+		return true
+	}
+
+	// writes queued during the flush are newer than the failed writes
+	store.mirror.mutex.Lock()
+	failedBatch.merge(store.mirror.queue)
+	store.mirror.queue = failedBatch
+	store.mirror.mutex.Unlock()
+
+	return false
+}
+
+// hasPendingMirrorWrites checks if there are queued mirror writes waiting for the mirror loop.
+func (store *clickhouseStore) hasPendingMirrorWrites() bool {
+	store.mirror.mutex.Lock()
+	defer store.mirror.mutex.Unlock()
+
+	return !store.mirror.queue.isEmpty()
+}
+
+func (store *clickhouseStore) InsertEpoch(epoch *dbtypes.Epoch, tx Tx) error {
+	if err := store.Store.InsertEpoch(epoch, tx); err != nil {
+		return err
+	}
+
+	store.addMirrorWrite(tx, func(batch *clickhouseMirrorBatch) {
+		batch.epochs[epoch.Epoch] = epoch
+	})
+
+	return nil
+}
+
+func (store *clickhouseStore) UpdateClientDiversity(firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx Tx) error {
+	if err := store.Store.UpdateClientDiversity(firstEpoch, lastEpoch, slotsPerEpoch, tx); err != nil {
+		return err
+	}
+
+	store.addMirrorWrite(tx, func(batch *clickhouseMirrorBatch) {
+		batch.diversityRanges = append(batch.diversityRanges, [2]uint64{firstEpoch, lastEpoch})
+	})
+
+	return nil
+}
+
+func (store *clickhouseStore) InsertChartRollups(rollups []*dbtypes.ChartRollup, tx Tx) error {
+	if err := store.Store.InsertChartRollups(rollups, tx); err != nil {
+		return err
+	}
+
+	store.addMirrorWrite(tx, func(batch *clickhouseMirrorBatch) {
+		for _, rollup := range rollups {
+			batch.rollups[[2]uint64{uint64(rollup.Period), rollup.Time}] = rollup
+		}
+	})
+
+	return nil
+}
+
+func (store *clickhouseStore) GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	epochs, err := store.getEpochs(firstEpoch, limit)
+	if err != nil {
+		logger.Warnf("failed fetching epochs from clickhouse, falling back to primary db: %v", err)
+		return store.Store.GetEpochs(firstEpoch, limit)
+	}
+
+	return epochs
+}
+
+func (store *clickhouseStore) getEpochs(firstEpoch uint64, limit uint32) ([]*dbtypes.Epoch, error) {
+	epochs := []*dbtypes.Epoch{}
+	err := store.selectRows(fmt.Sprintf(`
+		SELECT %v
+		FROM epochs FINAL
+		WHERE epoch <= %d
+		ORDER BY epoch DESC
+		LIMIT %d`, clickhouseEpochsFields, firstEpoch, limit), func(row []byte) error {
+		epoch := clickhouseEpoch{}
+		if err := json.Unmarshal(row, &epoch); err != nil {
+			return err
+		}
+
+		dbEpoch := dbtypes.Epoch(epoch)
+		epochs = append(epochs, &dbEpoch)
+		return nil
+	})
+
+	return epochs, err
+}
+
+func (store *clickhouseStore) GetClientDiversity(firstEpoch uint64, lastEpoch uint64) []*dbtypes.ClientDiversity {
+	clientDiversity := []*dbtypes.ClientDiversity{}
+	err := store.selectRows(fmt.Sprintf(`
+		SELECT epoch, cl_client, el_client, block_count
+		FROM client_diversity FINAL
+		WHERE epoch >= %d AND epoch <= %d
+		ORDER BY epoch ASC`, firstEpoch, lastEpoch), func(row []byte) error {
+		aggregate := clickhouseClientDiversity{}
+		if err := json.Unmarshal(row, &aggregate); err != nil {
+			return err
+		}
+
+		dbAggregate := dbtypes.ClientDiversity(aggregate)
+		clientDiversity = append(clientDiversity, &dbAggregate)
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("failed fetching client diversity from clickhouse, falling back to primary db: %v", err)
+		return store.Store.GetClientDiversity(firstEpoch, lastEpoch)
+	}
+
+	return clientDiversity
+}
+
+func (store *clickhouseStore) GetChartRollups(period uint32, firstTime uint64, lastTime uint64) []*dbtypes.ChartRollup {
+	rollups := []*dbtypes.ChartRollup{}
+	err := store.selectRows(fmt.Sprintf(`
+		SELECT %v
+		FROM chart_rollups FINAL
+		WHERE period = %d AND time >= %d AND time <= %d
+		ORDER BY time ASC`, clickhouseChartRollupsFields, period, firstTime, lastTime), func(row []byte) error {
+		rollup := clickhouseChartRollup{}
+		if err := json.Unmarshal(row, &rollup); err != nil {
+			return err
+		}
+
+		dbRollup := dbtypes.ChartRollup(rollup)
+		rollups = append(rollups, &dbRollup)
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("failed fetching chart rollups from clickhouse, falling back to primary db: %v", err)
+		return store.Store.GetChartRollups(period, firstTime, lastTime)
+	}
+
+	return rollups
+}
+
+// ApplySchema applies the schema of the primary store and starts copying the missing aggregates to clickhouse afterwards.
+func (store *clickhouseStore) ApplySchema(version int64) error {
+	if err := store.Store.ApplySchema(version); err != nil {
+		return err
+	}
+
+	go store.runMirrorLoop()
+
+	return nil
+}
+
+// runMirrorLoop copies the missing aggregates to clickhouse and flushes the queued mirror writes.
+// the queue is flushed whenever new writes are committed, failed writes are retried after a minute.
+func (store *clickhouseStore) runMirrorLoop() {
+	defer utils.HandleSubroutinePanic("db.clickhouse.runMirrorLoop")
+
+	for {
+		if err := store.syncAggregates(); err != nil {
+			logger.Errorf("failed copying aggregates to clickhouse: %v", err)
+		} else {
+			break
+		}
+
+		time.Sleep(1 * time.Minute)
+	}
+
+	for {
+		select {
+		case <-store.mirror.notifyChan:
+		case <-time.After(1 * time.Minute):
+		}
+
+		if !store.flushMirror() {
+			time.Sleep(1 * time.Minute)
+		}
+	}
+}
+
+// syncAggregates copies all aggregates from the primary store, that are missing or differ in clickhouse.
+func (store *clickhouseStore) syncAggregates() error {
+	copiedEpochs, copiedDiversity, err := store.syncEpochs()
+	if err != nil {
+		return err
+	}
+
+	copiedRollups, err := store.syncChartRollups()
+	if err != nil {
+		return err
+	}
+
+	if copiedEpochs > 0 || copiedDiversity > 0 || copiedRollups > 0 {
+		logger.Infof("copied %v epochs, client diversity of %v epochs and %v chart rollups to clickhouse", copiedEpochs, copiedDiversity, copiedRollups)
+	}
+
+	return nil
+}
+
+// syncEpochs compares the epochs & client diversity aggregates of the primary store and clickhouse in ranges of clickhouseSyncBatchSize epochs.
+// epochs that are missing in clickhouse and epochs with a different client diversity block count are copied.
+func (store *clickhouseStore) syncEpochs() (int, int, error) {
+	copiedEpochs := 0
+	copiedDiversity := 0
+	firstEpoch := uint64(math.MaxInt64)
+	for {
+		epochs := store.Store.GetEpochs(firstEpoch, clickhouseSyncBatchSize)
+		if len(epochs) == 0 {
+			break
+		}
+
+		rangeLast := epochs[0].Epoch
+		rangeFirst := epochs[len(epochs)-1].Epoch
+
+		mirroredEpochs := map[uint64]bool{}
+		err := store.selectRows(fmt.Sprintf("SELECT DISTINCT epoch FROM epochs WHERE epoch >= %d AND epoch <= %d", rangeFirst, rangeLast), func(row []byte) error {
+			result := struct {
+				Epoch uint64 `json:"epoch"`
+			}{}
+			if err := json.Unmarshal(row, &result); err != nil {
+				return err
+			}
+			mirroredEpochs[result.Epoch] = true
+			return nil
+		})
+		if err != nil {
+			return copiedEpochs, copiedDiversity, err
+		}
+
+		copyEpochs := make([]*dbtypes.Epoch, 0, len(epochs))
+		for _, epoch := range epochs {
+			if !mirroredEpochs[epoch.Epoch] {
+				copyEpochs = append(copyEpochs, epoch)
+			}
+		}
+
+		if err := store.insertEpochs(copyEpochs); err != nil {
+			return copiedEpochs, copiedDiversity, err
+		}
+		copiedEpochs += len(copyEpochs)
+
+		diversityEpochs, err := store.syncClientDiversity(rangeFirst, rangeLast)
+		if err != nil {
+			return copiedEpochs, copiedDiversity, err
+		}
+		copiedDiversity += diversityEpochs
+
+		if len(epochs) < clickhouseSyncBatchSize || rangeFirst == 0 {
+			break
+		}
+		firstEpoch = rangeFirst - 1
+	}
+
+	return copiedEpochs, copiedDiversity, nil
+}
+
+// syncClientDiversity copies the client diversity aggregates of all epochs in the given range with a different block count in clickhouse.
+func (store *clickhouseStore) syncClientDiversity(firstEpoch uint64, lastEpoch uint64) (int, error) {
+	primaryCounts := map[uint64]uint64{}
+	for _, aggregate := range store.Store.GetClientDiversity(firstEpoch, lastEpoch) {
+		primaryCounts[aggregate.Epoch] += aggregate.BlockCount
+	}
+
+	mirroredCounts := map[uint64]uint64{}
+	err := store.selectRows(fmt.Sprintf("SELECT epoch, sum(block_count) AS block_count FROM client_diversity FINAL WHERE epoch >= %d AND epoch <= %d GROUP BY epoch", firstEpoch, lastEpoch), func(row []byte) error {
+		result := struct {
+			Epoch      uint64 `json:"epoch"`
+			BlockCount uint64 `json:"block_count"`
+		}{}
+		if err := json.Unmarshal(row, &result); err != nil {
+			return err
+		}
+		mirroredCounts[result.Epoch] = result.BlockCount
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// consecutive differing epochs are copied as one range
+	copiedEpochs := 0
+	for epoch := firstEpoch; epoch <= lastEpoch; epoch++ {
+		if primaryCounts[epoch] == mirroredCounts[epoch] {
+			continue
+		}
+
+		rangeLast := epoch
+		for rangeLast < lastEpoch && primaryCounts[rangeLast+1] != mirroredCounts[rangeLast+1] {
+			rangeLast++
+		}
+
+		if err := store.copyClientDiversity(epoch, rangeLast); err != nil {
+			return copiedEpochs, err
+		}
+		copiedEpochs += int(rangeLast - epoch + 1)
+		epoch = rangeLast
+	}
+
+	return copiedEpochs, nil
+}
+
+// syncChartRollups copies all chart rollups from the primary store, the rollups are small enough to be copied completely.
+func (store *clickhouseStore) syncChartRollups() (int, error) {
+	copiedRollups := 0
+	for _, period := range []uint32{dbtypes.ChartRollupPeriodHourly, dbtypes.ChartRollupPeriodDaily} {
+		rollups := store.Store.GetChartRollups(period, 0, math.MaxInt64)
+		if err := store.insertChartRollups(rollups); err != nil {
+			return copiedRollups, err
+		}
+		copiedRollups += len(rollups)
+	}
+
+	return copiedRollups, nil
+}
+
+func mustInitAnalyticsStore(primary Store) Store {
+	switch utils.Config.Database.Analytics.Engine {
+	case "":
+		return primary
+	case "clickhouse":
+		config := (*types.ClickhouseDatabaseConfig)(&utils.Config.Database.Analytics.Clickhouse)
+		logger.Infof("initializing clickhouse analytics store at %v", config.Url)
+
+		analyticsStore, err := newClickhouseStore(primary, config)
+		if err != nil {
+			logger.Fatalf("failed initializing clickhouse analytics store: %v", err)
+		}
+
+		return analyticsStore
+	default:
+		logger.Fatalf("unknown analytics database engine: %s", utils.Config.Database.Analytics.Engine)
+		return nil
+	}
+}
@@ -0,0 +1,280 @@
+package db_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/db/dbtest"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// fakeClickhouse emulates the queries of the clickhouse analytics store via the http interface.
+type fakeClickhouse struct {
+	mutex           sync.Mutex
+	epochs          map[uint64]*dbtypes.Epoch
+	clientDiversity map[uint64][]*dbtypes.ClientDiversity
+	rollups         map[[2]uint64]*dbtypes.ChartRollup
+	insertedRows    int
+	failInserts     int
+}
+
+func newFakeClickhouse(t *testing.T) (*fakeClickhouse, string) {
+	fake := &fakeClickhouse{
+		epochs:          map[uint64]*dbtypes.Epoch{},
+		clientDiversity: map[uint64][]*dbtypes.ClientDiversity{},
+		rollups:         map[[2]uint64]*dbtypes.ChartRollup{},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(fake.handleQuery))
+	t.Cleanup(server.Close)
+
+	return fake, server.URL
+}
+
+func (fake *fakeClickhouse) handleQuery(w http.ResponseWriter, r *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	query := strings.Join(strings.Fields(r.URL.Query().Get("query")), " ")
+	var firstEpoch, lastEpoch uint64
+
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE") || strings.HasPrefix(query, "ALTER TABLE"):
+	case strings.HasPrefix(query, "INSERT INTO"):
+		if fake.failInserts > 0 {
+			fake.failInserts--
+			http.Error(w, "insert failed", http.StatusInternalServerError)
+			return
+		}
+
+		var table string
+		fmt.Sscanf(query, "INSERT INTO %s FORMAT JSONEachRow", &table)
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			fake.insertedRows++
+			switch table {
+			case "epochs":
+				epoch := &dbtypes.Epoch{}
+				json.Unmarshal(scanner.Bytes(), epoch)
+				fake.epochs[epoch.Epoch] = epoch
+			case "client_diversity":
+				row := struct {
+					Epoch      uint64 `json:"epoch"`
+					ClClient   uint8  `json:"cl_client"`
+					ElClient   uint8  `json:"el_client"`
+					BlockCount uint64 `json:"block_count"`
+				}{}
+				json.Unmarshal(scanner.Bytes(), &row)
+				aggregate := dbtypes.ClientDiversity(row)
+				fake.clientDiversity[row.Epoch] = append(fake.clientDiversity[row.Epoch], &aggregate)
+			case "chart_rollups":
+				row := struct {
+					Period uint32 `json:"period"`
+					Time   uint64 `json:"time"`
+				}{}
+				json.Unmarshal(scanner.Bytes(), &row)
+				fake.rollups[[2]uint64{uint64(row.Period), row.Time}] = &dbtypes.ChartRollup{Period: row.Period, Time: row.Time}
+			}
+		}
+	case strings.HasPrefix(query, "DELETE FROM client_diversity"):
+		fmt.Sscanf(query, "DELETE FROM client_diversity WHERE epoch >= %d AND epoch <= %d", &firstEpoch, &lastEpoch)
+		for epoch := range fake.clientDiversity {
+			if epoch >= firstEpoch && epoch <= lastEpoch {
+				delete(fake.clientDiversity, epoch)
+			}
+		}
+	case strings.HasPrefix(query, "SELECT DISTINCT epoch FROM epochs"):
+		fmt.Sscanf(query, "SELECT DISTINCT epoch FROM epochs WHERE epoch >= %d AND epoch <= %d", &firstEpoch, &lastEpoch)
+		for epoch := range fake.epochs {
+			if epoch >= firstEpoch && epoch <= lastEpoch {
+				fmt.Fprintf(w, "{\"epoch\":%d}\n", epoch)
+			}
+		}
+	case strings.HasPrefix(query, "SELECT epoch, sum(block_count)"):
+		fmt.Sscanf(query, "SELECT epoch, sum(block_count) AS block_count FROM client_diversity FINAL WHERE epoch >= %d AND epoch <= %d", &firstEpoch, &lastEpoch)
+		for epoch, aggregates := range fake.clientDiversity {
+			if epoch >= firstEpoch && epoch <= lastEpoch {
+				blockCount := uint64(0)
+				for _, aggregate := range aggregates {
+					blockCount += aggregate.BlockCount
+				}
+				fmt.Fprintf(w, "{\"epoch\":%d,\"block_count\":%d}\n", epoch, blockCount)
+			}
+		}
+	default:
+		http.Error(w, "unsupported query", http.StatusBadRequest)
+	}
+}
+
+func (fake *fakeClickhouse) hasEpoch(epoch uint64) bool {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return fake.epochs[epoch] != nil
+}
+
+func newTestClickhouseStore(t *testing.T) (*dbtest.Store, *fakeClickhouse, db.Store) {
+	primary := dbtest.NewStore()
+	fake, url := newFakeClickhouse(t)
+
+	store, err := db.NewClickhouseStore(primary, url)
+	if err != nil {
+		t.Fatalf("failed creating clickhouse store: %v", err)
+	}
+
+	return primary, fake, store
+}
+
+func TestClickhouseMirrorsCommittedTransactionsOnly(t *testing.T) {
+	primary, fake, store := newTestClickhouseStore(t)
+
+	err := store.RunTransaction(func(tx db.Tx) error {
+		if err := store.InsertEpoch(&dbtypes.Epoch{Epoch: 5}, tx); err != nil {
+			return err
+		}
+
+		// mirrored after the commit, not while the transaction is open
+		if fake.hasEpoch(5) {
+			t.Errorf("epoch 5 mirrored before commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+
+	// the commit does not wait for clickhouse, the write is queued for the mirror loop
+	if fake.hasEpoch(5) {
+		t.Errorf("expected epoch 5 to be queued, not mirrored by the commit")
+	}
+	if pending := db.FlushClickhouseMirror(store); pending || !fake.hasEpoch(5) {
+		t.Errorf("expected epoch 5 to be mirrored by the flush, pending: %v", pending)
+	}
+
+	err = store.RunTransaction(func(tx db.Tx) error {
+		if err := store.InsertEpoch(&dbtypes.Epoch{Epoch: 6}, tx); err != nil {
+			return err
+		}
+		return fmt.Errorf("rollback")
+	})
+	if err == nil {
+		t.Fatalf("expected transaction error")
+	}
+	db.FlushClickhouseMirror(store)
+	if fake.hasEpoch(6) || primary.IsEpochSynchronized(6) {
+		t.Errorf("expected epoch 6 of the rolled back transaction not to be written")
+	}
+}
+
+func TestClickhouseRetriesFailedMirrorWrites(t *testing.T) {
+	_, fake, store := newTestClickhouseStore(t)
+
+	fake.failInserts = 1
+	err := store.RunTransaction(func(tx db.Tx) error {
+		return store.InsertEpoch(&dbtypes.Epoch{Epoch: 7}, tx)
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if pending := db.FlushClickhouseMirror(store); !pending || fake.hasEpoch(7) {
+		t.Fatalf("expected the mirror write of epoch 7 to fail and stay queued")
+	}
+
+	// the failed write is retried with the next flush
+	err = store.RunTransaction(func(tx db.Tx) error {
+		return store.InsertEpoch(&dbtypes.Epoch{Epoch: 8}, tx)
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if pending := db.FlushClickhouseMirror(store); pending || !fake.hasEpoch(7) || !fake.hasEpoch(8) {
+		t.Errorf("expected epochs 7 & 8 to be mirrored, got 7: %v, 8: %v, pending: %v", fake.hasEpoch(7), fake.hasEpoch(8), pending)
+	}
+}
+
+func TestClickhouseSyncCopiesMissingRanges(t *testing.T) {
+	primary, fake, store := newTestClickhouseStore(t)
+
+	for epoch := uint64(0); epoch < 2500; epoch++ {
+		primary.InsertEpoch(&dbtypes.Epoch{Epoch: epoch, BlockCount: 4}, nil)
+		primary.SetClientDiversity(epoch, []*dbtypes.ClientDiversity{
+			{Epoch: epoch, ClClient: 1, ElClient: 2, BlockCount: 3},
+			{Epoch: epoch, ClClient: 2, ElClient: 1, BlockCount: 1},
+		})
+
+		// gaps in the middle and at the head of the mirrored epochs, stale client diversity for epoch 50
+		if (epoch < 100 || epoch >= 200) && epoch < 2400 {
+			fake.epochs[epoch] = &dbtypes.Epoch{Epoch: epoch, BlockCount: 4}
+			blockCount := uint64(3)
+			if epoch == 50 {
+				blockCount = 2
+			}
+			fake.clientDiversity[epoch] = []*dbtypes.ClientDiversity{
+				{Epoch: epoch, ClClient: 1, ElClient: 2, BlockCount: blockCount},
+				{Epoch: epoch, ClClient: 2, ElClient: 1, BlockCount: 1},
+			}
+		}
+	}
+
+	if err := db.SyncClickhouseAggregates(store); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	for epoch := uint64(0); epoch < 2500; epoch++ {
+		if !fake.hasEpoch(epoch) {
+			t.Fatalf("expected epoch %v to be copied", epoch)
+		}
+		if blockCount := fake.clientDiversity[epoch][0].BlockCount + fake.clientDiversity[epoch][1].BlockCount; blockCount != 4 {
+			t.Fatalf("expected client diversity of epoch %v to be copied, got %v blocks", epoch, blockCount)
+		}
+	}
+
+	// 200 missing epochs, client diversity of 201 epochs (2 rows each)
+	if expected := 200 + 201*2; fake.insertedRows != expected {
+		t.Errorf("expected %v copied rows, got %v", expected, fake.insertedRows)
+	}
+}
+
+func TestClickhouseMirrorsChartRollups(t *testing.T) {
+	primary, fake, store := newTestClickhouseStore(t)
+
+	primary.InsertChartRollups([]*dbtypes.ChartRollup{
+		{Period: dbtypes.ChartRollupPeriodDaily, Time: 86400},
+	}, nil)
+	if err := db.SyncClickhouseAggregates(store); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	err := store.RunTransaction(func(tx db.Tx) error {
+		return store.InsertChartRollups([]*dbtypes.ChartRollup{
+			{Period: dbtypes.ChartRollupPeriodHourly, Time: 3600},
+			{Period: dbtypes.ChartRollupPeriodHourly, Time: 7200},
+		}, tx)
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	db.FlushClickhouseMirror(store)
+
+	if len(fake.rollups) != 3 {
+		t.Errorf("expected 3 mirrored chart rollups, got %v", len(fake.rollups))
+	}
+}
+
+func TestClickhouseFallsBackToPrimaryOnReadErrors(t *testing.T) {
+	primary, _, store := newTestClickhouseStore(t)
+	primary.InsertEpoch(&dbtypes.Epoch{Epoch: 3}, nil)
+
+	// the fake does not support the read queries
+	epochs := store.GetEpochs(10, 10)
+	if len(epochs) != 1 || epochs[0].Epoch != 3 {
+		t.Errorf("expected epoch 3 from the primary store, got %v epochs", len(epochs))
+	}
+}
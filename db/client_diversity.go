@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// UpdateClientDiversity rebuilds the client diversity aggregates of the given epoch range from the classified canonical blocks.
+func (store *sqlStore) UpdateClientDiversity(firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`DELETE FROM client_diversity WHERE epoch >= $1 AND epoch <= $2`, firstEpoch, lastEpoch)
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlTx(tx).Exec(`
+		INSERT INTO client_diversity (epoch, cl_client, el_client, block_count)
+		SELECT slot / $1 AS epoch, cl_client, el_client, COUNT(*) AS block_count
+		FROM slots
+		WHERE slot >= $2 AND slot < $3 AND status = 1 AND cl_client IS NOT NULL
+		GROUP BY epoch, cl_client, el_client`,
+		slotsPerEpoch, firstEpoch*slotsPerEpoch, (lastEpoch+1)*slotsPerEpoch)
+	return err
+}
+
+// GetClientDiversity returns the client diversity aggregates of the given epoch range.
+func (store *sqlStore) GetClientDiversity(firstEpoch uint64, lastEpoch uint64) []*dbtypes.ClientDiversity {
+	clientDiversity := []*dbtypes.ClientDiversity{}
+	err := ReaderDb.SelectContext(store.ctx, &clientDiversity, `
+	SELECT epoch, cl_client, el_client, block_count
+	FROM client_diversity
+	WHERE epoch >= $1 AND epoch <= $2
+	ORDER BY epoch ASC`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching client diversity: %v", err)
+		return nil
+	}
+	return clientDiversity
+}
+
+// GetClientDiversityByProposer returns the number of canonical blocks per proposer & inferred client in the given slot range.
+func (store *sqlStore) GetClientDiversityByProposer(firstSlot uint64, lastSlot uint64) []*dbtypes.ClientDiversityProposer {
+	proposerClients := []*dbtypes.ClientDiversityProposer{}
+	err := ReaderDb.SelectContext(store.ctx, &proposerClients, `
+	SELECT proposer, cl_client, el_client, COUNT(*) AS block_count
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1 AND cl_client IS NOT NULL
+	GROUP BY proposer, cl_client, el_client`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching client diversity by proposer: %v", err)
+		return nil
+	}
+	return proposerClients
+}
+
+// GetUnclassifiedSlots returns blocks that have been persisted before the proposer client classification was introduced.
+func (store *sqlStore) GetUnclassifiedSlots(limit uint32) ([]*dbtypes.UnclassifiedSlot, error) {
+	slots := []*dbtypes.UnclassifiedSlot{}
+	err := writerDb.SelectContext(store.ctx, &slots, `
+	SELECT slot, root, COALESCE(graffiti_text, '') AS graffiti_text, COALESCE(eth_block_extra_text, '') AS eth_block_extra_text
+	FROM slots
+	WHERE cl_client IS NULL AND status != 0
+	ORDER BY slot ASC
+	LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching unclassified slots: %v", err)
+	}
+	return slots, nil
+}
+
+func (store *sqlStore) UpdateSlotClients(slot uint64, root []byte, clClient uint8, elClient uint8, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`UPDATE slots SET cl_client = $1, el_client = $2 WHERE slot = $3 AND root = $4`, clClient, elClient, slot, root)
+	return err
+}
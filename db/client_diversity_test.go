@@ -0,0 +1,63 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+func TestClientDiversityMigrationKeepsBlocksUnclassified(t *testing.T) {
+	// schema right before the client diversity migration
+	newTestSqliteDbAtVersion(t, 20240912101500)
+
+	for _, slot := range []struct {
+		slot   uint64
+		root   byte
+		status int
+	}{
+		{8, 0x08, 1},
+		{9, 0x09, 0},
+		{10, 0x0a, 1},
+		{11, 0x0b, 2},
+	} {
+		err := db.ExecWriterQuery(`INSERT INTO slots (slot, proposer, status, root, graffiti_text) VALUES ($1, 1, $2, $3, 'lighthouse')`, slot.slot, slot.status, []byte{slot.root})
+		if err != nil {
+			t.Fatalf("failed inserting slot %v: %v", slot.slot, err)
+		}
+	}
+
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+
+	// existing blocks need to be classified by the client diversity service, missed slots are skipped
+	slots, err := db.GetUnclassifiedSlots(10)
+	if err != nil {
+		t.Fatalf("failed fetching unclassified slots: %v", err)
+	}
+	if len(slots) != 3 || slots[0].Slot != 8 || slots[1].Slot != 10 || slots[2].Slot != 11 || slots[0].GraffitiText != "lighthouse" {
+		t.Fatalf("expected unclassified blocks 8, 10 & 11, got %v slots", len(slots))
+	}
+
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		for _, slot := range slots {
+			if err := db.UpdateSlotClients(slot.Slot, slot.Root, 1, 2, tx); err != nil {
+				return err
+			}
+		}
+		// 4 slots per epoch, orphaned blocks are not counted
+		return db.UpdateClientDiversity(2, 2, 4, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed classifying slots: %v", err)
+	}
+
+	if slots, err = db.GetUnclassifiedSlots(10); err != nil || len(slots) != 0 {
+		t.Errorf("expected all blocks to be classified, got %v slots (err: %v)", len(slots), err)
+	}
+
+	aggregates := db.GetClientDiversity(0, 10)
+	if len(aggregates) != 1 || aggregates[0].Epoch != 2 || aggregates[0].ClClient != 1 || aggregates[0].ElClient != 2 || aggregates[0].BlockCount != 2 {
+		t.Errorf("expected 2 canonical blocks in epoch 2, got %v aggregates", len(aggregates))
+	}
+}
@@ -1,8 +1,10 @@
 package db
 
 import (
+	"context"
 	"embed"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,12 +32,17 @@ var DBPGX *pgxpool.Conn
 
 // DB is a pointer to the explorer-database
 var DbEngine dbtypes.DBEngineType
-var ReaderDb *sqlx.DB
+var ReaderDb *DbReaderPool
 var writerDb *sqlx.DB
 var writerMutex sync.Mutex
 
 var logger = logrus.StandardLogger().WithField("module", "db")
 
+// sqlStore is the default store backed by the sqlite / pgsql database.
+type sqlStore struct {
+	ctx context.Context
+}
+
 func checkDbConn(dbConn *sqlx.DB, dataBaseName string) {
 	// The golang sql driver does not properly implement PingContext
 	// therefore we use a timer to catch db connection timeouts
@@ -54,7 +61,7 @@ func checkDbConn(dbConn *sqlx.DB, dataBaseName string) {
 	dbConnectionTimeout.Stop()
 }
 
-func mustInitSqlite(config *types.SqliteDatabaseConfig) (*sqlx.DB, *sqlx.DB) {
+func mustInitSqlite(config *types.SqliteDatabaseConfig) *sqlx.DB {
 	if config.MaxOpenConns == 0 {
 		config.MaxOpenConns = 50
 	}
@@ -66,7 +73,12 @@ func mustInitSqlite(config *types.SqliteDatabaseConfig) (*sqlx.DB, *sqlx.DB) {
 	}
 
 	logger.Infof("initializing sqlite connection to %v with %v/%v conn limit", config.File, config.MaxIdleConns, config.MaxOpenConns)
-	dbConn, err := sqlx.Open("sqlite", fmt.Sprintf("%s?_pragma=journal_mode(WAL)", config.File))
+	// the file may be a sqlite uri with parameters already (e.g. "file:dora?mode=memory&cache=shared")
+	dbParamSep := "?"
+	if strings.Contains(config.File, "?") {
+		dbParamSep = "&"
+	}
+	dbConn, err := sqlx.Open("sqlite", fmt.Sprintf("%s%s_pragma=journal_mode(WAL)", config.File, dbParamSep))
 	if err != nil {
 		utils.LogFatal(err, "error opening sqlite database", 0)
 	}
@@ -79,61 +91,63 @@ func mustInitSqlite(config *types.SqliteDatabaseConfig) (*sqlx.DB, *sqlx.DB) {
 
 	dbConn.MustExec("PRAGMA journal_mode = WAL")
 
-	return dbConn, dbConn
+	return dbConn
 }
 
-func mustInitPgsql(writer *types.PgsqlDatabaseConfig, reader *types.PgsqlDatabaseConfig) (*sqlx.DB, *sqlx.DB) {
-	if writer.MaxOpenConns == 0 {
-		writer.MaxOpenConns = 50
-	}
-	if writer.MaxIdleConns == 0 {
-		writer.MaxIdleConns = 10
-	}
-	if writer.MaxOpenConns < writer.MaxIdleConns {
-		writer.MaxIdleConns = writer.MaxOpenConns
-	}
-
-	if reader.MaxOpenConns == 0 {
-		reader.MaxOpenConns = 50
+func mustInitPgsqlConn(config *types.PgsqlDatabaseConfig, dataBaseName string) *sqlx.DB {
+	if config.MaxOpenConns == 0 {
+		config.MaxOpenConns = 50
 	}
-	if reader.MaxIdleConns == 0 {
-		reader.MaxIdleConns = 10
+	if config.MaxIdleConns == 0 {
+		config.MaxIdleConns = 10
 	}
-	if reader.MaxOpenConns < reader.MaxIdleConns {
-		reader.MaxIdleConns = reader.MaxOpenConns
+	if config.MaxOpenConns < config.MaxIdleConns {
+		config.MaxIdleConns = config.MaxOpenConns
 	}
 
-	logger.Infof("initializing pgsql writer connection to %v with %v/%v conn limit", writer.Host, writer.MaxIdleConns, writer.MaxOpenConns)
-	dbConnWriter, err := sqlx.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", writer.Username, writer.Password, writer.Host, writer.Port, writer.Name))
+	logger.Infof("initializing pgsql %v connection to %v with %v/%v conn limit", dataBaseName, config.Host, config.MaxIdleConns, config.MaxOpenConns)
+	dbConn, err := sqlx.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", config.Username, config.Password, config.Host, config.Port, config.Name))
 	if err != nil {
-		utils.LogFatal(err, "error getting pgsql writer database", 0)
+		utils.LogFatal(err, fmt.Sprintf("error getting pgsql %v database", dataBaseName), 0)
 	}
 
-	checkDbConn(dbConnWriter, "database")
-	dbConnWriter.SetConnMaxIdleTime(time.Second * 30)
-	dbConnWriter.SetConnMaxLifetime(time.Second * 60)
-	dbConnWriter.SetMaxOpenConns(writer.MaxOpenConns)
-	dbConnWriter.SetMaxIdleConns(writer.MaxIdleConns)
+	checkDbConn(dbConn, fmt.Sprintf("%v database", dataBaseName))
+	dbConn.SetConnMaxIdleTime(time.Second * 30)
+	dbConn.SetConnMaxLifetime(time.Second * 60)
+	dbConn.SetMaxOpenConns(config.MaxOpenConns)
+	dbConn.SetMaxIdleConns(config.MaxIdleConns)
+	return dbConn
+}
 
-	logger.Infof("initializing pgsql reader connection to %v with %v/%v conn limit", writer.Host, reader.MaxIdleConns, reader.MaxOpenConns)
-	dbConnReader, err := sqlx.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", reader.Username, reader.Password, reader.Host, reader.Port, reader.Name))
-	if err != nil {
-		utils.LogFatal(err, "error getting pgsql reader database", 0)
+func mustInitPgsql(writer *types.PgsqlDatabaseConfig, reader *types.PgsqlDatabaseConfig, replicas []types.PgsqlReplicaConfig) (*sqlx.DB, *DbReaderPool) {
+	dbConnWriter := mustInitPgsqlConn(writer, "writer")
+	readerPool := newDbReaderPool(dbConnWriter)
+
+	if reader != writer {
+		readerPool.addReader(reader.Host, mustInitPgsqlConn(reader, "reader"))
+	}
+	for idx := range replicas {
+		replicaConfig := (*types.PgsqlDatabaseConfig)(&replicas[idx])
+		readerPool.addReader(replicaConfig.Host, mustInitPgsqlConn(replicaConfig, "replica"))
+	}
+
+	if len(readerPool.readers) == 0 {
+		// no separate readers configured, use a dedicated connection pool to the writer for reads
+		readerPool.addReader(writer.Host, mustInitPgsqlConn(reader, "reader"))
+	} else {
+		readerPool.checkReaders()
+		go readerPool.runHealthChecks()
 	}
 
-	checkDbConn(dbConnReader, "read replica database")
-	dbConnReader.SetConnMaxIdleTime(time.Second * 30)
-	dbConnReader.SetConnMaxLifetime(time.Second * 60)
-	dbConnReader.SetMaxOpenConns(reader.MaxOpenConns)
-	dbConnReader.SetMaxIdleConns(reader.MaxIdleConns)
-	return dbConnWriter, dbConnReader
+	return dbConnWriter, readerPool
 }
 
 func MustInitDB() {
 	if utils.Config.Database.Engine == "sqlite" {
 		sqliteConfig := (*types.SqliteDatabaseConfig)(&utils.Config.Database.Sqlite)
 		DbEngine = dbtypes.DBEngineSqlite
-		writerDb, ReaderDb = mustInitSqlite(sqliteConfig)
+		writerDb = mustInitSqlite(sqliteConfig)
+		ReaderDb = newDbReaderPool(writerDb)
 	} else if utils.Config.Database.Engine == "pgsql" {
 		readerConfig := (*types.PgsqlDatabaseConfig)(&utils.Config.Database.Pgsql)
 		writerConfig := (*types.PgsqlDatabaseConfig)(&utils.Config.Database.PgsqlWriter)
@@ -141,13 +155,27 @@ func MustInitDB() {
 			writerConfig = readerConfig
 		}
 		DbEngine = dbtypes.DBEnginePgsql
-		writerDb, ReaderDb = mustInitPgsql(writerConfig, readerConfig)
+		writerDb, ReaderDb = mustInitPgsql(writerConfig, readerConfig, utils.Config.Database.PgsqlReplicas)
 	} else {
 		logger.Fatalf("unknown database engine type: %s", utils.Config.Database.Engine)
 	}
+
+	store = mustInitAnalyticsStore(&sqlStore{
+		ctx: context.Background(),
+	})
+}
+
+func (store *sqlStore) WithContext(ctx context.Context) Store {
+	return &sqlStore{
+		ctx: ctx,
+	}
 }
 
 func MustCloseDB() {
+	store.Close()
+}
+
+func (store *sqlStore) Close() {
 	err := writerDb.Close()
 	if err != nil {
 		logger.Errorf("Error closing writer db connection: %v", err)
@@ -158,7 +186,17 @@ func MustCloseDB() {
 	}
 }
 
-func RunDBTransaction(handler func(tx *sqlx.Tx) error) error {
+// sqlTx returns the sql transaction of a transaction handle passed to the sql store.
+func sqlTx(tx Tx) *sqlx.Tx {
+	sqlxTx, _ := tx.(*sqlx.Tx)
+	return sqlxTx
+}
+
+func RunDBTransaction(handler func(tx Tx) error) error {
+	return store.RunTransaction(handler)
+}
+
+func (store *sqlStore) RunTransaction(handler func(tx Tx) error) error {
 	if DbEngine == dbtypes.DBEngineSqlite {
 		writerMutex.Lock()
 		defer writerMutex.Unlock()
@@ -185,6 +223,10 @@ func RunDBTransaction(handler func(tx *sqlx.Tx) error) error {
 }
 
 func ApplyEmbeddedDbSchema(version int64) error {
+	return store.ApplySchema(version)
+}
+
+func (store *sqlStore) ApplySchema(version int64) error {
 	var engineDialect string
 	var schemaDirectory string
 	switch DbEngine {
@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertConsolidationRequests(consolidations []*dbtypes.ConsolidationRequest, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertConsolidationRequests(consolidations []*dbtypes.ConsolidationRequest, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -52,7 +51,7 @@ func InsertConsolidationRequests(consolidations []*dbtypes.ConsolidationRequest,
 		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot_index, slot_root) DO UPDATE SET orphaned = excluded.orphaned, fork_id = excluded.fork_id",
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,369 @@
+// Package dbtest provides an in-memory fake of the db store for tests.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// Store is an in-memory fake of db.Store.
+// it implements transactions, explorer state, epochs, client diversity & classification, chart rollups & missed slots.
+// all other methods are
+// forwarded to the embedded db.Store, which is nil by default (calls panic), so tests notice missing fakes right away.
+//
+// writes are applied when the transaction of RunTransaction is committed and dropped when the handler fails.
+type Store struct {
+	db.Store
+
+	mutex           sync.Mutex
+	explorerState   map[string][]byte
+	epochs          map[uint64]*dbtypes.Epoch
+	clientDiversity map[uint64][]*dbtypes.ClientDiversity
+	chartRollups    map[[2]uint64]*dbtypes.ChartRollup
+	missedSlots     map[uint64]bool
+	missedRanges    map[uint64]uint64
+	slotClients     map[uint64]*[2]uint8
+	slotFields      map[uint64]*dbtypes.UnclassifiedSlot
+
+	Commits   int
+	Rollbacks int
+}
+
+// Tx is the transaction handle passed by Store.RunTransaction.
+type Tx struct {
+	writes []func()
+}
+
+func (tx *Tx) Commit() error {
+	return nil
+}
+
+func (tx *Tx) Rollback() error {
+	return nil
+}
+
+// NewStore creates an empty fake store.
+func NewStore() *Store {
+	return &Store{
+		explorerState:   map[string][]byte{},
+		epochs:          map[uint64]*dbtypes.Epoch{},
+		clientDiversity: map[uint64][]*dbtypes.ClientDiversity{},
+		chartRollups:    map[[2]uint64]*dbtypes.ChartRollup{},
+		missedSlots:     map[uint64]bool{},
+		missedRanges:    map[uint64]uint64{},
+		slotClients:     map[uint64]*[2]uint8{},
+		slotFields:      map[uint64]*dbtypes.UnclassifiedSlot{},
+	}
+}
+
+// addWrite queues a write for the commit of the transaction (or applies it right away if there is no transaction).
+func (store *Store) addWrite(tx db.Tx, write func()) {
+	if fakeTx, ok := tx.(*Tx); ok && fakeTx != nil {
+		fakeTx.writes = append(fakeTx.writes, write)
+		return
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	write()
+}
+
+func (store *Store) WithContext(ctx context.Context) db.Store {
+	return store
+}
+
+func (store *Store) RunTransaction(handler func(tx db.Tx) error) error {
+	tx := &Tx{}
+	if err := handler(tx); err != nil {
+		store.mutex.Lock()
+		store.Rollbacks++
+		store.mutex.Unlock()
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, write := range tx.writes {
+		write()
+	}
+	store.Commits++
+
+	return nil
+}
+
+func (store *Store) ApplySchema(version int64) error {
+	return nil
+}
+
+func (store *Store) Close() {}
+
+func (store *Store) GetExplorerState(key string, returnValue interface{}) (interface{}, error) {
+	store.mutex.Lock()
+	value, found := store.explorerState[key]
+	store.mutex.Unlock()
+
+	if !found {
+		return nil, fmt.Errorf("explorer state %v not found: %w", key, sql.ErrNoRows)
+	}
+	if err := json.Unmarshal(value, returnValue); err != nil {
+		return nil, err
+	}
+	return returnValue, nil
+}
+
+// GetExplorerStateForUpdate reads the committed state, the fake serializes transactions on commit only.
+func (store *Store) GetExplorerStateForUpdate(key string, returnValue interface{}, tx db.Tx) (interface{}, error) {
+	return store.GetExplorerState(key, returnValue)
+}
+
+func (store *Store) SetExplorerState(key string, value interface{}, tx db.Tx) error {
+	valueMarshal, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	store.addWrite(tx, func() {
+		store.explorerState[key] = valueMarshal
+	})
+	return nil
+}
+
+func (store *Store) InsertEpoch(epoch *dbtypes.Epoch, tx db.Tx) error {
+	epochCopy := *epoch
+	store.addWrite(tx, func() {
+		store.epochs[epoch.Epoch] = &epochCopy
+	})
+	return nil
+}
+
+func (store *Store) IsEpochSynchronized(epoch uint64) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.epochs[epoch] != nil
+}
+
+func (store *Store) GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	epochs := []*dbtypes.Epoch{}
+	for _, epoch := range store.epochs {
+		if epoch.Epoch <= firstEpoch {
+			epochCopy := *epoch
+			epochs = append(epochs, &epochCopy)
+		}
+	}
+
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i].Epoch > epochs[j].Epoch
+	})
+	if len(epochs) > int(limit) {
+		epochs = epochs[:limit]
+	}
+
+	return epochs
+}
+
+// SetClientDiversity sets the client diversity aggregates of an epoch (the fake does not have slots to aggregate).
+func (store *Store) SetClientDiversity(epoch uint64, aggregates []*dbtypes.ClientDiversity) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.clientDiversity[epoch] = aggregates
+}
+
+// UpdateClientDiversity keeps the aggregates set via SetClientDiversity, there are no slots to rebuild them from.
+func (store *Store) UpdateClientDiversity(firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx db.Tx) error {
+	return nil
+}
+
+// AddUnclassifiedSlots adds blocks without proposer client classification.
+func (store *Store) AddUnclassifiedSlots(slots ...*dbtypes.UnclassifiedSlot) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, slot := range slots {
+		store.slotFields[slot.Slot] = slot
+		delete(store.slotClients, slot.Slot)
+	}
+}
+
+// GetSlotClients returns the classified proposer clients of a block added via AddUnclassifiedSlots.
+func (store *Store) GetSlotClients(slot uint64) (clClient uint8, elClient uint8, classified bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	clients := store.slotClients[slot]
+	if clients == nil {
+		return 0, 0, false
+	}
+	return clients[0], clients[1], true
+}
+
+func (store *Store) GetUnclassifiedSlots(limit uint32) ([]*dbtypes.UnclassifiedSlot, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	slots := []*dbtypes.UnclassifiedSlot{}
+	for slot, fields := range store.slotFields {
+		if store.slotClients[slot] == nil {
+			slots = append(slots, fields)
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Slot < slots[j].Slot
+	})
+	if len(slots) > int(limit) {
+		slots = slots[:limit]
+	}
+
+	return slots, nil
+}
+
+func (store *Store) UpdateSlotClients(slot uint64, root []byte, clClient uint8, elClient uint8, tx db.Tx) error {
+	store.addWrite(tx, func() {
+		store.slotClients[slot] = &[2]uint8{clClient, elClient}
+	})
+	return nil
+}
+
+func (store *Store) GetClientDiversity(firstEpoch uint64, lastEpoch uint64) []*dbtypes.ClientDiversity {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	aggregates := []*dbtypes.ClientDiversity{}
+	for epoch, epochAggregates := range store.clientDiversity {
+		if epoch >= firstEpoch && epoch <= lastEpoch {
+			aggregates = append(aggregates, epochAggregates...)
+		}
+	}
+
+	sort.SliceStable(aggregates, func(i, j int) bool {
+		return aggregates[i].Epoch < aggregates[j].Epoch
+	})
+
+	return aggregates
+}
+
+func (store *Store) InsertChartRollups(rollups []*dbtypes.ChartRollup, tx db.Tx) error {
+	rollupCopies := make([]dbtypes.ChartRollup, len(rollups))
+	for i, rollup := range rollups {
+		rollupCopies[i] = *rollup
+	}
+
+	store.addWrite(tx, func() {
+		for i := range rollupCopies {
+			store.chartRollups[[2]uint64{uint64(rollupCopies[i].Period), rollupCopies[i].Time}] = &rollupCopies[i]
+		}
+	})
+	return nil
+}
+
+func (store *Store) GetChartRollups(period uint32, firstTime uint64, lastTime uint64) []*dbtypes.ChartRollup {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	rollups := []*dbtypes.ChartRollup{}
+	for _, rollup := range store.chartRollups {
+		if rollup.Period == period && rollup.Time >= firstTime && rollup.Time <= lastTime {
+			rollupCopy := *rollup
+			rollups = append(rollups, &rollupCopy)
+		}
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		return rollups[i].Time < rollups[j].Time
+	})
+
+	return rollups
+}
+
+// AddMissedSlots adds missed slot rows (the fake only tracks the slot numbers of missed slots).
+func (store *Store) AddMissedSlots(slots ...uint64) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, slot := range slots {
+		store.missedSlots[slot] = true
+	}
+}
+
+func (store *Store) GetMissedSlotsBefore(slot uint64, limit uint32, tx db.Tx) ([]uint64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	missedSlots := []uint64{}
+	for missedSlot := range store.missedSlots {
+		if missedSlot < slot {
+			missedSlots = append(missedSlots, missedSlot)
+		}
+	}
+
+	sort.Slice(missedSlots, func(i, j int) bool {
+		return missedSlots[i] < missedSlots[j]
+	})
+	if len(missedSlots) > int(limit) {
+		missedSlots = missedSlots[:limit]
+	}
+
+	return missedSlots, nil
+}
+
+func (store *Store) DeleteMissedSlotsInRange(firstSlot uint64, lastSlot uint64, tx db.Tx) error {
+	store.addWrite(tx, func() {
+		for slot := range store.missedSlots {
+			if slot >= firstSlot && slot <= lastSlot {
+				delete(store.missedSlots, slot)
+			}
+		}
+	})
+	return nil
+}
+
+func (store *Store) InsertMissedSlotRange(missedRange *dbtypes.MissedSlotRange, tx db.Tx) error {
+	firstSlot, lastSlot := missedRange.FirstSlot, missedRange.LastSlot
+	store.addWrite(tx, func() {
+		store.missedRanges[firstSlot] = lastSlot
+	})
+	return nil
+}
+
+func (store *Store) GetMissedSlotRanges(firstSlot uint64, lastSlot uint64) []*dbtypes.MissedSlotRange {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	missedRanges := []*dbtypes.MissedSlotRange{}
+	for rangeFirst, rangeLast := range store.missedRanges {
+		if rangeFirst <= lastSlot && rangeLast >= firstSlot {
+			missedRanges = append(missedRanges, &dbtypes.MissedSlotRange{FirstSlot: rangeFirst, LastSlot: rangeLast})
+		}
+	}
+
+	sort.Slice(missedRanges, func(i, j int) bool {
+		return missedRanges[i].FirstSlot < missedRanges[j].FirstSlot
+	})
+
+	return missedRanges
+}
+
+func (store *Store) GetMissedSlotRangeEndingAt(slot uint64, tx db.Tx) (*dbtypes.MissedSlotRange, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for rangeFirst, rangeLast := range store.missedRanges {
+		if rangeLast == slot {
+			return &dbtypes.MissedSlotRange{FirstSlot: rangeFirst, LastSlot: rangeLast}, nil
+		}
+	}
+	return nil, nil
+}
@@ -6,10 +6,9 @@ import (
 
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/utils"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -58,14 +57,14 @@ func InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx *sqlx.Tx) error {
 		dbtypes.DBEngineSqlite: "",
 	}))
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func InsertDeposits(deposits []*dbtypes.Deposit, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertDeposits(deposits []*dbtypes.Deposit, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -108,14 +107,14 @@ func InsertDeposits(deposits []*dbtypes.Deposit, tx *sqlx.Tx) error {
 		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot_index, slot_root) DO UPDATE SET deposit_index = excluded.deposit_index, orphaned = excluded.orphaned, fork_id = excluded.fork_id",
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
+func (store *sqlStore) GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -135,7 +134,7 @@ func GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
 	`, len(args))
 
 	depositTxs := []*dbtypes.DepositTx{}
-	err := ReaderDb.Select(&depositTxs, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &depositTxs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching deposit txs: %v", err)
 		return nil
@@ -143,7 +142,7 @@ func GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
 	return depositTxs
 }
 
-func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositTxFilter) ([]*dbtypes.DepositTx, uint64, error) {
+func (store *sqlStore) GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositTxFilter) ([]*dbtypes.DepositTx, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -227,7 +226,7 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 	fmt.Fprintf(&sql, ") AS t1")
 
 	depositTxs := []*dbtypes.DepositTx{}
-	err := ReaderDb.Select(&depositTxs, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &depositTxs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered deposit txs: %v", err)
 		return nil, 0, err
@@ -236,7 +235,7 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 	return depositTxs[1:], depositTxs[0].Index, nil
 }
 
-func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositFilter) ([]*dbtypes.Deposit, uint64, error) {
+func (store *sqlStore) GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositFilter) ([]*dbtypes.Deposit, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -308,7 +307,7 @@ func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, fil
 	fmt.Fprintf(&sql, ") AS t1")
 
 	deposits := []*dbtypes.Deposit{}
-	err := ReaderDb.Select(&deposits, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &deposits, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered deposits: %v", err)
 		return nil, 0, err
@@ -4,12 +4,12 @@ import (
 	"encoding/json"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func GetExplorerState(key string, returnValue interface{}) (interface{}, error) {
+// GetExplorerState reads from the writer, the indexers resume from their state and must not see a lagging replica.
+func (store *sqlStore) GetExplorerState(key string, returnValue interface{}) (interface{}, error) {
 	entry := dbtypes.ExplorerState{}
-	err := ReaderDb.Get(&entry, `SELECT key, value FROM explorer_state WHERE key = $1`, key)
+	err := writerDb.GetContext(store.ctx, &entry, `SELECT key, value FROM explorer_state WHERE key = $1`, key)
 	if err != nil {
 		return nil, err
 	}
@@ -20,12 +20,30 @@ func GetExplorerState(key string, returnValue interface{}) (interface{}, error)
 	return returnValue, nil
 }
 
-func SetExplorerState(key string, value interface{}, tx *sqlx.Tx) error {
+// GetExplorerStateForUpdate reads an explorer state entry within the given transaction and locks it until the transaction ends.
+// used for read-modify-write updates of states that are written by multiple routines.
+func (store *sqlStore) GetExplorerStateForUpdate(key string, returnValue interface{}, tx Tx) (interface{}, error) {
+	entry := dbtypes.ExplorerState{}
+	err := sqlTx(tx).GetContext(store.ctx, &entry, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `SELECT key, value FROM explorer_state WHERE key = $1 FOR UPDATE`,
+		dbtypes.DBEngineSqlite: `SELECT key, value FROM explorer_state WHERE key = $1`,
+	}), key)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(entry.Value), returnValue)
+	if err != nil {
+		return nil, err
+	}
+	return returnValue, nil
+}
+
+func (store *sqlStore) SetExplorerState(key string, value interface{}, tx Tx) error {
 	valueMarshal, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	_, err = tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+	_, err = sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO explorer_state (key, value)
 			VALUES ($1, $2)
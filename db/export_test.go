@@ -0,0 +1,30 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/types"
+)
+
+// NewClickhouseStore exposes the clickhouse analytics store to the external tests.
+func NewClickhouseStore(primary Store, url string) (Store, error) {
+	return newClickhouseStore(primary, &types.ClickhouseDatabaseConfig{
+		Url: url,
+	})
+}
+
+// SyncClickhouseAggregates runs the startup copy of the clickhouse analytics store.
+func SyncClickhouseAggregates(store Store) error {
+	return store.(*clickhouseStore).syncAggregates()
+}
+
+// FlushClickhouseMirror flushes the queued mirror writes of the clickhouse analytics store like the mirror loop.
+// returns true if writes are left in the queue.
+func FlushClickhouseMirror(store Store) bool {
+	store.(*clickhouseStore).flushMirror()
+	return store.(*clickhouseStore).hasPendingMirrorWrites()
+}
+
+// ExecWriterQuery runs a raw query on the writer, used to prepare data for the schema migration tests.
+func ExecWriterQuery(query string, args ...interface{}) error {
+	_, err := writerDb.Exec(query, args...)
+	return err
+}
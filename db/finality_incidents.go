@@ -0,0 +1,94 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+const finalityIncidentFields = `start_epoch, end_epoch, last_epoch, finalized_epoch, end_finalized_epoch, max_distance, leak_epochs,
+	participation_epochs, participation_avg, participation_min, max_forks, forks, offline_validators, offline_balance,
+	leak_estimate, leak_entities`
+
+func (store *sqlStore) InsertFinalityIncident(incident *dbtypes.FinalityIncident, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO finality_incidents (` + finalityIncidentFields + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			ON CONFLICT (start_epoch) DO UPDATE SET
+				end_epoch = excluded.end_epoch,
+				last_epoch = excluded.last_epoch,
+				finalized_epoch = excluded.finalized_epoch,
+				end_finalized_epoch = excluded.end_finalized_epoch,
+				max_distance = excluded.max_distance,
+				leak_epochs = excluded.leak_epochs,
+				participation_epochs = excluded.participation_epochs,
+				participation_avg = excluded.participation_avg,
+				participation_min = excluded.participation_min,
+				max_forks = excluded.max_forks,
+				forks = excluded.forks,
+				offline_validators = excluded.offline_validators,
+				offline_balance = excluded.offline_balance,
+				leak_estimate = excluded.leak_estimate,
+				leak_entities = excluded.leak_entities`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO finality_incidents (` + finalityIncidentFields + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+	}),
+		incident.StartEpoch, incident.EndEpoch, incident.LastEpoch, incident.FinalizedEpoch, incident.EndFinalizedEpoch, incident.MaxDistance,
+		incident.LeakEpochs, incident.ParticipationEpochs, incident.ParticipationAvg, incident.ParticipationMin, incident.MaxForks, incident.Forks,
+		incident.OfflineValidators, incident.OfflineBalance, incident.LeakEstimate, incident.LeakEntities)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *sqlStore) GetFinalityIncident(startEpoch uint64) *dbtypes.FinalityIncident {
+	incident := dbtypes.FinalityIncident{}
+	err := ReaderDb.GetContext(store.ctx, &incident, `
+	SELECT `+finalityIncidentFields+`
+	FROM finality_incidents
+	WHERE start_epoch = $1
+	`, startEpoch)
+	if err != nil {
+		return nil
+	}
+	return &incident
+}
+
+// GetOngoingFinalityIncident returns the latest incident that has not been resolved yet.
+func (store *sqlStore) GetOngoingFinalityIncident() *dbtypes.FinalityIncident {
+	incident := dbtypes.FinalityIncident{}
+	err := writerDb.Get(&incident, `
+	SELECT `+finalityIncidentFields+`
+	FROM finality_incidents
+	WHERE end_epoch IS NULL
+	ORDER BY start_epoch DESC
+	LIMIT 1
+	`)
+	if err != nil {
+		return nil
+	}
+	return &incident
+}
+
+func (store *sqlStore) GetFinalityIncidents(offset uint64, limit uint32) ([]*dbtypes.FinalityIncident, uint64, error) {
+	var totalCount uint64
+	err := ReaderDb.GetContext(store.ctx, &totalCount, `SELECT COUNT(*) FROM finality_incidents`)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	incidents := []*dbtypes.FinalityIncident{}
+	err = ReaderDb.SelectContext(store.ctx, &incidents, `
+	SELECT `+finalityIncidentFields+`
+	FROM finality_incidents
+	ORDER BY start_epoch DESC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching finality incidents: %v", err)
+		return nil, 0, err
+	}
+
+	return incidents, totalCount, nil
+}
@@ -0,0 +1,51 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestFinalityIncidents(t *testing.T) {
+	newTestSqliteDb(t)
+
+	insertIncident := func(incident *dbtypes.FinalityIncident) {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			return db.InsertFinalityIncident(incident, tx)
+		})
+		if err != nil {
+			t.Fatalf("failed inserting finality incident: %v", err)
+		}
+	}
+
+	endEpoch := uint64(30)
+	endFinalizedEpoch := uint64(28)
+	insertIncident(&dbtypes.FinalityIncident{StartEpoch: 21, EndEpoch: &endEpoch, LastEpoch: 29, FinalizedEpoch: 20, EndFinalizedEpoch: &endFinalizedEpoch, MaxDistance: 9})
+	insertIncident(&dbtypes.FinalityIncident{StartEpoch: 101, LastEpoch: 104, FinalizedEpoch: 100, MaxDistance: 4})
+
+	incident := db.GetOngoingFinalityIncident()
+	if incident == nil || incident.StartEpoch != 101 {
+		t.Fatalf("expected ongoing incident starting at epoch 101, got %v", incident)
+	}
+
+	// updates of the ongoing incident replace the row of its start epoch
+	incident.LastEpoch = 110
+	incident.MaxDistance = 10
+	incident.LeakEpochs = 5
+	insertIncident(incident)
+
+	incidents, totalCount, err := db.GetFinalityIncidents(0, 10)
+	if err != nil {
+		t.Fatalf("failed fetching finality incidents: %v", err)
+	}
+	if totalCount != 2 || len(incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %v (total %v)", len(incidents), totalCount)
+	}
+	if incidents[0].StartEpoch != 101 || incidents[0].LastEpoch != 110 || incidents[0].LeakEpochs != 5 || incidents[0].EndEpoch != nil {
+		t.Errorf("unexpected ongoing incident: %+v", incidents[0])
+	}
+	if incidents[1].StartEpoch != 21 || incidents[1].EndEpoch == nil || *incidents[1].EndEpoch != 30 {
+		t.Errorf("unexpected resolved incident: %+v", incidents[1])
+	}
+}
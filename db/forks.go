@@ -5,11 +5,10 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertFork(fork *dbtypes.Fork, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertFork(fork *dbtypes.Fork, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO forks (
 				fork_id, base_slot, base_root, leaf_slot, leaf_root, parent_fork
@@ -33,10 +32,10 @@ func InsertFork(fork *dbtypes.Fork, tx *sqlx.Tx) error {
 	return nil
 }
 
-func GetUnfinalizedForks(finalizedSlot uint64) []*dbtypes.Fork {
+func (store *sqlStore) GetUnfinalizedForks(finalizedSlot uint64) []*dbtypes.Fork {
 	forks := []*dbtypes.Fork{}
 
-	err := ReaderDb.Select(&forks, `SELECT fork_id, base_slot, base_root, leaf_slot, leaf_root, parent_fork
+	err := ReaderDb.SelectContext(store.ctx, &forks, `SELECT fork_id, base_slot, base_root, leaf_slot, leaf_root, parent_fork
 		FROM forks
 		WHERE base_slot >= $1
 		ORDER BY base_slot ASC
@@ -49,7 +48,7 @@ func GetUnfinalizedForks(finalizedSlot uint64) []*dbtypes.Fork {
 	return forks
 }
 
-func DeleteFinalizedForks(finalizedRoots [][]byte, tx *sqlx.Tx) error {
+func (store *sqlStore) DeleteFinalizedForks(finalizedRoots [][]byte, tx Tx) error {
 	var sql strings.Builder
 	args := []any{}
 
@@ -66,7 +65,7 @@ func DeleteFinalizedForks(finalizedRoots [][]byte, tx *sqlx.Tx) error {
 
 	fmt.Fprint(&sql, ")")
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetMevBlockStats returns the aggregated relay payload stats in the given slot range.
+// Missed slots had a payload delivered by a relay, but no canonical block exists for the slot.
+// Replaced slots had a payload delivered by a relay, but the canonical block of the slot carries another payload.
+func (store *sqlStore) GetMevBlockStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) *dbtypes.MevBlockStats {
+	var sql strings.Builder
+	args := []any{firstSlot, lastSlot}
+	fmt.Fprint(&sql, `
+	SELECT
+		COUNT(*) AS payload_count,
+		COUNT(DISTINCT CASE WHEN proposed = 1 THEN slot_number END) AS proposed_count,
+		COALESCE(SUM(CASE WHEN proposed = 1 THEN block_value_gwei ELSE 0 END), 0) AS proposed_value,
+		COUNT(DISTINCT CASE WHEN proposed != 1 AND NOT EXISTS (
+			SELECT 1 FROM slots WHERE slots.slot = mev_blocks.slot_number AND slots.status = 1
+		) THEN slot_number END) AS missed_count,
+		COUNT(DISTINCT CASE WHEN proposed != 1 AND EXISTS (
+			SELECT 1 FROM slots WHERE slots.slot = mev_blocks.slot_number AND slots.status = 1
+		) THEN slot_number END) AS replaced_count,
+		COALESCE(MIN(slot_number), 0) AS first_slot,
+		COALESCE(MAX(slot_number), 0) AS last_slot
+	FROM mev_blocks
+	WHERE slot_number >= $1 AND slot_number <= $2`)
+	if builderPubkey != nil {
+		args = append(args, builderPubkey)
+		fmt.Fprintf(&sql, " AND builder_pubkey = $%v", len(args))
+	}
+
+	stats := &dbtypes.MevBlockStats{}
+	err := ReaderDb.GetContext(store.ctx, stats, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching mev block stats: %v", err)
+		return nil
+	}
+
+	if stats.ProposedCount > 0 {
+		// median payment of the proposed blocks
+		var medianSql strings.Builder
+		fmt.Fprint(&medianSql, `
+		SELECT block_value_gwei
+		FROM mev_blocks
+		WHERE slot_number >= $1 AND slot_number <= $2 AND proposed = 1`)
+		if builderPubkey != nil {
+			fmt.Fprint(&medianSql, " AND builder_pubkey = $3")
+		}
+		args = append(args, stats.ProposedCount/2)
+		fmt.Fprintf(&medianSql, " ORDER BY block_value_gwei ASC LIMIT 1 OFFSET $%v", len(args))
+
+		err = ReaderDb.GetContext(store.ctx, &stats.MedianValue, medianSql.String(), args...)
+		if err != nil {
+			logger.Errorf("Error while fetching mev block median value: %v", err)
+		}
+	}
+
+	return stats
+}
+
+// GetMevBuilderStats returns the number & value of proposed blocks per builder in the given slot range.
+func (store *sqlStore) GetMevBuilderStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevBuilderStats {
+	builderStats := []*dbtypes.MevBuilderStats{}
+	err := ReaderDb.SelectContext(store.ctx, &builderStats, `
+	SELECT builder_pubkey, COUNT(*) AS block_count, SUM(block_value_gwei) AS block_value, MAX(slot_number) AS last_slot
+	FROM mev_blocks
+	WHERE slot_number >= $1 AND slot_number <= $2 AND proposed = 1
+	GROUP BY builder_pubkey
+	ORDER BY block_count DESC`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching mev builder stats: %v", err)
+		return nil
+	}
+	return builderStats
+}
+
+// GetMevRelayStats returns the number & value of proposed blocks per relay combination in the given slot range.
+func (store *sqlStore) GetMevRelayStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) []*dbtypes.MevRelayStats {
+	var sql strings.Builder
+	args := []any{firstSlot, lastSlot}
+	fmt.Fprint(&sql, `
+	SELECT seenby_relays, COUNT(*) AS block_count, SUM(block_value_gwei) AS block_value
+	FROM mev_blocks
+	WHERE slot_number >= $1 AND slot_number <= $2 AND proposed = 1`)
+	if builderPubkey != nil {
+		args = append(args, builderPubkey)
+		fmt.Fprintf(&sql, " AND builder_pubkey = $%v", len(args))
+	}
+	fmt.Fprint(&sql, " GROUP BY seenby_relays")
+
+	relayStats := []*dbtypes.MevRelayStats{}
+	err := ReaderDb.SelectContext(store.ctx, &relayStats, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching mev relay stats: %v", err)
+		return nil
+	}
+	return relayStats
+}
+
+// GetMevProposerStats returns the number of canonical blocks & blocks delivered by a relay per proposer in the given slot range.
+func (store *sqlStore) GetMevProposerStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevProposerStats {
+	proposerStats := []*dbtypes.MevProposerStats{}
+	err := ReaderDb.SelectContext(store.ctx, &proposerStats, `
+	SELECT
+		slots.proposer, COUNT(*) AS block_count, COUNT(mev_blocks.block_hash) AS mev_count,
+		COALESCE(SUM(mev_blocks.block_value_gwei), 0) AS mev_value
+	FROM slots
+	LEFT JOIN mev_blocks ON mev_blocks.block_hash = slots.eth_block_hash AND mev_blocks.proposed = 1
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 1
+	GROUP BY slots.proposer`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching mev proposer stats: %v", err)
+		return nil
+	}
+	return proposerStats
+}
+
+// GetMevDailyStats returns the number of canonical blocks & blocks delivered by a relay per day in the given slot range.
+// Days are counted in slotsPerDay buckets since genesis. With a builder pubkey, only blocks of that builder are counted as relay blocks.
+func (store *sqlStore) GetMevDailyStats(firstSlot uint64, lastSlot uint64, slotsPerDay uint64, builderPubkey []byte) []*dbtypes.MevDailyStats {
+	var sql strings.Builder
+	args := []any{firstSlot, lastSlot, slotsPerDay}
+	fmt.Fprint(&sql, `
+	SELECT
+		slots.slot / $3 AS day, COUNT(*) AS block_count, COUNT(mev_blocks.block_hash) AS mev_count,
+		COALESCE(SUM(mev_blocks.block_value_gwei), 0) AS mev_value
+	FROM slots
+	LEFT JOIN mev_blocks ON mev_blocks.block_hash = slots.eth_block_hash AND mev_blocks.proposed = 1`)
+	if builderPubkey != nil {
+		args = append(args, builderPubkey)
+		fmt.Fprintf(&sql, " AND mev_blocks.builder_pubkey = $%v", len(args))
+	}
+	fmt.Fprint(&sql, `
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 1
+	GROUP BY day
+	ORDER BY day ASC`)
+
+	dailyStats := []*dbtypes.MevDailyStats{}
+	err := ReaderDb.SelectContext(store.ctx, &dailyStats, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching mev daily stats: %v", err)
+		return nil
+	}
+	return dailyStats
+}
@@ -0,0 +1,96 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func insertTestMevData(t *testing.T) {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		for _, slot := range []*dbtypes.Slot{
+			{Slot: 10, Root: []byte{0x10}, Status: dbtypes.Canonical, Proposer: 1, EthBlockHash: []byte{0xa0}},
+			{Slot: 11, Root: []byte{0x11}, Status: dbtypes.Canonical, Proposer: 2, EthBlockHash: []byte{0xa1}},
+			{Slot: 12, Root: []byte{0x12}, Status: dbtypes.Canonical, Proposer: 1, EthBlockHash: []byte{0xa2}},
+			{Slot: 14, Root: []byte{0x14}, Status: dbtypes.Canonical, Proposer: 2, EthBlockHash: []byte{0xa4}},
+			{Slot: 20, Root: []byte{0x20}, Status: dbtypes.Canonical, Proposer: 3, EthBlockHash: []byte{0xb0}},
+		} {
+			if err := db.InsertSlot(slot, tx); err != nil {
+				return err
+			}
+		}
+
+		return db.InsertMevBlocks([]*dbtypes.MevBlock{
+			{SlotNumber: 10, BlockHash: []byte{0xa0}, BuilderPubkey: []byte{0x01}, Proposed: 1, SeenbyRelays: 1, BlockValueGwei: 100},
+			{SlotNumber: 11, BlockHash: []byte{0xa1}, BuilderPubkey: []byte{0x01}, Proposed: 1, SeenbyRelays: 3, BlockValueGwei: 300},
+			{SlotNumber: 12, BlockHash: []byte{0xa2}, BuilderPubkey: []byte{0x02}, Proposed: 1, SeenbyRelays: 2, BlockValueGwei: 200},
+			// replaced by the canonical block of slot 14
+			{SlotNumber: 14, BlockHash: []byte{0xc4}, BuilderPubkey: []byte{0x02}, Proposed: 2, SeenbyRelays: 1, BlockValueGwei: 50},
+			// missed slot
+			{SlotNumber: 15, BlockHash: []byte{0xc5}, BuilderPubkey: []byte{0x01}, Proposed: 2, SeenbyRelays: 1, BlockValueGwei: 70},
+			// outside of the stats range
+			{SlotNumber: 20, BlockHash: []byte{0xb0}, BuilderPubkey: []byte{0x01}, Proposed: 1, SeenbyRelays: 1, BlockValueGwei: 1000},
+		}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed inserting mev data: %v", err)
+	}
+}
+
+func TestGetMevBlockStats(t *testing.T) {
+	newTestSqliteDb(t)
+	insertTestMevData(t)
+
+	stats := db.GetMevBlockStats(10, 19, nil)
+	if stats == nil {
+		t.Fatalf("expected mev block stats")
+	}
+	if stats.PayloadCount != 5 || stats.ProposedCount != 3 || stats.ProposedValue != 600 {
+		t.Errorf("expected 5 payloads with 3 proposed blocks worth 600 gwei, got %+v", stats)
+	}
+	if stats.MissedCount != 1 || stats.ReplacedCount != 1 {
+		t.Errorf("expected 1 missed & 1 replaced slot, got %+v", stats)
+	}
+	if stats.MedianValue != 200 {
+		t.Errorf("expected median value of 200 gwei, got %v", stats.MedianValue)
+	}
+	if stats.FirstSlot != 10 || stats.LastSlot != 15 {
+		t.Errorf("expected slot range 10 - 15, got %v - %v", stats.FirstSlot, stats.LastSlot)
+	}
+
+	stats = db.GetMevBlockStats(10, 19, []byte{0x01})
+	if stats == nil || stats.ProposedCount != 2 || stats.MissedCount != 1 || stats.ReplacedCount != 0 || stats.MedianValue != 300 {
+		t.Errorf("unexpected stats for builder 0x01: %+v", stats)
+	}
+}
+
+func TestGetMevBuilderAndDailyStats(t *testing.T) {
+	newTestSqliteDb(t)
+	insertTestMevData(t)
+
+	builderStats := db.GetMevBuilderStats(10, 19)
+	if len(builderStats) != 2 {
+		t.Fatalf("expected stats for 2 builders, got %v", len(builderStats))
+	}
+	if builderStats[0].BuilderPubkey[0] != 0x01 || builderStats[0].BlockCount != 2 || builderStats[0].BlockValue != 400 || builderStats[0].LastSlot != 11 {
+		t.Errorf("unexpected stats for builder 0x01: %+v", builderStats[0])
+	}
+
+	// 10 slots per day: slots 10 - 14 on day 1, slot 20 on day 2
+	dailyStats := db.GetMevDailyStats(0, 29, 10, nil)
+	if len(dailyStats) != 2 {
+		t.Fatalf("expected stats for 2 days, got %v", len(dailyStats))
+	}
+	if dailyStats[0].Day != 1 || dailyStats[0].BlockCount != 4 || dailyStats[0].MevCount != 3 || dailyStats[0].MevValue != 600 {
+		t.Errorf("unexpected stats for day 1: %+v", dailyStats[0])
+	}
+	if dailyStats[1].Day != 2 || dailyStats[1].BlockCount != 1 || dailyStats[1].MevCount != 1 {
+		t.Errorf("unexpected stats for day 2: %+v", dailyStats[1])
+	}
+
+	dailyStats = db.GetMevDailyStats(0, 29, 10, []byte{0x02})
+	if len(dailyStats) != 2 || dailyStats[0].MevCount != 1 || dailyStats[1].MevCount != 0 {
+		t.Errorf("expected only the blocks of builder 0x02 to be counted, got %+v", dailyStats)
+	}
+}
@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -55,14 +54,14 @@ func InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx *sqlx.Tx) error {
 		dbtypes.DBEngineSqlite: "",
 	}))
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func UpdateMevBlockByEpoch(epoch uint64, slotsPerEpoch uint64, canonicalHashes [][]byte, tx *sqlx.Tx) error {
+func (store *sqlStore) UpdateMevBlockByEpoch(epoch uint64, slotsPerEpoch uint64, canonicalHashes [][]byte, tx Tx) error {
 	var sql strings.Builder
 	var sqlArgs strings.Builder
 
@@ -98,16 +97,16 @@ func UpdateMevBlockByEpoch(epoch uint64, slotsPerEpoch uint64, canonicalHashes [
 		"WHERE slot_number >= $1 AND slot_number <= $2",
 	)
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetHighestMevBlockSlotByRelay(relayId uint8) (uint64, error) {
+func (store *sqlStore) GetHighestMevBlockSlotByRelay(relayId uint8) (uint64, error) {
 	highestSlot := uint64(0)
-	err := ReaderDb.Get(&highestSlot, `
+	err := ReaderDb.GetContext(store.ctx, &highestSlot, `
 	SELECT
 		MAX(slot_number)
 	FROM mev_blocks
@@ -119,9 +118,9 @@ func GetHighestMevBlockSlotByRelay(relayId uint8) (uint64, error) {
 	return highestSlot, nil
 }
 
-func GetMevBlockByBlockHash(blockHash []byte) *dbtypes.MevBlock {
+func (store *sqlStore) GetMevBlockByBlockHash(blockHash []byte) *dbtypes.MevBlock {
 	mevBlock := dbtypes.MevBlock{}
-	err := ReaderDb.Get(&mevBlock, `
+	err := ReaderDb.GetContext(store.ctx, &mevBlock, `
 	SELECT
 		slot_number, block_hash, block_number, builder_pubkey, proposer_index, proposed, seenby_relays, fee_recipient, tx_count, gas_used, block_value, block_value_gwei
 	FROM mev_blocks
@@ -133,7 +132,7 @@ func GetMevBlockByBlockHash(blockHash []byte) *dbtypes.MevBlock {
 	return &mevBlock
 }
 
-func GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockFilter) ([]*dbtypes.MevBlock, uint64, error) {
+func (store *sqlStore) GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockFilter) ([]*dbtypes.MevBlock, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -239,7 +238,7 @@ func GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockF
 	fmt.Fprintf(&sql, ") AS t1")
 
 	mevBlocks := []*dbtypes.MevBlock{}
-	err := ReaderDb.Select(&mevBlocks, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &mevBlocks, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered mev blocks: %v", err)
 		return nil, 0, err
@@ -247,3 +246,15 @@ func GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockF
 
 	return mevBlocks[1:], mevBlocks[0].SlotNumber, nil
 }
+
+func (store *sqlStore) DeleteMevBlocksBefore(slot uint64, limit uint32, tx Tx) (int64, error) {
+	res, err := sqlTx(tx).Exec(`
+	DELETE FROM mev_blocks
+	WHERE block_hash IN (
+		SELECT block_hash FROM mev_blocks WHERE slot_number < $1 LIMIT $2
+	)`, slot, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func (store *sqlStore) InsertMissedSlotRange(missedRange *dbtypes.MissedSlotRange, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO missed_slot_ranges (
+				first_slot, last_slot
+			) VALUES ($1, $2)
+			ON CONFLICT (first_slot) DO UPDATE SET
+				last_slot = excluded.last_slot`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO missed_slot_ranges (
+				first_slot, last_slot
+			) VALUES ($1, $2)`,
+	}),
+		missedRange.FirstSlot, missedRange.LastSlot)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *sqlStore) GetMissedSlotRanges(firstSlot uint64, lastSlot uint64) []*dbtypes.MissedSlotRange {
+	missedRanges := []*dbtypes.MissedSlotRange{}
+	err := ReaderDb.SelectContext(store.ctx, &missedRanges, `
+	SELECT
+		first_slot, last_slot
+	FROM missed_slot_ranges
+	WHERE first_slot <= $2 AND last_slot >= $1
+	ORDER BY first_slot DESC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching missed slot ranges: %v", err)
+		return nil
+	}
+	return missedRanges
+}
+
+// GetMissedSlotRangeEndingAt returns the compacted missed slot range that ends at the given slot within the given transaction.
+func (store *sqlStore) GetMissedSlotRangeEndingAt(slot uint64, tx Tx) (*dbtypes.MissedSlotRange, error) {
+	missedRanges := []*dbtypes.MissedSlotRange{}
+	err := sqlTx(tx).SelectContext(store.ctx, &missedRanges, `
+	SELECT
+		first_slot, last_slot
+	FROM missed_slot_ranges
+	WHERE last_slot = $1
+	ORDER BY first_slot ASC
+	LIMIT 1
+	`, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching missed slot range ending at %v: %v", slot, err)
+	}
+	if len(missedRanges) == 0 {
+		return nil, nil
+	}
+	return missedRanges[0], nil
+}
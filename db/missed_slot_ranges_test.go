@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestMissedSlotCompactionQueries(t *testing.T) {
+	newTestSqliteDb(t)
+
+	for slot := uint64(1); slot <= 6; slot++ {
+		status := dbtypes.Missing
+		if slot == 4 || slot == 6 {
+			status = dbtypes.Canonical
+		}
+		if err := db.ExecWriterQuery(`INSERT INTO slots (slot, proposer, status, root) VALUES ($1, 1, $2, $3)`, slot, status, []byte{byte(slot)}); err != nil {
+			t.Fatalf("failed inserting slot %v: %v", slot, err)
+		}
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		return db.InsertMissedSlotRange(&dbtypes.MissedSlotRange{FirstSlot: 0, LastSlot: 0}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed inserting missed slot range: %v", err)
+	}
+
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		missedSlots, err := db.GetMissedSlotsBefore(6, 10, tx)
+		if err != nil {
+			return err
+		}
+		if len(missedSlots) != 4 || missedSlots[0] != 1 || missedSlots[3] != 5 {
+			t.Errorf("expected missed slots 1, 2, 3 & 5, got %v", missedSlots)
+		}
+
+		// the candidates are read within the transaction, so the ranges written before are visible
+		prevRange, err := db.GetMissedSlotRangeEndingAt(0, tx)
+		if err != nil {
+			return err
+		}
+		if prevRange == nil || prevRange.FirstSlot != 0 {
+			t.Errorf("expected missed slot range 0-0, got %v", prevRange)
+		}
+		if err := db.InsertMissedSlotRange(&dbtypes.MissedSlotRange{FirstSlot: 0, LastSlot: 3}, tx); err != nil {
+			return err
+		}
+		if extended, err := db.GetMissedSlotRangeEndingAt(3, tx); err != nil || extended == nil {
+			t.Errorf("expected the extended range to be visible in the transaction, got %v (err: %v)", extended, err)
+		}
+
+		return db.DeleteMissedSlotsInRange(0, 3, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed compacting missed slots: %v", err)
+	}
+
+	missedRanges := db.GetMissedSlotRanges(0, 10)
+	if len(missedRanges) != 1 || missedRanges[0].FirstSlot != 0 || missedRanges[0].LastSlot != 3 {
+		t.Errorf("expected the missed slot range 0-3, got %v ranges", len(missedRanges))
+	}
+
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		missedSlots, err := db.GetMissedSlotsBefore(10, 10, tx)
+		if len(missedSlots) != 1 || missedSlots[0] != 5 {
+			t.Errorf("expected only missed slot 5 to be left, got %v", missedSlots)
+		}
+		if missedRange, err := db.GetMissedSlotRangeEndingAt(5, tx); err != nil || missedRange != nil {
+			t.Errorf("expected no missed slot range ending at 5, got %v (err: %v)", missedRange, err)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed fetching missed slots: %v", err)
+	}
+}
@@ -2,11 +2,10 @@ package db
 
 import (
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO orphaned_blocks (
 				root, header_ver, header_ssz, block_ver, block_ssz
@@ -24,9 +23,9 @@ func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
 	return nil
 }
 
-func GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
+func (store *sqlStore) GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
 	block := dbtypes.OrphanedBlock{}
-	err := ReaderDb.Get(&block, `
+	err := ReaderDb.GetContext(store.ctx, &block, `
 	SELECT root, header_ver, header_ssz, block_ver, block_ssz
 	FROM orphaned_blocks
 	WHERE root = $1
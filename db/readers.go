@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// DbReaderPool routes read queries to the healthy read replicas.
+// replicas that are unreachable or lag behind the writer are skipped, the writer is used if no replica is ready.
+type DbReaderPool struct {
+	writer      *dbReader
+	readers     []*dbReader
+	readerIndex atomic.Uint64
+}
+
+type dbReader struct {
+	name     string
+	db       *sqlx.DB
+	healthy  atomic.Bool
+	headSlot atomic.Uint64
+}
+
+// DbRows wraps the result rows of a query and releases the query context when closed.
+type DbRows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+func (rows *DbRows) Close() error {
+	err := rows.Rows.Close()
+	rows.cancel()
+	return err
+}
+
+func newDbReaderPool(writer *sqlx.DB) *DbReaderPool {
+	pool := &DbReaderPool{
+		writer: &dbReader{
+			name: "writer",
+			db:   writer,
+		},
+	}
+	pool.writer.healthy.Store(true)
+
+	return pool
+}
+
+func (pool *DbReaderPool) addReader(name string, db *sqlx.DB) {
+	reader := &dbReader{
+		name: name,
+		db:   db,
+	}
+	reader.healthy.Store(true)
+
+	pool.readers = append(pool.readers, reader)
+}
+
+// getDb returns the next ready replica (round robin) or the writer if no replica is ready.
+func (pool *DbReaderPool) getDb() *sqlx.DB {
+	readerCount := uint64(len(pool.readers))
+	if readerCount == 0 {
+		return pool.writer.db
+	}
+
+	startIndex := pool.readerIndex.Add(1)
+	for i := uint64(0); i < readerCount; i++ {
+		reader := pool.readers[(startIndex+i)%readerCount]
+		if pool.isReaderReady(reader) {
+			return reader.db
+		}
+	}
+
+	return pool.writer.db
+}
+
+func (pool *DbReaderPool) isReaderReady(reader *dbReader) bool {
+	if !reader.healthy.Load() {
+		return false
+	}
+
+	maxLag := utils.Config.Database.Replication.MaxLagSlots
+	if maxLag == 0 {
+		maxLag = 32
+	}
+
+	writerHead := pool.writer.headSlot.Load()
+	readerHead := reader.headSlot.Load()
+	return readerHead+maxLag >= writerHead
+}
+
+// getQueryContext returns the context for a single query, limited by the configured query timeout.
+func (pool *DbReaderPool) getQueryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := utils.Config.Database.QueryTimeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (pool *DbReaderPool) Get(dest interface{}, query string, args ...interface{}) error {
+	return pool.GetContext(context.Background(), dest, query, args...)
+}
+
+func (pool *DbReaderPool) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	queryCtx, cancel := pool.getQueryContext(ctx)
+	defer cancel()
+
+	return pool.getDb().GetContext(queryCtx, dest, query, args...)
+}
+
+func (pool *DbReaderPool) Select(dest interface{}, query string, args ...interface{}) error {
+	return pool.SelectContext(context.Background(), dest, query, args...)
+}
+
+func (pool *DbReaderPool) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	queryCtx, cancel := pool.getQueryContext(ctx)
+	defer cancel()
+
+	return pool.getDb().SelectContext(queryCtx, dest, query, args...)
+}
+
+func (pool *DbReaderPool) Query(query string, args ...interface{}) (*DbRows, error) {
+	return pool.QueryContext(context.Background(), query, args...)
+}
+
+func (pool *DbReaderPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*DbRows, error) {
+	queryCtx, cancel := pool.getQueryContext(ctx)
+
+	rows, err := pool.getDb().QueryContext(queryCtx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &DbRows{
+		Rows:   rows,
+		cancel: cancel,
+	}, nil
+}
+
+func (pool *DbReaderPool) Close() error {
+	var closeErr error
+	for _, reader := range pool.readers {
+		if reader.db == pool.writer.db {
+			continue
+		}
+		if err := reader.db.Close(); err != nil {
+			closeErr = err
+		}
+	}
+	return closeErr
+}
+
+// runHealthChecks periodically checks the reachability & head slot of the writer and all replicas.
+func (pool *DbReaderPool) runHealthChecks() {
+	defer utils.HandleSubroutinePanic("db.DbReaderPool.runHealthChecks")
+
+	interval := utils.Config.Database.Replication.HealthCheckInterval
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+
+	for {
+		time.Sleep(interval)
+		pool.checkReaders()
+	}
+}
+
+func (pool *DbReaderPool) checkReaders() {
+	pool.checkReader(pool.writer)
+	for _, reader := range pool.readers {
+		pool.checkReader(reader)
+	}
+}
+
+func (pool *DbReaderPool) checkReader(reader *dbReader) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the highest persisted slot is used to detect replicas that are behind the indexer
+	headSlot := uint64(0)
+	err := reader.db.GetContext(ctx, &headSlot, `SELECT COALESCE(MAX(slot), 0) FROM slots`)
+
+	wasHealthy := reader.healthy.Load()
+	if err != nil {
+		reader.healthy.Store(false)
+		if wasHealthy {
+			logger.Warnf("database reader %v is unhealthy: %v", reader.name, err)
+		}
+		return
+	}
+
+	wasReady := reader == pool.writer || pool.isReaderReady(reader)
+	reader.headSlot.Store(headSlot)
+	reader.healthy.Store(true)
+
+	if reader == pool.writer {
+		return
+	}
+
+	isReady := pool.isReaderReady(reader)
+	if !wasHealthy {
+		logger.Infof("database reader %v is healthy again (head slot: %v)", reader.name, headSlot)
+	} else if wasReady && !isReady {
+		logger.Warnf("database reader %v is lagging behind (head slot: %v, writer head slot: %v)", reader.name, headSlot, pool.writer.headSlot.Load())
+	} else if !wasReady && isReady {
+		logger.Infof("database reader %v caught up (head slot: %v)", reader.name, headSlot)
+	}
+}
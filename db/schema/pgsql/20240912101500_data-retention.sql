@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."missed_slot_ranges"
+(
+    "first_slot" bigint NOT NULL,
+    "last_slot" bigint NOT NULL,
+    CONSTRAINT "missed_slot_ranges_pkey" PRIMARY KEY ("first_slot")
+);
+
+CREATE INDEX IF NOT EXISTS "missed_slot_ranges_last_slot_idx"
+    ON public."missed_slot_ranges"
+    ("last_slot" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "tx_pending_signatures_queuetime_idx"
+    ON public."tx_pending_signatures"
+    ("queuetime" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
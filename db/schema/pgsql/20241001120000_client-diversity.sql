@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- inferred proposer clients, NULL for blocks that have not been classified yet
+ALTER TABLE public."slots"
+ADD "cl_client" smallint NULL;
+
+ALTER TABLE public."slots"
+ADD "el_client" smallint NULL;
+
+CREATE INDEX IF NOT EXISTS "slots_unclassified_idx"
+    ON public."slots"
+    ("slot" ASC NULLS LAST)
+    WHERE "cl_client" IS NULL AND "status" != 0;
+
+CREATE TABLE IF NOT EXISTS public."client_diversity"
+(
+    "epoch" bigint NOT NULL,
+    "cl_client" smallint NOT NULL,
+    "el_client" smallint NOT NULL,
+    "block_count" integer NOT NULL,
+    CONSTRAINT "client_diversity_pkey" PRIMARY KEY ("epoch", "cl_client", "el_client")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
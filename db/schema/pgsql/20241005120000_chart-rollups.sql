@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."epochs"
+ADD "blob_count" integer NOT NULL DEFAULT 0;
+
+-- the blob count of already persisted epochs is unknown, schedule the blob count backfill of the chart rollup service
+INSERT INTO public."explorer_state" ("key", "value")
+SELECT 'chartrollups.blobbackfill', '{"next_epoch":0,"end_epoch":' || CAST(max_epoch + 1 AS text) || '}'
+FROM (SELECT MAX("epoch") AS max_epoch FROM public."epochs") AS epoch_range
+WHERE max_epoch IS NOT NULL;
+
+-- pre-aggregated epoch stats for the network charts, "period" is the bucket size in seconds (hourly / daily)
+CREATE TABLE IF NOT EXISTS public."chart_rollups"
+(
+    "period" integer NOT NULL,
+    "time" bigint NOT NULL,
+    "first_epoch" bigint NOT NULL,
+    "last_epoch" bigint NOT NULL,
+    "epoch_count" integer NOT NULL,
+    "validator_count" bigint NOT NULL,
+    "validator_balance" bigint NOT NULL,
+    "target_participation" real NOT NULL,
+    "head_participation" real NOT NULL,
+    "total_participation" real NOT NULL,
+    "sync_participation" real NOT NULL,
+    "block_count" integer NOT NULL,
+    "orphaned_count" integer NOT NULL,
+    "deposit_count" integer NOT NULL,
+    "exit_count" integer NOT NULL,
+    "blob_count" integer NOT NULL,
+    CONSTRAINT "chart_rollups_pkey" PRIMARY KEY ("period", "time")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
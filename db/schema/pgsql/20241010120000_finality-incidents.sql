@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- non-finality incidents, "end_epoch" is NULL while the incident is ongoing
+CREATE TABLE IF NOT EXISTS public."finality_incidents"
+(
+    "start_epoch" bigint NOT NULL,
+    "end_epoch" bigint NULL,
+    "last_epoch" bigint NOT NULL,
+    "finalized_epoch" bigint NOT NULL,
+    "end_finalized_epoch" bigint NULL,
+    "max_distance" integer NOT NULL,
+    "leak_epochs" integer NOT NULL,
+    "participation_epochs" integer NOT NULL,
+    "participation_avg" real NOT NULL,
+    "participation_min" real NOT NULL,
+    "max_forks" integer NOT NULL,
+    "forks" text NOT NULL,
+    "offline_validators" bigint NOT NULL,
+    "offline_balance" bigint NOT NULL,
+    "leak_estimate" bigint NOT NULL,
+    "leak_entities" text NOT NULL,
+    CONSTRAINT "finality_incidents_pkey" PRIMARY KEY ("start_epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- block arrival & head switch times per client in milliseconds relative to the slot start
+CREATE TABLE IF NOT EXISTS public."block_timings"
+(
+    "root" bytea NOT NULL,
+    "slot" bigint NOT NULL,
+    "client_name" character varying(100) NOT NULL,
+    "seen_delay" bigint NOT NULL,
+    "head_delay" bigint NULL,
+    CONSTRAINT "block_timings_pkey" PRIMARY KEY ("root", "client_name")
+);
+
+CREATE INDEX IF NOT EXISTS "block_timings_slot_idx"
+    ON public."block_timings"
+    ("slot" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ssz encoded header & body of canonical finalized blocks (optional, see indexer.persistBlockBodies)
+CREATE TABLE IF NOT EXISTS public."block_bodies"
+(
+    "root" bytea NOT NULL,
+    "slot" bigint NOT NULL,
+    "header_ver" int NOT NULL,
+    "header_ssz" bytea NOT NULL,
+    "block_ver" int NOT NULL,
+    "block_ssz" bytea NOT NULL,
+    CONSTRAINT "block_bodies_pkey" PRIMARY KEY ("root")
+);
+
+CREATE INDEX IF NOT EXISTS "block_bodies_slot_idx"
+    ON public."block_bodies"
+    ("slot" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
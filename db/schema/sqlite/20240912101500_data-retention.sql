@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "missed_slot_ranges"
+(
+    "first_slot" BIGINT NOT NULL,
+    "last_slot" BIGINT NOT NULL,
+    CONSTRAINT "missed_slot_ranges_pkey" PRIMARY KEY ("first_slot")
+);
+
+CREATE INDEX IF NOT EXISTS "missed_slot_ranges_last_slot_idx"
+    ON "missed_slot_ranges"
+    ("last_slot" ASC);
+
+CREATE INDEX IF NOT EXISTS "tx_pending_signatures_queuetime_idx"
+    ON "tx_pending_signatures"
+    ("queuetime" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
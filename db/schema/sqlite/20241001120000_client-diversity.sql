@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- inferred proposer clients, NULL for blocks that have not been classified yet
+ALTER TABLE "slots"
+ADD "cl_client" INTEGER NULL;
+
+ALTER TABLE "slots"
+ADD "el_client" INTEGER NULL;
+
+CREATE INDEX IF NOT EXISTS "slots_unclassified_idx"
+    ON "slots"
+    ("slot" ASC)
+    WHERE "cl_client" IS NULL AND "status" != 0;
+
+CREATE TABLE IF NOT EXISTS "client_diversity"
+(
+    "epoch" BIGINT NOT NULL,
+    "cl_client" INTEGER NOT NULL,
+    "el_client" INTEGER NOT NULL,
+    "block_count" INTEGER NOT NULL,
+    CONSTRAINT "client_diversity_pkey" PRIMARY KEY ("epoch", "cl_client", "el_client")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
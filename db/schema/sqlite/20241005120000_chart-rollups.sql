@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "epochs"
+ADD "blob_count" INTEGER NOT NULL DEFAULT 0;
+
+-- the blob count of already persisted epochs is unknown, schedule the blob count backfill of the chart rollup service
+INSERT INTO "explorer_state" ("key", "value")
+SELECT 'chartrollups.blobbackfill', '{"next_epoch":0,"end_epoch":' || CAST(max_epoch + 1 AS TEXT) || '}'
+FROM (SELECT MAX("epoch") AS max_epoch FROM "epochs") AS epoch_range
+WHERE max_epoch IS NOT NULL;
+
+-- pre-aggregated epoch stats for the network charts, "period" is the bucket size in seconds (hourly / daily)
+CREATE TABLE IF NOT EXISTS "chart_rollups"
+(
+    "period" INTEGER NOT NULL,
+    "time" BIGINT NOT NULL,
+    "first_epoch" BIGINT NOT NULL,
+    "last_epoch" BIGINT NOT NULL,
+    "epoch_count" INTEGER NOT NULL,
+    "validator_count" BIGINT NOT NULL,
+    "validator_balance" BIGINT NOT NULL,
+    "target_participation" REAL NOT NULL,
+    "head_participation" REAL NOT NULL,
+    "total_participation" REAL NOT NULL,
+    "sync_participation" REAL NOT NULL,
+    "block_count" INTEGER NOT NULL,
+    "orphaned_count" INTEGER NOT NULL,
+    "deposit_count" INTEGER NOT NULL,
+    "exit_count" INTEGER NOT NULL,
+    "blob_count" INTEGER NOT NULL,
+    CONSTRAINT "chart_rollups_pkey" PRIMARY KEY ("period", "time")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- non-finality incidents, "end_epoch" is NULL while the incident is ongoing
+CREATE TABLE IF NOT EXISTS "finality_incidents"
+(
+    "start_epoch" BIGINT NOT NULL,
+    "end_epoch" BIGINT NULL,
+    "last_epoch" BIGINT NOT NULL,
+    "finalized_epoch" BIGINT NOT NULL,
+    "end_finalized_epoch" BIGINT NULL,
+    "max_distance" INTEGER NOT NULL,
+    "leak_epochs" INTEGER NOT NULL,
+    "participation_epochs" INTEGER NOT NULL,
+    "participation_avg" REAL NOT NULL,
+    "participation_min" REAL NOT NULL,
+    "max_forks" INTEGER NOT NULL,
+    "forks" TEXT NOT NULL,
+    "offline_validators" BIGINT NOT NULL,
+    "offline_balance" BIGINT NOT NULL,
+    "leak_estimate" BIGINT NOT NULL,
+    "leak_entities" TEXT NOT NULL,
+    CONSTRAINT "finality_incidents_pkey" PRIMARY KEY ("start_epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- block arrival & head switch times per client in milliseconds relative to the slot start
+CREATE TABLE IF NOT EXISTS "block_timings"
+(
+    "root" BLOB NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "client_name" TEXT NOT NULL,
+    "seen_delay" BIGINT NOT NULL,
+    "head_delay" BIGINT NULL,
+    CONSTRAINT "block_timings_pkey" PRIMARY KEY ("root", "client_name")
+);
+
+CREATE INDEX IF NOT EXISTS "block_timings_slot_idx"
+    ON "block_timings"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ssz encoded header & body of canonical finalized blocks (optional, see indexer.persistBlockBodies)
+CREATE TABLE IF NOT EXISTS "block_bodies"
+(
+    "root" BLOB NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "header_ver" INTEGER NOT NULL,
+    "header_ssz" BLOB NOT NULL,
+    "block_ver" INTEGER NOT NULL,
+    "block_ssz" BLOB NOT NULL,
+    CONSTRAINT "block_bodies_pkey" PRIMARY KEY ("root")
+);
+
+CREATE INDEX IF NOT EXISTS "block_bodies_slot_idx"
+    ON "block_bodies"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// SearchSlotsByNumber returns the blocks with the given slot number or execution block number.
+func (store *sqlStore) SearchSlotsByNumber(number uint64, limit uint32) []*dbtypes.SearchSlotResult {
+	slots := []*dbtypes.SearchSlotResult{}
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
+	SELECT slot, root, state_root, eth_block_hash, eth_block_number, status
+	FROM slots
+	WHERE (slot = $1 OR eth_block_number = $1) AND status != 0
+	ORDER BY slot DESC
+	LIMIT $2`, number, limit)
+	if err != nil {
+		logger.Errorf("Error while searching slots by number: %v", err)
+		return nil
+	}
+	return slots
+}
+
+// SearchSlotsByHash returns the blocks with the given block root, state root or execution block hash.
+func (store *sqlStore) SearchSlotsByHash(hash []byte, limit uint32) []*dbtypes.SearchSlotResult {
+	slots := []*dbtypes.SearchSlotResult{}
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
+	SELECT slot, root, state_root, eth_block_hash, eth_block_number, status
+	FROM slots
+	WHERE root = $1 OR state_root = $1 OR eth_block_hash = $1
+	ORDER BY slot DESC
+	LIMIT $2`, hash, limit)
+	if err != nil {
+		logger.Errorf("Error while searching slots by hash: %v", err)
+		return nil
+	}
+	return slots
+}
+
+// SearchGraffiti returns the graffitis containing the given text, ordered by the number of blocks using it.
+func (store *sqlStore) SearchGraffiti(text string, limit uint32) []*dbtypes.SearchGraffitiCountResult {
+	graffitis := []*dbtypes.SearchGraffitiCountResult{}
+	err := ReaderDb.SelectContext(store.ctx, &graffitis, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			SELECT graffiti_text AS graffiti, count(*) as count
+			FROM slots
+			WHERE graffiti_text ILIKE $1
+			GROUP BY graffiti_text
+			ORDER BY count desc
+			LIMIT $2`,
+		dbtypes.DBEngineSqlite: `
+			SELECT graffiti_text AS graffiti, count(*) as count
+			FROM slots
+			WHERE graffiti_text LIKE $1
+			GROUP BY graffiti_text
+			ORDER BY count desc
+			LIMIT $2`,
+	}), "%"+text+"%", limit)
+	if err != nil {
+		logger.Errorf("Error while searching graffiti: %v", err)
+		return nil
+	}
+	return graffitis
+}
+
+// SearchAheadEpochs returns the synchronized epochs starting with the given number prefix.
+func (store *sqlStore) SearchAheadEpochs(prefix string) (dbtypes.SearchAheadEpochsResult, error) {
+	epochs := dbtypes.SearchAheadEpochsResult{}
+	err := ReaderDb.SelectContext(store.ctx, &epochs, `
+	SELECT epoch
+	FROM epochs
+	WHERE CAST(epoch AS text) LIKE $1
+	ORDER BY epoch
+	LIMIT 10`, prefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// SearchAheadSlotsByHash returns the block with the given block or state root before the given slot.
+func (store *sqlStore) SearchAheadSlotsByHash(hash []byte, beforeSlot uint64) (dbtypes.SearchAheadSlotsResult, error) {
+	slots := dbtypes.SearchAheadSlotsResult{}
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
+	SELECT slot, root, status
+	FROM slots
+	WHERE slot < $1 AND (root = $2 OR state_root = $2)
+	ORDER BY slot
+	LIMIT 1`, beforeSlot, hash)
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// SearchAheadSlotsByNumber returns the blocks of the given slot.
+func (store *sqlStore) SearchAheadSlotsByNumber(slot uint64) (dbtypes.SearchAheadSlotsResult, error) {
+	slots := dbtypes.SearchAheadSlotsResult{}
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
+	SELECT slot, root, status
+	FROM slots
+	WHERE slot = $1 AND status != 0
+	ORDER BY slot
+	LIMIT 10`, slot)
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// SearchAheadExecBlocksByHash returns the blocks with the given execution block hash before the given slot.
+func (store *sqlStore) SearchAheadExecBlocksByHash(hash []byte, beforeSlot uint64) (dbtypes.SearchAheadExecBlocksResult, error) {
+	blocks := dbtypes.SearchAheadExecBlocksResult{}
+	err := ReaderDb.SelectContext(store.ctx, &blocks, `
+	SELECT slot, root, eth_block_hash, eth_block_number, status
+	FROM slots
+	WHERE slot < $1 AND eth_block_hash = $2
+	ORDER BY slot
+	LIMIT 10`, beforeSlot, hash)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// SearchAheadExecBlocksByNumber returns the blocks with the given execution block number before the given slot.
+func (store *sqlStore) SearchAheadExecBlocksByNumber(number uint64, beforeSlot uint64) (dbtypes.SearchAheadExecBlocksResult, error) {
+	blocks := dbtypes.SearchAheadExecBlocksResult{}
+	err := ReaderDb.SelectContext(store.ctx, &blocks, `
+	SELECT slot, root, eth_block_hash, eth_block_number, status
+	FROM slots
+	WHERE slot < $1 AND eth_block_number = $2
+	ORDER BY slot
+	LIMIT 10`, beforeSlot, number)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// SearchAheadGraffiti returns the graffitis containing the given text, ordered by the number of blocks using it.
+func (store *sqlStore) SearchAheadGraffiti(text string) (dbtypes.SearchAheadGraffitiResult, error) {
+	graffitis := dbtypes.SearchAheadGraffitiResult{}
+	err := ReaderDb.SelectContext(store.ctx, &graffitis, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			SELECT graffiti, count(*) as count
+			FROM slots
+			WHERE graffiti_text ILIKE LOWER($1)
+			GROUP BY graffiti
+			ORDER BY count desc
+			LIMIT 10`,
+		dbtypes.DBEngineSqlite: `
+			SELECT graffiti, count(*) as count
+			FROM slots
+			WHERE graffiti_text LIKE LOWER($1)
+			GROUP BY graffiti
+			ORDER BY count desc
+			LIMIT 10`,
+	}), "%"+text+"%")
+	if err != nil {
+		return nil, err
+	}
+	return graffitis, nil
+}
+
+// SearchAheadValidatorNames returns the validator names containing the given text, ordered by the number of proposed blocks.
+func (store *sqlStore) SearchAheadValidatorNames(text string) (dbtypes.SearchAheadValidatorNameResult, error) {
+	names := dbtypes.SearchAheadValidatorNameResult{}
+	err := ReaderDb.SelectContext(store.ctx, &names, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			SELECT name, count(*) as count
+			FROM validator_names
+			LEFT JOIN slots ON validator_names."index" = slots.proposer
+			WHERE name ILIKE LOWER($1)
+			GROUP BY name
+			ORDER BY count desc
+			LIMIT 10`,
+		dbtypes.DBEngineSqlite: `
+			SELECT name, count(*) as count
+			FROM validator_names
+			LEFT JOIN slots ON validator_names."index" = slots.proposer
+			WHERE name LIKE LOWER($1)
+			GROUP BY name
+			ORDER BY count desc
+			LIMIT 10`,
+	}), "%"+text+"%")
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
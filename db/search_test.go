@@ -0,0 +1,95 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestSearchAhead(t *testing.T) {
+	newTestSqliteDb(t)
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		for slot := uint64(10); slot < 15; slot++ {
+			blockNumber := slot + 100
+			err := db.InsertSlot(&dbtypes.Slot{
+				Slot:           slot,
+				Status:         dbtypes.Canonical,
+				Root:           []byte{byte(slot)},
+				StateRoot:      []byte{byte(slot), 0xff},
+				GraffitiText:   "dora test",
+				EthBlockNumber: &blockNumber,
+				EthBlockHash:   []byte{byte(slot), 0xee},
+			}, tx)
+			if err != nil {
+				return err
+			}
+		}
+		return db.InsertEpoch(&dbtypes.Epoch{Epoch: 12}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed inserting test data: %v", err)
+	}
+
+	store := db.WithContext(context.Background())
+
+	if epochs, err := store.SearchAheadEpochs("1"); err != nil || len(epochs) != 1 || epochs[0].Epoch != 12 {
+		t.Errorf("unexpected epoch results: %v (err: %v)", epochs, err)
+	}
+	if slots, err := store.SearchAheadSlotsByHash([]byte{12, 0xff}, 20); err != nil || len(slots) != 1 || slots[0].Slot != 12 {
+		t.Errorf("unexpected state root results: %v (err: %v)", slots, err)
+	}
+	if slots, err := store.SearchAheadSlotsByHash([]byte{12}, 12); err != nil || len(slots) != 0 {
+		t.Errorf("expected blocks from the given slot on to be excluded, got %v (err: %v)", slots, err)
+	}
+	if slots, err := store.SearchAheadSlotsByNumber(13); err != nil || len(slots) != 1 || slots[0].Slot != 13 {
+		t.Errorf("unexpected slot results: %v (err: %v)", slots, err)
+	}
+	if blocks, err := store.SearchAheadExecBlocksByHash([]byte{11, 0xee}, 20); err != nil || len(blocks) != 1 || blocks[0].ExecNumber != 111 {
+		t.Errorf("unexpected exec block hash results: %v (err: %v)", blocks, err)
+	}
+	if blocks, err := store.SearchAheadExecBlocksByNumber(114, 20); err != nil || len(blocks) != 1 || blocks[0].Slot != 14 {
+		t.Errorf("unexpected exec block number results: %v (err: %v)", blocks, err)
+	}
+	if graffitis, err := store.SearchAheadGraffiti("TEST"); err != nil || len(graffitis) != 1 || graffitis[0].Count != 5 {
+		t.Errorf("unexpected graffiti results: %v (err: %v)", graffitis, err)
+	}
+	if names, err := store.SearchAheadValidatorNames("unknown"); err != nil || len(names) != 0 {
+		t.Errorf("unexpected validator name results: %v (err: %v)", names, err)
+	}
+}
+
+func TestWriterPinnedReads(t *testing.T) {
+	newTestSqliteDb(t)
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		if err := db.InsertEpoch(&dbtypes.Epoch{Epoch: 3}, tx); err != nil {
+			return err
+		}
+		return db.SetExplorerState("test.state", &dbtypes.IndexerSyncState{Epoch: 3}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed inserting test data: %v", err)
+	}
+
+	if !db.IsEpochSynchronized(3) || db.IsEpochSynchronized(4) {
+		t.Errorf("unexpected epoch sync state")
+	}
+
+	syncState := &dbtypes.IndexerSyncState{}
+	if _, err := db.GetExplorerState("test.state", syncState); err != nil || syncState.Epoch != 3 {
+		t.Errorf("unexpected explorer state: %v (err: %v)", syncState, err)
+	}
+
+	// the reads are bound to the store context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if db.WithContext(ctx).IsEpochSynchronized(3) {
+		t.Errorf("expected the canceled context to fail the read")
+	}
+	if _, err := db.WithContext(ctx).GetExplorerState("test.state", syncState); err == nil {
+		t.Errorf("expected the canceled context to fail the read")
+	}
+}
@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertSlashings(slashings []*dbtypes.Slashing, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertSlashings(slashings []*dbtypes.Slashing, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -51,14 +50,14 @@ func InsertSlashings(slashings []*dbtypes.Slashing, tx *sqlx.Tx) error {
 		dbtypes.DBEngineSqlite: "",
 	}))
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetSlashingForValidator(validator uint64) *dbtypes.Slashing {
+func (store *sqlStore) GetSlashingForValidator(validator uint64) *dbtypes.Slashing {
 	var sql strings.Builder
 	args := []any{
 		validator,
@@ -71,14 +70,14 @@ func GetSlashingForValidator(validator uint64) *dbtypes.Slashing {
 	`)
 
 	slashing := &dbtypes.Slashing{}
-	err := ReaderDb.Get(&slashing, sql.String(), args...)
+	err := ReaderDb.GetContext(store.ctx, &slashing, sql.String(), args...)
 	if err != nil {
 		return nil
 	}
 	return slashing
 }
 
-func GetSlashingsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.SlashingFilter) ([]*dbtypes.Slashing, uint64, error) {
+func (store *sqlStore) GetSlashingsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.SlashingFilter) ([]*dbtypes.Slashing, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -180,7 +179,7 @@ func GetSlashingsFiltered(offset uint64, limit uint32, finalizedBlock uint64, fi
 	fmt.Fprintf(&sql, ") AS t1")
 
 	slashings := []*dbtypes.Slashing{}
-	err := ReaderDb.Select(&slashings, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &slashings, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered slashings: %v", err)
 		return nil, 0, err
@@ -1,46 +1,46 @@
 package db
 
 import (
-	"database/sql"
 	"fmt"
 	"math"
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 	"github.com/mitchellh/mapstructure"
 )
 
-func InsertSlot(slot *dbtypes.Slot, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertSlot(slot *dbtypes.Slot, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO slots (
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+				eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
 			ON CONFLICT (slot, root) DO UPDATE SET
 				status = excluded.status,
 				eth_block_extra = excluded.eth_block_extra,
-				eth_block_extra_text = excluded.eth_block_extra_text`,
+				eth_block_extra_text = excluded.eth_block_extra_text,
+				cl_client = excluded.cl_client,
+				el_client = excluded.el_client`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO slots (
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+				eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)`,
 	}),
 		slot.Slot, slot.Proposer, slot.Status, slot.Root, slot.ParentRoot, slot.StateRoot, slot.Graffiti, slot.GraffitiText,
 		slot.AttestationCount, slot.DepositCount, slot.ExitCount, slot.WithdrawCount, slot.WithdrawAmount, slot.AttesterSlashingCount,
 		slot.ProposerSlashingCount, slot.BLSChangeCount, slot.EthTransactionCount, slot.EthBlockNumber, slot.EthBlockHash,
-		slot.EthBlockExtra, slot.EthBlockExtraText, slot.SyncParticipation, slot.ForkId)
+		slot.EthBlockExtra, slot.EthBlockExtraText, slot.SyncParticipation, slot.ForkId, slot.ClClient, slot.ElClient)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM slots WHERE slot = $1 AND proposer = $2 AND status = 0", slot.Slot, slot.Proposer)
+	_, err = sqlTx(tx).Exec("DELETE FROM slots WHERE slot = $1 AND proposer = $2 AND status = 0", slot.Slot, slot.Proposer)
 	if err != nil {
 		return err
 	}
@@ -48,9 +48,9 @@ func InsertSlot(slot *dbtypes.Slot, tx *sqlx.Tx) error {
 	return nil
 }
 
-func InsertMissingSlot(block *dbtypes.SlotHeader, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertMissingSlot(block *dbtypes.SlotHeader, tx Tx) error {
 	var blockCount int
-	err := ReaderDb.Get(&blockCount, `
+	err := ReaderDb.GetContext(store.ctx, &blockCount, `
 		SELECT
 			COUNT(*)
 		FROM slots
@@ -64,7 +64,7 @@ func InsertMissingSlot(block *dbtypes.SlotHeader, tx *sqlx.Tx) error {
 		return nil
 	}
 
-	_, err = tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+	_, err = sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO slots (
 				slot, proposer, status, root
@@ -83,7 +83,7 @@ func InsertMissingSlot(block *dbtypes.SlotHeader, tx *sqlx.Tx) error {
 	return nil
 }
 
-func GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
+func (store *sqlStore) GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
 	var sql strings.Builder
 	fmt.Fprintf(&sql, `SELECT slots.slot, slots.proposer`)
 	blockFields := []string{
@@ -106,7 +106,7 @@ func GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned boo
 	}
 	fmt.Fprintf(&sql, ` ORDER BY slot DESC LIMIT $2`)
 
-	rows, err := ReaderDb.Query(sql.String(), firstSlot, limit)
+	rows, err := ReaderDb.QueryContext(store.ctx, sql.String(), firstSlot, limit)
 	if err != nil {
 		logger.WithError(err).Errorf("Error while fetching slots: %v", sql.String())
 		return nil
@@ -115,7 +115,7 @@ func GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned boo
 	return parseAssignedSlots(rows, blockFields, 2)
 }
 
-func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
+func (store *sqlStore) GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
 	var sql strings.Builder
 	fmt.Fprintf(&sql, `SELECT slots.slot, slots.proposer`)
 	blockFields := []string{
@@ -138,7 +138,7 @@ func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrph
 	}
 	fmt.Fprintf(&sql, ` ORDER BY slot DESC `)
 
-	rows, err := ReaderDb.Query(sql.String(), firstSlot, lastSlot)
+	rows, err := ReaderDb.QueryContext(store.ctx, sql.String(), firstSlot, lastSlot)
 	if err != nil {
 		logger.WithError(err).Errorf("Error while fetching slots range: %v", sql.String())
 		return nil
@@ -147,9 +147,9 @@ func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrph
 	return parseAssignedSlots(rows, blockFields, 2)
 }
 
-func GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
+func (store *sqlStore) GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
 	slots := []*dbtypes.Slot{}
-	err := ReaderDb.Select(&slots, `
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
 	SELECT
 		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
@@ -166,9 +166,9 @@ func GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
 	return slots
 }
 
-func GetSlotByRoot(root []byte) *dbtypes.Slot {
+func (store *sqlStore) GetSlotByRoot(root []byte) *dbtypes.Slot {
 	block := dbtypes.Slot{}
-	err := ReaderDb.Get(&block, `
+	err := ReaderDb.GetContext(store.ctx, &block, `
 	SELECT
 		root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
@@ -184,9 +184,9 @@ func GetSlotByRoot(root []byte) *dbtypes.Slot {
 	return &block
 }
 
-func GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
+func (store *sqlStore) GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
 	blockHead := dbtypes.BlockHead{}
-	err := ReaderDb.Get(&blockHead, `
+	err := ReaderDb.GetContext(store.ctx, &blockHead, `
 	SELECT
 		root, slot, parent_root, fork_id
 	FROM slots
@@ -198,9 +198,9 @@ func GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
 	return &blockHead
 }
 
-func GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
+func (store *sqlStore) GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
 	slots := []*dbtypes.Slot{}
-	err := ReaderDb.Select(&slots, `
+	err := ReaderDb.SelectContext(store.ctx, &slots, `
 	SELECT
 		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
@@ -217,7 +217,8 @@ func GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
 	return slots
 }
 
-func parseAssignedSlots(rows *sql.Rows, fields []string, fieldsOffset int) []*dbtypes.AssignedSlot {
+func parseAssignedSlots(rows *DbRows, fields []string, fieldsOffset int) []*dbtypes.AssignedSlot {
+	defer rows.Close()
 	blockAssignments := []*dbtypes.AssignedSlot{}
 
 	scanArgs := make([]interface{}, len(fields)+fieldsOffset)
@@ -258,7 +259,7 @@ func parseAssignedSlots(rows *sql.Rows, fields []string, fieldsOffset int) []*db
 	return blockAssignments
 }
 
-func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedSlot {
+func (store *sqlStore) GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedSlot {
 	var sql strings.Builder
 	fmt.Fprintf(&sql, `SELECT slots.slot, slots.proposer`)
 	blockFields := []string{
@@ -329,7 +330,7 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 	args = append(args, offset)
 
 	//fmt.Printf("sql: %v, args: %v\n", sql.String(), args)
-	rows, err := ReaderDb.Query(sql.String(), args...)
+	rows, err := ReaderDb.QueryContext(store.ctx, sql.String(), args...)
 	if err != nil {
 		logger.WithError(err).Errorf("Error while fetching filtered slots: %v", sql.String())
 		return nil
@@ -338,7 +339,7 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 	return parseAssignedSlots(rows, blockFields, 2)
 }
 
-func GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus {
+func (store *sqlStore) GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus {
 	orphanedRefs := []*dbtypes.BlockStatus{}
 	if len(blockRoots) == 0 {
 		return orphanedRefs
@@ -360,7 +361,7 @@ func GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus {
 		argIdx += 1
 	}
 	fmt.Fprintf(&sql, ")")
-	err := ReaderDb.Select(&orphanedRefs, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &orphanedRefs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching orphaned status: %v", err)
 		return nil
@@ -368,14 +369,14 @@ func GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus {
 	return orphanedRefs
 }
 
-func GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte {
+func (store *sqlStore) GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte {
 	var result []byte
 	statusFilter := ""
 	if !withOrphaned {
 		statusFilter = "AND status != 2"
 	}
 
-	err := ReaderDb.Get(&result, `
+	err := ReaderDb.GetContext(store.ctx, &result, `
 	SELECT root FROM slots WHERE slot < $1 `+statusFilter+` AND status != 0 ORDER BY slot DESC LIMIT 1
 	`, slot)
 	if err != nil {
@@ -385,9 +386,9 @@ func GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte {
 	return result
 }
 
-func GetSlotAssignment(slot uint64) uint64 {
+func (store *sqlStore) GetSlotAssignment(slot uint64) uint64 {
 	proposer := uint64(math.MaxInt64)
-	err := ReaderDb.Get(&proposer, `
+	err := ReaderDb.GetContext(store.ctx, &proposer, `
 	SELECT
 		proposer
 	FROM slots
@@ -398,3 +399,25 @@ func GetSlotAssignment(slot uint64) uint64 {
 	}
 	return proposer
 }
+
+// GetMissedSlotsBefore returns the missed slot rows before the given slot within the given transaction.
+func (store *sqlStore) GetMissedSlotsBefore(slot uint64, limit uint32, tx Tx) ([]uint64, error) {
+	missedSlots := []uint64{}
+	err := sqlTx(tx).SelectContext(store.ctx, &missedSlots, `
+	SELECT
+		slot
+	FROM slots
+	WHERE slot < $1 AND status = 0
+	ORDER BY slot ASC
+	LIMIT $2
+	`, slot, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching missed slots before %v: %v", slot, err)
+	}
+	return missedSlots, nil
+}
+
+func (store *sqlStore) DeleteMissedSlotsInRange(firstSlot uint64, lastSlot uint64, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`DELETE FROM slots WHERE slot >= $1 AND slot <= $2 AND status = 0`, firstSlot, lastSlot)
+	return err
+}
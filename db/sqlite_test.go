@@ -0,0 +1,28 @@
+package db_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// newTestSqliteDb initializes the global db with a temporary sqlite database and the embedded schema.
+func newTestSqliteDb(t *testing.T) {
+	newTestSqliteDbAtVersion(t, -2)
+}
+
+// newTestSqliteDbAtVersion initializes the global db with a temporary sqlite database and the embedded schema up to the given version.
+func newTestSqliteDbAtVersion(t *testing.T, version int64) {
+	utils.Config = &types.Config{}
+	utils.Config.Database.Engine = "sqlite"
+	utils.Config.Database.Sqlite.File = filepath.Join(t.TempDir(), "dora.sqlite")
+
+	db.MustInitDB()
+	t.Cleanup(db.MustCloseDB)
+	if err := db.ApplyEmbeddedDbSchema(version); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package db
+
+import (
+	"context"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+)
+
+// Store is the persistence layer of the explorer.
+// the default implementation is backed by the sqlite / pgsql database, alternative backends can be set via SetStore.
+// all package level functions of the db package are forwarded to the active store.
+//
+// write methods get the transaction handle of RunTransaction passed (see Tx), backends that are not transactional may ignore it.
+// fake stores for tests can embed the Store interface and override the methods used by the tested code.
+//
+// read methods of the store returned by WithContext are cancelled when the context is done (e.g. when a page call times out).
+type Store interface {
+	SlotStore
+	EpochStore
+	SyncAssignmentStore
+	BlobStore
+	DepositStore
+	VoluntaryExitStore
+	SlashingStore
+	ElRequestStore
+	ForkStore
+	UnfinalizedStore
+	MevBlockStore
+	MevAnalyticsStore
+	TxSignatureStore
+	ValidatorNameStore
+	ExplorerStateStore
+	SearchStore
+	ClientDiversityStore
+	ChartRollupStore
+	FinalityIncidentStore
+	BlockTimingStore
+	BlockBodyStore
+
+	WithContext(ctx context.Context) Store
+	RunTransaction(handler func(tx Tx) error) error
+	ApplySchema(version int64) error
+	Close()
+}
+
+// Tx is the transaction handle passed by RunTransaction to the write methods of the store.
+// the sql store passes a *sqlx.Tx, other backends can pass their own transaction type (or nil if not transactional).
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// SlotStore persists finalized slots, missed slots (incl. compacted missed slot ranges) & orphaned blocks.
+type SlotStore interface {
+	InsertSlot(slot *dbtypes.Slot, tx Tx) error
+	InsertMissingSlot(block *dbtypes.SlotHeader, tx Tx) error
+	GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot
+	GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot
+	GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot
+	GetSlotByRoot(root []byte) *dbtypes.Slot
+	GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead
+	GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot
+	GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedSlot
+	GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus
+	GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte
+	GetSlotAssignment(slot uint64) uint64
+	InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx Tx) error
+	GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock
+	GetMissedSlotsBefore(slot uint64, limit uint32, tx Tx) ([]uint64, error)
+	DeleteMissedSlotsInRange(firstSlot uint64, lastSlot uint64, tx Tx) error
+	InsertMissedSlotRange(missedRange *dbtypes.MissedSlotRange, tx Tx) error
+	GetMissedSlotRanges(firstSlot uint64, lastSlot uint64) []*dbtypes.MissedSlotRange
+	GetMissedSlotRangeEndingAt(slot uint64, tx Tx) (*dbtypes.MissedSlotRange, error)
+}
+
+// EpochStore persists finalized epoch aggregations.
+type EpochStore interface {
+	InsertEpoch(epoch *dbtypes.Epoch, tx Tx) error
+	// IsEpochSynchronized is pinned to the writer (not the read replicas).
+	IsEpochSynchronized(epoch uint64) bool
+	GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch
+}
+
+// SyncAssignmentStore persists sync committee assignments.
+type SyncAssignmentStore interface {
+	IsSyncCommitteeSynchronized(period uint64) bool
+	InsertSyncAssignments(syncAssignments []*dbtypes.SyncAssignment, tx Tx) error
+	GetSyncAssignmentsForPeriod(period uint64) []uint64
+}
+
+// BlobStore persists blob sidecars.
+type BlobStore interface {
+	InsertBlob(blob *dbtypes.Blob, tx Tx) error
+	InsertBlobAssignment(blobAssignment *dbtypes.BlobAssignment, tx Tx) error
+	GetBlob(commitment []byte, withData bool) *dbtypes.Blob
+	GetLatestBlobAssignment(commitment []byte) *dbtypes.BlobAssignment
+}
+
+// DepositStore persists deposit transactions & included deposits.
+type DepositStore interface {
+	InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx Tx) error
+	InsertDeposits(deposits []*dbtypes.Deposit, tx Tx) error
+	GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx
+	GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositTxFilter) ([]*dbtypes.DepositTx, uint64, error)
+	GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositFilter) ([]*dbtypes.Deposit, uint64, error)
+}
+
+// VoluntaryExitStore persists voluntary exits.
+type VoluntaryExitStore interface {
+	InsertVoluntaryExits(voluntaryExits []*dbtypes.VoluntaryExit, tx Tx) error
+	GetVoluntaryExitForValidator(validator uint64) *dbtypes.VoluntaryExit
+	GetVoluntaryExitsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.VoluntaryExitFilter) ([]*dbtypes.VoluntaryExit, uint64, error)
+}
+
+// SlashingStore persists attester & proposer slashings.
+type SlashingStore interface {
+	InsertSlashings(slashings []*dbtypes.Slashing, tx Tx) error
+	GetSlashingForValidator(validator uint64) *dbtypes.Slashing
+	GetSlashingsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.SlashingFilter) ([]*dbtypes.Slashing, uint64, error)
+}
+
+// ElRequestStore persists execution layer triggered requests.
+type ElRequestStore interface {
+	InsertConsolidationRequests(consolidations []*dbtypes.ConsolidationRequest, tx Tx) error
+	InsertWithdrawalRequests(elRequests []*dbtypes.WithdrawalRequest, tx Tx) error
+	GetWithdrawalRequestsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.WithdrawalRequestFilter) ([]*dbtypes.WithdrawalRequest, uint64, error)
+}
+
+// ForkStore persists unfinalized forks.
+type ForkStore interface {
+	InsertFork(fork *dbtypes.Fork, tx Tx) error
+	GetUnfinalizedForks(finalizedSlot uint64) []*dbtypes.Fork
+	DeleteFinalizedForks(finalizedRoots [][]byte, tx Tx) error
+}
+
+// UnfinalizedStore persists unfinalized blocks, duties & epoch aggregations for restoration after restarts.
+type UnfinalizedStore interface {
+	InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx Tx) error
+	UpdateUnfinalizedBlockStatus(roots [][]byte, blockStatus dbtypes.UnfinalizedBlockStatus, tx Tx) error
+	UpdateUnfinalizedBlockForkId(roots [][]byte, forkId uint64, tx Tx) error
+	GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.UnfinalizedBlock
+	StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBlock)) error
+	GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock
+	DeleteUnfinalizedBlocksBefore(slot uint64, tx Tx) error
+	InsertUnfinalizedDuty(duty *dbtypes.UnfinalizedDuty, tx Tx) error
+	StreamUnfinalizedDuties(epoch uint64, cb func(duty *dbtypes.UnfinalizedDuty)) error
+	GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.UnfinalizedDuty
+	DeleteUnfinalizedDutiesBefore(epoch uint64, tx Tx) error
+	InsertUnfinalizedEpoch(epoch *dbtypes.UnfinalizedEpoch, tx Tx) error
+	StreamUnfinalizedEpochs(epoch uint64, cb func(duty *dbtypes.UnfinalizedEpoch)) error
+	GetUnfinalizedEpochs(epoch uint64) *dbtypes.UnfinalizedEpoch
+	GetUnfinalizedEpoch(epoch uint64) *dbtypes.UnfinalizedEpoch
+	DeleteUnfinalizedEpochsIn(epoch uint64, tx Tx) error
+}
+
+// MevBlockStore persists mev relay blocks.
+type MevBlockStore interface {
+	InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx Tx) error
+	UpdateMevBlockByEpoch(epoch uint64, slotsPerEpoch uint64, canonicalHashes [][]byte, tx Tx) error
+	GetHighestMevBlockSlotByRelay(relayId uint8) (uint64, error)
+	GetMevBlockByBlockHash(blockHash []byte) *dbtypes.MevBlock
+	GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockFilter) ([]*dbtypes.MevBlock, uint64, error)
+	DeleteMevBlocksBefore(slot uint64, limit uint32, tx Tx) (int64, error)
+}
+
+// MevAnalyticsStore aggregates the mev relay blocks for the builder & relay market analytics.
+type MevAnalyticsStore interface {
+	GetMevBlockStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) *dbtypes.MevBlockStats
+	GetMevBuilderStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevBuilderStats
+	GetMevRelayStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) []*dbtypes.MevRelayStats
+	GetMevProposerStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevProposerStats
+	GetMevDailyStats(firstSlot uint64, lastSlot uint64, slotsPerDay uint64, builderPubkey []byte) []*dbtypes.MevDailyStats
+}
+
+// TxSignatureStore persists resolved transaction function signatures.
+type TxSignatureStore interface {
+	GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtypes.TxFunctionSignature
+	InsertTxFunctionSignature(txFuncSig *dbtypes.TxFunctionSignature, tx Tx) error
+	GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.TxUnknownFunctionSignature
+	InsertUnknownFunctionSignatures(txUnknownSigs []*dbtypes.TxUnknownFunctionSignature, tx Tx) error
+	InsertPendingFunctionSignatures(txPendingSigs []*dbtypes.TxPendingFunctionSignature, tx Tx) error
+	GetPendingFunctionSignatures(limit uint64) []*dbtypes.TxPendingFunctionSignature
+	GetPendingFunctionSignatureCount() uint64
+	DeletePendingFunctionSignatures(sigBytes []types.TxSignatureBytes, tx Tx) error
+	DeletePendingFunctionSignaturesBefore(queueTime uint64, limit uint32, tx Tx) (int64, error)
+}
+
+// ValidatorNameStore persists validator names.
+type ValidatorNameStore interface {
+	GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName
+	InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx Tx) error
+	DeleteValidatorNames(validatorNames []uint64, tx Tx) error
+}
+
+// ExplorerStateStore persists arbitrary explorer state values.
+type ExplorerStateStore interface {
+	// GetExplorerState is pinned to the writer (not the read replicas).
+	GetExplorerState(key string, returnValue interface{}) (interface{}, error)
+	GetExplorerStateForUpdate(key string, returnValue interface{}, tx Tx) (interface{}, error)
+	SetExplorerState(key string, value interface{}, tx Tx) error
+}
+
+// SearchStore provides the lookups used by the unified search & the search ahead boxes.
+type SearchStore interface {
+	SearchSlotsByNumber(number uint64, limit uint32) []*dbtypes.SearchSlotResult
+	SearchSlotsByHash(hash []byte, limit uint32) []*dbtypes.SearchSlotResult
+	SearchGraffiti(text string, limit uint32) []*dbtypes.SearchGraffitiCountResult
+	SearchAheadEpochs(prefix string) (dbtypes.SearchAheadEpochsResult, error)
+	SearchAheadSlotsByHash(hash []byte, beforeSlot uint64) (dbtypes.SearchAheadSlotsResult, error)
+	SearchAheadSlotsByNumber(slot uint64) (dbtypes.SearchAheadSlotsResult, error)
+	SearchAheadExecBlocksByHash(hash []byte, beforeSlot uint64) (dbtypes.SearchAheadExecBlocksResult, error)
+	SearchAheadExecBlocksByNumber(number uint64, beforeSlot uint64) (dbtypes.SearchAheadExecBlocksResult, error)
+	SearchAheadGraffiti(text string) (dbtypes.SearchAheadGraffitiResult, error)
+	SearchAheadValidatorNames(text string) (dbtypes.SearchAheadValidatorNameResult, error)
+}
+
+// ClientDiversityStore persists the inferred proposer clients & their per epoch aggregates.
+type ClientDiversityStore interface {
+	UpdateClientDiversity(firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx Tx) error
+	GetClientDiversity(firstEpoch uint64, lastEpoch uint64) []*dbtypes.ClientDiversity
+	GetClientDiversityByProposer(firstSlot uint64, lastSlot uint64) []*dbtypes.ClientDiversityProposer
+	GetUnclassifiedSlots(limit uint32) ([]*dbtypes.UnclassifiedSlot, error)
+	UpdateSlotClients(slot uint64, root []byte, clClient uint8, elClient uint8, tx Tx) error
+}
+
+// ChartRollupStore persists the pre-aggregated epoch stats for the network charts.
+type ChartRollupStore interface {
+	InsertChartRollups(rollups []*dbtypes.ChartRollup, tx Tx) error
+	GetChartRollups(period uint32, firstTime uint64, lastTime uint64) []*dbtypes.ChartRollup
+}
+
+// FinalityIncidentStore persists the non-finality incidents observed by the finality monitor.
+type FinalityIncidentStore interface {
+	InsertFinalityIncident(incident *dbtypes.FinalityIncident, tx Tx) error
+	GetFinalityIncident(startEpoch uint64) *dbtypes.FinalityIncident
+	GetOngoingFinalityIncident() *dbtypes.FinalityIncident
+	GetFinalityIncidents(offset uint64, limit uint32) ([]*dbtypes.FinalityIncident, uint64, error)
+}
+
+// BlockTimingStore persists the block arrival times reported by the consensus clients.
+type BlockTimingStore interface {
+	InsertBlockTimings(timings []*dbtypes.BlockTiming, tx Tx) error
+	GetBlockTimingsByRoot(root []byte) []*dbtypes.BlockTiming
+	GetLateBlocks(firstSlot uint64, minDelay int64, offset uint64, limit uint32) ([]*dbtypes.LateBlock, uint64, error)
+	GetBlockTimingClientStats(firstSlot uint64, lateDelay int64) []*dbtypes.BlockTimingClientStats
+}
+
+// BlockBodyStore persists the ssz encoded bodies of canonical finalized blocks.
+type BlockBodyStore interface {
+	InsertBlockBody(body *dbtypes.BlockBody, tx Tx) error
+	GetBlockBody(root []byte) *dbtypes.BlockBody
+	GetCanonicalBlockBodyBySlot(slot uint64) *dbtypes.BlockBody
+	DeleteBlockBodiesBefore(slot uint64, limit uint32, tx Tx) (int64, error)
+}
+
+var store Store = &sqlStore{
+	ctx: context.Background(),
+}
+
+// SetStore replaces the active store.
+func SetStore(newStore Store) {
+	store = newStore
+}
+
+// GetStore returns the active store.
+func GetStore() Store {
+	return store
+}
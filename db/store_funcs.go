@@ -0,0 +1,455 @@
+package db
+
+import (
+	"context"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+)
+
+// package level accessors, forwarded to the active store
+
+// WithContext returns the active store bound to the given context.
+func WithContext(ctx context.Context) Store {
+	return store.WithContext(ctx)
+}
+
+func InsertSlot(slot *dbtypes.Slot, tx Tx) error {
+	return store.InsertSlot(slot, tx)
+}
+
+func InsertMissingSlot(block *dbtypes.SlotHeader, tx Tx) error {
+	return store.InsertMissingSlot(block, tx)
+}
+
+func GetSlots(firstSlot uint64, limit uint32, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
+	return store.GetSlots(firstSlot, limit, withMissing, withOrphaned)
+}
+
+func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrphaned bool) []*dbtypes.AssignedSlot {
+	return store.GetSlotsRange(firstSlot, lastSlot, withMissing, withOrphaned)
+}
+
+func GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
+	return store.GetSlotsByParentRoot(parentRoot)
+}
+
+func GetSlotByRoot(root []byte) *dbtypes.Slot {
+	return store.GetSlotByRoot(root)
+}
+
+func GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
+	return store.GetBlockHeadByRoot(root)
+}
+
+func GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
+	return store.GetSlotsByBlockHash(blockHash)
+}
+
+func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedSlot {
+	return store.GetFilteredSlots(filter, firstSlot, offset, limit)
+}
+
+func GetSlotStatus(blockRoots [][]byte) []*dbtypes.BlockStatus {
+	return store.GetSlotStatus(blockRoots)
+}
+
+func GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte {
+	return store.GetHighestRootBeforeSlot(slot, withOrphaned)
+}
+
+func GetSlotAssignment(slot uint64) uint64 {
+	return store.GetSlotAssignment(slot)
+}
+
+func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx Tx) error {
+	return store.InsertOrphanedBlock(block, tx)
+}
+
+func GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
+	return store.GetOrphanedBlock(root)
+}
+
+func GetMissedSlotsBefore(slot uint64, limit uint32, tx Tx) ([]uint64, error) {
+	return store.GetMissedSlotsBefore(slot, limit, tx)
+}
+
+func DeleteMissedSlotsInRange(firstSlot uint64, lastSlot uint64, tx Tx) error {
+	return store.DeleteMissedSlotsInRange(firstSlot, lastSlot, tx)
+}
+
+func InsertMissedSlotRange(missedRange *dbtypes.MissedSlotRange, tx Tx) error {
+	return store.InsertMissedSlotRange(missedRange, tx)
+}
+
+func GetMissedSlotRanges(firstSlot uint64, lastSlot uint64) []*dbtypes.MissedSlotRange {
+	return store.GetMissedSlotRanges(firstSlot, lastSlot)
+}
+
+func GetMissedSlotRangeEndingAt(slot uint64, tx Tx) (*dbtypes.MissedSlotRange, error) {
+	return store.GetMissedSlotRangeEndingAt(slot, tx)
+}
+
+func InsertEpoch(epoch *dbtypes.Epoch, tx Tx) error {
+	return store.InsertEpoch(epoch, tx)
+}
+
+func IsEpochSynchronized(epoch uint64) bool {
+	return store.IsEpochSynchronized(epoch)
+}
+
+func GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	return store.GetEpochs(firstEpoch, limit)
+}
+
+func IsSyncCommitteeSynchronized(period uint64) bool {
+	return store.IsSyncCommitteeSynchronized(period)
+}
+
+func InsertSyncAssignments(syncAssignments []*dbtypes.SyncAssignment, tx Tx) error {
+	return store.InsertSyncAssignments(syncAssignments, tx)
+}
+
+func GetSyncAssignmentsForPeriod(period uint64) []uint64 {
+	return store.GetSyncAssignmentsForPeriod(period)
+}
+
+func InsertBlob(blob *dbtypes.Blob, tx Tx) error {
+	return store.InsertBlob(blob, tx)
+}
+
+func InsertBlobAssignment(blobAssignment *dbtypes.BlobAssignment, tx Tx) error {
+	return store.InsertBlobAssignment(blobAssignment, tx)
+}
+
+func GetBlob(commitment []byte, withData bool) *dbtypes.Blob {
+	return store.GetBlob(commitment, withData)
+}
+
+func GetLatestBlobAssignment(commitment []byte) *dbtypes.BlobAssignment {
+	return store.GetLatestBlobAssignment(commitment)
+}
+
+func InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx Tx) error {
+	return store.InsertDepositTxs(depositTxs, tx)
+}
+
+func InsertDeposits(deposits []*dbtypes.Deposit, tx Tx) error {
+	return store.InsertDeposits(deposits, tx)
+}
+
+func GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
+	return store.GetDepositTxs(firstIndex, limit)
+}
+
+func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositTxFilter) ([]*dbtypes.DepositTx, uint64, error) {
+	return store.GetDepositTxsFiltered(offset, limit, finalizedBlock, filter)
+}
+
+func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositFilter) ([]*dbtypes.Deposit, uint64, error) {
+	return store.GetDepositsFiltered(offset, limit, finalizedBlock, filter)
+}
+
+func InsertVoluntaryExits(voluntaryExits []*dbtypes.VoluntaryExit, tx Tx) error {
+	return store.InsertVoluntaryExits(voluntaryExits, tx)
+}
+
+func GetVoluntaryExitForValidator(validator uint64) *dbtypes.VoluntaryExit {
+	return store.GetVoluntaryExitForValidator(validator)
+}
+
+func GetVoluntaryExitsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.VoluntaryExitFilter) ([]*dbtypes.VoluntaryExit, uint64, error) {
+	return store.GetVoluntaryExitsFiltered(offset, limit, finalizedBlock, filter)
+}
+
+func InsertSlashings(slashings []*dbtypes.Slashing, tx Tx) error {
+	return store.InsertSlashings(slashings, tx)
+}
+
+func GetSlashingForValidator(validator uint64) *dbtypes.Slashing {
+	return store.GetSlashingForValidator(validator)
+}
+
+func GetSlashingsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.SlashingFilter) ([]*dbtypes.Slashing, uint64, error) {
+	return store.GetSlashingsFiltered(offset, limit, finalizedBlock, filter)
+}
+
+func InsertConsolidationRequests(consolidations []*dbtypes.ConsolidationRequest, tx Tx) error {
+	return store.InsertConsolidationRequests(consolidations, tx)
+}
+
+func InsertWithdrawalRequests(elRequests []*dbtypes.WithdrawalRequest, tx Tx) error {
+	return store.InsertWithdrawalRequests(elRequests, tx)
+}
+
+func GetWithdrawalRequestsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.WithdrawalRequestFilter) ([]*dbtypes.WithdrawalRequest, uint64, error) {
+	return store.GetWithdrawalRequestsFiltered(offset, limit, finalizedBlock, filter)
+}
+
+func InsertFork(fork *dbtypes.Fork, tx Tx) error {
+	return store.InsertFork(fork, tx)
+}
+
+func GetUnfinalizedForks(finalizedSlot uint64) []*dbtypes.Fork {
+	return store.GetUnfinalizedForks(finalizedSlot)
+}
+
+func DeleteFinalizedForks(finalizedRoots [][]byte, tx Tx) error {
+	return store.DeleteFinalizedForks(finalizedRoots, tx)
+}
+
+func InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx Tx) error {
+	return store.InsertUnfinalizedBlock(block, tx)
+}
+
+func UpdateUnfinalizedBlockStatus(roots [][]byte, blockStatus dbtypes.UnfinalizedBlockStatus, tx Tx) error {
+	return store.UpdateUnfinalizedBlockStatus(roots, blockStatus, tx)
+}
+
+func UpdateUnfinalizedBlockForkId(roots [][]byte, forkId uint64, tx Tx) error {
+	return store.UpdateUnfinalizedBlockForkId(roots, forkId, tx)
+}
+
+func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.UnfinalizedBlock {
+	return store.GetUnfinalizedBlocks(filter)
+}
+
+func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBlock)) error {
+	return store.StreamUnfinalizedBlocks(slot, cb)
+}
+
+func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
+	return store.GetUnfinalizedBlock(root)
+}
+
+func DeleteUnfinalizedBlocksBefore(slot uint64, tx Tx) error {
+	return store.DeleteUnfinalizedBlocksBefore(slot, tx)
+}
+
+func InsertUnfinalizedDuty(duty *dbtypes.UnfinalizedDuty, tx Tx) error {
+	return store.InsertUnfinalizedDuty(duty, tx)
+}
+
+func StreamUnfinalizedDuties(epoch uint64, cb func(duty *dbtypes.UnfinalizedDuty)) error {
+	return store.StreamUnfinalizedDuties(epoch, cb)
+}
+
+func GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.UnfinalizedDuty {
+	return store.GetUnfinalizedDuty(epoch, dependentRoot)
+}
+
+func DeleteUnfinalizedDutiesBefore(epoch uint64, tx Tx) error {
+	return store.DeleteUnfinalizedDutiesBefore(epoch, tx)
+}
+
+func InsertUnfinalizedEpoch(epoch *dbtypes.UnfinalizedEpoch, tx Tx) error {
+	return store.InsertUnfinalizedEpoch(epoch, tx)
+}
+
+func StreamUnfinalizedEpochs(epoch uint64, cb func(duty *dbtypes.UnfinalizedEpoch)) error {
+	return store.StreamUnfinalizedEpochs(epoch, cb)
+}
+
+func GetUnfinalizedEpochs(epoch uint64) *dbtypes.UnfinalizedEpoch {
+	return store.GetUnfinalizedEpochs(epoch)
+}
+
+func GetUnfinalizedEpoch(epoch uint64) *dbtypes.UnfinalizedEpoch {
+	return store.GetUnfinalizedEpoch(epoch)
+}
+
+func DeleteUnfinalizedEpochsIn(epoch uint64, tx Tx) error {
+	return store.DeleteUnfinalizedEpochsIn(epoch, tx)
+}
+
+func InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx Tx) error {
+	return store.InsertMevBlocks(mevBlocks, tx)
+}
+
+func UpdateMevBlockByEpoch(epoch uint64, slotsPerEpoch uint64, canonicalHashes [][]byte, tx Tx) error {
+	return store.UpdateMevBlockByEpoch(epoch, slotsPerEpoch, canonicalHashes, tx)
+}
+
+func GetHighestMevBlockSlotByRelay(relayId uint8) (uint64, error) {
+	return store.GetHighestMevBlockSlotByRelay(relayId)
+}
+
+func GetMevBlockByBlockHash(blockHash []byte) *dbtypes.MevBlock {
+	return store.GetMevBlockByBlockHash(blockHash)
+}
+
+func GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockFilter) ([]*dbtypes.MevBlock, uint64, error) {
+	return store.GetMevBlocksFiltered(offset, limit, filter)
+}
+
+func DeleteMevBlocksBefore(slot uint64, limit uint32, tx Tx) (int64, error) {
+	return store.DeleteMevBlocksBefore(slot, limit, tx)
+}
+
+func GetMevBlockStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) *dbtypes.MevBlockStats {
+	return store.GetMevBlockStats(firstSlot, lastSlot, builderPubkey)
+}
+
+func GetMevBuilderStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevBuilderStats {
+	return store.GetMevBuilderStats(firstSlot, lastSlot)
+}
+
+func GetMevRelayStats(firstSlot uint64, lastSlot uint64, builderPubkey []byte) []*dbtypes.MevRelayStats {
+	return store.GetMevRelayStats(firstSlot, lastSlot, builderPubkey)
+}
+
+func GetMevProposerStats(firstSlot uint64, lastSlot uint64) []*dbtypes.MevProposerStats {
+	return store.GetMevProposerStats(firstSlot, lastSlot)
+}
+
+func GetMevDailyStats(firstSlot uint64, lastSlot uint64, slotsPerDay uint64, builderPubkey []byte) []*dbtypes.MevDailyStats {
+	return store.GetMevDailyStats(firstSlot, lastSlot, slotsPerDay, builderPubkey)
+}
+
+func GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtypes.TxFunctionSignature {
+	return store.GetTxFunctionSignaturesByBytes(sigBytes)
+}
+
+func InsertTxFunctionSignature(txFuncSig *dbtypes.TxFunctionSignature, tx Tx) error {
+	return store.InsertTxFunctionSignature(txFuncSig, tx)
+}
+
+func GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.TxUnknownFunctionSignature {
+	return store.GetUnknownFunctionSignatures(sigBytes)
+}
+
+func InsertUnknownFunctionSignatures(txUnknownSigs []*dbtypes.TxUnknownFunctionSignature, tx Tx) error {
+	return store.InsertUnknownFunctionSignatures(txUnknownSigs, tx)
+}
+
+func InsertPendingFunctionSignatures(txPendingSigs []*dbtypes.TxPendingFunctionSignature, tx Tx) error {
+	return store.InsertPendingFunctionSignatures(txPendingSigs, tx)
+}
+
+func GetPendingFunctionSignatures(limit uint64) []*dbtypes.TxPendingFunctionSignature {
+	return store.GetPendingFunctionSignatures(limit)
+}
+
+func GetPendingFunctionSignatureCount() uint64 {
+	return store.GetPendingFunctionSignatureCount()
+}
+
+func DeletePendingFunctionSignatures(sigBytes []types.TxSignatureBytes, tx Tx) error {
+	return store.DeletePendingFunctionSignatures(sigBytes, tx)
+}
+
+func DeletePendingFunctionSignaturesBefore(queueTime uint64, limit uint32, tx Tx) (int64, error) {
+	return store.DeletePendingFunctionSignaturesBefore(queueTime, limit, tx)
+}
+
+func GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
+	return store.GetValidatorNames(minIdx, maxIdx)
+}
+
+func InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx Tx) error {
+	return store.InsertValidatorNames(validatorNames, tx)
+}
+
+func DeleteValidatorNames(validatorNames []uint64, tx Tx) error {
+	return store.DeleteValidatorNames(validatorNames, tx)
+}
+
+func GetExplorerState(key string, returnValue interface{}) (interface{}, error) {
+	return store.GetExplorerState(key, returnValue)
+}
+
+func GetExplorerStateForUpdate(key string, returnValue interface{}, tx Tx) (interface{}, error) {
+	return store.GetExplorerStateForUpdate(key, returnValue, tx)
+}
+
+func SetExplorerState(key string, value interface{}, tx Tx) error {
+	return store.SetExplorerState(key, value, tx)
+}
+
+func SearchSlotsByNumber(number uint64, limit uint32) []*dbtypes.SearchSlotResult {
+	return store.SearchSlotsByNumber(number, limit)
+}
+
+func SearchSlotsByHash(hash []byte, limit uint32) []*dbtypes.SearchSlotResult {
+	return store.SearchSlotsByHash(hash, limit)
+}
+
+func SearchGraffiti(text string, limit uint32) []*dbtypes.SearchGraffitiCountResult {
+	return store.SearchGraffiti(text, limit)
+}
+
+func UpdateClientDiversity(firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx Tx) error {
+	return store.UpdateClientDiversity(firstEpoch, lastEpoch, slotsPerEpoch, tx)
+}
+
+func GetClientDiversity(firstEpoch uint64, lastEpoch uint64) []*dbtypes.ClientDiversity {
+	return store.GetClientDiversity(firstEpoch, lastEpoch)
+}
+
+func GetClientDiversityByProposer(firstSlot uint64, lastSlot uint64) []*dbtypes.ClientDiversityProposer {
+	return store.GetClientDiversityByProposer(firstSlot, lastSlot)
+}
+
+func GetUnclassifiedSlots(limit uint32) ([]*dbtypes.UnclassifiedSlot, error) {
+	return store.GetUnclassifiedSlots(limit)
+}
+
+func UpdateSlotClients(slot uint64, root []byte, clClient uint8, elClient uint8, tx Tx) error {
+	return store.UpdateSlotClients(slot, root, clClient, elClient, tx)
+}
+
+func InsertChartRollups(rollups []*dbtypes.ChartRollup, tx Tx) error {
+	return store.InsertChartRollups(rollups, tx)
+}
+
+func GetChartRollups(period uint32, firstTime uint64, lastTime uint64) []*dbtypes.ChartRollup {
+	return store.GetChartRollups(period, firstTime, lastTime)
+}
+
+func InsertFinalityIncident(incident *dbtypes.FinalityIncident, tx Tx) error {
+	return store.InsertFinalityIncident(incident, tx)
+}
+
+func GetFinalityIncident(startEpoch uint64) *dbtypes.FinalityIncident {
+	return store.GetFinalityIncident(startEpoch)
+}
+
+func GetOngoingFinalityIncident() *dbtypes.FinalityIncident {
+	return store.GetOngoingFinalityIncident()
+}
+
+func GetFinalityIncidents(offset uint64, limit uint32) ([]*dbtypes.FinalityIncident, uint64, error) {
+	return store.GetFinalityIncidents(offset, limit)
+}
+
+func InsertBlockTimings(timings []*dbtypes.BlockTiming, tx Tx) error {
+	return store.InsertBlockTimings(timings, tx)
+}
+
+func GetBlockTimingsByRoot(root []byte) []*dbtypes.BlockTiming {
+	return store.GetBlockTimingsByRoot(root)
+}
+
+func GetLateBlocks(firstSlot uint64, minDelay int64, offset uint64, limit uint32) ([]*dbtypes.LateBlock, uint64, error) {
+	return store.GetLateBlocks(firstSlot, minDelay, offset, limit)
+}
+
+func GetBlockTimingClientStats(firstSlot uint64, lateDelay int64) []*dbtypes.BlockTimingClientStats {
+	return store.GetBlockTimingClientStats(firstSlot, lateDelay)
+}
+
+func InsertBlockBody(body *dbtypes.BlockBody, tx Tx) error {
+	return store.InsertBlockBody(body, tx)
+}
+
+func GetBlockBody(root []byte) *dbtypes.BlockBody {
+	return store.GetBlockBody(root)
+}
+
+func GetCanonicalBlockBodyBySlot(slot uint64) *dbtypes.BlockBody {
+	return store.GetCanonicalBlockBodyBySlot(slot)
+}
+
+func DeleteBlockBodiesBefore(slot uint64, limit uint32, tx Tx) (int64, error) {
+	return store.DeleteBlockBodiesBefore(slot, limit, tx)
+}
@@ -5,19 +5,18 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func IsSyncCommitteeSynchronized(period uint64) bool {
+func (store *sqlStore) IsSyncCommitteeSynchronized(period uint64) bool {
 	var count uint64
-	err := ReaderDb.Get(&count, `SELECT COUNT(*) FROM sync_assignments WHERE period = $1`, period)
+	err := writerDb.Get(&count, `SELECT COUNT(*) FROM sync_assignments WHERE period = $1`, period)
 	if err != nil {
 		return false
 	}
 	return count > 0
 }
 
-func InsertSyncAssignments(syncAssignments []*dbtypes.SyncAssignment, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertSyncAssignments(syncAssignments []*dbtypes.SyncAssignment, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql:  `INSERT INTO sync_assignments (period, "index", validator) VALUES `,
@@ -39,16 +38,16 @@ func InsertSyncAssignments(syncAssignments []*dbtypes.SyncAssignment, tx *sqlx.T
 		dbtypes.DBEnginePgsql:  ` ON CONFLICT (period, "index") DO UPDATE SET validator = excluded.validator`,
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetSyncAssignmentsForPeriod(period uint64) []uint64 {
+func (store *sqlStore) GetSyncAssignmentsForPeriod(period uint64) []uint64 {
 	assignments := []uint64{}
-	err := ReaderDb.Select(&assignments, `
+	err := ReaderDb.SelectContext(store.ctx, &assignments, `
 	SELECT
 		validator
 	FROM sync_assignments
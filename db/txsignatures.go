@@ -6,10 +6,9 @@ import (
 
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/types"
-	"github.com/jmoiron/sqlx"
 )
 
-func GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtypes.TxFunctionSignature {
+func (store *sqlStore) GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtypes.TxFunctionSignature {
 	fnSigs := []*dbtypes.TxFunctionSignature{}
 
 	var sql strings.Builder
@@ -30,7 +29,7 @@ func GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtype
 	}
 	fmt.Fprintf(&sql, ")")
 
-	err := ReaderDb.Select(&fnSigs, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &fnSigs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching tx function signatures: %v", err)
 		return nil
@@ -38,8 +37,8 @@ func GetTxFunctionSignaturesByBytes(sigBytes []types.TxSignatureBytes) []*dbtype
 	return fnSigs
 }
 
-func InsertTxFunctionSignature(txFuncSig *dbtypes.TxFunctionSignature, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertTxFunctionSignature(txFuncSig *dbtypes.TxFunctionSignature, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO tx_function_signatures (
 				signature, bytes, name
@@ -57,7 +56,7 @@ func InsertTxFunctionSignature(txFuncSig *dbtypes.TxFunctionSignature, tx *sqlx.
 	return nil
 }
 
-func GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.TxUnknownFunctionSignature {
+func (store *sqlStore) GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.TxUnknownFunctionSignature {
 	unknwonFnSigs := []*dbtypes.TxUnknownFunctionSignature{}
 	if len(sigBytes) == 0 {
 		return unknwonFnSigs
@@ -79,7 +78,7 @@ func GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.
 		argIdx += 1
 	}
 	fmt.Fprintf(&sql, ")")
-	err := ReaderDb.Select(&unknwonFnSigs, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &unknwonFnSigs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching unknown function signatures: %v", err)
 		return nil
@@ -87,7 +86,7 @@ func GetUnknownFunctionSignatures(sigBytes []types.TxSignatureBytes) []*dbtypes.
 	return unknwonFnSigs
 }
 
-func InsertUnknownFunctionSignatures(txUnknownSigs []*dbtypes.TxUnknownFunctionSignature, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertUnknownFunctionSignatures(txUnknownSigs []*dbtypes.TxUnknownFunctionSignature, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql:  `INSERT INTO tx_unknown_signatures (bytes, lastcheck) VALUES `,
@@ -108,14 +107,14 @@ func InsertUnknownFunctionSignatures(txUnknownSigs []*dbtypes.TxUnknownFunctionS
 		dbtypes.DBEnginePgsql:  ` ON CONFLICT (bytes) DO UPDATE SET lastcheck = excluded.lastcheck`,
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func InsertPendingFunctionSignatures(txPendingSigs []*dbtypes.TxPendingFunctionSignature, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertPendingFunctionSignatures(txPendingSigs []*dbtypes.TxPendingFunctionSignature, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql:  `INSERT INTO tx_pending_signatures (bytes, queuetime) VALUES `,
@@ -136,16 +135,16 @@ func InsertPendingFunctionSignatures(txPendingSigs []*dbtypes.TxPendingFunctionS
 		dbtypes.DBEnginePgsql:  ` ON CONFLICT (bytes) DO NOTHING`,
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetPendingFunctionSignatures(limit uint64) []*dbtypes.TxPendingFunctionSignature {
+func (store *sqlStore) GetPendingFunctionSignatures(limit uint64) []*dbtypes.TxPendingFunctionSignature {
 	pendingFnSigs := []*dbtypes.TxPendingFunctionSignature{}
-	err := ReaderDb.Select(&pendingFnSigs, `
+	err := ReaderDb.SelectContext(store.ctx, &pendingFnSigs, `
 	SELECT
 		bytes, queuetime
 	FROM tx_pending_signatures
@@ -158,7 +157,17 @@ func GetPendingFunctionSignatures(limit uint64) []*dbtypes.TxPendingFunctionSign
 	return pendingFnSigs
 }
 
-func DeletePendingFunctionSignatures(sigBytes []types.TxSignatureBytes, tx *sqlx.Tx) error {
+func (store *sqlStore) GetPendingFunctionSignatureCount() uint64 {
+	var count uint64
+	err := ReaderDb.GetContext(store.ctx, &count, `SELECT COUNT(*) FROM tx_pending_signatures`)
+	if err != nil {
+		logger.Errorf("Error while counting pending function signatures: %v", err)
+		return 0
+	}
+	return count
+}
+
+func (store *sqlStore) DeletePendingFunctionSignatures(sigBytes []types.TxSignatureBytes, tx Tx) error {
 	if len(sigBytes) == 0 {
 		return nil
 	}
@@ -175,6 +184,18 @@ func DeletePendingFunctionSignatures(sigBytes []types.TxSignatureBytes, tx *sqlx
 		args[i] = sigBytes[i][:]
 	}
 	fmt.Fprintf(&sql, ")")
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	return err
 }
+
+func (store *sqlStore) DeletePendingFunctionSignaturesBefore(queueTime uint64, limit uint32, tx Tx) (int64, error) {
+	res, err := sqlTx(tx).Exec(`
+	DELETE FROM tx_pending_signatures
+	WHERE bytes IN (
+		SELECT bytes FROM tx_pending_signatures WHERE queuetime < $1 LIMIT $2
+	)`, queueTime, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -5,11 +5,10 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO unfinalized_blocks (
 				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
@@ -27,7 +26,7 @@ func InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx *sqlx.Tx) error
 	return nil
 }
 
-func UpdateUnfinalizedBlockStatus(roots [][]byte, blockStatus dbtypes.UnfinalizedBlockStatus, tx *sqlx.Tx) error {
+func (store *sqlStore) UpdateUnfinalizedBlockStatus(roots [][]byte, blockStatus dbtypes.UnfinalizedBlockStatus, tx Tx) error {
 	var sql strings.Builder
 	args := []any{}
 
@@ -45,14 +44,14 @@ func UpdateUnfinalizedBlockStatus(roots [][]byte, blockStatus dbtypes.Unfinalize
 
 	fmt.Fprint(&sql, ")")
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func UpdateUnfinalizedBlockForkId(roots [][]byte, forkId uint64, tx *sqlx.Tx) error {
+func (store *sqlStore) UpdateUnfinalizedBlockForkId(roots [][]byte, forkId uint64, tx Tx) error {
 	var sql strings.Builder
 	args := []any{}
 
@@ -70,14 +69,14 @@ func UpdateUnfinalizedBlockForkId(roots [][]byte, forkId uint64, tx *sqlx.Tx) er
 
 	fmt.Fprint(&sql, ")")
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.UnfinalizedBlock {
+func (store *sqlStore) GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.UnfinalizedBlock {
 	blockRefs := []*dbtypes.UnfinalizedBlock{}
 
 	var sql strings.Builder
@@ -108,7 +107,7 @@ func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.Unf
 		}
 	}
 
-	err := ReaderDb.Select(&blockRefs, sql.String(), args...)
+	err := writerDb.Select(&blockRefs, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching unfinalized blocks: %v", err)
 		return nil
@@ -116,13 +115,13 @@ func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.Unf
 	return blockRefs
 }
 
-func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBlock)) error {
+func (store *sqlStore) StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBlock)) error {
 	var sql strings.Builder
 	args := []any{slot}
 
 	fmt.Fprint(&sql, `SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id FROM unfinalized_blocks WHERE slot >= $1`)
 
-	rows, err := ReaderDb.Query(sql.String(), args...)
+	rows, err := writerDb.Query(sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching unfinalized blocks: %v", err)
 		return nil
@@ -141,9 +140,9 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 	return nil
 }
 
-func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
+func (store *sqlStore) GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
 	block := dbtypes.UnfinalizedBlock{}
-	err := ReaderDb.Get(&block, `
+	err := writerDb.Get(&block, `
 	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
 	FROM unfinalized_blocks
 	WHERE root = $1
@@ -155,8 +154,8 @@ func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
 	return &block
 }
 
-func DeleteUnfinalizedBlocksBefore(slot uint64, tx *sqlx.Tx) error {
-	_, err := tx.Exec(`DELETE FROM unfinalized_blocks WHERE slot < $1`, slot)
+func (store *sqlStore) DeleteUnfinalizedBlocksBefore(slot uint64, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`DELETE FROM unfinalized_blocks WHERE slot < $1`, slot)
 	if err != nil {
 		return err
 	}
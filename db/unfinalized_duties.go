@@ -5,11 +5,10 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertUnfinalizedDuty(duty *dbtypes.UnfinalizedDuty, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertUnfinalizedDuty(duty *dbtypes.UnfinalizedDuty, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO unfinalized_duties (
 				epoch, dependent_root, duties
@@ -26,13 +25,13 @@ func InsertUnfinalizedDuty(duty *dbtypes.UnfinalizedDuty, tx *sqlx.Tx) error {
 	return nil
 }
 
-func StreamUnfinalizedDuties(epoch uint64, cb func(duty *dbtypes.UnfinalizedDuty)) error {
+func (store *sqlStore) StreamUnfinalizedDuties(epoch uint64, cb func(duty *dbtypes.UnfinalizedDuty)) error {
 	var sql strings.Builder
 	args := []any{epoch}
 
 	fmt.Fprint(&sql, `SELECT epoch, dependent_root, duties FROM unfinalized_duties WHERE epoch >= $1`)
 
-	rows, err := ReaderDb.Query(sql.String(), args...)
+	rows, err := writerDb.Query(sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching unfinalized duties: %v", err)
 		return nil
@@ -51,9 +50,9 @@ func StreamUnfinalizedDuties(epoch uint64, cb func(duty *dbtypes.UnfinalizedDuty
 	return nil
 }
 
-func GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.UnfinalizedDuty {
+func (store *sqlStore) GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.UnfinalizedDuty {
 	duty := dbtypes.UnfinalizedDuty{}
-	err := ReaderDb.Get(&duty, `
+	err := writerDb.Get(&duty, `
 	SELECT epoch, dependent_root, duties
 	FROM unfinalized_duties
 	WHERE epoch = $1 AND dependent_root = $2
@@ -65,8 +64,8 @@ func GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.Unfinalized
 	return &duty
 }
 
-func DeleteUnfinalizedDutiesBefore(epoch uint64, tx *sqlx.Tx) error {
-	_, err := tx.Exec(`DELETE FROM unfinalized_duties WHERE epoch < $1`, epoch)
+func (store *sqlStore) DeleteUnfinalizedDutiesBefore(epoch uint64, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`DELETE FROM unfinalized_duties WHERE epoch < $1`, epoch)
 	if err != nil {
 		return err
 	}
@@ -2,11 +2,10 @@ package db
 
 import (
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertUnfinalizedEpoch(epoch *dbtypes.UnfinalizedEpoch, tx *sqlx.Tx) error {
-	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+func (store *sqlStore) InsertUnfinalizedEpoch(epoch *dbtypes.UnfinalizedEpoch, tx Tx) error {
+	_, err := sqlTx(tx).Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO unfinalized_epochs (
 				epoch, dependent_root, epoch_head_root, epoch_head_fork_id, validator_count, validator_balance, eligible, voted_target, 
@@ -50,8 +49,8 @@ func InsertUnfinalizedEpoch(epoch *dbtypes.UnfinalizedEpoch, tx *sqlx.Tx) error
 	return nil
 }
 
-func StreamUnfinalizedEpochs(epoch uint64, cb func(duty *dbtypes.UnfinalizedEpoch)) error {
-	rows, err := ReaderDb.Query(`
+func (store *sqlStore) StreamUnfinalizedEpochs(epoch uint64, cb func(duty *dbtypes.UnfinalizedEpoch)) error {
+	rows, err := writerDb.Query(`
 	SELECT
 		epoch, dependent_root, epoch_head_root, epoch_head_fork_id, validator_count, validator_balance, eligible, voted_target,
 		voted_head, voted_total, block_count, orphaned_count, attestation_count, deposit_count, exit_count, withdraw_count,
@@ -80,9 +79,9 @@ func StreamUnfinalizedEpochs(epoch uint64, cb func(duty *dbtypes.UnfinalizedEpoc
 	return nil
 }
 
-func GetUnfinalizedEpochs(epoch uint64) *dbtypes.UnfinalizedEpoch {
+func (store *sqlStore) GetUnfinalizedEpochs(epoch uint64) *dbtypes.UnfinalizedEpoch {
 	unfinalizedEpoch := dbtypes.UnfinalizedEpoch{}
-	err := ReaderDb.Get(&unfinalizedEpoch, `
+	err := writerDb.Get(&unfinalizedEpoch, `
 	SELECT
 		epoch, dependent_root, epoch_head_root, epoch_head_fork_id, validator_count, validator_balance, eligible, voted_target,
 		voted_head, voted_total, block_count, orphaned_count, attestation_count, deposit_count, exit_count, withdraw_count,
@@ -96,9 +95,9 @@ func GetUnfinalizedEpochs(epoch uint64) *dbtypes.UnfinalizedEpoch {
 	return &unfinalizedEpoch
 }
 
-func GetUnfinalizedEpoch(epoch uint64) *dbtypes.UnfinalizedEpoch {
+func (store *sqlStore) GetUnfinalizedEpoch(epoch uint64) *dbtypes.UnfinalizedEpoch {
 	unfinalizedEpoch := dbtypes.UnfinalizedEpoch{}
-	err := ReaderDb.Get(&unfinalizedEpoch, `
+	err := writerDb.Get(&unfinalizedEpoch, `
 	SELECT
 		epoch, dependent_root, epoch_head_root, epoch_head_fork_id, validator_count, validator_balance, eligible, voted_target,
 		voted_head, voted_total, block_count, orphaned_count, attestation_count, deposit_count, exit_count, withdraw_count,
@@ -112,8 +111,8 @@ func GetUnfinalizedEpoch(epoch uint64) *dbtypes.UnfinalizedEpoch {
 	return &unfinalizedEpoch
 }
 
-func DeleteUnfinalizedEpochsIn(epoch uint64, tx *sqlx.Tx) error {
-	_, err := tx.Exec(`DELETE FROM unfinalized_epochs WHERE epoch = $1`, epoch)
+func (store *sqlStore) DeleteUnfinalizedEpochsIn(epoch uint64, tx Tx) error {
+	_, err := sqlTx(tx).Exec(`DELETE FROM unfinalized_epochs WHERE epoch = $1`, epoch)
 	if err != nil {
 		return err
 	}
@@ -5,12 +5,11 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
+func (store *sqlStore) GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
 	names := []*dbtypes.ValidatorName{}
-	err := ReaderDb.Select(&names, `SELECT "index", "name" FROM validator_names WHERE "index" >= $1 AND "index" <= $2`, minIdx, maxIdx)
+	err := ReaderDb.SelectContext(store.ctx, &names, `SELECT "index", "name" FROM validator_names WHERE "index" >= $1 AND "index" <= $2`, minIdx, maxIdx)
 	if err != nil {
 		logger.Errorf("Error while fetching validator names: %v", err)
 		return nil
@@ -18,7 +17,7 @@ func GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
 	return names
 }
 
-func InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql:  `INSERT INTO validator_names ("index", "name") VALUES `,
@@ -39,14 +38,14 @@ func InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx *sqlx.Tx)
 		dbtypes.DBEnginePgsql:  ` ON CONFLICT ("index") DO UPDATE SET name = excluded.name`,
 		dbtypes.DBEngineSqlite: "",
 	}))
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func DeleteValidatorNames(validatorNames []uint64, tx *sqlx.Tx) error {
+func (store *sqlStore) DeleteValidatorNames(validatorNames []uint64, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, `DELETE FROM validator_names WHERE "index" IN (`)
 	argIdx := 0
@@ -60,7 +59,7 @@ func DeleteValidatorNames(validatorNames []uint64, tx *sqlx.Tx) error {
 		argIdx += 1
 	}
 	fmt.Fprint(&sql, ")")
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
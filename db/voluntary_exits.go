@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertVoluntaryExits(voluntaryExits []*dbtypes.VoluntaryExit, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertVoluntaryExits(voluntaryExits []*dbtypes.VoluntaryExit, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -48,14 +47,14 @@ func InsertVoluntaryExits(voluntaryExits []*dbtypes.VoluntaryExit, tx *sqlx.Tx)
 		dbtypes.DBEngineSqlite: "",
 	}))
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetVoluntaryExitForValidator(validator uint64) *dbtypes.VoluntaryExit {
+func (store *sqlStore) GetVoluntaryExitForValidator(validator uint64) *dbtypes.VoluntaryExit {
 	var sql strings.Builder
 	args := []any{
 		validator,
@@ -68,14 +67,14 @@ func GetVoluntaryExitForValidator(validator uint64) *dbtypes.VoluntaryExit {
 	`)
 
 	voluntaryExit := &dbtypes.VoluntaryExit{}
-	err := ReaderDb.Get(&voluntaryExit, sql.String(), args...)
+	err := ReaderDb.GetContext(store.ctx, &voluntaryExit, sql.String(), args...)
 	if err != nil {
 		return nil
 	}
 	return voluntaryExit
 }
 
-func GetVoluntaryExitsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.VoluntaryExitFilter) ([]*dbtypes.VoluntaryExit, uint64, error) {
+func (store *sqlStore) GetVoluntaryExitsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.VoluntaryExitFilter) ([]*dbtypes.VoluntaryExit, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
 	fmt.Fprint(&sql, `
@@ -154,7 +153,7 @@ func GetVoluntaryExitsFiltered(offset uint64, limit uint32, finalizedBlock uint6
 	fmt.Fprintf(&sql, ") AS t1")
 
 	voluntaryExits := []*dbtypes.VoluntaryExit{}
-	err := ReaderDb.Select(&voluntaryExits, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &voluntaryExits, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered voluntary exits: %v", err)
 		return nil, 0, err
@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
-func InsertWithdrawalRequests(elRequests []*dbtypes.WithdrawalRequest, tx *sqlx.Tx) error {
+func (store *sqlStore) InsertWithdrawalRequests(elRequests []*dbtypes.WithdrawalRequest, tx Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
@@ -53,14 +52,14 @@ func InsertWithdrawalRequests(elRequests []*dbtypes.WithdrawalRequest, tx *sqlx.
 		dbtypes.DBEngineSqlite: "",
 	}))
 
-	_, err := tx.Exec(sql.String(), args...)
+	_, err := sqlTx(tx).Exec(sql.String(), args...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetWithdrawalRequestsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.WithdrawalRequestFilter) ([]*dbtypes.WithdrawalRequest, uint64, error) {
+func (store *sqlStore) GetWithdrawalRequestsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.WithdrawalRequestFilter) ([]*dbtypes.WithdrawalRequest, uint64, error) {
 	var sql strings.Builder
 	args := []interface{}{}
 	fmt.Fprint(&sql, `
@@ -153,7 +152,7 @@ func GetWithdrawalRequestsFiltered(offset uint64, limit uint32, finalizedBlock u
 	fmt.Fprintf(&sql, ") AS t1")
 
 	withdrawalRequests := []*dbtypes.WithdrawalRequest{}
-	err := ReaderDb.Select(&withdrawalRequests, sql.String(), args...)
+	err := ReaderDb.SelectContext(store.ctx, &withdrawalRequests, sql.String(), args...)
 	if err != nil {
 		logger.Errorf("Error while fetching filtered withdrawal requests: %v", err)
 		return nil, 0, err
@@ -48,6 +48,8 @@ type Slot struct {
 	EthBlockExtraText     string     `db:"eth_block_extra_text"`
 	SyncParticipation     float32    `db:"sync_participation"`
 	ForkId                uint64     `db:"fork_id"`
+	ClClient              uint8      `db:"cl_client"`
+	ElClient              uint8      `db:"el_client"`
 }
 
 type Epoch struct {
@@ -70,6 +72,124 @@ type Epoch struct {
 	BLSChangeCount        uint64  `db:"bls_change_count"`
 	EthTransactionCount   uint64  `db:"eth_transaction_count"`
 	SyncParticipation     float32 `db:"sync_participation"`
+	BlobCount             uint64  `db:"blob_count"`
+}
+
+// chart rollup periods (bucket size in seconds)
+const (
+	ChartRollupPeriodHourly uint32 = 3600
+	ChartRollupPeriodDaily  uint32 = 86400
+)
+
+// ChartRollup is a pre-aggregated hourly / daily bucket of finalized epochs for the network charts.
+// the participation values are averages of the per epoch rates, validator count & balance are the values of the last epoch.
+type ChartRollup struct {
+	Period              uint32  `db:"period"`
+	Time                uint64  `db:"time"`
+	FirstEpoch          uint64  `db:"first_epoch"`
+	LastEpoch           uint64  `db:"last_epoch"`
+	EpochCount          uint32  `db:"epoch_count"`
+	ValidatorCount      uint64  `db:"validator_count"`
+	ValidatorBalance    uint64  `db:"validator_balance"`
+	TargetParticipation float32 `db:"target_participation"`
+	HeadParticipation   float32 `db:"head_participation"`
+	TotalParticipation  float32 `db:"total_participation"`
+	SyncParticipation   float32 `db:"sync_participation"`
+	BlockCount          uint32  `db:"block_count"`
+	OrphanedCount       uint32  `db:"orphaned_count"`
+	DepositCount        uint32  `db:"deposit_count"`
+	ExitCount           uint32  `db:"exit_count"`
+	BlobCount           uint32  `db:"blob_count"`
+}
+
+// FinalityIncident is a period of non-finality observed by the finality monitor.
+// forks & leak entities are json encoded lists of FinalityIncidentFork & FinalityIncidentEntity.
+type FinalityIncident struct {
+	StartEpoch          uint64  `db:"start_epoch"`
+	EndEpoch            *uint64 `db:"end_epoch"`
+	LastEpoch           uint64  `db:"last_epoch"`
+	FinalizedEpoch      uint64  `db:"finalized_epoch"`
+	EndFinalizedEpoch   *uint64 `db:"end_finalized_epoch"`
+	MaxDistance         uint32  `db:"max_distance"`
+	LeakEpochs          uint32  `db:"leak_epochs"`
+	ParticipationEpochs uint32  `db:"participation_epochs"`
+	ParticipationAvg    float32 `db:"participation_avg"`
+	ParticipationMin    float32 `db:"participation_min"`
+	MaxForks            uint32  `db:"max_forks"`
+	Forks               string  `db:"forks"`
+	OfflineValidators   uint64  `db:"offline_validators"`
+	OfflineBalance      uint64  `db:"offline_balance"`
+	LeakEstimate        uint64  `db:"leak_estimate"`
+	LeakEntities        string  `db:"leak_entities"`
+}
+
+type FinalityIncidentFork struct {
+	HeadSlot uint64   `json:"head_slot"`
+	HeadRoot []byte   `json:"head_root"`
+	Clients  []string `json:"clients"`
+}
+
+type FinalityIncidentEntity struct {
+	Name              string `json:"name"`
+	OfflineValidators uint64 `json:"offline_validators"`
+	OfflineBalance    uint64 `json:"offline_balance"`
+	LeakEstimate      uint64 `json:"leak_estimate"`
+}
+
+// BlockTiming is the arrival time of a block on a single client, delays are in milliseconds relative to the slot start.
+type BlockTiming struct {
+	Root       []byte `db:"root"`
+	Slot       uint64 `db:"slot"`
+	ClientName string `db:"client_name"`
+	SeenDelay  int64  `db:"seen_delay"`
+	HeadDelay  *int64 `db:"head_delay"`
+}
+
+type LateBlock struct {
+	Slot         uint64  `db:"slot"`
+	Root         []byte  `db:"root"`
+	Proposer     uint64  `db:"proposer"`
+	Status       uint8   `db:"status"`
+	ClientCount  uint64  `db:"client_count"`
+	MinSeenDelay int64   `db:"min_seen_delay"`
+	AvgSeenDelay float64 `db:"avg_seen_delay"`
+	MaxSeenDelay int64   `db:"max_seen_delay"`
+}
+
+type BlockTimingClientStats struct {
+	ClientName     string  `db:"client_name"`
+	BlockCount     uint64  `db:"block_count"`
+	LateCount      uint64  `db:"late_count"`
+	AvgSeenDelay   float64 `db:"avg_seen_delay"`
+	MaxSeenDelay   int64   `db:"max_seen_delay"`
+	HeadCount      uint64  `db:"head_count"`
+	AvgSwitchDelay float64 `db:"avg_switch_delay"`
+}
+
+type MissedSlotRange struct {
+	FirstSlot uint64 `db:"first_slot"`
+	LastSlot  uint64 `db:"last_slot"`
+}
+
+type ClientDiversity struct {
+	Epoch      uint64 `db:"epoch"`
+	ClClient   uint8  `db:"cl_client"`
+	ElClient   uint8  `db:"el_client"`
+	BlockCount uint64 `db:"block_count"`
+}
+
+type ClientDiversityProposer struct {
+	Proposer   uint64 `db:"proposer"`
+	ClClient   uint8  `db:"cl_client"`
+	ElClient   uint8  `db:"el_client"`
+	BlockCount uint64 `db:"block_count"`
+}
+
+type UnclassifiedSlot struct {
+	Slot              uint64 `db:"slot"`
+	Root              []byte `db:"root"`
+	GraffitiText      string `db:"graffiti_text"`
+	EthBlockExtraText string `db:"eth_block_extra_text"`
 }
 
 type OrphanedBlock struct {
@@ -80,6 +200,15 @@ type OrphanedBlock struct {
 	BlockSSZ  []byte `db:"block_ssz"`
 }
 
+type BlockBody struct {
+	Root      []byte `db:"root"`
+	Slot      uint64 `db:"slot"`
+	HeaderVer uint64 `db:"header_ver"`
+	HeaderSSZ []byte `db:"header_ssz"`
+	BlockVer  uint64 `db:"block_ver"`
+	BlockSSZ  []byte `db:"block_ssz"`
+}
+
 type SlotAssignment struct {
 	Slot     uint64 `db:"slot"`
 	Proposer uint64 `db:"proposer"`
@@ -194,6 +323,44 @@ type MevBlock struct {
 	BlockValueGwei uint64 `db:"block_value_gwei"`
 }
 
+type MevBlockStats struct {
+	PayloadCount  uint64 `db:"payload_count"`
+	ProposedCount uint64 `db:"proposed_count"`
+	ProposedValue uint64 `db:"proposed_value"`
+	MedianValue   uint64 `db:"-"`
+	MissedCount   uint64 `db:"missed_count"`
+	ReplacedCount uint64 `db:"replaced_count"`
+	FirstSlot     uint64 `db:"first_slot"`
+	LastSlot      uint64 `db:"last_slot"`
+}
+
+type MevBuilderStats struct {
+	BuilderPubkey []byte `db:"builder_pubkey"`
+	BlockCount    uint64 `db:"block_count"`
+	BlockValue    uint64 `db:"block_value"`
+	LastSlot      uint64 `db:"last_slot"`
+}
+
+type MevRelayStats struct {
+	SeenbyRelays uint64 `db:"seenby_relays"`
+	BlockCount   uint64 `db:"block_count"`
+	BlockValue   uint64 `db:"block_value"`
+}
+
+type MevProposerStats struct {
+	Proposer   uint64 `db:"proposer"`
+	BlockCount uint64 `db:"block_count"`
+	MevCount   uint64 `db:"mev_count"`
+	MevValue   uint64 `db:"mev_value"`
+}
+
+type MevDailyStats struct {
+	Day        uint64 `db:"day"`
+	BlockCount uint64 `db:"block_count"`
+	MevCount   uint64 `db:"mev_count"`
+	MevValue   uint64 `db:"mev_value"`
+}
+
 type DepositTx struct {
 	Index                 uint64 `db:"deposit_index"`
 	BlockNumber           uint64 `db:"block_number"`
@@ -41,3 +41,17 @@ type SearchAheadValidatorNameResult []struct {
 	Name  string `db:"name"`
 	Count uint64 `db:"count"`
 }
+
+type SearchSlotResult struct {
+	Slot       uint64     `db:"slot"`
+	Root       []byte     `db:"root"`
+	StateRoot  []byte     `db:"state_root"`
+	ExecHash   []byte     `db:"eth_block_hash"`
+	ExecNumber *uint64    `db:"eth_block_number"`
+	Status     SlotStatus `db:"status"`
+}
+
+type SearchGraffitiCountResult struct {
+	Graffiti string `db:"graffiti"`
+	Count    uint64 `db:"count"`
+}
@@ -8,6 +8,14 @@ type IndexerPruneState struct {
 	Epoch uint64 `json:"epoch"`
 }
 
+type IndexerBootstrapState struct {
+	Epoch           uint64 `json:"epoch"`
+	BackfillStarted bool   `json:"backfill_started"`
+	CheckpointSlot  uint64 `json:"checkpoint_slot"`
+	CheckpointRoot  []byte `json:"checkpoint_root"`
+	StateRoot       []byte `json:"state_root"`
+}
+
 type IndexerForkState struct {
 	ForkId    uint64 `json:"fork_id"`
 	Finalized uint64 `json:"finalized"`
@@ -18,3 +26,38 @@ type DepositIndexerState struct {
 	HeadBlock    uint64 `json:"head_block"`
 	DepositIndex uint64 `json:"deposit_index"`
 }
+
+type IndexerRangeBackfillState struct {
+	StartEpoch   uint64   `json:"start_epoch"`
+	EndEpoch     uint64   `json:"end_epoch"`
+	Stages       []string `json:"stages"`
+	Concurrency  uint64   `json:"concurrency"`
+	NextEpoch    uint64   `json:"next_epoch"`
+	FailedEpochs []uint64 `json:"failed_epochs"`
+	Completed    bool     `json:"completed"`
+}
+
+type IndexerEraImportState struct {
+	LastFile             string  `json:"last_file"`
+	NextSlot             uint64  `json:"next_slot"`
+	DepositIndex         *uint64 `json:"deposit_index"`
+	DepositIndexInclNext bool    `json:"deposit_index_incl_next"`
+}
+
+type IndexerEraExportState struct {
+	NextEra uint64 `json:"next_era"`
+}
+
+type RetentionState struct {
+	MevBlocksBefore   uint64 `json:"mev_blocks"`
+	MissedSlotsBefore uint64 `json:"missed_slots"`
+}
+
+type ChartRollupState struct {
+	Epoch uint64 `json:"epoch"`
+}
+
+type ChartRollupBlobBackfillState struct {
+	NextEpoch uint64 `json:"next_epoch"`
+	EndEpoch  uint64 `json:"end_epoch"`
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/handlers/adminapi"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// number of blocks & pending signature lookups shown on the indexer admin page
+const adminIndexerBlockLimit = 320
+const adminIndexerTxSigLimit = 50
+
+// AdminIndexer will return the "indexer status" admin page using a go template
+// GET shows the indexer internals, POST handles the login & admin actions.
+func AdminIndexer(w http.ResponseWriter, r *http.Request) {
+	var adminIndexerTemplateFiles = append(layoutTemplateFiles,
+		"admin/indexer.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(adminIndexerTemplateFiles...)
+	data := InitPageData(w, r, "admin", "/admin/indexer", "Indexer Status", adminIndexerTemplateFiles)
+
+	var pageData *models.AdminIndexerPageData
+	if r.Method == http.MethodPost && r.FormValue("action") == "login" {
+		token := r.FormValue("token")
+		if adminapi.CheckAuthToken(token) {
+			adminapi.SetAuthCookie(w, r, token)
+			http.Redirect(w, r, utils.GetBasePath()+r.URL.Path, http.StatusSeeOther)
+			return
+		}
+
+		pageData = &models.AdminIndexerPageData{
+			LoginRequired: true,
+			LoginFailed:   true,
+		}
+	} else if !adminapi.IsPageAuthorized(r) {
+		pageData = &models.AdminIndexerPageData{
+			LoginRequired: true,
+		}
+	} else {
+		var actionResult string
+		var actionErr error
+		if r.Method == http.MethodPost {
+			actionResult, actionErr = handleAdminIndexerAction(r)
+		}
+
+		pageData = buildAdminIndexerPageData()
+		pageData.ActionResult = actionResult
+		if actionErr != nil {
+			pageData.ActionError = actionErr.Error()
+		}
+	}
+	data.Data = pageData
+
+	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("Cache-Control", "no-store")
+	if pageData.LoginRequired {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	if handleTemplateError(w, r, "admin_indexer.go", "Indexer Status", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func handleAdminIndexerAction(r *http.Request) (string, error) {
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+
+	switch r.FormValue("action") {
+	case "resync":
+		epoch, err := strconv.ParseUint(r.FormValue("epoch"), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid epoch: %v", err)
+		}
+		forceUpdate := r.FormValue("force") != ""
+
+		err = beaconIndexer.ResyncFromEpoch(phase0.Epoch(epoch), forceUpdate)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("synchronization restarted from epoch %v", epoch), nil
+
+	case "refinalize":
+		epoch, err := strconv.ParseUint(r.FormValue("epoch"), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid epoch: %v", err)
+		}
+
+		err = beaconIndexer.RerunEpochFinalization(phase0.Epoch(epoch))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("finalization of epoch %v scheduled", epoch), nil
+
+	case "refresh_names":
+		validatorNames := services.GlobalBeaconService.GetValidatorNames()
+		go func() {
+			err := validatorNames.RefreshValidatorNames()
+			if err != nil {
+				logrus.Errorf("failed refreshing validator names: %v", err)
+			}
+		}()
+		return "validator names refresh started", nil
+
+	default:
+		return "", fmt.Errorf("unknown action")
+	}
+}
+
+func buildAdminIndexerPageData() *models.AdminIndexerPageData {
+	logrus.Debugf("indexer admin page called")
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	cacheFinalizedEpoch, prunedEpoch := beaconIndexer.GetBlockCacheState()
+	syncStatus := beaconIndexer.GetSynchronizerStatus()
+
+	pageData := &models.AdminIndexerPageData{
+		CurrentEpoch:        uint64(chainState.CurrentEpoch()),
+		FinalizedEpoch:      uint64(finalizedEpoch),
+		CacheFinalizedEpoch: uint64(cacheFinalizedEpoch),
+		PrunedEpoch:         uint64(prunedEpoch),
+		SyncDisabled:        syncStatus.Disabled,
+		SyncRunning:         syncStatus.Running,
+		SyncCurrentEpoch:    uint64(syncStatus.CurrentEpoch),
+		SyncRetryCount:      uint64(syncStatus.RetryCount),
+		SyncForceUpdate:     syncStatus.ForceUpdateEnd > syncStatus.ForceUpdateStart,
+		SyncForceStart:      uint64(syncStatus.ForceUpdateStart),
+		SyncForceEnd:        uint64(syncStatus.ForceUpdateEnd),
+		ValidatorNamesCount: services.GlobalBeaconService.GetValidatorNamesCount(),
+	}
+
+	// range backfill
+	if backfillStatus := beaconIndexer.GetRangeBackfillStatus(); backfillStatus != nil {
+		pageData.BackfillStarted = true
+		pageData.BackfillRunning = backfillStatus.Running
+		pageData.BackfillCompleted = backfillStatus.Completed
+		pageData.BackfillStartEpoch = uint64(backfillStatus.StartEpoch)
+		pageData.BackfillEndEpoch = uint64(backfillStatus.EndEpoch)
+		pageData.BackfillNextEpoch = uint64(backfillStatus.NextEpoch)
+		pageData.BackfillStages = strings.Join(backfillStatus.Stages, ", ")
+		pageData.BackfillFailedCount = uint64(len(backfillStatus.FailedEpochs))
+	}
+
+	// block cache
+	blocks, totalBlockCount := beaconIndexer.GetBlockCacheStatus(adminIndexerBlockLimit)
+	pageData.BlockTotalCount = uint64(totalBlockCount)
+	for _, block := range blocks {
+		processingStatus := "unprocessed"
+		switch block.ProcessingStatus {
+		case dbtypes.UnfinalizedBlockStatusPruned:
+			processingStatus = "pruned"
+		case dbtypes.UnfinalizedBlockStatusProcessed:
+			processingStatus = "processed"
+		}
+
+		pageData.Blocks = append(pageData.Blocks, &models.AdminIndexerPageDataBlock{
+			Slot:             uint64(block.Slot),
+			Root:             block.Root[:],
+			ForkId:           uint64(block.ForkId),
+			HasHeader:        block.HasHeader,
+			HasBody:          block.HasBody,
+			IsCanonical:      block.IsCanonical,
+			InFinalizedDb:    block.InFinalizedDb,
+			InUnfinalizedDb:  block.InUnfinalizedDb,
+			ProcessingStatus: processingStatus,
+			SeenBy:           block.SeenBy,
+		})
+	}
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+
+	// epoch cache
+	for _, epoch := range beaconIndexer.GetEpochCacheStatus() {
+		pageData.Epochs = append(pageData.Epochs, &models.AdminIndexerPageDataEpoch{
+			Epoch:         uint64(epoch.Epoch),
+			DependentRoot: epoch.DependentRoot[:],
+			Ready:         epoch.Ready,
+			Pruned:        epoch.Pruned,
+			InDb:          epoch.InDb,
+			RequestedBy:   epoch.RequestedBy,
+			StateStatus:   epoch.StateStatus,
+			StateClient:   epoch.StateClient,
+			StateStarted:  epoch.StateStarted,
+			StateRetries:  epoch.StateRetries,
+		})
+	}
+	pageData.EpochCount = uint64(len(pageData.Epochs))
+
+	// fork cache
+	for _, forkHead := range beaconIndexer.GetForkHeads() {
+		fork := &models.AdminIndexerPageDataFork{
+			ForkId: uint64(forkHead.ForkId),
+		}
+		if forkHead.Fork != nil {
+			baseSlot, baseRoot := forkHead.Fork.GetBase()
+			leafSlot, leafRoot := forkHead.Fork.GetLeaf()
+			fork.ParentFork = uint64(forkHead.Fork.GetParent())
+			fork.BaseSlot = uint64(baseSlot)
+			fork.BaseRoot = baseRoot[:]
+			fork.LeafSlot = uint64(leafSlot)
+			fork.LeafRoot = leafRoot[:]
+		}
+		if forkHead.Block != nil {
+			fork.HeadSlot = uint64(forkHead.Block.Slot)
+			fork.HeadRoot = forkHead.Block.Root[:]
+		}
+		pageData.Forks = append(pageData.Forks, fork)
+	}
+	pageData.ForkCount = uint64(len(pageData.Forks))
+
+	// deposit indexer
+	if depositIndexer := services.GlobalBeaconService.GetDepositIndexer(); depositIndexer != nil {
+		if depositState := depositIndexer.GetState(); depositState != nil {
+			pageData.DepositStateLoaded = true
+			pageData.DepositFinalBlock = depositState.FinalBlock
+			pageData.DepositHeadBlock = depositState.HeadBlock
+			pageData.DepositIndex = depositState.DepositIndex
+		}
+	}
+
+	// mev relay indexer
+	if mevIndexer := services.GlobalBeaconService.GetMevRelayIndexer(); mevIndexer != nil {
+		pageData.MevIndexerEnabled = true
+		pageData.MevLastRefresh = mevIndexer.GetLastRefresh()
+		for _, relay := range mevIndexer.GetRelayStatus() {
+			pageData.MevRelays = append(pageData.MevRelays, &models.AdminIndexerPageDataMevRelay{
+				Index:          relay.Index,
+				Name:           relay.Name,
+				LastLoadedSlot: relay.LastLoadedSlot,
+			})
+		}
+	}
+
+	// pending tx signature lookups
+	pageData.PendingTxSigCount = db.GetPendingFunctionSignatureCount()
+	for _, pendingSig := range db.GetPendingFunctionSignatures(adminIndexerTxSigLimit) {
+		pageData.PendingTxSigs = append(pageData.PendingTxSigs, &models.AdminIndexerPageDataTxSig{
+			Bytes:     pendingSig.Bytes,
+			QueueTime: time.Unix(int64(pendingSig.QueueTime), 0),
+		})
+	}
+
+	return pageData
+}
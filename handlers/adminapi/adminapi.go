@@ -0,0 +1,81 @@
+package adminapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// apiError is the error response format used by the admin api.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// AuthCookieName is the name of the cookie that holds the admin token for the admin pages.
+const AuthCookieName = "dora_admin_token"
+
+// AuthMiddleware rejects all requests that do not carry the configured admin api token.
+// the token is accepted as bearer token in the Authorization header.
+func AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !CheckAuthToken(token) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CheckAuthToken checks the given token against the configured admin api token.
+func CheckAuthToken(token string) bool {
+	authToken := utils.Config.AdminApi.AuthToken
+	return authToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) == 1
+}
+
+// IsPageAuthorized checks if a request to an admin page carries the admin token in the auth cookie or Authorization header.
+func IsPageAuthorized(r *http.Request) bool {
+	if cookie, err := r.Cookie(AuthCookieName); err == nil && CheckAuthToken(cookie.Value) {
+		return true
+	}
+
+	return CheckAuthToken(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+}
+
+// SetAuthCookie stores the admin token in a cookie for the admin pages.
+// the cookie is restricted to same-site requests, so actions can't be triggered from other sites.
+func SetAuthCookie(w http.ResponseWriter, r *http.Request, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     AuthCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// writeJson writes the given value as json response.
+func writeJson(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logrus.WithError(err).Warnf("error encoding admin api response")
+	}
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&apiError{
+		Code:    code,
+		Message: message,
+	})
+}
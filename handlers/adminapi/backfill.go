@@ -0,0 +1,115 @@
+package adminapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+)
+
+type backfillRequest struct {
+	StartEpoch  *uint64  `json:"start_epoch"`
+	EndEpoch    *uint64  `json:"end_epoch"`
+	Stages      []string `json:"stages"`
+	Concurrency int      `json:"concurrency"`
+}
+
+type backfillResponse struct {
+	Running         bool      `json:"running"`
+	Completed       bool      `json:"completed"`
+	StartEpoch      uint64    `json:"start_epoch"`
+	EndEpoch        uint64    `json:"end_epoch"`
+	Stages          []string  `json:"stages"`
+	Concurrency     int       `json:"concurrency"`
+	NextEpoch       uint64    `json:"next_epoch"`
+	ProcessedEpochs uint64    `json:"processed_epochs"`
+	FailedEpochs    []uint64  `json:"failed_epochs"`
+	Progress        float64   `json:"progress"`
+	StartTime       time.Time `json:"start_time"`
+	LastError       string    `json:"last_error,omitempty"`
+}
+
+// Backfill will return the progress of the current or last range backfill.
+// GET /api/admin/backfill
+func Backfill(w http.ResponseWriter, r *http.Request) {
+	status := services.GlobalBeaconService.GetBeaconIndexer().GetRangeBackfillStatus()
+	if status == nil {
+		writeError(w, http.StatusNotFound, "no range backfill started")
+		return
+	}
+
+	writeJson(w, getBackfillResponse(status))
+}
+
+// StartBackfill will start reindexing a range of finalized epochs.
+// POST /api/admin/backfill
+func StartBackfill(w http.ResponseWriter, r *http.Request) {
+	request := &backfillRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if request.StartEpoch == nil || request.EndEpoch == nil {
+		writeError(w, http.StatusBadRequest, "start_epoch and end_epoch are required")
+		return
+	}
+
+	stages, err := beacon.ParseRangeBackfillStages(request.Stages)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+	err = beaconIndexer.StartRangeBackfill(phase0.Epoch(*request.StartEpoch), phase0.Epoch(*request.EndEpoch), stages, request.Concurrency)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJson(w, getBackfillResponse(beaconIndexer.GetRangeBackfillStatus()))
+}
+
+// StopBackfill will cancel the running range backfill.
+// DELETE /api/admin/backfill
+func StopBackfill(w http.ResponseWriter, r *http.Request) {
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+	if err := beaconIndexer.StopRangeBackfill(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJson(w, getBackfillResponse(beaconIndexer.GetRangeBackfillStatus()))
+}
+
+func getBackfillResponse(status *beacon.RangeBackfillStatus) *backfillResponse {
+	response := &backfillResponse{
+		Running:         status.Running,
+		Completed:       status.Completed,
+		StartEpoch:      uint64(status.StartEpoch),
+		EndEpoch:        uint64(status.EndEpoch),
+		Stages:          status.Stages,
+		Concurrency:     status.Concurrency,
+		NextEpoch:       uint64(status.NextEpoch),
+		ProcessedEpochs: status.ProcessedEpochs,
+		FailedEpochs:    make([]uint64, len(status.FailedEpochs)),
+		StartTime:       status.StartTime,
+		LastError:       status.LastError,
+	}
+	for idx, epoch := range status.FailedEpochs {
+		response.FailedEpochs[idx] = uint64(epoch)
+	}
+
+	if status.Completed {
+		response.Progress = 100
+	} else if totalEpochs := status.EndEpoch - status.StartEpoch + 1; status.NextEpoch > status.StartEpoch {
+		response.Progress = float64(status.NextEpoch-status.StartEpoch) * 100 / float64(totalEpochs)
+	}
+
+	return response
+}
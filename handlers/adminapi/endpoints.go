@@ -0,0 +1,196 @@
+package adminapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ethpandaops/dora/clients/clienthealth"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/types"
+)
+
+type endpointsResponse struct {
+	Consensus []*endpointInfo `json:"consensus"`
+	Execution []*endpointInfo `json:"execution"`
+}
+
+type endpointInfo struct {
+	Index          uint16          `json:"index"`
+	Name           string          `json:"name"`
+	Url            string          `json:"url"`
+	Status         string          `json:"status"`
+	Version        string          `json:"version"`
+	HeadSlot       uint64          `json:"head_slot,omitempty"`
+	HeadNumber     uint64          `json:"head_number,omitempty"`
+	Priority       int             `json:"priority"`
+	Archive        bool            `json:"archive"`
+	SkipValidators bool            `json:"skip_validators,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	Health         *endpointHealth `json:"health"`
+}
+
+type endpointHealth struct {
+	Score        float64 `json:"score"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	Requests     int     `json:"requests"`
+	ErrorRate    float64 `json:"error_rate"`
+	TimeoutRate  float64 `json:"timeout_rate"`
+	CircuitOpen  bool    `json:"circuit_open"`
+}
+
+type endpointUpdateRequest struct {
+	Priority *int  `json:"priority"`
+	Archive  *bool `json:"archive"`
+}
+
+// Endpoints will return the list of all consensus & execution endpoints.
+// GET /api/admin/endpoints
+func Endpoints(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, getEndpointsResponse())
+}
+
+// AddEndpoint will add a new consensus or execution endpoint.
+// POST /api/admin/endpoints/{type}
+func AddEndpoint(w http.ResponseWriter, r *http.Request) {
+	endpoint := &types.EndpointConfig{}
+	if err := json.NewDecoder(r.Body).Decode(endpoint); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	var err error
+	switch mux.Vars(r)["type"] {
+	case "consensus":
+		_, err = services.GlobalBeaconService.AddConsensusEndpoint(endpoint)
+	case "execution":
+		_, err = services.GlobalBeaconService.AddExecutionEndpoint(endpoint)
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint type")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJson(w, getEndpointsResponse())
+}
+
+// UpdateEndpoint will update the priority & archive flag of an endpoint.
+// PATCH /api/admin/endpoints/{type}/{name}
+func UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
+	request := &endpointUpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	vars := mux.Vars(r)
+	update := &services.EndpointUpdate{
+		Priority: request.Priority,
+		Archive:  request.Archive,
+	}
+
+	var err error
+	switch vars["type"] {
+	case "consensus":
+		err = services.GlobalBeaconService.UpdateConsensusEndpoint(vars["name"], update)
+	case "execution":
+		err = services.GlobalBeaconService.UpdateExecutionEndpoint(vars["name"], update)
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint type")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJson(w, getEndpointsResponse())
+}
+
+// RemoveEndpoint will stop & remove an endpoint.
+// DELETE /api/admin/endpoints/{type}/{name}
+func RemoveEndpoint(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var err error
+	switch vars["type"] {
+	case "consensus":
+		err = services.GlobalBeaconService.RemoveConsensusEndpoint(vars["name"])
+	case "execution":
+		err = services.GlobalBeaconService.RemoveExecutionEndpoint(vars["name"])
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint type")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJson(w, getEndpointsResponse())
+}
+
+func getEndpointsResponse() *endpointsResponse {
+	response := &endpointsResponse{
+		Consensus: []*endpointInfo{},
+		Execution: []*endpointInfo{},
+	}
+
+	for _, client := range services.GlobalBeaconService.GetConsensusClients() {
+		headSlot, _ := client.GetLastHead()
+		info := &endpointInfo{
+			Index:    client.GetIndex(),
+			Name:     client.GetName(),
+			Url:      client.GetEndpointConfig().URL,
+			Status:   client.GetStatus().String(),
+			Version:  client.GetVersion(),
+			HeadSlot: uint64(headSlot),
+		}
+		if indexerClient := services.GlobalBeaconService.GetConsensusIndexerClient(client); indexerClient != nil {
+			info.Priority = indexerClient.GetPriority()
+			info.Archive = indexerClient.IsArchive()
+			info.SkipValidators = indexerClient.IsSkipValidators()
+		}
+		if lastErr := client.GetLastClientError(); lastErr != nil {
+			info.LastError = lastErr.Error()
+		}
+		info.Health = getEndpointHealth(client.GetHealth().GetStats(), client.GetHealthScore())
+		response.Consensus = append(response.Consensus, info)
+	}
+
+	for _, client := range services.GlobalBeaconService.GetExecutionClients() {
+		headNumber, _ := client.GetLastHead()
+		info := &endpointInfo{
+			Index:      client.GetIndex(),
+			Name:       client.GetName(),
+			Url:        client.GetEndpointConfig().URL,
+			Status:     client.GetStatus().String(),
+			Version:    client.GetVersion(),
+			HeadNumber: headNumber,
+		}
+		info.Priority, info.Archive = services.GlobalBeaconService.GetExecutionClientInfo(client)
+		if lastErr := client.GetLastClientError(); lastErr != nil {
+			info.LastError = lastErr.Error()
+		}
+		info.Health = getEndpointHealth(client.GetHealth().GetStats(), client.GetHealthScore())
+		response.Execution = append(response.Execution, info)
+	}
+
+	return response
+}
+
+func getEndpointHealth(stats clienthealth.Stats, score float64) *endpointHealth {
+	return &endpointHealth{
+		Score:        score,
+		AvgLatencyMs: stats.AvgLatency.Milliseconds(),
+		Requests:     stats.Requests,
+		ErrorRate:    stats.ErrorRate,
+		TimeoutRate:  stats.TimeoutRate,
+		CircuitOpen:  stats.CircuitOpen,
+	}
+}
@@ -0,0 +1,62 @@
+package adminapi
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+type eraFileInfo struct {
+	Era      uint64 `json:"era"`
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+	Partial  bool   `json:"partial"`
+}
+
+// EraFiles will return the list of all exported era files.
+// GET /api/admin/era
+func EraFiles(w http.ResponseWriter, r *http.Request) {
+	eraFiles := []*eraFileInfo{}
+	for _, eraFile := range services.GlobalBeaconService.GetBeaconIndexer().GetEraExportFiles() {
+		eraFiles = append(eraFiles, &eraFileInfo{
+			Era:      eraFile.Era,
+			FileName: eraFile.FileName,
+			Size:     eraFile.Size,
+			Partial:  eraFile.Partial,
+		})
+	}
+
+	writeJson(w, eraFiles)
+}
+
+// EraFile will download the exported era file of the given era.
+// GET /api/admin/era/{era}
+func EraFile(w http.ResponseWriter, r *http.Request) {
+	eraNumber, err := strconv.ParseUint(mux.Vars(r)["era"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid era: %v", err))
+		return
+	}
+
+	// the partial file of the era in progress is written on demand
+	indexer := services.GlobalBeaconService.GetBeaconIndexer()
+	if err := indexer.ExportPartialEra(r.Context(), eraNumber); err != nil {
+		logrus.WithError(err).Warnf("failed exporting partial era %v", eraNumber)
+	}
+
+	filePath := indexer.GetEraExportFilePath(eraNumber)
+	if filePath == "" {
+		writeError(w, http.StatusNotFound, "era file not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(filePath)))
+	http.ServeFile(w, r, filePath)
+}
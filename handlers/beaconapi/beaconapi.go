@@ -0,0 +1,166 @@
+package beaconapi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const (
+	mediaTypeJson = "application/json"
+	mediaTypeSSZ  = "application/octet-stream"
+)
+
+// apiResponse is the common response envelope used by the beacon api.
+type apiResponse struct {
+	Version             string `json:"version,omitempty"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+	Data                any    `json:"data"`
+}
+
+// apiError is the error response format used by the beacon api.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeJson writes the given value as json response.
+func writeJson(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", mediaTypeJson)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logrus.WithError(err).Warnf("error encoding beacon api response")
+	}
+}
+
+// writeSSZ writes the given ssz encoded data as octet-stream response.
+func writeSSZ(w http.ResponseWriter, version string, data []byte) {
+	w.Header().Set("Content-Type", mediaTypeSSZ)
+	if version != "" {
+		w.Header().Set("Eth-Consensus-Version", version)
+	}
+	w.Write(data)
+}
+
+// writeError writes a beacon api compatible error response.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", mediaTypeJson)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&apiError{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// wantsSSZ checks the Accept header of the request and returns true if the client prefers ssz over json.
+func wantsSSZ(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	ranges := []mediaRange{}
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(strings.TrimSpace(part), ";")
+		mr := mediaRange{
+			mediaType: strings.ToLower(strings.TrimSpace(params[0])),
+			quality:   1,
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					mr.quality = q
+				}
+			}
+		}
+		ranges = append(ranges, mr)
+	}
+
+	sort.SliceStable(ranges, func(a, b int) bool {
+		return ranges[a].quality > ranges[b].quality
+	})
+
+	for _, mr := range ranges {
+		switch mr.mediaType {
+		case mediaTypeSSZ:
+			return true
+		case mediaTypeJson, "application/*", "*/*":
+			return false
+		}
+	}
+
+	return false
+}
+
+// checkCallLimit applies the frontend call rate limit to beacon api requests.
+func checkCallLimit(w http.ResponseWriter, r *http.Request, callCost uint) bool {
+	if err := services.GlobalCallRateLimiter.CheckCallLimit(r, callCost); err != nil {
+		writeError(w, http.StatusTooManyRequests, err.Error())
+		return false
+	}
+	return true
+}
+
+// proxyRequest forwards the request to the best ready beacon client.
+// this is used for all requests that can't be served from the indexer cache.
+func proxyRequest(w http.ResponseWriter, r *http.Request) {
+	if utils.Config.BeaconApiProxy.DisableFallthrough {
+		writeError(w, http.StatusNotFound, "not found in cache")
+		return
+	}
+
+	client := services.GlobalBeaconService.GetBeaconIndexer().GetReadyClient(true)
+	if client == nil {
+		writeError(w, http.StatusServiceUnavailable, "no beacon clients available")
+		return
+	}
+
+	rsp, err := client.GetClient().GetRPCClient().ProxyRequest(r.Context(), r.URL.RequestURI(), r.Header.Get("Accept"))
+	if err != nil {
+		logrus.WithError(err).WithField("client", client.GetClient().GetName()).Warnf("beacon api proxy request failed: %v", r.URL.Path)
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("upstream request failed: %v", err))
+		return
+	}
+	defer rsp.Body.Close()
+
+	for _, header := range []string{"Content-Type", "Eth-Consensus-Version"} {
+		if value := rsp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(rsp.StatusCode)
+	io.Copy(w, rsp.Body)
+}
+
+// parseRoot parses a 0x prefixed 32 byte hex string.
+func parseRoot(value string) (phase0.Root, bool) {
+	root := phase0.Root{}
+	if !strings.HasPrefix(value, "0x") || len(value) != 66 {
+		return root, false
+	}
+
+	rootBytes, err := hex.DecodeString(value[2:])
+	if err != nil {
+		return root, false
+	}
+
+	copy(root[:], rootBytes)
+	return root, true
+}
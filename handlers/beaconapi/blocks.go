@@ -0,0 +1,235 @@
+package beaconapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/services"
+)
+
+// resolveBlock resolves a beacon api block identifier (head, genesis, finalized, justified, slot or root).
+// returns nil if the block is not known to the indexer.
+func resolveBlock(ctx context.Context, blockId string) (*services.CombinedBlockResponse, error) {
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	var blockRoot phase0.Root
+	switch blockId {
+	case "head":
+		headBlock := services.GlobalBeaconService.GetBeaconIndexer().GetCanonicalHead(nil)
+		if headBlock == nil {
+			return nil, nil
+		}
+		blockRoot = headBlock.Root
+	case "genesis":
+		return services.GlobalBeaconService.GetSlotDetailsBySlot(ctx, 0)
+	case "finalized":
+		_, blockRoot = chainState.GetFinalizedCheckpoint()
+	case "justified":
+		_, blockRoot = chainState.GetJustifiedCheckpoint()
+	default:
+		if root, ok := parseRoot(blockId); ok {
+			blockRoot = root
+			break
+		}
+
+		slot, err := strconv.ParseUint(blockId, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block id: %v", blockId)
+		}
+
+		block, err := services.GlobalBeaconService.GetSlotDetailsBySlot(ctx, phase0.Slot(slot))
+		if err != nil || block == nil || block.Orphaned {
+			return nil, err
+		}
+		return block, nil
+	}
+
+	if bytes.Equal(blockRoot[:], consensus.NullRoot[:]) {
+		return nil, nil
+	}
+
+	return services.GlobalBeaconService.GetSlotDetailsByBlockroot(ctx, blockRoot)
+}
+
+// isFinalizedBlock returns true if the block is canonical and part of the finalized chain.
+func isFinalizedBlock(block *services.CombinedBlockResponse) bool {
+	if block.Orphaned || block.Header == nil {
+		return false
+	}
+
+	return block.Header.Message.Slot <= services.GlobalBeaconService.GetChainState().GetFinalizedSlot()
+}
+
+// getVersionedBlockData returns the fork specific signed beacon block.
+func getVersionedBlockData(block *spec.VersionedSignedBeaconBlock) (any, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		return block.Phase0, nil
+	case spec.DataVersionAltair:
+		return block.Altair, nil
+	case spec.DataVersionBellatrix:
+		return block.Bellatrix, nil
+	case spec.DataVersionCapella:
+		return block.Capella, nil
+	case spec.DataVersionDeneb:
+		return block.Deneb, nil
+	case spec.DataVersionElectra:
+		return block.Electra, nil
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+}
+
+// BlockHeader serves /eth/v1/beacon/headers/{block_id}
+func BlockHeader(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 1) {
+		return
+	}
+
+	block, err := resolveBlock(r.Context(), mux.Vars(r)["block_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if block == nil || block.Header == nil {
+		proxyRequest(w, r)
+		return
+	}
+
+	writeJson(w, &apiResponse{
+		Finalized: isFinalizedBlock(block),
+		Data: &v1.BeaconBlockHeader{
+			Root:      block.Root,
+			Canonical: !block.Orphaned,
+			Header:    block.Header,
+		},
+	})
+}
+
+// Block serves /eth/v2/beacon/blocks/{block_id}
+func Block(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 1) {
+		return
+	}
+
+	block, err := resolveBlock(r.Context(), mux.Vars(r)["block_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if block == nil || block.Block == nil {
+		proxyRequest(w, r)
+		return
+	}
+
+	blockData, err := getVersionedBlockData(block.Block)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	version := block.Block.Version.String()
+	if wantsSSZ(r) {
+		blockSSZ, err := services.GlobalBeaconService.GetBeaconIndexer().GetDynSSZ().MarshalSSZ(blockData)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed encoding block: %v", err))
+			return
+		}
+
+		writeSSZ(w, version, blockSSZ)
+		return
+	}
+
+	w.Header().Set("Eth-Consensus-Version", version)
+	writeJson(w, &apiResponse{
+		Version:   version,
+		Finalized: isFinalizedBlock(block),
+		Data:      blockData,
+	})
+}
+
+// BlobSidecars serves /eth/v1/beacon/blob_sidecars/{block_id}
+func BlobSidecars(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 2) {
+		return
+	}
+
+	block, err := resolveBlock(r.Context(), mux.Vars(r)["block_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if block == nil {
+		proxyRequest(w, r)
+		return
+	}
+
+	indices := map[uint64]bool{}
+	for _, indicesArg := range r.URL.Query()["indices"] {
+		for _, indexStr := range strings.Split(indicesArg, ",") {
+			index, err := strconv.ParseUint(strings.TrimSpace(indexStr), 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid blob index: %v", indexStr))
+				return
+			}
+			indices[index] = true
+		}
+	}
+
+	blobs, err := services.GlobalBeaconService.GetBlobSidecarsByBlockRoot(r.Context(), block.Root[:])
+	if err != nil {
+		proxyRequest(w, r)
+		return
+	}
+	if blobs == nil {
+		blobs = []*deneb.BlobSidecar{}
+	}
+
+	if len(indices) > 0 {
+		filteredBlobs := blobs[:0]
+		for _, blob := range blobs {
+			if indices[uint64(blob.Index)] {
+				filteredBlobs = append(filteredBlobs, blob)
+			}
+		}
+		blobs = filteredBlobs
+	}
+
+	if wantsSSZ(r) {
+		// blob sidecars are fixed size containers, so the ssz list is a plain concatenation
+		dynSsz := services.GlobalBeaconService.GetBeaconIndexer().GetDynSSZ()
+		blobsSSZ := []byte{}
+		for _, blob := range blobs {
+			blobSSZ, err := dynSsz.MarshalSSZ(blob)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed encoding blob sidecar: %v", err))
+				return
+			}
+			blobsSSZ = append(blobsSSZ, blobSSZ...)
+		}
+
+		version := ""
+		if block.Block != nil {
+			version = block.Block.Version.String()
+		}
+
+		writeSSZ(w, version, blobsSSZ)
+		return
+	}
+
+	writeJson(w, &apiResponse{
+		Finalized: isFinalizedBlock(block),
+		Data:      blobs,
+	})
+}
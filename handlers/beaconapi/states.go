@@ -0,0 +1,233 @@
+package beaconapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+// proposerDutiesResponse is the response format for the proposer duties endpoint.
+type proposerDutiesResponse struct {
+	DependentRoot       phase0.Root        `json:"dependent_root"`
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Data                []*v1.ProposerDuty `json:"data"`
+}
+
+// FinalityCheckpoints serves /eth/v1/beacon/states/{state_id}/finality_checkpoints
+// only the head state is served from cache, all other states are forwarded to a ready client.
+func FinalityCheckpoints(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 1) {
+		return
+	}
+
+	if mux.Vars(r)["state_id"] != "head" {
+		proxyRequest(w, r)
+		return
+	}
+
+	finality := services.GlobalBeaconService.GetChainState().GetFinality()
+	if finality == nil {
+		proxyRequest(w, r)
+		return
+	}
+
+	writeJson(w, &apiResponse{
+		Data: finality,
+	})
+}
+
+// Validators serves /eth/v1/beacon/states/{state_id}/validators
+// only the head state is served from the cached canonical validator set.
+func Validators(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 2) {
+		return
+	}
+
+	if mux.Vars(r)["state_id"] != "head" {
+		proxyRequest(w, r)
+		return
+	}
+
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	if len(validatorSet) == 0 {
+		proxyRequest(w, r)
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	statusFilter := []string{}
+	for _, statusArg := range urlArgs["status"] {
+		for _, status := range strings.Split(statusArg, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statusFilter = append(statusFilter, status)
+			}
+		}
+	}
+
+	var validators []*v1.Validator
+	if urlArgs.Has("id") {
+		validators = []*v1.Validator{}
+		var pubkeyMap map[phase0.BLSPubKey]*v1.Validator
+		for _, idArg := range urlArgs["id"] {
+			for _, validatorId := range strings.Split(idArg, ",") {
+				validator, err := resolveValidator(validatorSet, &pubkeyMap, strings.TrimSpace(validatorId))
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				if validator != nil {
+					validators = append(validators, validator)
+				}
+			}
+		}
+	} else {
+		validators = validatorSet
+	}
+
+	if len(statusFilter) > 0 {
+		filteredValidators := make([]*v1.Validator, 0, len(validators))
+		for _, validator := range validators {
+			if matchValidatorStatus(validator.Status, statusFilter) {
+				filteredValidators = append(filteredValidators, validator)
+			}
+		}
+		validators = filteredValidators
+	}
+
+	writeJson(w, &apiResponse{
+		Data: validators,
+	})
+}
+
+// Validator serves /eth/v1/beacon/states/{state_id}/validators/{validator_id}
+func Validator(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 1) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	if vars["state_id"] != "head" {
+		proxyRequest(w, r)
+		return
+	}
+
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	if len(validatorSet) == 0 {
+		proxyRequest(w, r)
+		return
+	}
+
+	var pubkeyMap map[phase0.BLSPubKey]*v1.Validator
+	validator, err := resolveValidator(validatorSet, &pubkeyMap, vars["validator_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if validator == nil {
+		writeError(w, http.StatusNotFound, "validator not found")
+		return
+	}
+
+	writeJson(w, &apiResponse{
+		Data: validator,
+	})
+}
+
+// ProposerDuties serves /eth/v1/validator/duties/proposer/{epoch}
+func ProposerDuties(w http.ResponseWriter, r *http.Request) {
+	if !checkCallLimit(w, r, 1) {
+		return
+	}
+
+	epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid epoch")
+		return
+	}
+
+	epochStats := services.GlobalBeaconService.GetBeaconIndexer().GetEpochStats(phase0.Epoch(epoch), nil)
+	if epochStats == nil {
+		proxyRequest(w, r)
+		return
+	}
+
+	epochStatsValues := epochStats.GetValues(true)
+	if epochStatsValues == nil || len(epochStatsValues.ProposerDuties) == 0 {
+		proxyRequest(w, r)
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	firstSlot := chainState.EpochToSlot(phase0.Epoch(epoch))
+
+	duties := make([]*v1.ProposerDuty, len(epochStatsValues.ProposerDuties))
+	for slotIdx, proposer := range epochStatsValues.ProposerDuties {
+		if int(proposer) >= len(validatorSet) {
+			// proposer not in cached validator set, can't resolve pubkey
+			proxyRequest(w, r)
+			return
+		}
+
+		duties[slotIdx] = &v1.ProposerDuty{
+			PubKey:         validatorSet[proposer].Validator.PublicKey,
+			Slot:           firstSlot + phase0.Slot(slotIdx),
+			ValidatorIndex: proposer,
+		}
+	}
+
+	writeJson(w, &proposerDutiesResponse{
+		DependentRoot: epochStats.GetDependentRoot(),
+		Data:          duties,
+	})
+}
+
+// resolveValidator resolves a validator by index or 0x prefixed pubkey.
+func resolveValidator(validatorSet []*v1.Validator, pubkeyMap *map[phase0.BLSPubKey]*v1.Validator, validatorId string) (*v1.Validator, error) {
+	if strings.HasPrefix(validatorId, "0x") {
+		pubkey := phase0.BLSPubKey{}
+		if err := pubkey.UnmarshalJSON([]byte(fmt.Sprintf("%q", validatorId))); err != nil {
+			return nil, fmt.Errorf("invalid validator pubkey: %v", validatorId)
+		}
+
+		if *pubkeyMap == nil {
+			*pubkeyMap = make(map[phase0.BLSPubKey]*v1.Validator, len(validatorSet))
+			for _, validator := range validatorSet {
+				(*pubkeyMap)[validator.Validator.PublicKey] = validator
+			}
+		}
+
+		return (*pubkeyMap)[pubkey], nil
+	}
+
+	index, err := strconv.ParseUint(validatorId, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator id: %v", validatorId)
+	}
+
+	if index >= uint64(len(validatorSet)) {
+		return nil, nil
+	}
+
+	return validatorSet[index], nil
+}
+
+// matchValidatorStatus checks if the validator state matches one of the status filters.
+// filters may either be a specific state (active_ongoing) or a state group (active).
+func matchValidatorStatus(state v1.ValidatorState, filters []string) bool {
+	stateStr := state.String()
+	for _, filter := range filters {
+		if stateStr == filter || strings.HasPrefix(stateStr, filter+"_") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type chartsRange struct {
+	key    string
+	label  string
+	days   uint64 // 0 = all data
+	period uint32
+}
+
+var chartsRanges = []*chartsRange{
+	{key: "7d", label: "7 days", days: 7, period: dbtypes.ChartRollupPeriodHourly},
+	{key: "30d", label: "30 days", days: 30, period: dbtypes.ChartRollupPeriodDaily},
+	{key: "90d", label: "90 days", days: 90, period: dbtypes.ChartRollupPeriodDaily},
+	{key: "1y", label: "1 year", days: 365, period: dbtypes.ChartRollupPeriodDaily},
+	{key: "all", label: "All", days: 0, period: dbtypes.ChartRollupPeriodDaily},
+}
+
+// maximum number of points per chart, longer ranges are merged into larger buckets
+const chartsMaxPoints = 400
+
+// size of the svg viewbox the chart points are scaled to
+const chartsWidth = 600
+const chartsHeight = 160
+
+type chartsSeriesDef struct {
+	label string
+	color string // bootstrap theme color
+	value func(rollup *dbtypes.ChartRollup) float64
+}
+
+type chartsSeriesValues struct {
+	label  string
+	color  string // bootstrap theme color
+	values []float64
+}
+
+// Charts will return the "charts" page using a go template
+func Charts(w http.ResponseWriter, r *http.Request) {
+	var chartsTemplateFiles = append(layoutTemplateFiles,
+		"charts/charts.html",
+		"_svg/chart.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(chartsTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/charts", "Charts", chartsTemplateFiles)
+
+	rangeKey := "30d"
+	if urlArgs := r.URL.Query(); urlArgs.Has("range") {
+		rangeKey = urlArgs.Get("range")
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getChartsPageData(rangeKey)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "charts.go", "Charts", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getChartsPageData(rangeKey string) (*models.ChartsPageData, error) {
+	var selectedRange *chartsRange
+	for _, chartRange := range chartsRanges {
+		if chartRange.key == rangeKey {
+			selectedRange = chartRange
+			break
+		}
+	}
+	if selectedRange == nil {
+		selectedRange = chartsRanges[1]
+	}
+
+	pageData := &models.ChartsPageData{}
+	pageCacheKey := fmt.Sprintf("charts:%v", selectedRange.key)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildChartsPageData(pageCall.CallCtx, selectedRange)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ChartsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildChartsPageData(ctx context.Context, selectedRange *chartsRange) (*models.ChartsPageData, time.Duration) {
+	logrus.Debugf("charts page called: %v", selectedRange.key)
+	specs := services.GlobalBeaconService.GetChainState().GetSpecs()
+
+	pageData := &models.ChartsPageData{
+		Range:         selectedRange.key,
+		RollupPending: services.GlobalChartRollupService.IsRollupPending(),
+	}
+	for _, chartRange := range chartsRanges {
+		pageData.Ranges = append(pageData.Ranges, &models.ChartsPageDataRange{
+			Key:    chartRange.key,
+			Label:  chartRange.label,
+			Active: chartRange == selectedRange,
+		})
+	}
+
+	now := time.Now()
+	firstTime := uint64(0)
+	if selectedRange.days > 0 {
+		firstTime = uint64(now.Add(-time.Duration(selectedRange.days) * 24 * time.Hour).Unix())
+	}
+
+	rollups := db.WithContext(ctx).GetChartRollups(selectedRange.period, firstTime, uint64(now.Unix()))
+	period := uint64(selectedRange.period)
+	if len(rollups) > chartsMaxPoints {
+		groupSize := (len(rollups) + chartsMaxPoints - 1) / chartsMaxPoints
+		rollups = mergeChartRollups(rollups, groupSize)
+		period *= uint64(groupSize)
+	}
+
+	switch period {
+	case uint64(dbtypes.ChartRollupPeriodHourly):
+		pageData.PeriodLabel = "hour"
+	case uint64(dbtypes.ChartRollupPeriodDaily):
+		pageData.PeriodLabel = "day"
+	default:
+		pageData.PeriodLabel = fmt.Sprintf("%v days", period/uint64(dbtypes.ChartRollupPeriodDaily))
+	}
+
+	cacheTimeout := specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	if len(rollups) == 0 {
+		return pageData, cacheTimeout
+	}
+
+	pageData.FirstTime = time.Unix(int64(rollups[0].Time), 0)
+	pageData.LastTime = time.Unix(int64(rollups[len(rollups)-1].Time), 0)
+	pageData.PointCount = uint64(len(rollups))
+
+	timeFormat := "2006-01-02"
+	if period < uint64(dbtypes.ChartRollupPeriodDaily) {
+		timeFormat = "2006-01-02 15:04"
+	}
+
+	slotsPerEpoch := float64(specs.SlotsPerEpoch)
+	pageData.Charts = []*models.ChartsPageDataChart{
+		buildChart("participation", "Participation", "%", rollups, timeFormat,
+			chartsSeriesDef{"Target", "primary", func(r *dbtypes.ChartRollup) float64 { return float64(r.TargetParticipation) * 100 }},
+			chartsSeriesDef{"Head", "success", func(r *dbtypes.ChartRollup) float64 { return float64(r.HeadParticipation) * 100 }},
+			chartsSeriesDef{"Total", "warning", func(r *dbtypes.ChartRollup) float64 { return float64(r.TotalParticipation) * 100 }},
+		),
+		buildChart("missed", "Missed slot rate", "%", rollups, timeFormat,
+			chartsSeriesDef{"Missed", "danger", func(r *dbtypes.ChartRollup) float64 {
+				slotCount := float64(r.EpochCount) * slotsPerEpoch
+				if slotCount == 0 || float64(r.BlockCount) >= slotCount {
+					return 0
+				}
+				return (slotCount - float64(r.BlockCount)) * 100 / slotCount
+			}},
+		),
+		buildChart("orphaned", "Orphan rate", "%", rollups, timeFormat,
+			chartsSeriesDef{"Orphaned", "info", func(r *dbtypes.ChartRollup) float64 {
+				if r.BlockCount+r.OrphanedCount == 0 {
+					return 0
+				}
+				return float64(r.OrphanedCount) * 100 / float64(r.BlockCount+r.OrphanedCount)
+			}},
+		),
+		buildChart("sync", "Sync committee participation", "%", rollups, timeFormat,
+			chartsSeriesDef{"Sync participation", "primary", func(r *dbtypes.ChartRollup) float64 { return float64(r.SyncParticipation) * 100 }},
+		),
+		buildChart("validators", "Active validators", "", rollups, timeFormat,
+			chartsSeriesDef{"Validators", "primary", func(r *dbtypes.ChartRollup) float64 { return float64(r.ValidatorCount) }},
+		),
+		buildChart("balance", "Total active balance", " ETH", rollups, timeFormat,
+			chartsSeriesDef{"Balance", "success", func(r *dbtypes.ChartRollup) float64 { return math.Round(float64(r.ValidatorBalance) / 1e9) }},
+		),
+		buildChart("deposits", fmt.Sprintf("Deposits & exits per %v", pageData.PeriodLabel), "", rollups, timeFormat,
+			chartsSeriesDef{"Deposits", "success", func(r *dbtypes.ChartRollup) float64 { return float64(r.DepositCount) }},
+			chartsSeriesDef{"Exits", "danger", func(r *dbtypes.ChartRollup) float64 { return float64(r.ExitCount) }},
+		),
+		buildChart("blobs", fmt.Sprintf("Blobs per %v", pageData.PeriodLabel), "", rollups, timeFormat,
+			chartsSeriesDef{"Blobs", "info", func(r *dbtypes.ChartRollup) float64 { return float64(r.BlobCount) }},
+		),
+	}
+
+	return pageData, cacheTimeout
+}
+
+// buildChart scales the series values of the rollups to svg polyline points.
+func buildChart(id string, title string, unit string, rollups []*dbtypes.ChartRollup, timeFormat string, seriesDefs ...chartsSeriesDef) *models.ChartsPageDataChart {
+	times := make([]uint64, len(rollups))
+	for j, rollup := range rollups {
+		times[j] = rollup.Time
+	}
+
+	series := make([]*chartsSeriesValues, len(seriesDefs))
+	for i, seriesDef := range seriesDefs {
+		series[i] = &chartsSeriesValues{
+			label:  seriesDef.label,
+			color:  seriesDef.color,
+			values: make([]float64, len(rollups)),
+		}
+		for j, rollup := range rollups {
+			series[i].values[j] = seriesDef.value(rollup)
+		}
+	}
+
+	return buildChartFromValues(id, title, unit, times, timeFormat, series)
+}
+
+// buildChartFromValues scales the series values to svg polyline points.
+// times holds the unix timestamps of the points, each series needs to hold a value per point.
+func buildChartFromValues(id string, title string, unit string, times []uint64, timeFormat string, series []*chartsSeriesValues) *models.ChartsPageDataChart {
+	chart := &models.ChartsPageDataChart{
+		Id:    id,
+		Title: title,
+	}
+
+	minValue, maxValue := math.MaxFloat64, -math.MaxFloat64
+	for _, seriesValues := range series {
+		for _, value := range seriesValues.values {
+			minValue = math.Min(minValue, value)
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+	if maxValue-minValue < 1e-9 {
+		// flat line, show it in the middle of the chart
+		padding := math.Max(math.Abs(maxValue)*0.01, 1)
+		minValue -= padding
+		maxValue += padding
+		if minValue < 0 && maxValue-padding >= 0 {
+			minValue = 0
+		}
+	}
+
+	chart.MinLabel = utils.FormatFloat(minValue, 2) + unit
+	chart.MaxLabel = utils.FormatFloat(maxValue, 2) + unit
+
+	firstTime := float64(times[0])
+	timeRange := float64(times[len(times)-1]) - firstTime
+	getX := func(idx int) float64 {
+		if timeRange == 0 {
+			return chartsWidth / 2
+		}
+		return (float64(times[idx]) - firstTime) * chartsWidth / timeRange
+	}
+
+	for _, seriesValues := range series {
+		points := make([]string, len(times))
+		for j := range times {
+			y := chartsHeight - (seriesValues.values[j]-minValue)*chartsHeight/(maxValue-minValue)
+			points[j] = fmt.Sprintf("%.2f,%.2f", getX(j), y)
+		}
+
+		chart.Series = append(chart.Series, &models.ChartsPageDataSeries{
+			Label:  seriesValues.label,
+			Color:  seriesValues.color,
+			Latest: utils.FormatFloat(seriesValues.values[len(times)-1], 2) + unit,
+			Points: strings.Join(points, " "),
+		})
+	}
+
+	// hover areas between the midpoints of the neighbouring points
+	for j, pointTime := range times {
+		startX, endX := 0.0, float64(chartsWidth)
+		if j > 0 {
+			startX = (getX(j-1) + getX(j)) / 2
+		}
+		if j < len(times)-1 {
+			endX = (getX(j) + getX(j+1)) / 2
+		}
+
+		title := strings.Builder{}
+		title.WriteString(time.Unix(int64(pointTime), 0).UTC().Format(timeFormat))
+		for _, seriesValues := range series {
+			fmt.Fprintf(&title, "\n%v: %v%v", seriesValues.label, utils.FormatFloat(seriesValues.values[j], 2), unit)
+		}
+
+		chart.Tooltips = append(chart.Tooltips, &models.ChartsPageDataPointTooltip{
+			X:     math.Round(startX*100) / 100,
+			Width: math.Round((endX-startX)*100) / 100,
+			Title: title.String(),
+		})
+	}
+
+	return chart
+}
+
+// mergeChartRollups merges groups of consecutive rollups into larger buckets.
+func mergeChartRollups(rollups []*dbtypes.ChartRollup, groupSize int) []*dbtypes.ChartRollup {
+	merged := make([]*dbtypes.ChartRollup, 0, (len(rollups)+groupSize-1)/groupSize)
+	for i := 0; i < len(rollups); i += groupSize {
+		group := rollups[i:min(i+groupSize, len(rollups))]
+		mergedRollup := &dbtypes.ChartRollup{
+			Period:     group[0].Period * uint32(groupSize),
+			Time:       group[0].Time,
+			FirstEpoch: group[0].FirstEpoch,
+		}
+
+		var targetSum, headSum, totalSum, syncSum float64
+		for _, rollup := range group {
+			mergedRollup.LastEpoch = rollup.LastEpoch
+			mergedRollup.EpochCount += rollup.EpochCount
+			mergedRollup.ValidatorCount = rollup.ValidatorCount
+			mergedRollup.ValidatorBalance = rollup.ValidatorBalance
+			mergedRollup.BlockCount += rollup.BlockCount
+			mergedRollup.OrphanedCount += rollup.OrphanedCount
+			mergedRollup.DepositCount += rollup.DepositCount
+			mergedRollup.ExitCount += rollup.ExitCount
+			mergedRollup.BlobCount += rollup.BlobCount
+
+			targetSum += float64(rollup.TargetParticipation) * float64(rollup.EpochCount)
+			headSum += float64(rollup.HeadParticipation) * float64(rollup.EpochCount)
+			totalSum += float64(rollup.TotalParticipation) * float64(rollup.EpochCount)
+			syncSum += float64(rollup.SyncParticipation) * float64(rollup.EpochCount)
+		}
+		if mergedRollup.EpochCount > 0 {
+			mergedRollup.TargetParticipation = float32(targetSum / float64(mergedRollup.EpochCount))
+			mergedRollup.HeadParticipation = float32(headSum / float64(mergedRollup.EpochCount))
+			mergedRollup.TotalParticipation = float32(totalSum / float64(mergedRollup.EpochCount))
+			mergedRollup.SyncParticipation = float32(syncSum / float64(mergedRollup.EpochCount))
+		}
+
+		merged = append(merged, mergedRollup)
+	}
+
+	return merged
+}
@@ -204,5 +204,33 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 	}
 	pageData.ClientCount = uint64(len(pageData.Clients))
 
+	if services.GlobalConsensusDivergence != nil {
+		pageData.DivergenceEnabled = true
+		if report := services.GlobalConsensusDivergence.GetReport(); report != nil {
+			pageData.DivergenceCheckTime = report.CheckTime
+			pageData.DivergenceCheckSlot = uint64(report.Slot)
+			pageData.DivergenceStateEpoch = uint64(report.StateEpoch)
+			pageData.DivergenceStateRoot = report.StateRoot[:]
+			pageData.Divergences = make([]*models.ClientsCLPageDataDivergence, len(report.Divergences))
+			for idx, divergence := range report.Divergences {
+				resDivergence := &models.ClientsCLPageDataDivergence{
+					Check:           divergence.Check,
+					FirstSlot:       uint64(divergence.FirstSlot),
+					FirstSeen:       divergence.FirstSeen,
+					MajorityValue:   divergence.MajorityValue,
+					MajorityClients: divergence.MajorityClients,
+					Minorities:      make([]*models.ClientsCLPageDataDivergenceMinority, len(divergence.Minorities)),
+				}
+				for midx, minority := range divergence.Minorities {
+					resDivergence.Minorities[midx] = &models.ClientsCLPageDataDivergenceMinority{
+						Value:   minority.Value,
+						Clients: minority.Clients,
+					}
+				}
+				pageData.Divergences[idx] = resDivergence
+			}
+		}
+	}
+
 	return pageData, cacheTime
 }
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maximum number of epochs that can be analyzed at once
+const clientsDiversityMaxEpochs = 10000
+
+// number of entities & time buckets shown on the client diversity page
+const clientsDiversityEntityLimit = 25
+const clientsDiversityTimeBuckets = 30
+
+var clientsDiversityColors = []string{"bg-secondary", "bg-primary", "bg-success", "bg-info", "bg-warning", "bg-danger", "bg-dark", "bg-light"}
+
+// ClientsDiversity will return the "client diversity" page using a go template
+func ClientsDiversity(w http.ResponseWriter, r *http.Request) {
+	var diversityTemplateFiles = append(layoutTemplateFiles,
+		"clients/clients_diversity.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(diversityTemplateFiles...)
+	data := InitPageData(w, r, "clients", "/clients/diversity", "Client diversity", diversityTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var firstEpoch, lastEpoch int64 = -1, -1
+	if urlArgs.Has("start") {
+		firstEpoch, _ = strconv.ParseInt(urlArgs.Get("start"), 10, 64)
+	}
+	if urlArgs.Has("end") {
+		lastEpoch, _ = strconv.ParseInt(urlArgs.Get("end"), 10, 64)
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getClientsDiversityPageData(firstEpoch, lastEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "clients_diversity.go", "Client diversity", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getClientsDiversityPageData(firstEpoch int64, lastEpoch int64) (*models.ClientsDiversityPageData, error) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	// only finalized epochs are aggregated
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	maxEpoch := int64(finalizedEpoch) - 1
+	if maxEpoch < 0 {
+		maxEpoch = 0
+	}
+
+	epochsPerDay := uint64(24*time.Hour) / uint64(specs.SecondsPerSlot*time.Duration(specs.SlotsPerEpoch))
+	if lastEpoch < 0 || lastEpoch > maxEpoch {
+		lastEpoch = maxEpoch
+	}
+	if firstEpoch < 0 {
+		firstEpoch = lastEpoch - int64(epochsPerDay) + 1
+	}
+	if firstEpoch > lastEpoch {
+		firstEpoch = lastEpoch
+	}
+	if lastEpoch-firstEpoch >= clientsDiversityMaxEpochs {
+		firstEpoch = lastEpoch - clientsDiversityMaxEpochs + 1
+	}
+	if firstEpoch < 0 {
+		firstEpoch = 0
+	}
+
+	pageData := &models.ClientsDiversityPageData{}
+	pageCacheKey := fmt.Sprintf("clients/diversity:%v:%v", firstEpoch, lastEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildClientsDiversityPageData(pageCall.CallCtx, uint64(firstEpoch), uint64(lastEpoch), epochsPerDay)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ClientsDiversityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildClientsDiversityPageData(ctx context.Context, firstEpoch uint64, lastEpoch uint64, epochsPerDay uint64) (*models.ClientsDiversityPageData, time.Duration) {
+	logrus.Debugf("client diversity page called: %v-%v", firstEpoch, lastEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	store := db.WithContext(ctx)
+
+	pageData := &models.ClientsDiversityPageData{
+		FirstEpoch:      firstEpoch,
+		LastEpoch:       lastEpoch,
+		BackfillPending: services.GlobalClientDiversityService.IsBackfillPending(),
+	}
+
+	for _, days := range []uint64{1, 7, 30} {
+		presetFirstEpoch := uint64(0)
+		if presetEpochs := days * epochsPerDay; lastEpoch+1 > presetEpochs {
+			presetFirstEpoch = lastEpoch + 1 - presetEpochs
+		}
+		pageData.RangePresets = append(pageData.RangePresets, &models.ClientsDiversityPageDataPreset{
+			Label:      fmt.Sprintf("%vd", days),
+			FirstEpoch: presetFirstEpoch,
+			Active:     presetFirstEpoch == firstEpoch,
+		})
+	}
+
+	// overall shares & client combinations
+	clCounts := make([]uint64, len(utils.ConsensusClientNames))
+	elCounts := make([]uint64, len(utils.ExecutionClientNames))
+	comboCounts := map[[2]uint8]uint64{}
+
+	bucketCount := uint64(clientsDiversityTimeBuckets)
+	epochCount := lastEpoch - firstEpoch + 1
+	if epochCount < bucketCount {
+		bucketCount = epochCount
+	}
+	bucketSize := (epochCount + bucketCount - 1) / bucketCount
+	bucketCount = (epochCount + bucketSize - 1) / bucketSize
+	bucketClCounts := make([][]uint64, bucketCount)
+	bucketElCounts := make([][]uint64, bucketCount)
+	for i := range bucketClCounts {
+		bucketClCounts[i] = make([]uint64, len(utils.ConsensusClientNames))
+		bucketElCounts[i] = make([]uint64, len(utils.ExecutionClientNames))
+	}
+
+	for _, aggregate := range store.GetClientDiversity(firstEpoch, lastEpoch) {
+		clClient := clampClientType(aggregate.ClClient, len(utils.ConsensusClientNames))
+		elClient := clampClientType(aggregate.ElClient, len(utils.ExecutionClientNames))
+		clCounts[clClient] += aggregate.BlockCount
+		elCounts[elClient] += aggregate.BlockCount
+		comboCounts[[2]uint8{clClient, elClient}] += aggregate.BlockCount
+		pageData.TotalBlocks += aggregate.BlockCount
+
+		bucketIdx := (aggregate.Epoch - firstEpoch) / bucketSize
+		bucketClCounts[bucketIdx][clClient] += aggregate.BlockCount
+		bucketElCounts[bucketIdx][elClient] += aggregate.BlockCount
+	}
+
+	pageData.ClClients = buildClientsDiversityShares(clCounts, utils.ConsensusClientNames)
+	pageData.ElClients = buildClientsDiversityShares(elCounts, utils.ExecutionClientNames)
+
+	for combo, blocks := range comboCounts {
+		pageData.Combinations = append(pageData.Combinations, &models.ClientsDiversityPageDataCombo{
+			ClClient: utils.GetConsensusClientName(combo[0]),
+			ElClient: utils.GetExecutionClientName(combo[1]),
+			Blocks:   blocks,
+			Share:    float64(blocks) * 100 / float64(pageData.TotalBlocks),
+		})
+	}
+	sort.Slice(pageData.Combinations, func(a, b int) bool {
+		return pageData.Combinations[a].Blocks > pageData.Combinations[b].Blocks
+	})
+
+	// shares over time
+	for i := uint64(0); i < bucketCount; i++ {
+		bucket := &models.ClientsDiversityPageDataTimeBucket{
+			FirstEpoch: firstEpoch + i*bucketSize,
+			LastEpoch:  firstEpoch + (i+1)*bucketSize - 1,
+			ClClients:  buildClientsDiversityShares(bucketClCounts[i], utils.ConsensusClientNames),
+			ElClients:  buildClientsDiversityShares(bucketElCounts[i], utils.ExecutionClientNames),
+		}
+		if bucket.LastEpoch > lastEpoch {
+			bucket.LastEpoch = lastEpoch
+		}
+		for _, count := range bucketClCounts[i] {
+			bucket.Blocks += count
+		}
+		pageData.Timeline = append(pageData.Timeline, bucket)
+	}
+
+	// shares per entity (validator name)
+	entityClCounts := map[string][]uint64{}
+	entityElCounts := map[string][]uint64{}
+	entityBlocks := map[string]uint64{}
+	firstSlot := uint64(chainState.EpochToSlot(phase0.Epoch(firstEpoch)))
+	lastSlot := uint64(chainState.EpochToSlot(phase0.Epoch(lastEpoch+1))) - 1
+	for _, proposerClients := range store.GetClientDiversityByProposer(firstSlot, lastSlot) {
+		name := services.GlobalBeaconService.GetValidatorName(proposerClients.Proposer)
+		if name == "" {
+			name = "Unnamed validators"
+		}
+
+		if entityClCounts[name] == nil {
+			entityClCounts[name] = make([]uint64, len(utils.ConsensusClientNames))
+			entityElCounts[name] = make([]uint64, len(utils.ExecutionClientNames))
+		}
+		entityClCounts[name][clampClientType(proposerClients.ClClient, len(utils.ConsensusClientNames))] += proposerClients.BlockCount
+		entityElCounts[name][clampClientType(proposerClients.ElClient, len(utils.ExecutionClientNames))] += proposerClients.BlockCount
+		entityBlocks[name] += proposerClients.BlockCount
+	}
+
+	for name, blocks := range entityBlocks {
+		pageData.Entities = append(pageData.Entities, &models.ClientsDiversityPageDataEntity{
+			Name:      name,
+			Blocks:    blocks,
+			ClClients: buildClientsDiversityShares(entityClCounts[name], utils.ConsensusClientNames),
+			ElClients: buildClientsDiversityShares(entityElCounts[name], utils.ExecutionClientNames),
+		})
+	}
+	sort.Slice(pageData.Entities, func(a, b int) bool {
+		return pageData.Entities[a].Blocks > pageData.Entities[b].Blocks
+	})
+	pageData.EntityCount = uint64(len(pageData.Entities))
+	if len(pageData.Entities) > clientsDiversityEntityLimit {
+		pageData.Entities = pageData.Entities[:clientsDiversityEntityLimit]
+	}
+
+	return pageData, chainState.GetSpecs().SecondsPerSlot * time.Duration(chainState.GetSpecs().SlotsPerEpoch)
+}
+
+// buildClientsDiversityShares converts the block counts per client type to shares, ordered by block count.
+func buildClientsDiversityShares(counts []uint64, names []string) []*models.ClientsDiversityPageDataShare {
+	total := uint64(0)
+	for _, count := range counts {
+		total += count
+	}
+
+	shares := []*models.ClientsDiversityPageDataShare{}
+	for client, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		shares = append(shares, &models.ClientsDiversityPageDataShare{
+			Client: names[client],
+			Color:  clientsDiversityColors[client%len(clientsDiversityColors)],
+			Blocks: count,
+			Share:  float64(count) * 100 / float64(total),
+		})
+	}
+	sort.SliceStable(shares, func(a, b int) bool {
+		return shares[a].Blocks > shares[b].Blocks
+	})
+
+	return shares
+}
+
+func clampClientType(client uint8, typeCount int) uint8 {
+	if int(client) >= typeCount {
+		return 0
+	}
+	return client
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuildClientsDiversityShares(t *testing.T) {
+	names := []string{"Unknown", "A", "B", "C"}
+	shares := buildClientsDiversityShares([]uint64{1, 2, 0, 5}, names)
+
+	if len(shares) != 3 {
+		t.Fatalf("expected clients without blocks to be skipped, got %v shares", len(shares))
+	}
+
+	expected := []struct {
+		client string
+		blocks uint64
+		share  float64
+	}{
+		{"C", 5, 62.5},
+		{"A", 2, 25},
+		{"Unknown", 1, 12.5},
+	}
+	for i, share := range shares {
+		if share.Client != expected[i].client || share.Blocks != expected[i].blocks || math.Abs(share.Share-expected[i].share) > 1e-9 {
+			t.Errorf("share %v: expected %v (%v blocks, %v%%), got %v (%v blocks, %v%%)", i, expected[i].client, expected[i].blocks, expected[i].share, share.Client, share.Blocks, share.Share)
+		}
+	}
+
+	if shares := buildClientsDiversityShares([]uint64{0, 0}, names); len(shares) != 0 {
+		t.Errorf("expected no shares without blocks, got %v", len(shares))
+	}
+}
+
+func TestClampClientType(t *testing.T) {
+	if client := clampClientType(3, 4); client != 3 {
+		t.Errorf("expected known client type to be kept, got %v", client)
+	}
+	if client := clampClientType(4, 4); client != 0 {
+		t.Errorf("expected unknown client type to be mapped to 0, got %v", client)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ClientsElDivergences will return the "execution client divergences" page using a go template
+func ClientsElDivergences(w http.ResponseWriter, r *http.Request) {
+	var divergencesTemplateFiles = append(layoutTemplateFiles,
+		"clients/clients_el_divergences.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(divergencesTemplateFiles...)
+	data := InitPageData(w, r, "clients/execution", "/clients/execution/divergences", "Execution client divergences", divergencesTemplateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getELDivergencesPageData()
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "clients_el_divergences.go", "Execution client divergences", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getELDivergencesPageData() (*models.ClientsELDivergencesPageData, error) {
+	if services.GlobalExecutionDivergence == nil {
+		return nil, errors.New("execution client comparison is not enabled")
+	}
+
+	pageData := &models.ClientsELDivergencesPageData{}
+	pageCacheKey := "clients/execution/divergences"
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildELDivergencesPageData()
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ClientsELDivergencesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildELDivergencesPageData() (*models.ClientsELDivergencesPageData, time.Duration) {
+	logrus.Debugf("execution client divergences page called")
+
+	lastNumber, lastCheckTime, lastClients := services.GlobalExecutionDivergence.GetStatus()
+	pageData := &models.ClientsELDivergencesPageData{
+		LastCheckedNumber:  lastNumber,
+		LastCheckTime:      lastCheckTime,
+		LastCheckedClients: uint64(lastClients),
+		Divergences:        []*models.ClientsELDivergencesPageDataDivergence{},
+	}
+
+	for _, divergence := range services.GlobalExecutionDivergence.GetDivergences() {
+		resDivergence := &models.ClientsELDivergencesPageDataDivergence{
+			Number:     divergence.Number,
+			DetectTime: divergence.DetectTime,
+			Groups:     make([]*models.ClientsELDivergencesPageDataGroup, len(divergence.Groups)),
+		}
+		for idx, group := range divergence.Groups {
+			resDivergence.Groups[idx] = &models.ClientsELDivergencesPageDataGroup{
+				IsMajority:   idx == 0,
+				Hash:         group.Hash[:],
+				StateRoot:    group.StateRoot[:],
+				ReceiptsRoot: group.ReceiptsRoot[:],
+				GasUsed:      group.GasUsed,
+				Clients:      group.Clients,
+			}
+		}
+		pageData.Divergences = append(pageData.Divergences, resDivergence)
+	}
+	pageData.DivergenceCount = uint64(len(pageData.Divergences))
+
+	return pageData, services.GlobalBeaconService.GetChainState().GetSpecs().SecondsPerSlot
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
@@ -57,7 +58,7 @@ func getDepositsPageData(firstEpoch uint64, pageSize uint64) (*models.DepositsPa
 	pageData := &models.DepositsPageData{}
 	pageCacheKey := fmt.Sprintf("deposits:%v:%v", firstEpoch, pageSize)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildDepositsPageData(firstEpoch, pageSize)
+		pageData, cacheTimeout := buildDepositsPageData(pageCall.CallCtx, firstEpoch, pageSize)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -71,7 +72,7 @@ func getDepositsPageData(firstEpoch uint64, pageSize uint64) (*models.DepositsPa
 	return pageData, pageErr
 }
 
-func buildDepositsPageData(firstEpoch uint64, pageSize uint64) (*models.DepositsPageData, time.Duration) {
+func buildDepositsPageData(ctx context.Context, firstEpoch uint64, pageSize uint64) (*models.DepositsPageData, time.Duration) {
 	logrus.Debugf("deposits page called: %v:%v", firstEpoch, pageSize)
 	pageData := &models.DepositsPageData{
 		InitiatedDeposits: []*models.DepositsPageDataInitiatedDeposit{},
@@ -131,7 +132,7 @@ func buildDepositsPageData(firstEpoch uint64, pageSize uint64) (*models.Deposits
 	pageData.InitiatedDepositCount = uint64(len(pageData.InitiatedDeposits))
 
 	// load included deposits
-	dbDeposits, _ := services.GlobalBeaconService.GetIncludedDepositsByFilter(&dbtypes.DepositFilter{}, 0, 20)
+	dbDeposits, _ := services.GlobalBeaconService.GetIncludedDepositsByFilter(ctx, &dbtypes.DepositFilter{}, 0, 20)
 	for _, deposit := range dbDeposits {
 		depositData := &models.DepositsPageDataIncludedDeposit{
 			PublicKey:             deposit.PublicKey,
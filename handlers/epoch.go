@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -68,7 +69,7 @@ func getEpochPageData(epoch uint64) (*models.EpochPageData, error) {
 	pageData := &models.EpochPageData{}
 	pageCacheKey := fmt.Sprintf("epoch:%v", epoch)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildEpochPageData(epoch)
+		pageData, cacheTimeout := buildEpochPageData(pageCall.CallCtx, epoch)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -82,7 +83,7 @@ func getEpochPageData(epoch uint64) (*models.EpochPageData, error) {
 	return pageData, pageErr
 }
 
-func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
+func buildEpochPageData(ctx context.Context, epoch uint64) (*models.EpochPageData, time.Duration) {
 	logrus.Debugf("epoch page called: %v", epoch)
 
 	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
@@ -117,8 +118,11 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 		Synchronized:  syncedEpoch,
 		Finalized:     finalizedEpoch > phase0.Epoch(epoch),
 	}
+	if bootstrapEpoch := beaconIndexer.GetBootstrapEpoch(); epoch < uint64(bootstrapEpoch) {
+		pageData.NotIndexedBefore = uint64(bootstrapEpoch)
+	}
 
-	dbEpochs := services.GlobalBeaconService.GetDbEpochs(epoch, 1)
+	dbEpochs := services.GlobalBeaconService.GetDbEpochs(ctx, epoch, 1)
 	dbEpoch := dbEpochs[0]
 	if dbEpoch != nil {
 		pageData.AttestationCount = dbEpoch.AttestationCount
@@ -148,7 +152,7 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 
 	// load slots
 	pageData.Slots = make([]*models.EpochPageDataSlot, 0)
-	dbSlots := services.GlobalBeaconService.GetDbBlocksForSlots(uint64(lastSlot), uint32(specs.SlotsPerEpoch), true, true)
+	dbSlots := services.GlobalBeaconService.GetDbBlocksForSlots(ctx, uint64(lastSlot), uint32(specs.SlotsPerEpoch), true, true)
 	dbIdx := 0
 	dbCnt := len(dbSlots)
 	blockCount := uint64(0)
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
@@ -53,7 +54,7 @@ func getEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPageDa
 	pageData := &models.EpochsPageData{}
 	pageCacheKey := fmt.Sprintf("epochs:%v:%v", firstEpoch, pageSize)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildEpochsPageData(firstEpoch, pageSize)
+		pageData, cacheTimeout := buildEpochsPageData(pageCall.CallCtx, firstEpoch, pageSize)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -67,7 +68,7 @@ func getEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPageDa
 	return pageData, pageErr
 }
 
-func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPageData, time.Duration) {
+func buildEpochsPageData(ctx context.Context, firstEpoch uint64, pageSize uint64) (*models.EpochsPageData, time.Duration) {
 	logrus.Debugf("epochs page called: %v:%v", firstEpoch, pageSize)
 	pageData := &models.EpochsPageData{}
 
@@ -107,7 +108,7 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 
 	// load epochs
 	pageData.Epochs = make([]*models.EpochsPageDataEpoch, 0)
-	dbEpochs := services.GlobalBeaconService.GetDbEpochs(uint64(firstEpoch), uint32(epochLimit))
+	dbEpochs := services.GlobalBeaconService.GetDbEpochs(ctx, uint64(firstEpoch), uint32(epochLimit))
 	dbIdx := 0
 	dbCnt := len(dbEpochs)
 	epochCount := uint64(0)
@@ -155,6 +156,9 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 	pageData.EpochCount = uint64(epochCount)
 	pageData.FirstEpoch = firstEpoch
 	pageData.LastEpoch = firstEpoch - pageData.EpochCount + 1
+	if bootstrapEpoch := services.GlobalBeaconService.GetBeaconIndexer().GetBootstrapEpoch(); pageData.LastEpoch < uint64(bootstrapEpoch) {
+		pageData.NotIndexedBefore = uint64(bootstrapEpoch)
+	}
 
 	var cacheTimeout time.Duration
 	if !allSynchronized {
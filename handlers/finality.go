@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+const finalityIncidentsPageSize = 25
+
+// Finality will return the "finality" incidents page using a go template
+func Finality(w http.ResponseWriter, r *http.Request) {
+	var finalityTemplateFiles = append(layoutTemplateFiles,
+		"finality/finality.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(finalityTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/finality", "Finality Incidents", finalityTemplateFiles)
+
+	pageIdx := uint64(1)
+	if urlArgs := r.URL.Query(); urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getFinalityPageData(pageIdx)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "finality.go", "Finality", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getFinalityPageData(pageIdx uint64) (*models.FinalityPageData, error) {
+	pageData := &models.FinalityPageData{}
+	pageCacheKey := fmt.Sprintf("finality:%v", pageIdx)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildFinalityPageData(pageCall.CallCtx, pageIdx)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FinalityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFinalityPageData(ctx context.Context, pageIdx uint64) (*models.FinalityPageData, time.Duration) {
+	logrus.Debugf("finality page called: %v", pageIdx)
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	currentEpoch := chainState.CurrentEpoch()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	pageData := &models.FinalityPageData{
+		CurrentEpoch:   uint64(currentEpoch),
+		FinalizedEpoch: uint64(finalizedEpoch),
+	}
+	if currentEpoch > finalizedEpoch {
+		pageData.FinalizationDelay = uint64(currentEpoch - finalizedEpoch)
+	}
+	pageData.IsFinalizing = pageData.FinalizationDelay < services.FinalityIncidentMinDistance
+
+	dbIncidents, totalCount, err := db.WithContext(ctx).GetFinalityIncidents((pageIdx-1)*finalityIncidentsPageSize, finalityIncidentsPageSize)
+	if err != nil {
+		return pageData, 1 * time.Minute
+	}
+
+	for _, dbIncident := range dbIncidents {
+		pageData.Incidents = append(pageData.Incidents, buildFinalityPageDataIncident(dbIncident))
+	}
+	pageData.IncidentCount = totalCount
+
+	pageData.CurrentPageIndex = pageIdx
+	pageData.TotalPages = (totalCount + finalityIncidentsPageSize - 1) / finalityIncidentsPageSize
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	return pageData, 1 * time.Minute
+}
+
+func buildFinalityPageDataIncident(dbIncident *dbtypes.FinalityIncident) *models.FinalityPageDataIncident {
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	incident := &models.FinalityPageDataIncident{
+		StartEpoch:        dbIncident.StartEpoch,
+		StartTime:         chainState.EpochToTime(phase0.Epoch(dbIncident.StartEpoch)),
+		Ongoing:           dbIncident.EndEpoch == nil,
+		FinalizedEpoch:    dbIncident.FinalizedEpoch,
+		MaxDistance:       dbIncident.MaxDistance,
+		LeakEpochs:        dbIncident.LeakEpochs,
+		ParticipationAvg:  float64(dbIncident.ParticipationAvg),
+		ParticipationMin:  float64(dbIncident.ParticipationMin),
+		MaxForks:          dbIncident.MaxForks,
+		OfflineValidators: dbIncident.OfflineValidators,
+		LeakEstimate:      dbIncident.LeakEstimate,
+	}
+
+	incident.EndEpoch = dbIncident.LastEpoch
+	if dbIncident.EndEpoch != nil {
+		incident.EndEpoch = *dbIncident.EndEpoch
+	}
+	incident.EndTime = chainState.EpochToTime(phase0.Epoch(incident.EndEpoch))
+	incident.Duration = incident.EndEpoch - incident.StartEpoch
+
+	return incident
+}
+
+// FinalityIncident will return the details page of a non-finality incident using a go template
+func FinalityIncident(w http.ResponseWriter, r *http.Request) {
+	var incidentTemplateFiles = append(layoutTemplateFiles,
+		"finality/incident.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"finality/notfound.html",
+	)
+
+	vars := mux.Vars(r)
+	startEpoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+
+	var pageData *models.FinalityIncidentPageData
+	var pageError error
+	if err == nil {
+		pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+		if pageError == nil {
+			pageData, pageError = getFinalityIncidentPageData(startEpoch)
+		}
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	if pageData == nil {
+		data := InitPageData(w, r, "blockchain", "/finality", "Finality Incident not found", notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "finality.go", "FinalityIncident", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+
+	data := InitPageData(w, r, "blockchain", "/finality", fmt.Sprintf("Finality Incident %v", startEpoch), incidentTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "finality.go", "FinalityIncident", "", templates.GetTemplate(incidentTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getFinalityIncidentPageData(startEpoch uint64) (*models.FinalityIncidentPageData, error) {
+	pageData := &models.FinalityIncidentPageData{}
+	pageCacheKey := fmt.Sprintf("finality_incident:%v", startEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildFinalityIncidentPageData(pageCall.CallCtx, startEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FinalityIncidentPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFinalityIncidentPageData(ctx context.Context, startEpoch uint64) (*models.FinalityIncidentPageData, time.Duration) {
+	logrus.Debugf("finality incident page called: %v", startEpoch)
+	specs := services.GlobalBeaconService.GetChainState().GetSpecs()
+
+	dbIncident := db.WithContext(ctx).GetFinalityIncident(startEpoch)
+	if dbIncident == nil {
+		return nil, 1 * time.Minute
+	}
+
+	pageData := &models.FinalityIncidentPageData{
+		Incident:            buildFinalityPageDataIncident(dbIncident),
+		LastEpoch:           dbIncident.LastEpoch,
+		ParticipationEpochs: dbIncident.ParticipationEpochs,
+		OfflineBalance:      dbIncident.OfflineBalance,
+	}
+	if dbIncident.EndFinalizedEpoch != nil {
+		pageData.EndFinalizedEpoch = *dbIncident.EndFinalizedEpoch
+	}
+
+	if dbIncident.Forks != "" {
+		forks := []*dbtypes.FinalityIncidentFork{}
+		if err := json.Unmarshal([]byte(dbIncident.Forks), &forks); err != nil {
+			logrus.Warnf("failed decoding forks of finality incident %v: %v", startEpoch, err)
+		}
+		for _, fork := range forks {
+			pageData.Forks = append(pageData.Forks, &models.FinalityIncidentPageDataFork{
+				HeadSlot: fork.HeadSlot,
+				HeadRoot: fork.HeadRoot,
+				Clients:  fork.Clients,
+			})
+		}
+	}
+
+	if dbIncident.LeakEntities != "" {
+		entities := []*dbtypes.FinalityIncidentEntity{}
+		if err := json.Unmarshal([]byte(dbIncident.LeakEntities), &entities); err != nil {
+			logrus.Warnf("failed decoding leak entities of finality incident %v: %v", startEpoch, err)
+		}
+		for _, entity := range entities {
+			pageData.Entities = append(pageData.Entities, &models.FinalityIncidentPageDataEntity{
+				Name:              entity.Name,
+				OfflineValidators: entity.OfflineValidators,
+				OfflineBalance:    entity.OfflineBalance,
+				LeakEstimate:      entity.LeakEstimate,
+			})
+		}
+	}
+
+	// inactivity leak of a single validator with max effective balance that has been offline during the whole incident
+	penaltyQuotient := specs.GetInactivityPenaltyQuotient(phase0.Epoch(dbIncident.LastEpoch))
+	pageData.ValidatorBalance = specs.MaxEffectiveBalance
+	pageData.ValidatorLeak = services.GetInactivityLeakEstimate(specs.MaxEffectiveBalance, penaltyQuotient, uint64(dbIncident.LeakEpochs))
+
+	cacheTimeout := 10 * time.Minute
+	if pageData.Incident.Ongoing {
+		cacheTimeout = 1 * time.Minute
+	}
+	return pageData, cacheTimeout
+}
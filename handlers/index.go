@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -75,7 +76,7 @@ func getIndexPageData() (*models.IndexPageData, error) {
 	pageData := &models.IndexPageData{}
 	pageCacheKey := "index"
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildIndexPageData()
+		pageData, cacheTimeout := buildIndexPageData(pageCall.CallCtx)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -89,7 +90,7 @@ func getIndexPageData() (*models.IndexPageData, error) {
 	return pageData, pageErr
 }
 
-func buildIndexPageData() (*models.IndexPageData, time.Duration) {
+func buildIndexPageData(ctx context.Context) (*models.IndexPageData, time.Duration) {
 	logrus.Debugf("index page called")
 
 	recentEpochCount := 7
@@ -218,23 +219,23 @@ func buildIndexPageData() (*models.IndexPageData, time.Duration) {
 	}
 
 	// load recent epochs
-	buildIndexPageRecentEpochsData(pageData, currentEpoch, finalizedEpoch, justifiedEpoch, recentEpochCount)
+	buildIndexPageRecentEpochsData(ctx, pageData, currentEpoch, finalizedEpoch, justifiedEpoch, recentEpochCount)
 
 	// load recent blocks
-	buildIndexPageRecentBlocksData(pageData, currentSlot, recentBlockCount)
+	buildIndexPageRecentBlocksData(ctx, pageData, currentSlot, recentBlockCount)
 
 	// load recent slots
-	buildIndexPageRecentSlotsData(pageData, currentSlot, recentSlotsCount)
+	buildIndexPageRecentSlotsData(ctx, pageData, currentSlot, recentSlotsCount)
 
 	return pageData, 12 * time.Second
 }
 
-func buildIndexPageRecentEpochsData(pageData *models.IndexPageData, currentEpoch phase0.Epoch, finalizedEpoch phase0.Epoch, justifiedEpoch phase0.Epoch, recentEpochCount int) {
+func buildIndexPageRecentEpochsData(ctx context.Context, pageData *models.IndexPageData, currentEpoch phase0.Epoch, finalizedEpoch phase0.Epoch, justifiedEpoch phase0.Epoch, recentEpochCount int) {
 	pageData.RecentEpochs = make([]*models.IndexPageDataEpochs, 0)
 
 	chainState := services.GlobalBeaconService.GetChainState()
 
-	epochsData := services.GlobalBeaconService.GetDbEpochs(uint64(currentEpoch), uint32(recentEpochCount))
+	epochsData := services.GlobalBeaconService.GetDbEpochs(ctx, uint64(currentEpoch), uint32(recentEpochCount))
 	for i := 0; i < len(epochsData); i++ {
 		epochData := epochsData[i]
 		if epochData == nil {
@@ -257,12 +258,12 @@ func buildIndexPageRecentEpochsData(pageData *models.IndexPageData, currentEpoch
 	pageData.RecentEpochCount = uint64(len(pageData.RecentEpochs))
 }
 
-func buildIndexPageRecentBlocksData(pageData *models.IndexPageData, currentSlot phase0.Slot, recentBlockCount int) {
+func buildIndexPageRecentBlocksData(ctx context.Context, pageData *models.IndexPageData, currentSlot phase0.Slot, recentBlockCount int) {
 	pageData.RecentBlocks = make([]*models.IndexPageDataBlocks, 0)
 
 	chainState := services.GlobalBeaconService.GetChainState()
 
-	blocksData := services.GlobalBeaconService.GetDbBlocks(uint64(currentSlot), int32(recentBlockCount), false, false)
+	blocksData := services.GlobalBeaconService.GetDbBlocks(ctx, uint64(currentSlot), int32(recentBlockCount), false, false)
 	for i := 0; i < len(blocksData); i++ {
 		blockData := blocksData[i]
 		if blockData == nil {
@@ -289,7 +290,7 @@ func buildIndexPageRecentBlocksData(pageData *models.IndexPageData, currentSlot
 	pageData.RecentBlockCount = uint64(len(pageData.RecentBlocks))
 }
 
-func buildIndexPageRecentSlotsData(pageData *models.IndexPageData, firstSlot phase0.Slot, slotLimit int) {
+func buildIndexPageRecentSlotsData(ctx context.Context, pageData *models.IndexPageData, firstSlot phase0.Slot, slotLimit int) {
 	var lastSlot uint64
 	if uint64(firstSlot) >= uint64(slotLimit) {
 		lastSlot = uint64(firstSlot) - uint64(slotLimit)
@@ -301,7 +302,7 @@ func buildIndexPageRecentSlotsData(pageData *models.IndexPageData, firstSlot pha
 
 	// load slots
 	pageData.RecentSlots = make([]*models.IndexPageDataSlots, 0)
-	dbSlots := services.GlobalBeaconService.GetDbBlocksForSlots(uint64(firstSlot), uint32(slotLimit), true, true)
+	dbSlots := services.GlobalBeaconService.GetDbBlocksForSlots(ctx, uint64(firstSlot), uint32(slotLimit), true, true)
 	dbIdx := 0
 	dbCnt := len(dbSlots)
 	blockCount := uint64(0)
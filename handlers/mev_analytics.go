@@ -0,0 +1,459 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type mevAnalyticsRange struct {
+	key   string
+	label string
+	days  uint64
+}
+
+var mevAnalyticsRanges = []*mevAnalyticsRange{
+	{key: "7d", label: "7 days", days: 7},
+	{key: "30d", label: "30 days", days: 30},
+	{key: "90d", label: "90 days", days: 90},
+	{key: "1y", label: "1 year", days: 365},
+}
+
+const mevAnalyticsBuilderLimit = 50
+const mevAnalyticsEntityLimit = 50
+const mevBuilderBlocksLimit = 25
+
+// MevAnalytics will return the "mev analytics" page using a go template
+func MevAnalytics(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"mev_analytics/mev_analytics.html",
+		"_svg/chart.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/mev/analytics", "MEV Analytics", templateFiles)
+
+	rangeKey := ""
+	if urlArgs := r.URL.Query(); urlArgs.Has("range") {
+		rangeKey = urlArgs.Get("range")
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getMevAnalyticsPageData(getMevAnalyticsRange(rangeKey))
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "mev_analytics.go", "MevAnalytics", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getMevAnalyticsRange(rangeKey string) *mevAnalyticsRange {
+	for _, analyticsRange := range mevAnalyticsRanges {
+		if analyticsRange.key == rangeKey {
+			return analyticsRange
+		}
+	}
+	return mevAnalyticsRanges[1]
+}
+
+// getMevAnalyticsSlotRange returns the finalized slot range covered by the analytics range.
+// The proposal status of mev blocks is only known for finalized slots.
+func getMevAnalyticsSlotRange(selectedRange *mevAnalyticsRange) (firstSlot uint64, lastSlot uint64, slotsPerDay uint64) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	slotsPerDay = uint64(24*time.Hour) / uint64(specs.SecondsPerSlot)
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	if finalizedSlot := uint64(chainState.EpochToSlot(finalizedEpoch)); finalizedSlot > 0 {
+		lastSlot = finalizedSlot - 1
+	}
+	if rangeSlots := selectedRange.days * slotsPerDay; lastSlot > rangeSlots {
+		firstSlot = lastSlot - rangeSlots
+	}
+	return
+}
+
+func getMevAnalyticsRanges(selectedRange *mevAnalyticsRange) []*models.ChartsPageDataRange {
+	ranges := make([]*models.ChartsPageDataRange, 0, len(mevAnalyticsRanges))
+	for _, analyticsRange := range mevAnalyticsRanges {
+		ranges = append(ranges, &models.ChartsPageDataRange{
+			Key:    analyticsRange.key,
+			Label:  analyticsRange.label,
+			Active: analyticsRange == selectedRange,
+		})
+	}
+	return ranges
+}
+
+func getMevAnalyticsPageData(selectedRange *mevAnalyticsRange) (*models.MevAnalyticsPageData, error) {
+	pageData := &models.MevAnalyticsPageData{}
+	pageCacheKey := fmt.Sprintf("mev_analytics:%v", selectedRange.key)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildMevAnalyticsPageData(pageCall.CallCtx, selectedRange)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.MevAnalyticsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildMevAnalyticsPageData(ctx context.Context, selectedRange *mevAnalyticsRange) (*models.MevAnalyticsPageData, time.Duration) {
+	logrus.Debugf("mev analytics page called: %v", selectedRange.key)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	store := db.WithContext(ctx)
+
+	firstSlot, lastSlot, slotsPerDay := getMevAnalyticsSlotRange(selectedRange)
+	pageData := &models.MevAnalyticsPageData{
+		Range:        selectedRange.key,
+		Ranges:       getMevAnalyticsRanges(selectedRange),
+		FirstSlot:    firstSlot,
+		LastSlot:     lastSlot,
+		FirstTime:    chainState.SlotToTime(phase0.Slot(firstSlot)),
+		LastTime:     chainState.SlotToTime(phase0.Slot(lastSlot)),
+		PrunedBefore: uint64(services.GlobalRetentionService.GetMevBlocksPrunedBefore()),
+	}
+	cacheTimeout := specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+
+	dailyStats := store.GetMevDailyStats(firstSlot, lastSlot, slotsPerDay, nil)
+	for _, dayStats := range dailyStats {
+		pageData.BlockCount += dayStats.BlockCount
+	}
+
+	// payload & payment stats
+	if blockStats := store.GetMevBlockStats(firstSlot, lastSlot, nil); blockStats != nil {
+		pageData.RelayBlockCount = blockStats.ProposedCount
+		pageData.PayloadCount = blockStats.PayloadCount
+		pageData.MissedCount = blockStats.MissedCount
+		pageData.ReplacedCount = blockStats.ReplacedCount
+		pageData.TotalValue = blockStats.ProposedValue
+		pageData.MedianValue = blockStats.MedianValue
+		if blockStats.ProposedCount > 0 {
+			pageData.AvgValue = blockStats.ProposedValue / blockStats.ProposedCount
+		}
+		if deliveredSlots := blockStats.ProposedCount + blockStats.MissedCount + blockStats.ReplacedCount; deliveredSlots > 0 {
+			pageData.MissedPercent = float64(blockStats.MissedCount) * 100 / float64(deliveredSlots)
+		}
+	}
+	if pageData.BlockCount > pageData.RelayBlockCount {
+		pageData.LocalBlockCount = pageData.BlockCount - pageData.RelayBlockCount
+	}
+	if pageData.BlockCount > 0 {
+		pageData.RelayPercent = float64(pageData.RelayBlockCount) * 100 / float64(pageData.BlockCount)
+		pageData.LocalPercent = float64(pageData.LocalBlockCount) * 100 / float64(pageData.BlockCount)
+	}
+
+	// builder market share
+	builderStats := store.GetMevBuilderStats(firstSlot, lastSlot)
+	pageData.BuilderCount = uint64(len(builderStats))
+	for _, builder := range builderStats {
+		if len(pageData.Builders) >= mevAnalyticsBuilderLimit {
+			break
+		}
+		pageData.Builders = append(pageData.Builders, &models.MevAnalyticsPageDataBuilder{
+			Pubkey:     builder.BuilderPubkey,
+			BlockCount: builder.BlockCount,
+			Share:      getMevAnalyticsShare(builder.BlockCount, pageData.RelayBlockCount),
+			TotalValue: builder.BlockValue,
+			AvgValue:   builder.BlockValue / max(builder.BlockCount, 1),
+			LastSlot:   builder.LastSlot,
+		})
+	}
+
+	// relay market share
+	pageData.Relays = buildMevAnalyticsRelays(store.GetMevRelayStats(firstSlot, lastSlot, nil), pageData.RelayBlockCount)
+
+	// mev adoption per validator entity
+	entityStats := map[string]*models.MevAnalyticsPageDataEntity{}
+	for _, proposerStats := range store.GetMevProposerStats(firstSlot, lastSlot) {
+		name := services.GlobalBeaconService.GetValidatorName(proposerStats.Proposer)
+		if name == "" {
+			name = "Unnamed validators"
+		}
+
+		entity := entityStats[name]
+		if entity == nil {
+			entity = &models.MevAnalyticsPageDataEntity{
+				Name: name,
+			}
+			entityStats[name] = entity
+			pageData.Entities = append(pageData.Entities, entity)
+		}
+		entity.BlockCount += proposerStats.BlockCount
+		entity.MevCount += proposerStats.MevCount
+		entity.MevValue += proposerStats.MevValue
+	}
+	for _, entity := range pageData.Entities {
+		entity.MevPercent = getMevAnalyticsShare(entity.MevCount, entity.BlockCount)
+	}
+	sort.Slice(pageData.Entities, func(a, b int) bool {
+		return pageData.Entities[a].BlockCount > pageData.Entities[b].BlockCount
+	})
+	pageData.EntityCount = uint64(len(pageData.Entities))
+	if len(pageData.Entities) > mevAnalyticsEntityLimit {
+		pageData.Entities = pageData.Entities[:mevAnalyticsEntityLimit]
+	}
+
+	// daily charts
+	if len(dailyStats) > 0 {
+		times := make([]uint64, len(dailyStats))
+		relayShare := make([]float64, len(dailyStats))
+		relayBlocks := make([]float64, len(dailyStats))
+		localBlocks := make([]float64, len(dailyStats))
+		avgPayment := make([]float64, len(dailyStats))
+		for i, dayStats := range dailyStats {
+			times[i] = uint64(chainState.SlotToTime(phase0.Slot(dayStats.Day * slotsPerDay)).Unix())
+			relayShare[i] = getMevAnalyticsShare(dayStats.MevCount, dayStats.BlockCount)
+			relayBlocks[i] = float64(dayStats.MevCount)
+			localBlocks[i] = float64(dayStats.BlockCount - dayStats.MevCount)
+			if dayStats.MevCount > 0 {
+				avgPayment[i] = float64(dayStats.MevValue) / float64(dayStats.MevCount) / 1e9
+			}
+		}
+
+		pageData.Charts = []*models.ChartsPageDataChart{
+			buildChartFromValues("relayshare", "Relay block share", "%", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Relay blocks", color: "primary", values: relayShare},
+			}),
+			buildChartFromValues("payment", "Average proposer payment", " ETH", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Payment", color: "success", values: avgPayment},
+			}),
+			buildChartFromValues("blocks", "Blocks per day", "", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Relay", color: "primary", values: relayBlocks},
+				{label: "Local", color: "warning", values: localBlocks},
+			}),
+		}
+	}
+
+	return pageData, cacheTimeout
+}
+
+// buildMevAnalyticsRelays splits the block counts per relay combination up to the configured relays.
+// Blocks delivered by multiple relays are counted for each of them, so the shares might sum up to more than 100%.
+func buildMevAnalyticsRelays(relayStats []*dbtypes.MevRelayStats, relayBlockCount uint64) []*models.MevAnalyticsPageDataRelay {
+	relays := []*models.MevAnalyticsPageDataRelay{}
+	for _, relayConfig := range utils.Config.MevIndexer.Relays {
+		relay := &models.MevAnalyticsPageDataRelay{
+			Index: uint64(relayConfig.Index),
+			Name:  relayConfig.Name,
+		}
+
+		relayFlag := uint64(1) << uint64(relayConfig.Index)
+		for _, stats := range relayStats {
+			if stats.SeenbyRelays&relayFlag > 0 {
+				relay.BlockCount += stats.BlockCount
+				relay.TotalValue += stats.BlockValue
+			}
+		}
+		relay.Share = getMevAnalyticsShare(relay.BlockCount, relayBlockCount)
+		if relay.BlockCount > 0 {
+			relay.AvgValue = relay.TotalValue / relay.BlockCount
+		}
+
+		relays = append(relays, relay)
+	}
+	sort.Slice(relays, func(a, b int) bool {
+		return relays[a].BlockCount > relays[b].BlockCount
+	})
+	return relays
+}
+
+func getMevAnalyticsShare(count uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// MevBuilder will return the details page of a mev block builder using a go template
+func MevBuilder(w http.ResponseWriter, r *http.Request) {
+	var builderTemplateFiles = append(layoutTemplateFiles,
+		"mev_analytics/builder.html",
+		"_svg/chart.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"mev_analytics/notfound.html",
+	)
+
+	vars := mux.Vars(r)
+	builderPubkey, err := hex.DecodeString(strings.Replace(vars["pubkey"], "0x", "", -1))
+	if err == nil && len(builderPubkey) != 48 {
+		err = fmt.Errorf("invalid builder pubkey length")
+	}
+
+	rangeKey := ""
+	if urlArgs := r.URL.Query(); urlArgs.Has("range") {
+		rangeKey = urlArgs.Get("range")
+	}
+
+	var pageData *models.MevBuilderPageData
+	var pageError error
+	if err == nil {
+		pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+		if pageError == nil {
+			pageData, pageError = getMevBuilderPageData(builderPubkey, getMevAnalyticsRange(rangeKey))
+		}
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	if pageData == nil {
+		data := InitPageData(w, r, "blockchain", "/mev/analytics", "MEV Builder not found", notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "mev_analytics.go", "MevBuilder", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+
+	data := InitPageData(w, r, "blockchain", "/mev/analytics", fmt.Sprintf("MEV Builder 0x%x", builderPubkey), builderTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "mev_analytics.go", "MevBuilder", "", templates.GetTemplate(builderTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getMevBuilderPageData(builderPubkey []byte, selectedRange *mevAnalyticsRange) (*models.MevBuilderPageData, error) {
+	pageData := &models.MevBuilderPageData{}
+	pageCacheKey := fmt.Sprintf("mev_builder:%x:%v", builderPubkey, selectedRange.key)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildMevBuilderPageData(pageCall.CallCtx, builderPubkey, selectedRange)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.MevBuilderPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildMevBuilderPageData(ctx context.Context, builderPubkey []byte, selectedRange *mevAnalyticsRange) (*models.MevBuilderPageData, time.Duration) {
+	logrus.Debugf("mev builder page called: 0x%x %v", builderPubkey, selectedRange.key)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	store := db.WithContext(ctx)
+	cacheTimeout := specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+
+	// recent blocks of the builder, regardless of the selected range
+	dbMevBlocks, totalCount, err := store.GetMevBlocksFiltered(0, mevBuilderBlocksLimit, &dbtypes.MevBlockFilter{
+		BuilderPubkey: builderPubkey,
+	})
+	if err != nil || totalCount == 0 {
+		return nil, 1 * time.Minute
+	}
+
+	firstSlot, lastSlot, slotsPerDay := getMevAnalyticsSlotRange(selectedRange)
+	pageData := &models.MevBuilderPageData{
+		Pubkey:     builderPubkey,
+		Range:      selectedRange.key,
+		Ranges:     getMevAnalyticsRanges(selectedRange),
+		FirstTime:  chainState.SlotToTime(phase0.Slot(firstSlot)),
+		LastTime:   chainState.SlotToTime(phase0.Slot(lastSlot)),
+		TotalCount: totalCount,
+	}
+
+	for _, mevBlock := range dbMevBlocks {
+		pageData.Blocks = append(pageData.Blocks, &models.MevBuilderPageDataBlock{
+			Slot:          mevBlock.SlotNumber,
+			Time:          chainState.SlotToTime(phase0.Slot(mevBlock.SlotNumber)),
+			BlockHash:     mevBlock.BlockHash,
+			BlockNumber:   mevBlock.BlockNumber,
+			ProposerIndex: mevBlock.ProposerIndex,
+			ProposerName:  services.GlobalBeaconService.GetValidatorName(mevBlock.ProposerIndex),
+			Proposed:      mevBlock.Proposed,
+			TxCount:       mevBlock.TxCount,
+			BlockValue:    mevBlock.BlockValueGwei,
+		})
+	}
+
+	relayBlockCount := uint64(0)
+	if relayStats := store.GetMevBlockStats(firstSlot, lastSlot, nil); relayStats != nil {
+		relayBlockCount = relayStats.ProposedCount
+	}
+
+	if blockStats := store.GetMevBlockStats(firstSlot, lastSlot, builderPubkey); blockStats != nil {
+		pageData.BlockCount = blockStats.ProposedCount
+		pageData.Share = getMevAnalyticsShare(blockStats.ProposedCount, relayBlockCount)
+		pageData.PayloadCount = blockStats.PayloadCount
+		pageData.MissedCount = blockStats.MissedCount
+		pageData.ReplacedCount = blockStats.ReplacedCount
+		pageData.TotalValue = blockStats.ProposedValue
+		pageData.MedianValue = blockStats.MedianValue
+		if blockStats.ProposedCount > 0 {
+			pageData.AvgValue = blockStats.ProposedValue / blockStats.ProposedCount
+		}
+	}
+
+	pageData.Relays = buildMevAnalyticsRelays(store.GetMevRelayStats(firstSlot, lastSlot, builderPubkey), pageData.BlockCount)
+
+	// daily share of the relay blocks built by this builder
+	relayDailyStats := map[uint64]*dbtypes.MevDailyStats{}
+	for _, dayStats := range store.GetMevDailyStats(firstSlot, lastSlot, slotsPerDay, nil) {
+		relayDailyStats[dayStats.Day] = dayStats
+	}
+
+	dailyStats := store.GetMevDailyStats(firstSlot, lastSlot, slotsPerDay, builderPubkey)
+	if len(dailyStats) > 0 {
+		times := make([]uint64, len(dailyStats))
+		builderShare := make([]float64, len(dailyStats))
+		builderBlocks := make([]float64, len(dailyStats))
+		avgPayment := make([]float64, len(dailyStats))
+		for i, dayStats := range dailyStats {
+			times[i] = uint64(chainState.SlotToTime(phase0.Slot(dayStats.Day * slotsPerDay)).Unix())
+			if relayDayStats := relayDailyStats[dayStats.Day]; relayDayStats != nil {
+				builderShare[i] = getMevAnalyticsShare(dayStats.MevCount, relayDayStats.MevCount)
+			}
+			builderBlocks[i] = float64(dayStats.MevCount)
+			if dayStats.MevCount > 0 {
+				avgPayment[i] = float64(dayStats.MevValue) / float64(dayStats.MevCount) / 1e9
+			}
+		}
+
+		pageData.Charts = []*models.ChartsPageDataChart{
+			buildChartFromValues("share", "Relay block share", "%", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Share", color: "primary", values: builderShare},
+			}),
+			buildChartFromValues("blocks", "Blocks per day", "", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Blocks", color: "info", values: builderBlocks},
+			}),
+			buildChartFromValues("payment", "Average proposer payment", " ETH", times, "2006-01-02", []*chartsSeriesValues{
+				{label: "Payment", color: "success", values: avgPayment},
+			}),
+		}
+	}
+
+	return pageData, cacheTimeout
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+func TestGetMevAnalyticsShare(t *testing.T) {
+	if share := getMevAnalyticsShare(5, 0); share != 0 {
+		t.Errorf("expected 0%% share without blocks, got %v", share)
+	}
+	if share := getMevAnalyticsShare(1, 8); math.Abs(share-12.5) > 1e-9 {
+		t.Errorf("expected 12.5%% share, got %v", share)
+	}
+}
+
+func TestBuildMevAnalyticsRelays(t *testing.T) {
+	prevConfig := utils.Config
+	t.Cleanup(func() {
+		utils.Config = prevConfig
+	})
+	utils.Config = &types.Config{}
+	utils.Config.MevIndexer.Relays = []types.MevRelayConfig{
+		{Index: 0, Name: "relay-a"},
+		{Index: 1, Name: "relay-b"},
+		{Index: 2, Name: "relay-c"},
+	}
+
+	relays := buildMevAnalyticsRelays([]*dbtypes.MevRelayStats{
+		{SeenbyRelays: 0b001, BlockCount: 6, BlockValue: 600},
+		{SeenbyRelays: 0b011, BlockCount: 2, BlockValue: 400},
+		{SeenbyRelays: 0b010, BlockCount: 2, BlockValue: 100},
+	}, 10)
+
+	// blocks delivered by multiple relays are counted for each of them
+	expected := []struct {
+		name     string
+		blocks   uint64
+		share    float64
+		avgValue uint64
+	}{
+		{"relay-a", 8, 80, 125},
+		{"relay-b", 4, 40, 125},
+		{"relay-c", 0, 0, 0},
+	}
+	if len(relays) != len(expected) {
+		t.Fatalf("expected %v relays, got %v", len(expected), len(relays))
+	}
+	for i, relay := range relays {
+		if relay.Name != expected[i].name || relay.BlockCount != expected[i].blocks || math.Abs(relay.Share-expected[i].share) > 1e-9 || relay.AvgValue != expected[i].avgValue {
+			t.Errorf("relay %v: expected %v (%v blocks, %v%%, avg %v), got %v (%v blocks, %v%%, avg %v)", i, expected[i].name, expected[i].blocks, expected[i].share, expected[i].avgValue, relay.Name, relay.BlockCount, relay.Share, relay.AvgValue)
+		}
+	}
+}
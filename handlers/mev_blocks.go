@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -45,6 +46,7 @@ func MevBlocks(w http.ResponseWriter, r *http.Request) {
 	var minIndex uint64
 	var maxIndex uint64
 	var vname string
+	var builder string
 	var withRelays string
 	var withProposed string
 
@@ -64,6 +66,9 @@ func MevBlocks(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.vname") {
 			vname = urlArgs.Get("f.vname")
 		}
+		if urlArgs.Has("f.builder") {
+			builder = urlArgs.Get("f.builder")
+		}
 		if urlArgs.Has("f.relays") {
 			withRelays = urlArgs.Get("f.relays")
 		}
@@ -74,7 +79,7 @@ func MevBlocks(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
 	if pageError == nil {
-		data.Data, pageError = getFilteredMevBlocksPageData(pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, withRelays, withProposed)
+		data.Data, pageError = getFilteredMevBlocksPageData(pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, builder, withRelays, withProposed)
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -86,11 +91,11 @@ func MevBlocks(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, minIndex uint64, maxIndex uint64, vname string, withRelays string, withProposed string) (*models.MevBlocksPageData, error) {
+func getFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, minIndex uint64, maxIndex uint64, vname string, builder string, withRelays string, withProposed string) (*models.MevBlocksPageData, error) {
 	pageData := &models.MevBlocksPageData{}
-	pageCacheKey := fmt.Sprintf("mev_blocks:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, withRelays, withProposed)
+	pageCacheKey := fmt.Sprintf("mev_blocks:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, builder, withRelays, withProposed)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
-		return buildFilteredMevBlocksPageData(pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, withRelays, withProposed)
+		return buildFilteredMevBlocksPageData(pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, builder, withRelays, withProposed)
 	})
 	if pageErr == nil && pageRes != nil {
 		resData, resOk := pageRes.(*models.MevBlocksPageData)
@@ -102,7 +107,7 @@ func getFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uint6
 	return pageData, pageErr
 }
 
-func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, minIndex uint64, maxIndex uint64, vname string, withRelays string, withProposed string) *models.MevBlocksPageData {
+func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, minIndex uint64, maxIndex uint64, vname string, builder string, withRelays string, withProposed string) *models.MevBlocksPageData {
 	filterArgs := url.Values{}
 	if minSlot != 0 {
 		filterArgs.Add("f.mins", fmt.Sprintf("%v", minSlot))
@@ -119,6 +124,9 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 	if vname != "" {
 		filterArgs.Add("f.vname", vname)
 	}
+	if builder != "" {
+		filterArgs.Add("f.builder", builder)
+	}
 	if withRelays != "" {
 		filterArgs.Add("f.relays", withRelays)
 	}
@@ -132,6 +140,7 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		FilterMinIndex:      minIndex,
 		FilterMaxIndex:      maxIndex,
 		FilterValidatorName: vname,
+		FilterBuilder:       builder,
 		FilterRelays:        map[uint8]bool{},
 		FilterRelayOpts:     []*models.MevBlocksPageDataRelay{},
 		FilterProposed:      map[uint8]bool{},
@@ -203,6 +212,9 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		MevRelay:     withRelayIdx,
 		Proposed:     withProposedOpts,
 	}
+	if builder != "" {
+		mevBlockFilter.BuilderPubkey, _ = hex.DecodeString(strings.Replace(builder, "0x", "", -1))
+	}
 
 	offset := (pageIdx - 1) * pageSize
 	dbMevBlocks, totalRows, err := db.GetMevBlocksFiltered(offset, uint32(pageSize), mevBlockFilter)
@@ -243,6 +255,7 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		pageData.MevBlocks = append(pageData.MevBlocks, mevBlockData)
 	}
 	pageData.BlockCount = uint64(len(pageData.MevBlocks))
+	pageData.PrunedBefore = uint64(services.GlobalRetentionService.GetMevBlocksPrunedBefore())
 
 	if pageData.BlockCount > 0 {
 		pageData.FirstIndex = pageData.MevBlocks[0].SlotNumber
@@ -65,6 +65,15 @@ func InitPageData(w http.ResponseWriter, r *http.Request, active, path, title st
 		MainMenuItems:         createMenuItems(active),
 	}
 
+	currentEpoch := chainState.CurrentEpoch()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	data.CurrentEpoch = uint64(currentEpoch)
+	data.LatestFinalizedEpoch = uint64(finalizedEpoch)
+	if currentEpoch > finalizedEpoch {
+		data.FinalizationDelay = uint64(currentEpoch - finalizedEpoch)
+	}
+	data.FinalityIncident = data.FinalizationDelay >= services.FinalityIncidentMinDistance
+
 	if utils.Config.Frontend.SiteDescription != "" {
 		data.Meta.Description = utils.Config.Frontend.SiteDescription
 	}
@@ -118,6 +127,25 @@ func createMenuItems(active string) []types.MainMenuItem {
 				Path:  "/slots",
 				Icon:  "fa-cube",
 			},
+			{
+				Label: "Late Blocks",
+				Path:  "/slots/late",
+				Icon:  "fa-hourglass-half",
+			},
+		},
+	})
+	blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+		Links: []types.NavigationLink{
+			{
+				Label: "Charts",
+				Path:  "/charts",
+				Icon:  "fa-chart-line",
+			},
+			{
+				Label: "Finality Incidents",
+				Path:  "/finality",
+				Icon:  "fa-triangle-exclamation",
+			},
 		},
 	})
 	if len(utils.Config.MevIndexer.Relays) > 0 {
@@ -128,6 +156,11 @@ func createMenuItems(active string) []types.MainMenuItem {
 					Path:  "/mev/blocks",
 					Icon:  "fa-money-bill",
 				},
+				{
+					Label: "MEV Analytics",
+					Path:  "/mev/analytics",
+					Icon:  "fa-chart-pie",
+				},
 			},
 		})
 	}
@@ -146,8 +179,22 @@ func createMenuItems(active string) []types.MainMenuItem {
 			Path:  "/clients/execution",
 			Icon:  "fa-circle-nodes",
 		})
+
+		if utils.Config.ClientCompare.ExecutionEnabled {
+			clientLinks = append(clientLinks, types.NavigationLink{
+				Label: "Execution Divergences",
+				Path:  "/clients/execution/divergences",
+				Icon:  "fa-code-compare",
+			})
+		}
 	}
 
+	clientLinks = append(clientLinks, types.NavigationLink{
+		Label: "Client Diversity",
+		Path:  "/clients/diversity",
+		Icon:  "fa-chart-pie",
+	})
+
 	clientLinks = append(clientLinks, types.NavigationLink{
 		Label: "Forks",
 		Path:  "/forks",
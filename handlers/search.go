@@ -23,94 +23,70 @@ import (
 
 var searchLikeRE = regexp.MustCompile(`^[0-9a-fA-F]{0,96}$`)
 
-// Search will return the main "search" page using a go template
+var searchResultTypeLabels = map[services.SearchResultType]string{
+	services.SearchResultSlot:              "Slot",
+	services.SearchResultBlockRoot:         "Block Root",
+	services.SearchResultStateRoot:         "State Root",
+	services.SearchResultExecBlock:         "Execution Block",
+	services.SearchResultTransaction:       "Transaction",
+	services.SearchResultEpoch:             "Epoch",
+	services.SearchResultValidator:         "Validator",
+	services.SearchResultBuilder:           "Builder",
+	services.SearchResultDeposit:           "Deposit",
+	services.SearchResultWithdrawalAddress: "Withdrawal Address",
+	services.SearchResultDepositAddress:    "Deposit Address",
+	services.SearchResultValidatorName:     "Validator Name",
+	services.SearchResultGraffiti:          "Graffiti",
+}
+
+// Search will return the ranked "search" result page using a go template
+// a single result redirects to the matched entity directly.
 func Search(w http.ResponseWriter, r *http.Request) {
 	var notfoundTemplateFiles = append(layoutTemplateFiles,
 		"search/notfound.html",
 	)
+	var resultsTemplateFiles = append(layoutTemplateFiles,
+		"search/results.html",
+	)
 
 	urlArgs := r.URL.Query()
-	searchQuery := urlArgs.Get("q")
+	searchQuery := strings.TrimSpace(urlArgs.Get("q"))
 
-	_, err := strconv.Atoi(searchQuery)
-	if err == nil {
-		blockResult := &dbtypes.SearchBlockResult{}
-		err = db.ReaderDb.Get(blockResult, `
-			SELECT slot, root, status 
-			FROM slots 
-			WHERE slot = $1 AND status != 0
-			LIMIT 1`, searchQuery)
-		if err == nil {
-			if blockResult.Status == dbtypes.Orphaned {
-				http.Redirect(w, r, fmt.Sprintf("/slot/0x%x", blockResult.Root), http.StatusMovedPermanently)
-			} else {
-				http.Redirect(w, r, fmt.Sprintf("/slot/%v", blockResult.Slot), http.StatusMovedPermanently)
-			}
-			return
-		}
+	results := services.GlobalBeaconService.Search(r.Context(), searchQuery, 50)
+	if len(results) == 1 {
+		http.Redirect(w, r, utils.GetBasePath()+results[0].Link, http.StatusMovedPermanently)
+		return
 	}
 
-	hashQuery := strings.Replace(searchQuery, "0x", "", -1)
-	if len(hashQuery) == 64 {
-		blockHash, err := hex.DecodeString(hashQuery)
-		if err == nil {
-			blockResult := &dbtypes.SearchBlockResult{}
-			err = db.ReaderDb.Get(blockResult, `
-			SELECT slot, root, orphaned 
-			FROM slots 
-			WHERE root = $1 OR
-				state_root = $1
-			LIMIT 1`, blockHash)
-			if err == nil {
-				if blockResult.Status == dbtypes.Orphaned {
-					http.Redirect(w, r, fmt.Sprintf("/slot/0x%x", blockResult.Root), http.StatusMovedPermanently)
-				} else {
-					http.Redirect(w, r, fmt.Sprintf("/slot/%v", blockResult.Slot), http.StatusMovedPermanently)
-				}
-				return
-			}
+	w.Header().Set("Content-Type", "text/html")
+	if len(results) == 0 {
+		data := InitPageData(w, r, "search", "/search", fmt.Sprintf("Search: %v", searchQuery), notfoundTemplateFiles)
+		if handleTemplateError(w, r, "search.go", "Search", "", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
 		}
-	}
-
-	names := &dbtypes.SearchNameResult{}
-	err = db.ReaderDb.Get(names, db.EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql: `
-			SELECT name
-			FROM validator_names
-			WHERE name ILIKE LOWER($1)
-			LIMIT 1`,
-		dbtypes.DBEngineSqlite: `
-			SELECT name
-			FROM validator_names
-			WHERE name LIKE LOWER($1)
-			LIMIT 1`,
-	}), "%"+searchQuery+"%")
-	if err == nil {
-		http.Redirect(w, r, "/slots/filtered?f&f.missing=1&f.orphaned=1&f.pname="+searchQuery, http.StatusMovedPermanently)
 		return
 	}
 
-	graffiti := &dbtypes.SearchGraffitiResult{}
-	err = db.ReaderDb.Get(graffiti, db.EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql: `
-			SELECT graffiti
-			FROM slots
-			WHERE graffiti_text ILIKE LOWER($1)
-			LIMIT 1`,
-		dbtypes.DBEngineSqlite: `
-			SELECT graffiti
-			FROM slots
-			WHERE graffiti_text LIKE LOWER($1)
-			LIMIT 1`,
-	}), "%"+searchQuery+"%")
-	if err == nil {
-		http.Redirect(w, r, "/slots/filtered?f&f.missing=1&f.orphaned=1&f.graffiti="+searchQuery, http.StatusMovedPermanently)
-		return
+	pageData := &models.SearchResultPageData{
+		Query:       searchQuery,
+		Results:     make([]*models.SearchResultPageDataResult, len(results)),
+		ResultCount: uint64(len(results)),
+	}
+	for idx, result := range results {
+		pageData.Results[idx] = &models.SearchResultPageDataResult{
+			Type:        result.Type.String(),
+			TypeLabel:   searchResultTypeLabels[result.Type],
+			Score:       result.Score,
+			Title:       result.Title,
+			Description: result.Description,
+			Link:        result.Link,
+			Orphaned:    result.Orphaned,
+		}
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	data := InitPageData(w, r, "search", "/search", fmt.Sprintf("Search: %v", searchQuery), notfoundTemplateFiles)
-	if handleTemplateError(w, r, "search.go", "Search", "", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+	data := InitPageData(w, r, "search", "/search", fmt.Sprintf("Search: %v", searchQuery), resultsTemplateFiles)
+	data.Data = pageData
+	if handleTemplateError(w, r, "search.go", "Search", "", templates.GetTemplate(resultsTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
 		return // an error has occurred and was processed
 	}
 }
@@ -134,14 +110,30 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 	_, pruneEpoch := indexer.GetBlockCacheState()
 	chainState := services.GlobalBeaconService.GetChainState()
 	minSlotIdx := chainState.EpochStartSlot(pruneEpoch)
+	dbStore := db.WithContext(r.Context())
 
 	switch searchType {
+	case "all":
+		// unified search across all entity types, the query is passed unmodified (incl. 0x prefix)
+		searchResults := services.GlobalBeaconService.SearchAhead(r.Context(), urlArgs.Get("q"), 10)
+		model := make([]models.SearchAheadResult, len(searchResults))
+		for idx, entry := range searchResults {
+			model[idx] = models.SearchAheadResult{
+				Type:        entry.Type.String(),
+				TypeLabel:   searchResultTypeLabels[entry.Type],
+				Title:       utils.FormatGraffitiString(entry.Title),
+				Description: utils.FormatGraffitiString(entry.Description),
+				Link:        entry.Link,
+				Orphaned:    entry.Orphaned,
+			}
+		}
+		result = model
 	case "epochs":
-		dbres := &dbtypes.SearchAheadEpochsResult{}
-		err = db.ReaderDb.Select(dbres, "SELECT epoch FROM epochs WHERE CAST(epoch AS text) LIKE $1 ORDER BY epoch LIMIT 10", search+"%")
+		var dbres dbtypes.SearchAheadEpochsResult
+		dbres, err = dbStore.SearchAheadEpochs(search)
 		if err == nil {
-			model := make([]models.SearchAheadEpochsResult, len(*dbres))
-			for idx, entry := range *dbres {
+			model := make([]models.SearchAheadEpochsResult, len(dbres))
+			for idx, entry := range dbres {
 				model[idx] = models.SearchAheadEpochsResult{
 					Epoch: fmt.Sprintf("%v", entry.Epoch),
 				}
@@ -177,23 +169,18 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 					},
 				}
 			} else {
-				dbres := &dbtypes.SearchAheadSlotsResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, status 
-				FROM slots 
-				WHERE slot < $1 AND (root = $2 OR state_root = $2)
-				ORDER BY slot LIMIT 1`, minSlotIdx, blockHash)
+				dbres, err := dbStore.SearchAheadSlotsByHash(blockHash, uint64(minSlotIdx))
 				if err != nil {
 					logger.Errorf("error reading block root: %v", err)
 					http.Error(w, "Internal server error", http.StatusServiceUnavailable)
 					return
 				}
-				if len(*dbres) > 0 {
+				if len(dbres) > 0 {
 					result = &[]models.SearchAheadSlotsResult{
 						{
-							Slot:     fmt.Sprintf("%v", (*dbres)[0].Slot),
-							Root:     phase0.Root((*dbres)[0].Root),
-							Orphaned: (*dbres)[0].Status == dbtypes.Orphaned,
+							Slot:     fmt.Sprintf("%v", dbres[0].Slot),
+							Root:     phase0.Root(dbres[0].Root),
+							Orphaned: dbres[0].Status == dbtypes.Orphaned,
 						},
 					}
 				}
@@ -216,15 +203,11 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 				}
 				result = res
 			} else {
-				dbres := &dbtypes.SearchAheadSlotsResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, status 
-				FROM slots 
-				WHERE slot = $1 AND status != 0
-				ORDER BY slot LIMIT 10`, blockNumber)
+				var dbres dbtypes.SearchAheadSlotsResult
+				dbres, err = dbStore.SearchAheadSlotsByNumber(blockNumber)
 				if err == nil {
-					model := make([]models.SearchAheadSlotsResult, len(*dbres))
-					for idx, entry := range *dbres {
+					model := make([]models.SearchAheadSlotsResult, len(dbres))
+					for idx, entry := range dbres {
 						model[idx] = models.SearchAheadSlotsResult{
 							Slot:     fmt.Sprintf("%v", entry.Slot),
 							Root:     phase0.Root(entry.Root),
@@ -266,25 +249,20 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 				}
 				result = res
 			} else {
-				dbres := &dbtypes.SearchAheadExecBlocksResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, eth_block_hash, eth_block_number, status 
-				FROM slots 
-				WHERE slot < $1 AND eth_block_hash = $2
-				ORDER BY slot LIMIT 10`, minSlotIdx, blockHash)
+				dbres, err := dbStore.SearchAheadExecBlocksByHash(blockHash, uint64(minSlotIdx))
 				if err != nil {
 					logger.Errorf("error reading block: %v", err)
 					http.Error(w, "Internal server error", http.StatusServiceUnavailable)
 					return
 				}
-				if len(*dbres) > 0 {
+				if len(dbres) > 0 {
 					result = &[]models.SearchAheadExecBlocksResult{
 						{
-							Slot:       fmt.Sprintf("%v", (*dbres)[0].Slot),
-							Root:       phase0.Root((*dbres)[0].Root),
-							ExecHash:   phase0.Hash32((*dbres)[0].ExecHash),
-							ExecNumber: (*dbres)[0].ExecNumber,
-							Orphaned:   (*dbres)[0].Status == dbtypes.Orphaned,
+							Slot:       fmt.Sprintf("%v", dbres[0].Slot),
+							Root:       phase0.Root(dbres[0].Root),
+							ExecHash:   phase0.Hash32(dbres[0].ExecHash),
+							ExecNumber: dbres[0].ExecNumber,
+							Orphaned:   dbres[0].Status == dbtypes.Orphaned,
 						},
 					}
 				}
@@ -307,15 +285,11 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 				}
 				result = res
 			} else {
-				dbres := &dbtypes.SearchAheadExecBlocksResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, eth_block_hash, eth_block_number, status 
-				FROM slots 
-				WHERE slot < $1 AND eth_block_number = $2
-				ORDER BY slot LIMIT 10`, minSlotIdx, blockNumber)
+				var dbres dbtypes.SearchAheadExecBlocksResult
+				dbres, err = dbStore.SearchAheadExecBlocksByNumber(blockNumber, uint64(minSlotIdx))
 				if err == nil {
-					model := make([]models.SearchAheadExecBlocksResult, len(*dbres))
-					for idx, entry := range *dbres {
+					model := make([]models.SearchAheadExecBlocksResult, len(dbres))
+					for idx, entry := range dbres {
 						model[idx] = models.SearchAheadExecBlocksResult{
 							Slot:       fmt.Sprintf("%v", entry.Slot),
 							Root:       phase0.Root(entry.Root),
@@ -329,26 +303,11 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	case "graffiti":
-		graffiti := &dbtypes.SearchAheadGraffitiResult{}
-		err = db.ReaderDb.Select(graffiti, db.EngineQuery(map[dbtypes.DBEngineType]string{
-			dbtypes.DBEnginePgsql: `
-				SELECT graffiti, count(*) as count
-				FROM slots
-				WHERE graffiti_text ILIKE LOWER($1)
-				GROUP BY graffiti
-				ORDER BY count desc
-				LIMIT 10`,
-			dbtypes.DBEngineSqlite: `
-				SELECT graffiti, count(*) as count
-				FROM slots
-				WHERE graffiti_text LIKE LOWER($1)
-				GROUP BY graffiti
-				ORDER BY count desc
-				LIMIT 10`,
-		}), "%"+search+"%")
+		var graffiti dbtypes.SearchAheadGraffitiResult
+		graffiti, err = dbStore.SearchAheadGraffiti(search)
 		if err == nil {
-			model := make([]models.SearchAheadGraffitiResult, len(*graffiti))
-			for i, entry := range *graffiti {
+			model := make([]models.SearchAheadGraffitiResult, len(graffiti))
+			for i, entry := range graffiti {
 				model[i] = models.SearchAheadGraffitiResult{
 					Graffiti: utils.FormatGraffitiString(entry.Graffiti),
 					Count:    fmt.Sprintf("%v", entry.Count),
@@ -357,28 +316,11 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 			result = model
 		}
 	case "valname":
-		names := &dbtypes.SearchAheadValidatorNameResult{}
-		err = db.ReaderDb.Select(names, db.EngineQuery(map[dbtypes.DBEngineType]string{
-			dbtypes.DBEnginePgsql: `
-				SELECT name, count(*) as count
-				FROM validator_names
-				LEFT JOIN slots ON validator_names."index" = slots.proposer
-				WHERE name ILIKE LOWER($1)
-				GROUP BY name
-				ORDER BY count desc
-				LIMIT 10`,
-			dbtypes.DBEngineSqlite: `
-				SELECT name, count(*) as count
-				FROM validator_names
-				LEFT JOIN slots ON validator_names."index" = slots.proposer
-				WHERE name LIKE LOWER($1)
-				GROUP BY name
-				ORDER BY count desc
-				LIMIT 10`,
-		}), "%"+search+"%")
+		var names dbtypes.SearchAheadValidatorNameResult
+		names, err = dbStore.SearchAheadValidatorNames(search)
 		if err == nil {
-			model := make([]models.SearchAheadValidatorNameResult, len(*names))
-			for i, entry := range *names {
+			model := make([]models.SearchAheadValidatorNameResult, len(names))
+			for i, entry := range names {
 				model[i] = models.SearchAheadValidatorNameResult{
 					Name:  utils.FormatGraffitiString(entry.Name),
 					Count: fmt.Sprintf("%v", entry.Count),
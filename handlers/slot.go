@@ -18,12 +18,14 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/gorilla/mux"
 	"github.com/juliangruber/go-intersect"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
@@ -47,6 +49,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		"slot/deposit_requests.html",
 		"slot/withdrawal_requests.html",
 		"slot/consolidation_requests.html",
+		"slot/timings.html",
 	)
 	var notfoundTemplateFiles = append(layoutTemplateFiles,
 		"slot/notfound.html",
@@ -61,6 +64,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		blockSlot, err = strconv.ParseInt(vars["slotOrHash"], 10, 64)
 		if err != nil || blockSlot >= 2147483648 { // block slot must be lower then max int4
 			data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Slot %v", slotOrHash), notfoundTemplateFiles)
+			data.Data = &models.SlotNotFoundPageData{}
 			w.Header().Set("Content-Type", "text/html")
 			if handleTemplateError(w, r, "slot.go", "Slot", "blockSlot", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
 				return // an error has occurred and was processed
@@ -83,7 +87,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 	}
 	if pageData == nil {
 		data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Slot %v", slotOrHash), notfoundTemplateFiles)
-		data.Data = "slot"
+		data.Data = getSlotNotFoundPageData(blockSlot)
 		w.Header().Set("Content-Type", "text/html")
 		if handleTemplateError(w, r, "slot.go", "Slot", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
 			return // an error has occurred and was processed
@@ -91,7 +95,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if urlArgs.Has("blob") && pageData.Block != nil {
+	if urlArgs.Has("blob") && pageData.Block != nil && !pageData.Block.BlobsPruned {
 		commitment, err1 := hex.DecodeString(strings.Replace(urlArgs.Get("blob"), "0x", "", -1))
 		blobData, err2 := services.GlobalBeaconService.GetBlockBlob(r.Context(), phase0.Root(pageData.Block.BlockRoot), deneb.KZGCommitment(commitment))
 		if err1 == nil && err2 == nil && blobData != nil {
@@ -142,6 +146,11 @@ func SlotBlob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if blockHead := db.WithContext(r.Context()).GetBlockHeadByRoot(blockRoot); blockHead != nil && services.GlobalRetentionService.IsBlobDataPruned(phase0.Slot(blockHead.Slot)) {
+		http.Error(w, "Blob data has been pruned", http.StatusNotFound)
+		return
+	}
+
 	blobData, err := services.GlobalBeaconService.GetBlockBlob(r.Context(), phase0.Root(blockRoot), deneb.KZGCommitment(commitment))
 	if err != nil {
 		logrus.WithError(err).Error("error loading blob data")
@@ -160,6 +169,24 @@ func SlotBlob(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getSlotNotFoundPageData checks if the requested slot might not be found because it is before the bootstrap checkpoint.
+// block roots can't be assigned to an epoch, so the notice is shown for all unknown roots.
+func getSlotNotFoundPageData(blockSlot int64) *models.SlotNotFoundPageData {
+	pageData := &models.SlotNotFoundPageData{}
+
+	bootstrapEpoch := services.GlobalBeaconService.GetBeaconIndexer().GetBootstrapEpoch()
+	if bootstrapEpoch == 0 {
+		return pageData
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	if blockSlot < 0 || chainState.EpochOfSlot(phase0.Slot(blockSlot)) < bootstrapEpoch {
+		pageData.NotIndexedBefore = uint64(bootstrapEpoch)
+	}
+
+	return pageData
+}
+
 func getSlotPageData(blockSlot int64, blockRoot []byte) (*models.SlotPageData, error) {
 	pageData := &models.SlotPageData{}
 	pageCacheKey := fmt.Sprintf("slot:%v:%x", blockSlot, blockRoot)
@@ -252,7 +279,11 @@ func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (
 			}
 		}
 		if pageData.Proposer == math.MaxInt64 {
-			pageData.Proposer = db.GetSlotAssignment(uint64(slot))
+			pageData.Proposer = db.WithContext(ctx).GetSlotAssignment(uint64(slot))
+		}
+		if pageData.Proposer == math.MaxInt64 && slot < services.GlobalRetentionService.GetMissedSlotsPrunedBefore() {
+			// missed slot rows of old epochs have been compacted into ranges by the retention service
+			pageData.ProposerPruned = len(db.WithContext(ctx).GetMissedSlotRanges(uint64(slot), uint64(slot))) > 0
 		}
 		pageData.ProposerName = services.GlobalBeaconService.GetValidatorName(pageData.Proposer)
 	} else {
@@ -264,10 +295,11 @@ func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (
 		pageData.Proposer = uint64(blockData.Header.Message.ProposerIndex)
 		pageData.ProposerName = services.GlobalBeaconService.GetValidatorName(pageData.Proposer)
 		pageData.Block = getSlotPageBlockData(blockData, epochStatsValues)
+		getSlotPageTimings(pageData.Block, services.GlobalBeaconService.GetBlockTimings(ctx, blockData.Root))
 
 		// check mev block
 		if pageData.Block.ExecutionData != nil {
-			mevBlock := db.GetMevBlockByBlockHash(pageData.Block.ExecutionData.BlockHash)
+			mevBlock := db.WithContext(ctx).GetMevBlockByBlockHash(pageData.Block.ExecutionData.BlockHash)
 			if mevBlock != nil {
 				relays := []string{}
 				for _, relay := range utils.Config.MevIndexer.Relays {
@@ -715,6 +747,7 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 
 	if specs.DenebForkEpoch != nil && uint64(epoch) >= *specs.DenebForkEpoch {
 		pageData.BlobsCount = uint64(len(blobKzgCommitments))
+		pageData.BlobsPruned = services.GlobalRetentionService.IsBlobDataPruned(blockData.Header.Message.Slot)
 		pageData.Blobs = make([]*models.SlotPageBlob, pageData.BlobsCount)
 		for i := range blobKzgCommitments {
 			blobData := &models.SlotPageBlob{
@@ -728,6 +761,24 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 	return pageData
 }
 
+func getSlotPageTimings(pageData *models.SlotPageBlockData, timings []*dbtypes.BlockTiming) {
+	pageData.Timings = make([]*models.SlotPageBlockTiming, len(timings))
+	pageData.TimingsCount = uint64(len(timings))
+
+	for idx, timing := range timings {
+		pageTiming := &models.SlotPageBlockTiming{
+			ClientName: timing.ClientName,
+			SeenDelay:  timing.SeenDelay,
+		}
+		if timing.HeadDelay != nil {
+			pageTiming.HasHead = true
+			pageTiming.HeadDelay = *timing.HeadDelay
+			pageTiming.SwitchDelay = *timing.HeadDelay - timing.SeenDelay
+		}
+		pageData.Timings[idx] = pageTiming
+	}
+}
+
 func getSlotPageTransactions(pageData *models.SlotPageBlockData, tranactions []bellatrix.Transaction) {
 	pageData.Transactions = make([]*models.SlotPageTransaction, 0)
 	sigLookupBytes := []types.TxSignatureBytes{}
@@ -804,6 +855,92 @@ func getSlotPageTransactions(pageData *models.SlotPageBlockData, tranactions []b
 			}
 		}
 	}
+
+	// decode call arguments
+	sigRegistry := services.GlobalTxSignaturesService.GetRegistry()
+	for _, txData := range pageData.Transactions {
+		if txData.DataLen < 4 {
+			continue
+		}
+
+		var contract *common.Address
+		if txData.To != "new contract" {
+			address := common.HexToAddress(txData.To)
+			contract = &address
+		}
+
+		args, err := sigRegistry.DecodeCallData(contract, txData.FuncSig, txData.Data)
+		if err != nil {
+			logrus.Debugf("error decoding call data for tx 0x%x: %v", txData.Hash, err)
+			continue
+		}
+		txData.FuncArgs = convertSlotPageTransactionArgs(args)
+	}
+
+	// load & decode event logs
+	if !utils.Config.TxSignature.DisableLogDecoding && pageData.ExecutionData != nil && len(pageData.Transactions) > 0 {
+		getSlotPageTransactionLogs(pageData, sigRegistry)
+	}
+}
+
+func getSlotPageTransactionLogs(pageData *models.SlotPageBlockData, sigRegistry *services.TxSignatureRegistry) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipts, err := services.GlobalBeaconService.GetBlockReceipts(ctx, common.BytesToHash(pageData.ExecutionData.BlockHash))
+	if err != nil {
+		logrus.Debugf("error loading block receipts for 0x%x: %v", pageData.ExecutionData.BlockHash, err)
+		return
+	}
+
+	receiptMap := make(map[common.Hash]*ethtypes.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		receiptMap[receipt.TxHash] = receipt
+	}
+
+	for _, txData := range pageData.Transactions {
+		receipt := receiptMap[common.BytesToHash(txData.Hash)]
+		if receipt == nil {
+			continue
+		}
+
+		txData.LogsLoaded = true
+		txData.ReceiptFail = receipt.Status == ethtypes.ReceiptStatusFailed
+		txData.Logs = make([]*models.SlotPageTransactionLog, len(receipt.Logs))
+		for idx, log := range receipt.Logs {
+			decodedLog := sigRegistry.DecodeLog(log)
+			logData := &models.SlotPageTransactionLog{
+				Index:     decodedLog.Index,
+				Address:   decodedLog.Address[:],
+				Name:      decodedLog.Name,
+				Signature: decodedLog.Signature,
+				Topics:    make([][]byte, len(decodedLog.Topics)),
+				Data:      decodedLog.Data,
+				Args:      convertSlotPageTransactionArgs(decodedLog.Args),
+			}
+			for tidx, topic := range decodedLog.Topics {
+				logData.Topics[tidx] = topic[:]
+			}
+			txData.Logs[idx] = logData
+		}
+	}
+}
+
+func convertSlotPageTransactionArgs(args []*services.TxDecodedArg) []*models.SlotPageTransactionArg {
+	if len(args) == 0 {
+		return nil
+	}
+
+	resArgs := make([]*models.SlotPageTransactionArg, len(args))
+	for idx, arg := range args {
+		resArgs[idx] = &models.SlotPageTransactionArg{
+			Name:    arg.Name,
+			Type:    arg.Type,
+			Value:   arg.Value,
+			Indexed: arg.Indexed,
+		}
+	}
+	return resArgs
 }
 
 func getSlotPageDepositRequests(pageData *models.SlotPageBlockData, depositRequests []*electra.DepositRequest) {
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+const slotsLatePageSize = 50
+
+// SlotsLate will return the "late blocks" page using a go template
+func SlotsLate(w http.ResponseWriter, r *http.Request) {
+	var slotsLateTemplateFiles = append(layoutTemplateFiles,
+		"slots_late/slots_late.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(slotsLateTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots/late", "Late Blocks", slotsLateTemplateFiles)
+
+	// blocks are considered late when they arrive after the attestation deadline (1/3 into the slot) by default
+	specs := services.GlobalBeaconService.GetChainState().GetSpecs()
+	minDelay := specs.SecondsPerSlot.Milliseconds() / 3
+	pageIdx := uint64(1)
+
+	urlArgs := r.URL.Query()
+	if urlArgs.Has("delay") {
+		if delay, err := strconv.ParseInt(urlArgs.Get("delay"), 10, 64); err == nil && delay >= 0 {
+			minDelay = delay
+		}
+	}
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getSlotsLatePageData(minDelay, pageIdx)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "slots_late.go", "Late Blocks", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getSlotsLatePageData(minDelay int64, pageIdx uint64) (*models.SlotsLatePageData, error) {
+	pageData := &models.SlotsLatePageData{}
+	pageCacheKey := fmt.Sprintf("slots_late:%v:%v", minDelay, pageIdx)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildSlotsLatePageData(pageCall.CallCtx, minDelay, pageIdx)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.SlotsLatePageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildSlotsLatePageData(ctx context.Context, minDelay int64, pageIdx uint64) (*models.SlotsLatePageData, time.Duration) {
+	logrus.Debugf("late blocks page called: %v:%v", minDelay, pageIdx)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	pageData := &models.SlotsLatePageData{
+		MinDelay:    minDelay,
+		StatsPeriod: "24 hours",
+	}
+
+	// per client stats of the last day
+	statsSlots := uint64(24*time.Hour) / uint64(specs.SecondsPerSlot)
+	statsFirstSlot := uint64(0)
+	if currentSlot := uint64(chainState.CurrentSlot()); currentSlot > statsSlots {
+		statsFirstSlot = currentSlot - statsSlots
+	}
+
+	for _, clientStats := range db.WithContext(ctx).GetBlockTimingClientStats(statsFirstSlot, minDelay) {
+		client := &models.SlotsLatePageDataClient{
+			Name:           clientStats.ClientName,
+			BlockCount:     clientStats.BlockCount,
+			LateCount:      clientStats.LateCount,
+			AvgSeenDelay:   int64(math.Round(clientStats.AvgSeenDelay)),
+			MaxSeenDelay:   clientStats.MaxSeenDelay,
+			HeadCount:      clientStats.HeadCount,
+			AvgSwitchDelay: int64(math.Round(clientStats.AvgSwitchDelay)),
+		}
+		if clientStats.BlockCount > 0 {
+			client.LatePercent = float64(clientStats.LateCount) * 100 / float64(clientStats.BlockCount)
+		}
+		pageData.Clients = append(pageData.Clients, client)
+	}
+
+	// blocks of the last day that arrived late on all clients
+	lateBlocks, totalCount, err := db.WithContext(ctx).GetLateBlocks(statsFirstSlot, minDelay, (pageIdx-1)*slotsLatePageSize, slotsLatePageSize)
+	if err != nil {
+		return pageData, 1 * time.Minute
+	}
+
+	for _, lateBlock := range lateBlocks {
+		pageData.Blocks = append(pageData.Blocks, &models.SlotsLatePageDataBlock{
+			Slot:         lateBlock.Slot,
+			Epoch:        uint64(chainState.EpochOfSlot(phase0.Slot(lateBlock.Slot))),
+			Ts:           chainState.SlotToTime(phase0.Slot(lateBlock.Slot)),
+			BlockRoot:    lateBlock.Root,
+			Status:       lateBlock.Status,
+			Proposer:     lateBlock.Proposer,
+			ProposerName: services.GlobalBeaconService.GetValidatorName(lateBlock.Proposer),
+			ClientCount:  lateBlock.ClientCount,
+			MinSeenDelay: lateBlock.MinSeenDelay,
+			AvgSeenDelay: int64(math.Round(lateBlock.AvgSeenDelay)),
+			MaxSeenDelay: lateBlock.MaxSeenDelay,
+		})
+	}
+	pageData.BlockCount = totalCount
+
+	pageData.CurrentPageIndex = pageIdx
+	pageData.TotalPages = (totalCount + slotsLatePageSize - 1) / slotsLatePageSize
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	return pageData, 1 * time.Minute
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"net/http"
@@ -85,7 +86,7 @@ func getValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, err
 	pageData := &models.ValidatorPageData{}
 	pageCacheKey := fmt.Sprintf("validator:%v", validatorIndex)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildValidatorPageData(validatorIndex)
+		pageData, cacheTimeout := buildValidatorPageData(pageCall.CallCtx, validatorIndex)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -99,7 +100,7 @@ func getValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, err
 	return pageData, pageErr
 }
 
-func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, time.Duration) {
+func buildValidatorPageData(ctx context.Context, validatorIndex uint64) (*models.ValidatorPageData, time.Duration) {
 	logrus.Debugf("validator page called: %v", validatorIndex)
 
 	chainState := services.GlobalBeaconService.GetChainState()
@@ -165,7 +166,7 @@ func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, t
 
 	// load latest blocks
 	pageData.RecentBlocks = make([]*models.ValidatorPageDataBlocks, 0)
-	blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
+	blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(ctx, &dbtypes.BlockFilter{
 		ProposerIndex: &validatorIndex,
 		WithOrphaned:  1,
 		WithMissing:   1,
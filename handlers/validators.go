@@ -49,6 +49,7 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	var filterPubKey string
 	var filterIndex string
 	var filterName string
+	var filterWithdrawal string
 	var filterStatus string
 	if urlArgs.Has("f") {
 		if urlArgs.Has("f.pubkey") {
@@ -60,6 +61,9 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.name") {
 			filterName = urlArgs.Get("f.name")
 		}
+		if urlArgs.Has("f.withdrawal") {
+			filterWithdrawal = urlArgs.Get("f.withdrawal")
+		}
 		if urlArgs.Has("f.status") {
 			filterStatus = strings.Join(urlArgs["f.status"], ",")
 		}
@@ -72,7 +76,7 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
 	if pageError == nil {
-		data.Data, pageError = getValidatorsPageData(firstIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		data.Data, pageError = getValidatorsPageData(firstIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterWithdrawal, filterStatus)
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -94,11 +98,11 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, error) {
+func getValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterWithdrawal string, filterStatus string) (*models.ValidatorsPageData, error) {
 	pageData := &models.ValidatorsPageData{}
-	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v", firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v:%v", firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterWithdrawal, filterStatus)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildValidatorsPageData(firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		pageData, cacheTimeout := buildValidatorsPageData(firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterWithdrawal, filterStatus)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -112,8 +116,8 @@ func getValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string
 	return pageData, pageErr
 }
 
-func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, time.Duration) {
-	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v", firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterWithdrawal string, filterStatus string) (*models.ValidatorsPageData, time.Duration) {
+	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v:%v", firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterWithdrawal, filterStatus)
 	pageData := &models.ValidatorsPageData{}
 	cacheTime := 10 * time.Minute
 
@@ -146,9 +150,10 @@ func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder stri
 	})
 
 	filterArgs := url.Values{}
-	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" {
+	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterWithdrawal != "" || filterStatus != "" {
 		var filterPubKeyVal []byte
 		var filterIndexVal uint64
+		var filterWithdrawalVal []byte
 		var filterStatusVal []string
 
 		if filterPubKey != "" {
@@ -162,6 +167,10 @@ func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder stri
 		if filterName != "" {
 			filterArgs.Add("f.name", filterName)
 		}
+		if filterWithdrawal != "" {
+			filterArgs.Add("f.withdrawal", filterWithdrawal)
+			filterWithdrawalVal, _ = hex.DecodeString(strings.Replace(filterWithdrawal, "0x", "", -1))
+		}
 		if filterStatus != "" {
 			filterArgs.Add("f.status", filterStatus)
 			filterStatusVal = strings.Split(filterStatus, ",")
@@ -182,6 +191,13 @@ func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder stri
 					continue
 				}
 			}
+			if filterWithdrawal != "" {
+				// only 0x01 / 0x02 credentials contain an execution layer withdrawal address
+				withdrawalCreds := val.Validator.WithdrawalCredentials
+				if len(withdrawalCreds) != 32 || withdrawalCreds[0] == 0x00 || !bytes.Equal(withdrawalCreds[12:], filterWithdrawalVal) {
+					continue
+				}
+			}
 			if filterStatus != "" && !utils.SliceContains(filterStatusVal, val.Status.String()) {
 				continue
 			}
@@ -192,6 +208,7 @@ func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder stri
 	pageData.FilterPubKey = filterPubKey
 	pageData.FilterIndex = filterIndex
 	pageData.FilterName = filterName
+	pageData.FilterWithdrawal = filterWithdrawal
 	pageData.FilterStatus = filterStatus
 
 	// apply sort order
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math/rand/v2"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	dynssz "github.com/pk910/dynamic-ssz"
@@ -20,21 +23,30 @@ type Block struct {
 	Slot              phase0.Slot
 	dynSsz            *dynssz.DynSsz
 	parentRoot        *phase0.Root
-	dependentRoot     *phase0.Root
-	forkId            ForkKey
+	dependentRoot     atomic.Pointer[phase0.Root]
+	forkId            atomic.Uint64
 	fokChecked        bool
 	headerMutex       sync.Mutex
 	headerChan        chan bool
-	header            *phase0.SignedBeaconBlockHeader
+	header            atomic.Pointer[phase0.SignedBeaconBlockHeader]
 	blockMutex        sync.Mutex
 	blockChan         chan bool
-	block             *spec.VersionedSignedBeaconBlock
+	block             atomic.Pointer[spec.VersionedSignedBeaconBlock]
 	blockIndex        *BlockBodyIndex
 	isInFinalizedDb   bool // block is in finalized table (slots)
 	isInUnfinalizedDb bool // block is in unfinalized table (unfinalized_blocks)
 	processingStatus  dbtypes.UnfinalizedBlockStatus
 	seenMutex         sync.RWMutex
 	seenMap           map[uint16]*Client
+	seenTimes         map[uint16]time.Time // time the block was first received via the event stream of the client
+	headTimes         map[uint16]time.Time // time the client switched its head to this block
+}
+
+// BlockClientTiming holds the times a client reported a block via its event stream.
+type BlockClientTiming struct {
+	Client   *Client
+	SeenTime time.Time
+	HeadTime time.Time // zero if the client never switched its head to this block
 }
 
 // BlockBodyIndex holds important block propoerties that are used as index for cache lookups.
@@ -53,6 +65,8 @@ func newBlock(dynSsz *dynssz.DynSsz, root phase0.Root, slot phase0.Slot) *Block
 		Slot:       slot,
 		dynSsz:     dynSsz,
 		seenMap:    make(map[uint16]*Client),
+		seenTimes:  make(map[uint16]time.Time),
+		headTimes:  make(map[uint16]time.Time),
 		headerChan: make(chan bool),
 		blockChan:  make(chan bool),
 	}
@@ -66,6 +80,10 @@ func (block *Block) GetSeenBy() []*Client {
 	clients := []*Client{}
 
 	for _, client := range block.seenMap {
+		if client.isStopped() {
+			continue
+		}
+
 		clients = append(clients, client)
 	}
 
@@ -83,13 +101,102 @@ func (block *Block) SetSeenBy(client *Client) {
 	block.seenMap[client.index] = client
 }
 
-// GetHeader returns the signed beacon block header of this block.
-func (block *Block) GetHeader() *phase0.SignedBeaconBlockHeader {
-	if block.header != nil {
-		return block.header
+// setSeenTime sets the time the client first reported this block via its event stream.
+func (block *Block) setSeenTime(client *Client, seenTime time.Time) {
+	block.seenMutex.Lock()
+	defer block.seenMutex.Unlock()
+	if _, exists := block.seenTimes[client.index]; !exists {
+		block.seenTimes[client.index] = seenTime
+	}
+}
+
+// setHeadTime sets the time the client switched its head to this block.
+func (block *Block) setHeadTime(client *Client, headTime time.Time) {
+	block.seenMutex.Lock()
+	defer block.seenMutex.Unlock()
+	if _, exists := block.headTimes[client.index]; !exists {
+		block.headTimes[client.index] = headTime
+	}
+}
+
+// GetClientTimings returns the stream arrival & head switch times of all clients that reported this block, ordered by arrival.
+func (block *Block) GetClientTimings() []*BlockClientTiming {
+	block.seenMutex.RLock()
+	defer block.seenMutex.RUnlock()
+
+	timings := make([]*BlockClientTiming, 0, len(block.seenTimes))
+	for clientIdx, seenTime := range block.seenTimes {
+		client := block.seenMap[clientIdx]
+		if client == nil {
+			continue
+		}
+
+		timings = append(timings, &BlockClientTiming{
+			Client:   client,
+			SeenTime: seenTime,
+			HeadTime: block.headTimes[clientIdx],
+		})
+	}
+
+	sort.Slice(timings, func(a, b int) bool {
+		return timings[a].SeenTime.Before(timings[b].SeenTime)
+	})
+
+	return timings
+}
+
+// GetDbTimings returns the client timings of this block relative to the slot start time.
+// multiple clients with the same name are merged into one timing with the earliest arrival & head switch times.
+func (block *Block) GetDbTimings(chainState *consensus.ChainState) []*dbtypes.BlockTiming {
+	slotTime := chainState.SlotToTime(block.Slot)
+	clientTimings := block.GetClientTimings()
+	dbTimings := make([]*dbtypes.BlockTiming, len(clientTimings))
+	for idx, timing := range clientTimings {
+		dbTiming := &dbtypes.BlockTiming{
+			Root:       block.Root[:],
+			Slot:       uint64(block.Slot),
+			ClientName: timing.Client.client.GetName(),
+			SeenDelay:  timing.SeenTime.Sub(slotTime).Milliseconds(),
+		}
+		if !timing.HeadTime.IsZero() {
+			headDelay := timing.HeadTime.Sub(slotTime).Milliseconds()
+			dbTiming.HeadDelay = &headDelay
+		}
+		dbTimings[idx] = dbTiming
+	}
+
+	return mergeBlockTimings(dbTimings)
+}
+
+// mergeBlockTimings merges the timings of clients with the same name (root & client name are the primary key of block_timings).
+// the earliest arrival & head switch delays are kept, the order of the first occurrence of each client name is preserved.
+func mergeBlockTimings(timings []*dbtypes.BlockTiming) []*dbtypes.BlockTiming {
+	mergedTimings := make([]*dbtypes.BlockTiming, 0, len(timings))
+	clientTimings := make(map[string]*dbtypes.BlockTiming, len(timings))
+
+	for _, timing := range timings {
+		mergedTiming := clientTimings[timing.ClientName]
+		if mergedTiming == nil {
+			timingCopy := *timing
+			clientTimings[timing.ClientName] = &timingCopy
+			mergedTimings = append(mergedTimings, &timingCopy)
+			continue
+		}
+
+		if timing.SeenDelay < mergedTiming.SeenDelay {
+			mergedTiming.SeenDelay = timing.SeenDelay
+		}
+		if timing.HeadDelay != nil && (mergedTiming.HeadDelay == nil || *timing.HeadDelay < *mergedTiming.HeadDelay) {
+			mergedTiming.HeadDelay = timing.HeadDelay
+		}
 	}
 
-	return block.header
+	return mergedTimings
+}
+
+// GetHeader returns the signed beacon block header of this block.
+func (block *Block) GetHeader() *phase0.SignedBeaconBlockHeader {
+	return block.header.Load()
 }
 
 // AwaitHeader waits for the signed beacon block header of this block to be available.
@@ -104,13 +211,13 @@ func (block *Block) AwaitHeader(ctx context.Context, timeout time.Duration) *pha
 	case <-ctx.Done():
 	}
 
-	return block.header
+	return block.header.Load()
 }
 
 // GetBlock returns the versioned signed beacon block of this block.
 func (block *Block) GetBlock() *spec.VersionedSignedBeaconBlock {
-	if block.block != nil {
-		return block.block
+	if blockBody := block.block.Load(); blockBody != nil {
+		return blockBody
 	}
 
 	if block.isInUnfinalizedDb {
@@ -138,7 +245,7 @@ func (block *Block) AwaitBlock(ctx context.Context, timeout time.Duration) *spec
 	case <-ctx.Done():
 	}
 
-	return block.block
+	return block.block.Load()
 }
 
 // GetParentRoot returns the parent root of this block.
@@ -147,16 +254,17 @@ func (block *Block) GetParentRoot() *phase0.Root {
 		return block.parentRoot
 	}
 
-	if block.header == nil {
+	header := block.header.Load()
+	if header == nil {
 		return nil
 	}
 
-	return &block.header.Message.ParentRoot
+	return &header.Message.ParentRoot
 }
 
 // SetHeader sets the signed beacon block header of this block.
 func (block *Block) SetHeader(header *phase0.SignedBeaconBlockHeader) {
-	block.header = header
+	block.header.Store(header)
 	if header != nil {
 		close(block.headerChan)
 	}
@@ -164,7 +272,7 @@ func (block *Block) SetHeader(header *phase0.SignedBeaconBlockHeader) {
 
 // EnsureHeader ensures that the signed beacon block header of this block is available.
 func (block *Block) EnsureHeader(loadHeader func() (*phase0.SignedBeaconBlockHeader, error)) error {
-	if block.header != nil {
+	if block.header.Load() != nil {
 		return nil
 	}
 
@@ -175,7 +283,7 @@ func (block *Block) EnsureHeader(loadHeader func() (*phase0.SignedBeaconBlockHea
 	block.headerMutex.Lock()
 	defer block.headerMutex.Unlock()
 
-	if block.header != nil {
+	if block.header.Load() != nil {
 		return nil
 	}
 
@@ -184,7 +292,7 @@ func (block *Block) EnsureHeader(loadHeader func() (*phase0.SignedBeaconBlockHea
 		return err
 	}
 
-	block.header = header
+	block.header.Store(header)
 	close(block.headerChan)
 
 	return nil
@@ -193,7 +301,7 @@ func (block *Block) EnsureHeader(loadHeader func() (*phase0.SignedBeaconBlockHea
 // SetBlock sets the versioned signed beacon block of this block.
 func (block *Block) SetBlock(body *spec.VersionedSignedBeaconBlock) {
 	block.setBlockIndex(body)
-	block.block = body
+	block.block.Store(body)
 
 	if block.blockChan != nil {
 		close(block.blockChan)
@@ -203,7 +311,7 @@ func (block *Block) SetBlock(body *spec.VersionedSignedBeaconBlock) {
 
 // EnsureBlock ensures that the versioned signed beacon block of this block is available.
 func (block *Block) EnsureBlock(loadBlock func() (*spec.VersionedSignedBeaconBlock, error)) (bool, error) {
-	if block.block != nil {
+	if block.block.Load() != nil {
 		return false, nil
 	}
 
@@ -214,7 +322,7 @@ func (block *Block) EnsureBlock(loadBlock func() (*spec.VersionedSignedBeaconBlo
 	block.blockMutex.Lock()
 	defer block.blockMutex.Unlock()
 
-	if block.block != nil {
+	if block.block.Load() != nil {
 		return false, nil
 	}
 
@@ -224,7 +332,7 @@ func (block *Block) EnsureBlock(loadBlock func() (*spec.VersionedSignedBeaconBlo
 	}
 
 	block.setBlockIndex(blockBody)
-	block.block = blockBody
+	block.block.Store(blockBody)
 	if block.blockChan != nil {
 		close(block.blockChan)
 		block.blockChan = nil
@@ -260,7 +368,7 @@ func (block *Block) GetBlockIndex() *BlockBodyIndex {
 
 // buildUnfinalizedBlock builds an unfinalized block from the block data.
 func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBlock, error) {
-	headerSSZ, err := block.header.MarshalSSZ()
+	headerSSZ, err := block.header.Load().MarshalSSZ()
 	if err != nil {
 		return nil, fmt.Errorf("marshal header ssz failed: %v", err)
 	}
@@ -278,13 +386,13 @@ func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBl
 		BlockVer:  blockVer,
 		BlockSSZ:  blockSSZ,
 		Status:    0,
-		ForkId:    uint64(block.forkId),
+		ForkId:    block.forkId.Load(),
 	}, nil
 }
 
 // buildOrphanedBlock builds an orphaned block from the block data.
 func (block *Block) buildOrphanedBlock(compress bool) (*dbtypes.OrphanedBlock, error) {
-	headerSSZ, err := block.header.MarshalSSZ()
+	headerSSZ, err := block.header.Load().MarshalSSZ()
 	if err != nil {
 		return nil, fmt.Errorf("marshal header ssz failed: %v", err)
 	}
@@ -303,15 +411,44 @@ func (block *Block) buildOrphanedBlock(compress bool) (*dbtypes.OrphanedBlock, e
 	}, nil
 }
 
+// buildBlockBody builds the persisted body of a canonical finalized block from the given header & block data.
+// unlike unfinalized & orphaned blocks, block bodies are compressed with zstd as they are kept for a long time.
+func (block *Block) buildBlockBody(header *phase0.SignedBeaconBlockHeader, blockData *spec.VersionedSignedBeaconBlock, compress bool) (*dbtypes.BlockBody, error) {
+	headerSSZ, err := header.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("marshal header ssz failed: %v", err)
+	}
+
+	blockVer, blockSSZ, err := marshalVersionedSignedBeaconBlockSSZ(block.dynSsz, blockData, false)
+	if err != nil {
+		return nil, fmt.Errorf("marshal block ssz failed: %v", err)
+	}
+
+	if compress {
+		blockSSZ = compressBytesZstd(blockSSZ)
+		blockVer |= zstdCompressionFlag
+	}
+
+	return &dbtypes.BlockBody{
+		Root:      block.Root[:],
+		Slot:      uint64(block.Slot),
+		HeaderVer: 1,
+		HeaderSSZ: headerSSZ,
+		BlockVer:  blockVer,
+		BlockSSZ:  blockSSZ,
+	}, nil
+}
+
 // unpruneBlockBody retrieves the block body from the database if it is not already present.
 func (block *Block) unpruneBlockBody() {
-	if block.block != nil || !block.isInUnfinalizedDb {
+	if block.block.Load() != nil || !block.isInUnfinalizedDb {
 		return
 	}
 
 	dbBlock := db.GetUnfinalizedBlock(block.Root[:])
 	if dbBlock != nil {
-		block.block, _ = unmarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
+		blockBody, _ := unmarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
+		block.block.Store(blockBody)
 	}
 }
 
@@ -375,5 +512,10 @@ func (block *Block) GetExecutionExtraData() []byte {
 
 // GetForkId returns the fork ID of this block.
 func (block *Block) GetForkId() ForkKey {
-	return block.forkId
+	return ForkKey(block.forkId.Load())
+}
+
+// setForkId sets the fork ID of this block.
+func (block *Block) setForkId(forkId ForkKey) {
+	block.forkId.Store(uint64(forkId))
 }
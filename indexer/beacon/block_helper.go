@@ -16,7 +16,8 @@ import (
 )
 
 var jsonVersionFlag uint64 = 0x40000000
-var compressionFlag uint64 = 0x20000000
+var compressionFlag uint64 = 0x20000000     // zlib, used for unfinalized & orphaned blocks
+var zstdCompressionFlag uint64 = 0x10000000 // zstd, used for persisted block bodies
 
 // marshalVersionedSignedBeaconBlockSSZ marshals a versioned signed beacon block using SSZ encoding.
 func marshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, block *spec.VersionedSignedBeaconBlock, compress bool) (version uint64, ssz []byte, err error) {
@@ -59,7 +60,15 @@ func marshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, block *spec.Ver
 
 // unmarshalVersionedSignedBeaconBlockSSZ unmarshals a versioned signed beacon block using SSZ encoding.
 func unmarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, version uint64, ssz []byte) (*spec.VersionedSignedBeaconBlock, error) {
-	if (version & compressionFlag) != 0 {
+	if (version & zstdCompressionFlag) != 0 {
+		// decompress
+		if d, err := decompressBytesZstd(ssz); err != nil {
+			return nil, fmt.Errorf("failed to decompress: %v", err)
+		} else {
+			ssz = d
+			version &= ^zstdCompressionFlag
+		}
+	} else if (version & compressionFlag) != 0 {
 		// decompress
 		if d, err := decompressBytes(ssz); err != nil {
 			return nil, fmt.Errorf("failed to decompress: %v", err)
@@ -292,6 +301,26 @@ func getBlockExecutionWithdrawalRequests(v *spec.VersionedSignedBeaconBlock) ([]
 	}
 }
 
+// marshalVersionedBeaconStateSSZ marshals a versioned beacon state using SSZ encoding.
+func marshalVersionedBeaconStateSSZ(dynSsz *dynssz.DynSsz, state *spec.VersionedBeaconState) ([]byte, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return dynSsz.MarshalSSZ(state.Phase0)
+	case spec.DataVersionAltair:
+		return dynSsz.MarshalSSZ(state.Altair)
+	case spec.DataVersionBellatrix:
+		return dynSsz.MarshalSSZ(state.Bellatrix)
+	case spec.DataVersionCapella:
+		return dynSsz.MarshalSSZ(state.Capella)
+	case spec.DataVersionDeneb:
+		return dynSsz.MarshalSSZ(state.Deneb)
+	case spec.DataVersionElectra:
+		return dynSsz.MarshalSSZ(state.Electra)
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
 // getStateRandaoMixes returns the RANDAO mixes from a versioned beacon state.
 func getStateRandaoMixes(v *spec.VersionedBeaconState) ([]phase0.Root, error) {
 	switch v.Version {
@@ -355,6 +384,50 @@ func getStateDepositIndex(state *spec.VersionedBeaconState) uint64 {
 	return 0
 }
 
+// getStateLatestBlockHeader returns the latest block header from a versioned beacon state.
+func getStateLatestBlockHeader(v *spec.VersionedBeaconState) (*phase0.BeaconBlockHeader, error) {
+	switch v.Version {
+	case spec.DataVersionPhase0:
+		if v.Phase0 == nil || v.Phase0.LatestBlockHeader == nil {
+			return nil, errors.New("no phase0 block")
+		}
+
+		return v.Phase0.LatestBlockHeader, nil
+	case spec.DataVersionAltair:
+		if v.Altair == nil || v.Altair.LatestBlockHeader == nil {
+			return nil, errors.New("no altair block")
+		}
+
+		return v.Altair.LatestBlockHeader, nil
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.LatestBlockHeader == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+
+		return v.Bellatrix.LatestBlockHeader, nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.LatestBlockHeader == nil {
+			return nil, errors.New("no capella block")
+		}
+
+		return v.Capella.LatestBlockHeader, nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.LatestBlockHeader == nil {
+			return nil, errors.New("no deneb block")
+		}
+
+		return v.Deneb.LatestBlockHeader, nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.LatestBlockHeader == nil {
+			return nil, errors.New("no electra block")
+		}
+
+		return v.Electra.LatestBlockHeader, nil
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
 // getStateRandaoMixes returns the RANDAO mixes from a versioned beacon state.
 func getStateCurrentSyncCommittee(v *spec.VersionedBeaconState) ([]phase0.BLSPubKey, error) {
 	switch v.Version {
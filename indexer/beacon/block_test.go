@@ -0,0 +1,115 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+func TestMergeBlockTimings(t *testing.T) {
+	headDelay := func(delay int64) *int64 {
+		return &delay
+	}
+
+	timings := mergeBlockTimings([]*dbtypes.BlockTiming{
+		{ClientName: "lighthouse", SeenDelay: 1000},
+		{ClientName: "teku", SeenDelay: 1100, HeadDelay: headDelay(1500)},
+		{ClientName: "lighthouse", SeenDelay: 1200, HeadDelay: headDelay(1300)},
+		{ClientName: "teku", SeenDelay: 1400, HeadDelay: headDelay(1450)},
+		{ClientName: "lighthouse", SeenDelay: 1600, HeadDelay: headDelay(1700)},
+	})
+
+	if len(timings) != 2 {
+		t.Fatalf("expected one timing per client name, got %v", len(timings))
+	}
+	if timings[0].ClientName != "lighthouse" || timings[0].SeenDelay != 1000 || timings[0].HeadDelay == nil || *timings[0].HeadDelay != 1300 {
+		t.Errorf("expected lighthouse timing with the earliest delays (1000 / 1300), got %+v", timings[0])
+	}
+	if timings[1].ClientName != "teku" || timings[1].SeenDelay != 1100 || timings[1].HeadDelay == nil || *timings[1].HeadDelay != 1450 {
+		t.Errorf("expected teku timing with the earliest delays (1100 / 1450), got %+v", timings[1])
+	}
+}
+
+func newTestPhase0Block(slot phase0.Slot) (*phase0.SignedBeaconBlockHeader, *spec.VersionedSignedBeaconBlock) {
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: 7,
+		},
+	}
+	blockData := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: 7,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+					Graffiti: [32]byte{'d', 'o', 'r', 'a'},
+				},
+			},
+		},
+	}
+	return header, blockData
+}
+
+func TestBuildBlockBody(t *testing.T) {
+	utils.Config = &types.Config{}
+	dynSsz := dynssz.NewDynSsz(nil)
+	header, blockData := newTestPhase0Block(100)
+	block := newBlock(dynSsz, phase0.Root{0x01}, 100)
+
+	for _, compress := range []bool{false, true} {
+		blockBody, err := block.buildBlockBody(header, blockData, compress)
+		if err != nil {
+			t.Fatalf("failed building block body: %v", err)
+		}
+
+		// block bodies are compressed with zstd, never with zlib
+		if (blockBody.BlockVer&zstdCompressionFlag != 0) != compress || blockBody.BlockVer&compressionFlag != 0 {
+			t.Errorf("unexpected compression flags for compress=%v: %x", compress, blockBody.BlockVer)
+		}
+		if blockBody.Slot != 100 || blockBody.Root[0] != 0x01 {
+			t.Errorf("unexpected block body reference: slot %v, root %x", blockBody.Slot, blockBody.Root)
+		}
+
+		decodedBlock, err := unmarshalVersionedSignedBeaconBlockSSZ(dynSsz, blockBody.BlockVer, blockBody.BlockSSZ)
+		if err != nil {
+			t.Fatalf("failed decoding block body: %v", err)
+		}
+		if decodedBlock.Phase0.Message.Slot != 100 || decodedBlock.Phase0.Message.Body.Graffiti != blockData.Phase0.Message.Body.Graffiti {
+			t.Errorf("decoded block does not match the original block")
+		}
+	}
+}
+
+func TestMarshalBlockCompression(t *testing.T) {
+	utils.Config = &types.Config{}
+	dynSsz := dynssz.NewDynSsz(nil)
+	_, blockData := newTestPhase0Block(100)
+
+	// unfinalized & orphaned blocks keep using zlib
+	blockVer, blockSSZ, err := marshalVersionedSignedBeaconBlockSSZ(dynSsz, blockData, true)
+	if err != nil {
+		t.Fatalf("failed marshalling block: %v", err)
+	}
+	if blockVer&compressionFlag == 0 || blockVer&zstdCompressionFlag != 0 {
+		t.Errorf("expected zlib compressed block, got version %x", blockVer)
+	}
+
+	decodedBlock, err := unmarshalVersionedSignedBeaconBlockSSZ(dynSsz, blockVer, blockSSZ)
+	if err != nil {
+		t.Fatalf("failed decoding block: %v", err)
+	}
+	if decodedBlock.Phase0.Message.ProposerIndex != 7 {
+		t.Errorf("decoded block does not match the original block")
+	}
+}
@@ -21,6 +21,22 @@ type blockCache struct {
 	latestBlock *Block // latest added block (might not be the head block, just a marker for cache changes)
 }
 
+// setLatestBlock sets the latest added block, which marks a change of the cache for the canonical chain computation.
+func (cache *blockCache) setLatestBlock(block *Block) {
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	cache.latestBlock = block
+}
+
+// getLatestBlock returns the latest added block.
+func (cache *blockCache) getLatestBlock() *Block {
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	return cache.latestBlock
+}
+
 // newBlockCache creates a new instance of blockCache.
 func newBlockCache(indexer *Indexer) *blockCache {
 	return &blockCache{
@@ -168,10 +184,10 @@ func (cache *blockCache) getBlocksByExecutionBlockNumber(blockNumber uint64) []*
 			}
 		}
 
-		if block.block == nil {
+		blockBody := block.block.Load()
+		if blockBody == nil {
 			continue
 		}
-		blockBody := block.block
 
 		executionNumber, err := blockBody.ExecutionBlockNumber()
 		if err == nil && executionNumber == blockNumber {
@@ -194,7 +210,7 @@ func (cache *blockCache) getPruningBlocks(minInMemorySlot phase0.Slot) []*Block
 		}
 
 		for _, block := range slotBlocks {
-			if block.block == nil {
+			if block.block.Load() == nil {
 				continue
 			}
 
@@ -214,7 +230,7 @@ func (cache *blockCache) getForkBlocks(forkId ForkKey) []*Block {
 
 	for _, slotBlocks := range cache.slotMap {
 		for _, block := range slotBlocks {
-			if block.forkId != forkId {
+			if block.GetForkId() != forkId {
 				continue
 			}
 
@@ -243,10 +259,10 @@ func (cache *blockCache) getLatestBlocks(limit uint64, forkId *ForkKey) []*Block
 
 	for _, slot := range slots {
 		for _, block := range cache.slotMap[slot] {
-			if block.block == nil && !block.isInFinalizedDb && !block.isInUnfinalizedDb {
+			if block.block.Load() == nil && !block.isInFinalizedDb && !block.isInUnfinalizedDb {
 				continue
 			}
-			if forkId != nil && block.forkId != *forkId {
+			if forkId != nil && block.GetForkId() != *forkId {
 				continue
 			}
 
@@ -352,10 +368,10 @@ func (cache *blockCache) getCanonicalDistance(blockRoot phase0.Root, head phase0
 
 // getDependentBlock returns the dependent block of the given block based on the chain state.
 func (cache *blockCache) getDependentBlock(chainState *consensus.ChainState, block *Block, client *Client) *Block {
-	if block.dependentRoot != nil {
-		dependentBlock := cache.getBlockByRoot(*block.dependentRoot)
+	if dependentRoot := block.dependentRoot.Load(); dependentRoot != nil {
+		dependentBlock := cache.getBlockByRoot(*dependentRoot)
 		if dependentBlock == nil {
-			blockHead := db.GetBlockHeadByRoot((*block.dependentRoot)[:])
+			blockHead := db.GetBlockHeadByRoot(dependentRoot[:])
 			if blockHead != nil {
 				dependentBlock = newBlock(cache.indexer.dynSsz, phase0.Root(blockHead.Root), phase0.Slot(blockHead.Slot))
 				dependentBlock.isInFinalizedDb = true
@@ -365,9 +381,9 @@ func (cache *blockCache) getDependentBlock(chainState *consensus.ChainState, blo
 		}
 
 		if dependentBlock == nil && client != nil {
-			blockHead, _ := LoadBeaconHeader(client.getContext(), client, *block.dependentRoot)
+			blockHead, _ := LoadBeaconHeader(client.getContext(), client, *dependentRoot)
 			if blockHead != nil {
-				dependentBlock = newBlock(cache.indexer.dynSsz, *block.dependentRoot, phase0.Slot(blockHead.Message.Slot))
+				dependentBlock = newBlock(cache.indexer.dynSsz, *dependentRoot, phase0.Slot(blockHead.Message.Slot))
 				parentRootVal := phase0.Root(blockHead.Message.ParentRoot)
 				dependentBlock.parentRoot = &parentRootVal
 			}
@@ -414,7 +430,7 @@ func (cache *blockCache) getDependentBlock(chainState *consensus.ChainState, blo
 		}
 
 		if chainState.EpochOfSlot(parentBlock.Slot) < blockEpoch || parentBlock.Slot == 0 {
-			block.dependentRoot = &parentBlock.Root
+			block.dependentRoot.Store(&parentBlock.Root)
 			return parentBlock
 		}
 
@@ -0,0 +1,272 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const bootstrapStateKey = "indexer.bootstrapstate"
+
+// initBootstrapState restores the bootstrap boundary from the db or bootstraps a fresh db from a finalized checkpoint.
+// when bootstrapping, the synchronizer starts at the checkpoint epoch instead of genesis, so historic epochs stay unindexed until they get backfilled.
+func (indexer *Indexer) initBootstrapState(finalizedEpoch phase0.Epoch, finalizedRoot phase0.Root) {
+	indexer.bootstrapMutex.Lock()
+	defer indexer.bootstrapMutex.Unlock()
+
+	bootstrapState := &dbtypes.IndexerBootstrapState{}
+	if _, err := db.GetExplorerState(bootstrapStateKey, bootstrapState); err == nil {
+		indexer.bootstrapEpoch = phase0.Epoch(bootstrapState.Epoch)
+		indexer.bootstrapBackfillStarted = bootstrapState.BackfillStarted
+		return
+	}
+
+	if !utils.Config.Indexer.BootstrapFromCheckpoint || !indexer.writeDb {
+		return
+	}
+
+	// only bootstrap fresh databases, as the synchronizer has already written epochs otherwise
+	syncState := &dbtypes.IndexerSyncState{}
+	if _, err := db.GetExplorerState("indexer.syncstate", syncState); err == nil {
+		indexer.logger.Warnf("ignoring checkpoint bootstrap: database has already been synchronized up to epoch %v", syncState.Epoch)
+		return
+	}
+
+	bootstrapEpoch := finalizedEpoch
+	if utils.Config.Indexer.BootstrapEpoch != nil && phase0.Epoch(*utils.Config.Indexer.BootstrapEpoch) < finalizedEpoch {
+		bootstrapEpoch = phase0.Epoch(*utils.Config.Indexer.BootstrapEpoch)
+	}
+	if bootstrapEpoch == 0 {
+		indexer.logger.Warnf("ignoring checkpoint bootstrap: no finalized checkpoint available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), beaconStateRequestTimeout)
+	defer cancel()
+
+	checkpointBlock, checkpointState, err := indexer.loadBootstrapCheckpoint(ctx, bootstrapEpoch, finalizedEpoch, finalizedRoot)
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("ignoring checkpoint bootstrap: failed loading checkpoint of epoch %v", bootstrapEpoch)
+		return
+	}
+
+	// the deposit index of the post state includes the deposits of the checkpoint block
+	deposits, _ := checkpointBlock.GetBlock().Deposits()
+	depositIndex := getStateDepositIndex(checkpointState) - uint64(len(deposits))
+
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		_, err := indexer.dbWriter.persistBlockData(tx, checkpointBlock, nil, &depositIndex, false, nil)
+		if err != nil {
+			return err
+		}
+
+		err = db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
+			Epoch: uint64(bootstrapEpoch),
+		}, tx)
+		if err != nil {
+			return err
+		}
+
+		return db.SetExplorerState(bootstrapStateKey, &dbtypes.IndexerBootstrapState{
+			Epoch:          uint64(bootstrapEpoch),
+			CheckpointSlot: uint64(checkpointBlock.Slot),
+			CheckpointRoot: checkpointBlock.Root[:],
+			StateRoot:      checkpointBlock.GetHeader().Message.StateRoot[:],
+		}, tx)
+	})
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("error while saving bootstrap state")
+		return
+	}
+
+	indexer.bootstrapEpoch = bootstrapEpoch
+	indexer.logger.Infof("bootstrapped indexer from finalized checkpoint at epoch %v (block %v, slot %v)", bootstrapEpoch, checkpointBlock.Root.String(), checkpointBlock.Slot)
+}
+
+// loadBootstrapCheckpoint loads the checkpoint block of the given epoch and its post state from a client that follows the finalized chain.
+// the checkpoint block is the block at the first slot of the epoch or the last block before it if that slot is empty.
+// the block is verified against the finalized checkpoint of the clients (or its header root for older epochs), the state against the block.
+func (indexer *Indexer) loadBootstrapCheckpoint(ctx context.Context, epoch phase0.Epoch, finalizedEpoch phase0.Epoch, finalizedRoot phase0.Root) (*Block, *spec.VersionedBeaconState, error) {
+	clients := indexer.GetReadyClientsByCheckpoint(finalizedRoot, true)
+	if len(clients) == 0 {
+		return nil, nil, fmt.Errorf("no clients on finalized checkpoint %v", finalizedRoot.String())
+	}
+
+	var err error
+	for _, client := range clients {
+		var block *Block
+		var state *spec.VersionedBeaconState
+
+		block, state, err = indexer.loadBootstrapCheckpointFromClient(ctx, client, epoch, finalizedEpoch, finalizedRoot)
+		if err == nil {
+			return block, state, nil
+		}
+
+		client.logger.Warnf("failed loading bootstrap checkpoint: %v", err)
+	}
+
+	return nil, nil, err
+}
+
+func (indexer *Indexer) loadBootstrapCheckpointFromClient(ctx context.Context, client *Client, epoch phase0.Epoch, finalizedEpoch phase0.Epoch, finalizedRoot phase0.Root) (*Block, *spec.VersionedBeaconState, error) {
+	chainState := indexer.consensusPool.GetChainState()
+
+	var header *phase0.SignedBeaconBlockHeader
+	var root phase0.Root
+	var err error
+
+	if epoch == finalizedEpoch {
+		root = finalizedRoot
+		header, err = LoadBeaconHeader(ctx, client, root)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error loading checkpoint header: %v", err)
+		}
+	} else {
+		for slot := chainState.EpochStartSlot(epoch); header == nil; slot-- {
+			var orphaned bool
+			header, root, orphaned, err = LoadBeaconHeaderBySlot(ctx, client, slot)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error loading header of slot %v: %v", slot, err)
+			}
+			if orphaned {
+				header = nil
+			}
+			if header == nil && slot == 0 {
+				return nil, nil, fmt.Errorf("no block found before epoch %v", epoch)
+			}
+		}
+	}
+
+	if header == nil {
+		return nil, nil, fmt.Errorf("checkpoint header %v not found", root.String())
+	}
+
+	headerRoot, err := header.Message.HashTreeRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error computing checkpoint header root: %v", err)
+	}
+	if !bytes.Equal(headerRoot[:], root[:]) {
+		return nil, nil, fmt.Errorf("checkpoint header root mismatch (got %v, expected %v)", phase0.Root(headerRoot).String(), root.String())
+	}
+
+	blockBody, err := LoadBeaconBlock(ctx, client, root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading checkpoint block: %v", err)
+	}
+	if blockBody == nil {
+		return nil, nil, fmt.Errorf("checkpoint block %v not found", root.String())
+	}
+
+	bodyRoot, err := blockBody.BodyRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error computing checkpoint block body root: %v", err)
+	}
+	if !bytes.Equal(bodyRoot[:], header.Message.BodyRoot[:]) {
+		return nil, nil, fmt.Errorf("checkpoint block body root mismatch")
+	}
+
+	state, err := LoadBeaconState(ctx, client, header.Message.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading checkpoint state: %v", err)
+	}
+
+	// the latest block header of the post state is the checkpoint block header with empty state root
+	stateSlot, err := state.Slot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading checkpoint state slot: %v", err)
+	}
+	stateHeader, err := getStateLatestBlockHeader(state)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading checkpoint state header: %v", err)
+	}
+	if stateSlot != header.Message.Slot || stateHeader.Slot != header.Message.Slot || !bytes.Equal(stateHeader.ParentRoot[:], header.Message.ParentRoot[:]) || !bytes.Equal(stateHeader.BodyRoot[:], header.Message.BodyRoot[:]) {
+		return nil, nil, fmt.Errorf("checkpoint state %v does not match checkpoint block", header.Message.StateRoot.String())
+	}
+
+	block := newBlock(indexer.dynSsz, root, header.Message.Slot)
+	block.SetHeader(header)
+	block.SetBlock(blockBody)
+
+	return block, state, nil
+}
+
+// startBootstrapBackfill starts the background backfill of the epochs before the bootstrap checkpoint if configured.
+// the backfill is only started once, progress & resumption is handled by the range backfill.
+func (indexer *Indexer) startBootstrapBackfill() {
+	indexer.bootstrapMutex.Lock()
+	defer indexer.bootstrapMutex.Unlock()
+
+	if indexer.bootstrapEpoch == 0 || indexer.bootstrapBackfillStarted || !utils.Config.Indexer.BootstrapBackfill {
+		return
+	}
+
+	err := indexer.StartRangeBackfill(0, indexer.bootstrapEpoch-1, RangeBackfillStageAll, 0)
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("failed starting backfill of epochs before bootstrap checkpoint")
+		return
+	}
+
+	indexer.bootstrapBackfillStarted = true
+	indexer.updateBootstrapState()
+}
+
+// onRangeBackfillComplete moves the bootstrap boundary back when a backfill covered the epochs right before the boundary.
+// the boundary is only moved behind the last failed epoch, so epochs that failed to backfill are still reported as not indexed.
+func (indexer *Indexer) onRangeBackfillComplete(startEpoch phase0.Epoch, endEpoch phase0.Epoch, failedEpochs []phase0.Epoch) {
+	indexer.bootstrapMutex.Lock()
+	defer indexer.bootstrapMutex.Unlock()
+
+	if indexer.bootstrapEpoch == 0 || startEpoch >= indexer.bootstrapEpoch || endEpoch+1 < indexer.bootstrapEpoch {
+		return
+	}
+
+	bootstrapEpoch := startEpoch
+	for _, failedEpoch := range failedEpochs {
+		if failedEpoch < indexer.bootstrapEpoch && failedEpoch+1 > bootstrapEpoch {
+			bootstrapEpoch = failedEpoch + 1
+		}
+	}
+	if bootstrapEpoch >= indexer.bootstrapEpoch {
+		indexer.logger.Warnf("backfill of epochs before bootstrap checkpoint %v incomplete, %v epochs failed", indexer.bootstrapEpoch, len(failedEpochs))
+		return
+	}
+
+	if bootstrapEpoch == 0 {
+		indexer.logger.Infof("all epochs before bootstrap checkpoint %v have been backfilled", indexer.bootstrapEpoch)
+	} else {
+		indexer.logger.Infof("epochs %v - %v before bootstrap checkpoint have been backfilled", bootstrapEpoch, indexer.bootstrapEpoch-1)
+	}
+	indexer.bootstrapEpoch = bootstrapEpoch
+	indexer.updateBootstrapState()
+}
+
+// updateBootstrapState persists the bootstrap boundary, needs to be called with the bootstrapMutex held.
+func (indexer *Indexer) updateBootstrapState() {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		bootstrapState := &dbtypes.IndexerBootstrapState{}
+		db.GetExplorerState(bootstrapStateKey, bootstrapState)
+
+		bootstrapState.Epoch = uint64(indexer.bootstrapEpoch)
+		bootstrapState.BackfillStarted = indexer.bootstrapBackfillStarted
+
+		return db.SetExplorerState(bootstrapStateKey, bootstrapState, tx)
+	})
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("error while saving bootstrap state")
+	}
+}
+
+// GetBootstrapEpoch returns the first indexed epoch if the indexer has been bootstrapped from a checkpoint.
+// epochs before the returned epoch are not indexed (yet), returns 0 if the indexer has been synchronized from genesis.
+func (indexer *Indexer) GetBootstrapEpoch() phase0.Epoch {
+	indexer.bootstrapMutex.Lock()
+	defer indexer.bootstrapMutex.Unlock()
+
+	return indexer.bootstrapEpoch
+}
@@ -25,12 +25,12 @@ type ChainHead struct {
 func (indexer *Indexer) GetCanonicalHead(overrideForkId *ForkKey) *Block {
 	indexer.computeCanonicalChain()
 
-	if overrideForkId != nil && indexer.canonicalHead != nil && indexer.canonicalHead.forkId != *overrideForkId {
+	if overrideForkId != nil && indexer.canonicalHead != nil && indexer.canonicalHead.GetForkId() != *overrideForkId {
 		chainHeads := indexer.cachedChainHeads
 		chainHeadCandidates := []*ChainHead{}
 
 		for _, chainHead := range chainHeads {
-			parentForkIds := indexer.forkCache.getParentForkIds(chainHead.HeadBlock.forkId)
+			parentForkIds := indexer.forkCache.getParentForkIds(chainHead.HeadBlock.GetForkId())
 			isInParentIds := false
 			for _, parentForkId := range parentForkIds {
 				if parentForkId == *overrideForkId {
@@ -92,11 +92,12 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 	indexer.canonicalHeadMutex.Lock()
 	defer indexer.canonicalHeadMutex.Unlock()
 
-	if indexer.blockCache.latestBlock == nil {
+	latestBlock := indexer.blockCache.getLatestBlock()
+	if latestBlock == nil {
 		return false
 	}
 
-	latestBlockRoot := indexer.blockCache.latestBlock.Root
+	latestBlockRoot := latestBlock.Root
 	if bytes.Equal(latestBlockRoot[:], indexer.canonicalComputation[:]) {
 		return false
 	}
@@ -124,10 +125,10 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 		if len(latestBlocks) > 0 {
 			headBlock = latestBlocks[0]
 
-			forkVotes, thisEpochPercent, lastEpochPercent := indexer.aggregateForkVotes(headBlock.forkId)
+			forkVotes, thisEpochPercent, lastEpochPercent := indexer.aggregateForkVotes(headBlock.GetForkId())
 			indexer.logger.Debugf(
 				"fork %v votes in last 2 epochs: %v ETH (%.2f%%, %.2f%%), head: %v (%v)",
-				headBlock.forkId,
+				headBlock.GetForkId(),
 				forkVotes/EtherGweiFactor,
 				lastEpochPercent,
 				thisEpochPercent,
@@ -200,10 +201,12 @@ func (indexer *Indexer) aggregateForkVotes(forkId ForkKey) (totalVotes phase0.Gw
 	minAggregateSlot := chainState.EpochStartSlot(minAggregateEpoch)
 
 	fork := indexer.forkCache.getForkById(forkId)
-	if fork != nil && fork.headBlock != nil && fork.headBlock.Slot < minAggregateSlot {
-		// fork head block is outside aggregation range
-		// very old fork, skip aggregation and return 0
-		return
+	if fork != nil {
+		if headBlock := fork.headBlock.Load(); headBlock != nil && headBlock.Slot < minAggregateSlot {
+			// fork head block is outside aggregation range
+			// very old fork, skip aggregation and return 0
+			return
+		}
 	}
 
 	// get all blocks for given fork (and its parents) from the last 2 epochs
@@ -315,7 +318,7 @@ func (indexer *Indexer) GetCanonicalValidatorSet(overrideForkId *ForkKey) []*v1.
 		canonicalHead = dependentBlock
 
 		epochStats = indexer.epochCache.getEpochStats(epoch, dependentBlock.Root)
-		if epochStats == nil || epochStats.dependentState == nil || epochStats.dependentState.loadingStatus != 2 {
+		if epochStats == nil || epochStats.dependentState == nil || epochStats.dependentState.loadingStatus.Load() != 2 {
 			continue // retry previous state
 		}
 
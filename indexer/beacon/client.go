@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -13,7 +15,6 @@ import (
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,29 +26,34 @@ type Client struct {
 	logger   logrus.FieldLogger
 	indexing bool
 
-	priority       int
-	archive        bool
+	priority       atomic.Int64 // updated at runtime via SetPriority, read concurrently when sorting clients
+	archive        atomic.Bool  // updated at runtime via SetArchive, read concurrently when sorting clients
 	skipValidators bool
 
 	blockSubscription *consensus.Subscription[*v1.BlockEvent]
 	headSubscription  *consensus.Subscription[*v1.HeadEvent]
+	stopChan          chan bool
 
-	headRoot phase0.Root
+	headRootMutex sync.RWMutex
+	headRoot      phase0.Root
 }
 
 // newClient creates a new indexer client for a given consensus pool client.
 func newClient(index uint16, client *consensus.Client, priority int, archive bool, skipValidators bool, indexer *Indexer, logger logrus.FieldLogger) *Client {
-	return &Client{
+	indexerClient := &Client{
 		indexer:  indexer,
 		index:    index,
 		client:   client,
 		logger:   logger,
 		indexing: false,
 
-		priority:       priority,
-		archive:        archive,
 		skipValidators: skipValidators,
+		stopChan:       make(chan bool),
 	}
+	indexerClient.priority.Store(int64(priority))
+	indexerClient.archive.Store(archive)
+
+	return indexerClient
 }
 
 func (c *Client) getContext() context.Context {
@@ -59,13 +65,41 @@ func (c *Client) GetClient() *consensus.Client {
 }
 
 func (c *Client) GetPriority() int {
-	return c.priority
+	return int(c.priority.Load())
 }
 
 func (c *Client) GetIndex() uint16 {
 	return c.index
 }
 
+func (c *Client) IsArchive() bool {
+	return c.archive.Load()
+}
+
+func (c *Client) IsSkipValidators() bool {
+	return c.skipValidators
+}
+
+// SetPriority updates the priority of the client, which is used to select clients for requests.
+func (c *Client) SetPriority(priority int) {
+	c.priority.Store(int64(priority))
+}
+
+// SetArchive updates the archive flag of the client, archive clients are preferred for historic requests.
+func (c *Client) SetArchive(archive bool) {
+	c.archive.Store(archive)
+}
+
+// isStopped returns true if the client has been removed from the indexer.
+func (c *Client) isStopped() bool {
+	select {
+	case <-c.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
 // startIndexing starts the indexing process for this client.
 // attaches block & head event handlers and starts the event processing subroutine.
 func (c *Client) startIndexing() {
@@ -82,6 +116,40 @@ func (c *Client) startIndexing() {
 	go c.startClientLoop()
 }
 
+// stopIndexing stops the event processing subroutine and detaches the block & head event handlers.
+func (c *Client) stopIndexing() {
+	if c.isStopped() {
+		return
+	}
+
+	close(c.stopChan)
+
+	if c.blockSubscription != nil {
+		unsubscribeBlocking(c.blockSubscription)
+	}
+	if c.headSubscription != nil {
+		unsubscribeBlocking(c.headSubscription)
+	}
+}
+
+// unsubscribeBlocking removes a blocking subscription from its dispatcher.
+// pending events are drained while unsubscribing, so a dispatcher waiting for the subscription does not get stuck.
+func unsubscribeBlocking[T any](subscription *consensus.Subscription[T]) {
+	drainDone := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-drainDone:
+				return
+			case <-subscription.Channel():
+			}
+		}
+	}()
+
+	subscription.Unsubscribe()
+	close(drainDone)
+}
+
 // startClientLoop starts the client event processing subroutine.
 func (c *Client) startClientLoop() {
 	defer func() {
@@ -97,11 +165,19 @@ func (c *Client) startClientLoop() {
 
 	for {
 		err := c.runClientLoop()
+		if c.isStopped() {
+			return
+		}
+
 		if err != nil {
 			c.logger.WithError(err).Warnf("error in indexer.beacon.Client.runClientLoop: %v (retrying in 10 sec)", err)
 		}
 
-		time.Sleep(10 * time.Second)
+		select {
+		case <-c.stopChan:
+			return
+		case <-time.After(10 * time.Second):
+		}
 	}
 }
 
@@ -114,7 +190,7 @@ func (c *Client) runClientLoop() error {
 		return nil
 	}
 
-	c.headRoot = headRoot
+	c.setHeadRoot(headRoot)
 
 	headBlock, isNew, err := c.processBlock(headSlot, headRoot, nil)
 	if err != nil {
@@ -144,6 +220,8 @@ func (c *Client) runClientLoop() error {
 		select {
 		case <-c.client.GetContext().Done():
 			return nil
+		case <-c.stopChan:
+			return nil
 		case blockEvent := <-c.blockSubscription.Channel():
 			err := c.processBlockEvent(blockEvent)
 			if err != nil {
@@ -166,8 +244,14 @@ func (c *Client) processBlockEvent(blockEvent *v1.BlockEvent) error {
 		return nil
 	}
 
-	_, err := c.processStreamBlock(blockEvent.Slot, blockEvent.Block)
-	return err
+	seenTime := time.Now()
+	block, err := c.processStreamBlock(blockEvent.Slot, blockEvent.Block)
+	if err != nil {
+		return err
+	}
+
+	block.setSeenTime(c, seenTime)
+	return nil
 }
 
 // processHeadEvent processes a head event from the event stream.
@@ -177,16 +261,21 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 		return nil
 	}
 
+	headTime := time.Now()
 	block, err := c.processStreamBlock(headEvent.Slot, headEvent.Block)
 	if err != nil {
 		return err
 	}
 
+	block.setSeenTime(c, headTime)
+
 	if bytes.Equal(c.headRoot[:], headEvent.Block[:]) {
 		// no head progress?
 		return nil
 	}
 
+	block.setHeadTime(c, headTime)
+
 	c.logger.Debugf("head %v -> %v", c.headRoot.String(), block.Root.String())
 
 	// check for chain reorgs
@@ -214,7 +303,7 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 
 	var dependentBlock *Block
 	if !bytes.Equal(dependentRoot[:], consensus.NullRoot[:]) {
-		block.dependentRoot = &dependentRoot
+		block.dependentRoot.Store(&dependentRoot)
 
 		dependentBlock = c.indexer.blockCache.getBlockByRoot(dependentRoot)
 		if dependentBlock == nil {
@@ -257,10 +346,26 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 		dependentBlock = c.indexer.blockCache.getDependentBlock(chainState, currentBlock, c)
 	}
 
-	c.headRoot = block.Root
+	c.setHeadRoot(block.Root)
 	return nil
 }
 
+// setHeadRoot sets the root of the latest processed head block of the client.
+func (c *Client) setHeadRoot(root phase0.Root) {
+	c.headRootMutex.Lock()
+	defer c.headRootMutex.Unlock()
+
+	c.headRoot = root
+}
+
+// getHeadRoot returns the root of the latest processed head block of the client.
+func (c *Client) getHeadRoot() phase0.Root {
+	c.headRootMutex.RLock()
+	defer c.headRootMutex.RUnlock()
+
+	return c.headRoot
+}
+
 // processStreamBlock processes a block received from the stream (either via block or head events).
 func (c *Client) processStreamBlock(slot phase0.Slot, root phase0.Root) (*Block, error) {
 	block, isNew, err := c.processBlock(slot, root, nil)
@@ -362,10 +467,12 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 		return
 	}
 
+	block.SetSeenBy(c)
+
 	if slot >= finalizedSlot && isNew {
 		// fork detection
 		forkId, err2 := c.indexer.forkCache.processBlock(block)
-		block.forkId = forkId
+		block.setForkId(forkId)
 		block.fokChecked = true
 
 		if err2 != nil {
@@ -380,7 +487,7 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 		}
 
 		// write to db
-		err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err = db.RunDBTransaction(func(tx db.Tx) error {
 			err := db.InsertUnfinalizedBlock(dbBlock, tx)
 			if err != nil {
 				return err
@@ -393,7 +500,7 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 		}
 
 		block.isInUnfinalizedDb = true
-		c.indexer.blockCache.latestBlock = block
+		c.indexer.blockCache.setLatestBlock(block)
 	}
 
 	if slot < finalizedSlot && !block.isInFinalizedDb {
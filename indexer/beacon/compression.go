@@ -3,8 +3,14 @@ package beacon
 import (
 	"bytes"
 	"compress/zlib"
+
+	"github.com/klauspost/compress/zstd"
 )
 
+// zstd encoder & decoder are safe for concurrent use via EncodeAll / DecodeAll.
+var zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+
 // compressBytes compresses the given byte slice using zlib compression algorithm.
 // It returns the compressed byte slice.
 func compressBytes(data []byte) []byte {
@@ -30,3 +36,15 @@ func decompressBytes(data []byte) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+// compressBytesZstd compresses the given byte slice using zstd compression algorithm.
+// zstd compresses ssz encoded blocks better & faster than zlib.
+func compressBytesZstd(data []byte) []byte {
+	return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data)/2))
+}
+
+// decompressBytesZstd decompresses the given byte slice using zstd decompression algorithm.
+// It returns the decompressed byte slice and any error encountered during decompression.
+func decompressBytesZstd(data []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(data, nil)
+}
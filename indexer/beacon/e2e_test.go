@@ -0,0 +1,324 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/consensus/mockbeacon"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// runIsolated runs the calling test in a separate test process and returns true in the parent process.
+// the indexer keeps running in the background and writes to the global db, so each chain replay needs its own process.
+func runIsolated(t *testing.T) bool {
+	t.Helper()
+
+	if os.Getenv("DORA_E2E_ISOLATED") == t.Name() {
+		return false
+	}
+
+	args := []string{"-test.run=^" + t.Name() + "$"}
+	if testing.Verbose() {
+		args = append(args, "-test.v")
+	}
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "DORA_E2E_ISOLATED="+t.Name())
+	output, err := cmd.CombinedOutput()
+	if testing.Verbose() || err != nil {
+		t.Logf("%s", output)
+	}
+	if err != nil {
+		t.Fatalf("isolated test process failed: %v", err)
+	}
+
+	return true
+}
+
+// startTestIndexer starts a mock beacon node and an indexer with a temporary sqlite db.
+// the genesis of the mock chain is a few seconds in the future, so the indexer starts before the first block
+// unless the beforeStart hook waits for the chain to progress.
+func startTestIndexer(t *testing.T, beforeStart func(node *mockbeacon.Node, pool *consensus.Pool)) (*mockbeacon.Node, *Indexer) {
+	t.Helper()
+
+	node, err := mockbeacon.NewNode(&mockbeacon.Config{
+		GenesisTime:    time.Now().Truncate(time.Second).Add(3 * time.Second),
+		SecondsPerSlot: 1,
+		SlotsPerEpoch:  4,
+		ValidatorCount: 64,
+	})
+	if err != nil {
+		t.Fatalf("failed creating mock beacon node: %v", err)
+	}
+	t.Cleanup(node.Close)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	if testing.Verbose() {
+		logger.SetLevel(logrus.InfoLevel)
+	}
+
+	utils.Config = &types.Config{}
+	utils.Config.Database.Engine = "sqlite"
+	utils.Config.Database.Sqlite.File = filepath.Join(t.TempDir(), "dora.sqlite")
+	utils.Config.Indexer.InMemoryEpochs = 2
+
+	db.MustInitDB()
+	t.Cleanup(db.MustCloseDB)
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	pool := consensus.NewPool(ctx, logger.WithField("service", "cl-pool"))
+	client, err := pool.AddEndpoint(&consensus.ClientConfig{
+		URL:  node.URL(),
+		Name: "mock",
+	})
+	if err != nil {
+		t.Fatalf("failed adding mock beacon node to pool: %v", err)
+	}
+
+	indexer := NewIndexer(logger.WithField("service", "cl-indexer"), pool)
+	indexer.AddClient(client.GetIndex(), client, 1, true, false)
+
+	awaitCondition(t, 10*time.Second, "chain specs", func() bool {
+		return pool.GetChainState().GetSpecs() != nil && pool.GetChainState().GetGenesis() != nil
+	})
+
+	if beforeStart != nil {
+		beforeStart(node, pool)
+	}
+
+	indexer.StartIndexer()
+
+	return node, indexer
+}
+
+// awaitCondition polls the condition until it is met or the timeout is reached.
+func awaitCondition(t *testing.T, timeout time.Duration, name string, condition func() bool) {
+	t.Helper()
+
+	timeoutTime := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(timeoutTime) {
+			t.Fatalf("timeout waiting for %v", name)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// replayChain replays the chain script in the background and returns a channel receiving the replay result.
+func replayChain(node *mockbeacon.Node, steps []mockbeacon.Step) chan error {
+	resultChan := make(chan error, 1)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		resultChan <- node.Replay(ctx, steps)
+	}()
+
+	return resultChan
+}
+
+func assertBlockStatus(t *testing.T, node *mockbeacon.Node, label string, status dbtypes.SlotStatus) {
+	t.Helper()
+
+	block := node.GetBlock(label)
+	if block == nil {
+		t.Fatalf("unknown block %v", label)
+	}
+
+	dbSlot := db.GetSlotByRoot(block.Root[:])
+	if dbSlot == nil {
+		t.Errorf("block %v (slot %v) not found in db", label, block.Slot)
+		return
+	}
+	if dbSlot.Slot != uint64(block.Slot) {
+		t.Errorf("block %v: expected slot %v in db, got %v", label, block.Slot, dbSlot.Slot)
+	}
+	if dbSlot.Status != status {
+		t.Errorf("block %v (slot %v): expected status %v in db, got %v", label, block.Slot, status, dbSlot.Status)
+	}
+}
+
+// assertMissedSlot checks that there is no canonical block for the slot in the db.
+func assertMissedSlot(t *testing.T, slot phase0.Slot) {
+	t.Helper()
+
+	for _, dbSlot := range db.GetSlotsRange(uint64(slot), uint64(slot), true, true) {
+		if dbSlot.Block != nil && dbSlot.Block.Status == dbtypes.Canonical {
+			t.Errorf("slot %v: expected missed slot, found canonical block", slot)
+		}
+	}
+}
+
+func awaitEpochsFinalized(t *testing.T, epoch phase0.Epoch, timeout time.Duration) {
+	t.Helper()
+
+	// only check committed db state, the indexer state is not synchronized for reads from other goroutines
+	awaitCondition(t, timeout, fmt.Sprintf("finalization of epoch %v", epoch), func() bool {
+		for e := uint64(0); e <= uint64(epoch); e++ {
+			if !db.IsEpochSynchronized(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func assertEpochBlockCounts(t *testing.T, firstEpoch uint64, expectedBlockCounts []uint16) {
+	t.Helper()
+
+	lastEpoch := firstEpoch + uint64(len(expectedBlockCounts)) - 1
+	dbEpochs := db.GetEpochs(lastEpoch, uint32(len(expectedBlockCounts)))
+	if len(dbEpochs) != len(expectedBlockCounts) {
+		t.Fatalf("expected %v epochs in db, got %v", len(expectedBlockCounts), len(dbEpochs))
+	}
+	for _, dbEpoch := range dbEpochs {
+		expectedBlockCount := expectedBlockCounts[dbEpoch.Epoch-firstEpoch]
+		if dbEpoch.BlockCount != expectedBlockCount {
+			t.Errorf("epoch %v: expected %v blocks, got %v", dbEpoch.Epoch, expectedBlockCount, dbEpoch.BlockCount)
+		}
+	}
+}
+
+// TestIndexerChainReplay replays a chain with missed slots, forks, reorgs & a long period of non-finality
+// and checks the finalized db state.
+// all scenarios share one chain, as the indexer keeps running in the background and cannot be restarted with a fresh db.
+// the test runs in its own process (see runIsolated).
+func TestIndexerChainReplay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping chain replay in short mode")
+	}
+	if runIsolated(t) {
+		return
+	}
+
+	node, _ := startTestIndexer(t, nil)
+
+	steps := []mockbeacon.Step{}
+	// epoch 0-2: canonical chain with a missed slot at 6
+	steps = append(steps, mockbeacon.ProduceRange(1, 9, "a", 6)...)
+	// slot 10: fork on top of a8, orphaned as a11 builds on a9
+	steps = append(steps, mockbeacon.Fork(10, "a8", "b10"))
+	steps = append(steps, mockbeacon.Produce(11, "a11"), mockbeacon.Produce(12, "a12"))
+	// slot 13: reorg, c13 builds on a11 and orphans a12
+	steps = append(steps, mockbeacon.Reorg(13, "a11", "c13"))
+	steps = append(steps, mockbeacon.ProduceRange(14, 19, "c")...)
+	// finalize epoch 4, so epochs 0-3 get written to the db
+	steps = append(steps, mockbeacon.Finalize(19, 4, 4))
+	// epoch 5-9: no finality for more epochs than kept in memory, with a fork that gets pruned before finalization
+	steps = append(steps, mockbeacon.ProduceRange(20, 21, "c")...)
+	steps = append(steps, mockbeacon.Fork(22, "c20", "e22"))
+	steps = append(steps, mockbeacon.ProduceRange(23, 38, "c")...)
+	// finalize epoch 9, so epochs 4-8 get written to the db
+	steps = append(steps, mockbeacon.Finalize(38, 9, 9))
+
+	replayResult := replayChain(node, steps)
+
+	awaitEpochsFinalized(t, 3, 60*time.Second)
+
+	for _, label := range []string{"a1", "a2", "a3", "a4", "a5", "a7", "a8", "a9", "a11", "c13", "c14", "c15"} {
+		assertBlockStatus(t, node, label, dbtypes.Canonical)
+	}
+	assertBlockStatus(t, node, "b10", dbtypes.Orphaned)
+	assertBlockStatus(t, node, "a12", dbtypes.Orphaned)
+	assertMissedSlot(t, 6)
+	assertMissedSlot(t, 10)
+
+	// genesis block is counted in epoch 0, orphaned blocks are not counted
+	assertEpochBlockCounts(t, 0, []uint16{4, 3, 3, 3})
+
+	if err := <-replayResult; err != nil {
+		t.Fatalf("failed replaying chain: %v", err)
+	}
+
+	awaitEpochsFinalized(t, 8, 60*time.Second)
+
+	for slot := 16; slot <= 35; slot++ {
+		if slot == 22 {
+			continue
+		}
+		assertBlockStatus(t, node, fmt.Sprintf("c%v", slot), dbtypes.Canonical)
+	}
+	// e22 has been persisted as canonical block of its fork when epoch 5 got pruned, finalization needs to fix its status
+	assertBlockStatus(t, node, "e22", dbtypes.Orphaned)
+	assertMissedSlot(t, 22)
+
+	assertEpochBlockCounts(t, 4, []uint16{4, 3, 4, 4, 4})
+}
+
+// TestIndexerCheckpointBootstrap bootstraps a fresh db from a finalized checkpoint and checks that the checkpoint block
+// is persisted and only the epochs after the checkpoint get indexed.
+func TestIndexerCheckpointBootstrap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping chain replay in short mode")
+	}
+	if runIsolated(t) {
+		return
+	}
+
+	var replayResult chan error
+	node, indexer := startTestIndexer(t, func(node *mockbeacon.Node, pool *consensus.Pool) {
+		// epoch 0-2: canonical chain with a missed slot at the start of epoch 1, finalize epoch 2 before the indexer starts
+		steps := mockbeacon.ProduceRange(1, 11, "a", 4)
+		steps = append(steps, mockbeacon.Finalize(11, 2, 2))
+		if err := <-replayChain(node, steps); err != nil {
+			t.Fatalf("failed replaying chain: %v", err)
+		}
+
+		awaitCondition(t, 30*time.Second, "finalized checkpoint", func() bool {
+			finalizedEpoch, _ := pool.GetChainState().GetFinalizedCheckpoint()
+			return finalizedEpoch == 2
+		})
+
+		// bootstrap from epoch 1, the checkpoint block is a3 as slot 4 is empty
+		bootstrapEpoch := uint64(1)
+		utils.Config.Indexer.BootstrapFromCheckpoint = true
+		utils.Config.Indexer.BootstrapEpoch = &bootstrapEpoch
+
+		steps = mockbeacon.ProduceRange(12, 19, "a")
+		steps = append(steps, mockbeacon.Finalize(19, 4, 4))
+		replayResult = replayChain(node, steps)
+	})
+
+	if bootstrapEpoch := indexer.GetBootstrapEpoch(); bootstrapEpoch != 1 {
+		t.Fatalf("expected bootstrap epoch 1, got %v", bootstrapEpoch)
+	}
+
+	if err := <-replayResult; err != nil {
+		t.Fatalf("failed replaying chain: %v", err)
+	}
+
+	awaitCondition(t, 60*time.Second, "finalization of epoch 3", func() bool {
+		return db.IsEpochSynchronized(1) && db.IsEpochSynchronized(2) && db.IsEpochSynchronized(3)
+	})
+
+	if db.IsEpochSynchronized(0) {
+		t.Errorf("expected epoch 0 before the bootstrap checkpoint to be unindexed")
+	}
+
+	assertBlockStatus(t, node, "a3", dbtypes.Canonical)
+	if db.GetSlotByRoot(node.GetBlock("a2").Root[:]) != nil {
+		t.Errorf("expected block a2 before the bootstrap checkpoint to be unindexed")
+	}
+	for slot := 5; slot <= 15; slot++ {
+		assertBlockStatus(t, node, fmt.Sprintf("a%v", slot), dbtypes.Canonical)
+	}
+	assertMissedSlot(t, 4)
+}
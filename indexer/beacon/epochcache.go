@@ -85,7 +85,7 @@ func (cache *epochCache) createOrGetEpochStats(epoch phase0.Epoch, dependentRoot
 
 	// get or create beacon state which the epoch status depends on (dependentRoot beacon state)
 	epochState := cache.stateMap[dependentRoot]
-	if epochState == nil && !epochStats.ready && createStateRequest {
+	if epochState == nil && !epochStats.ready.Load() && createStateRequest {
 		epochState = newEpochState(dependentRoot)
 		cache.stateMap[dependentRoot] = epochState
 
@@ -93,9 +93,11 @@ func (cache *epochCache) createOrGetEpochStats(epoch phase0.Epoch, dependentRoot
 	}
 
 	if epochState != nil {
-		epochStats.dependentState = epochState
+		if epochStats.dependentState != epochState {
+			epochStats.dependentState = epochState
+		}
 
-		if epochState.loadingStatus == 2 && !epochStats.ready {
+		if epochState.loadingStatus.Load() == 2 && !epochStats.ready.Load() {
 			// dependent state is already loaded, process it
 			go epochStats.processState(cache.indexer)
 		}
@@ -138,7 +140,7 @@ func (cache *epochCache) getPendingEpochStats() []*EpochStats {
 
 	pendingStats := make([]*EpochStats, 0)
 	for _, stats := range cache.statsMap {
-		if stats.dependentState != nil && stats.dependentState.loadingStatus == 0 {
+		if stats.dependentState != nil && stats.dependentState.loadingStatus.Load() == 0 {
 			pendingStats = append(pendingStats, stats)
 		}
 	}
@@ -332,9 +334,13 @@ func (cache *epochCache) runLoaderLoop() {
 	// 4. requested by clients count (prefer higher)
 	// 5. epoch number (prefer higher)
 	currentEpoch := cache.indexer.consensusPool.GetChainState().CurrentEpoch()
+	reqCounts := make(map[*EpochStats]int, len(pendingStats))
+	for _, epochStats := range pendingStats {
+		reqCounts[epochStats] = len(epochStats.getRequestedBy())
+	}
 	sort.Slice(pendingStats, func(a, b int) bool {
-		probablyBadA := pendingStats[a].dependentState.retryCount > beaconStateRetryCount
-		probablyBadB := pendingStats[b].dependentState.retryCount > beaconStateRetryCount
+		probablyBadA := pendingStats[a].dependentState.retryCount.Load() > beaconStateRetryCount
+		probablyBadB := pendingStats[b].dependentState.retryCount.Load() > beaconStateRetryCount
 		if probablyBadA != probablyBadB {
 			return probablyBadB
 		}
@@ -345,16 +351,16 @@ func (cache *epochCache) runLoaderLoop() {
 			return highPriorityA
 		}
 
-		if pendingStats[a].dependentState.retryCount != pendingStats[b].dependentState.retryCount {
-			return pendingStats[a].dependentState.retryCount < pendingStats[b].dependentState.retryCount
+		if pendingStats[a].dependentState.retryCount.Load() != pendingStats[b].dependentState.retryCount.Load() {
+			return pendingStats[a].dependentState.retryCount.Load() < pendingStats[b].dependentState.retryCount.Load()
 		}
 
-		if pendingStats[a].dependentState.retryCount != pendingStats[b].dependentState.retryCount {
-			return pendingStats[a].dependentState.retryCount < pendingStats[b].dependentState.retryCount
+		if pendingStats[a].dependentState.retryCount.Load() != pendingStats[b].dependentState.retryCount.Load() {
+			return pendingStats[a].dependentState.retryCount.Load() < pendingStats[b].dependentState.retryCount.Load()
 		}
 
-		reqCountA := len(pendingStats[a].requestedBy)
-		reqCountB := len(pendingStats[b].requestedBy)
+		reqCountA := reqCounts[pendingStats[a]]
+		reqCountB := reqCounts[pendingStats[b]]
 		if reqCountA != reqCountB {
 			return reqCountA > reqCountB
 		}
@@ -379,7 +385,7 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) {
 	}()
 
 	clients := []*Client{}
-	preferArchive := epochStats.epoch < cache.indexer.lastFinalizedEpoch
+	preferArchive := epochStats.epoch < cache.indexer.getLastFinalizedEpoch()
 	for _, client := range cache.indexer.GetReadyClientsByBlockRoot(epochStats.dependentRoot, preferArchive) {
 		if client.skipValidators {
 			continue
@@ -389,7 +395,7 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) {
 			continue
 		}
 
-		if !cache.indexer.blockCache.isCanonicalBlock(epochStats.dependentRoot, client.headRoot) {
+		if !cache.indexer.blockCache.isCanonicalBlock(epochStats.dependentRoot, client.getHeadRoot()) {
 			continue
 		}
 
@@ -398,7 +404,7 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) {
 
 	if len(clients) == 0 {
 		for _, client := range epochStats.getRequestedBy() {
-			if client.skipValidators {
+			if client.skipValidators || client.isStopped() {
 				continue
 			}
 
@@ -414,16 +420,16 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) {
 		cliA := clients[a]
 		cliB := clients[b]
 
-		if cliA.archive != cliB.archive {
-			if cliA.archive {
+		if archiveA := cliA.IsArchive(); archiveA != cliB.IsArchive() {
+			if archiveA {
 				return false
 			} else {
 				return true
 			}
 		}
 
-		if cliA.priority != cliB.priority {
-			if cliA.priority > cliB.priority {
+		if priorityA, priorityB := cliA.GetPriority(), cliB.GetPriority(); priorityA != priorityB {
+			if priorityA > priorityB {
 				return false
 			} else {
 				return true
@@ -433,13 +439,13 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) {
 		return cliA.index < cliB.index
 	})
 
-	client := clients[int(epochStats.dependentState.retryCount)%len(clients)]
+	client := clients[int(epochStats.dependentState.retryCount.Load())%len(clients)]
 	err := epochStats.dependentState.loadState(client.getContext(), client, cache)
-	if err != nil && epochStats.dependentState.loadingStatus == 0 {
+	if err != nil && epochStats.dependentState.loadingStatus.Load() == 0 {
 		client.logger.Warnf("failed loading epoch %v stats (dep: %v): %v", epochStats.epoch, epochStats.dependentRoot.String(), err)
 	}
 
-	if epochStats.dependentState.loadingStatus != 2 {
+	if epochStats.dependentState.loadingStatus.Load() != 2 {
 		// epoch state could not be loaded
 		return
 	}
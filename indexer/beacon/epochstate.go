@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
@@ -16,8 +17,10 @@ type epochState struct {
 	stateRoot phase0.Root
 
 	loadingCancel  context.CancelFunc
-	loadingStatus  uint8
-	retryCount     uint64
+	loadingStatus  atomic.Uint32 // 0: pending, 1: loading, 2: loaded
+	loadingClient  *Client
+	loadingStarted time.Time
+	retryCount     atomic.Uint64
 	readyChanMutex sync.Mutex
 	readyChan      chan bool
 	highPriority   bool
@@ -51,17 +54,17 @@ func (s *epochState) dispose() {
 
 func (s *epochState) awaitStateLoaded(ctx context.Context, timeout time.Duration) bool {
 	s.readyChanMutex.Lock()
-	if s.readyChan == nil && s.loadingStatus != 2 {
+	if s.readyChan == nil && s.loadingStatus.Load() != 2 {
 		s.readyChan = make(chan bool)
 	}
 	s.readyChanMutex.Unlock()
 
 	timeoutTime := time.Now().Add(timeout)
 	for {
-		if s.loadingStatus == 2 {
+		if s.loadingStatus.Load() == 2 {
 			return true
 		}
-		if s.retryCount > 10 {
+		if s.retryCount.Load() > 10 {
 			return false
 		}
 
@@ -79,11 +82,13 @@ func (s *epochState) awaitStateLoaded(ctx context.Context, timeout time.Duration
 
 // loadState loads the state for the epoch from the client.
 func (s *epochState) loadState(ctx context.Context, client *Client, cache *epochCache) error {
-	if s.loadingStatus > 0 {
+	if s.loadingStatus.Load() > 0 {
 		return fmt.Errorf("already loading")
 	}
 
-	s.loadingStatus = 1
+	s.loadingStatus.Store(1)
+	s.loadingClient = client
+	s.loadingStarted = time.Now()
 	client.logger.Debugf("loading state for slot %v", s.slotRoot.String())
 
 	ctx, cancel := context.WithTimeout(ctx, beaconStateRequestTimeout+(beaconHeaderRequestTimeout*2))
@@ -92,9 +97,9 @@ func (s *epochState) loadState(ctx context.Context, client *Client, cache *epoch
 		s.loadingCancel = nil
 		cancel()
 
-		if s.loadingStatus == 1 {
-			s.loadingStatus = 0
-			s.retryCount++
+		if s.loadingStatus.Load() == 1 {
+			s.loadingStatus.Store(0)
+			s.retryCount.Add(1)
 		}
 	}()
 
@@ -132,7 +137,7 @@ func (s *epochState) loadState(ctx context.Context, client *Client, cache *epoch
 		s.readyChan = nil
 	}
 
-	s.loadingStatus = 2
+	s.loadingStatus.Store(2)
 	return nil
 }
 
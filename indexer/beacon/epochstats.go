@@ -7,6 +7,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -14,7 +15,6 @@ import (
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon/duties"
-	"github.com/jmoiron/sqlx"
 	"github.com/mashingan/smapping"
 	dynssz "github.com/pk910/dynamic-ssz"
 )
@@ -27,15 +27,15 @@ type EpochStats struct {
 
 	requestedMutex sync.Mutex
 	requestedBy    []*Client
-	ready          bool
+	ready          atomic.Bool
 	readyChanMutex sync.Mutex
 	readyChan      chan bool
-	isInDb         bool
+	isInDb         atomic.Bool
 
 	precalcBaseRoot phase0.Root
-	precalcValues   *EpochStatsValues
-	values          *EpochStatsValues
-	prunedValues    *EpochStatsValues
+	precalcValues   atomic.Pointer[EpochStatsValues]
+	values          atomic.Pointer[EpochStatsValues]
+	prunedValues    atomic.Pointer[EpochStatsValues]
 
 	prunedEpochAggregations []*dbtypes.UnfinalizedEpoch
 }
@@ -88,6 +88,10 @@ func (es *EpochStats) GetEpoch() phase0.Epoch {
 	return es.epoch
 }
 
+func (es *EpochStats) GetDependentRoot() phase0.Root {
+	return es.dependentRoot
+}
+
 // addRequestedBy adds a client to the list of clients that have requested this EpochStats.
 func (es *EpochStats) addRequestedBy(client *Client) bool {
 	es.requestedMutex.Lock()
@@ -115,7 +119,7 @@ func (es *EpochStats) getRequestedBy() []*Client {
 }
 
 func (es *EpochStats) restoreFromDb(dbDuty *dbtypes.UnfinalizedDuty, dynSsz *dynssz.DynSsz, chainState *consensus.ChainState) error {
-	if es.ready {
+	if es.ready.Load() {
 		return nil
 	}
 
@@ -124,7 +128,7 @@ func (es *EpochStats) restoreFromDb(dbDuty *dbtypes.UnfinalizedDuty, dynSsz *dyn
 		return err
 	}
 
-	es.values = values
+	es.values.Store(values)
 	es.setStatsReady()
 
 	return nil
@@ -134,7 +138,7 @@ func (es *EpochStats) setStatsReady() {
 	es.readyChanMutex.Lock()
 	defer es.readyChanMutex.Unlock()
 
-	es.ready = true
+	es.ready.Store(true)
 	if es.readyChan != nil {
 		close(es.readyChan)
 		es.readyChan = nil
@@ -143,7 +147,8 @@ func (es *EpochStats) setStatsReady() {
 
 // marshalSSZ marshals the EpochStats values using SSZ.
 func (es *EpochStats) buildPackedSSZ(dynSsz *dynssz.DynSsz) ([]byte, error) {
-	if es.values == nil {
+	values := es.values.Load()
+	if values == nil {
 		return nil, fmt.Errorf("no values to marshal")
 	}
 
@@ -152,23 +157,23 @@ func (es *EpochStats) buildPackedSSZ(dynSsz *dynssz.DynSsz) ([]byte, error) {
 	}
 
 	packedValues := &EpochStatsPacked{
-		ActiveValidators:    make([]EpochStatsPackedValidator, es.values.ActiveValidators),
-		SyncCommitteeDuties: es.values.SyncCommitteeDuties,
-		RandaoMix:           es.values.RandaoMix,
-		NextRandaoMix:       es.values.NextRandaoMix,
-		TotalBalance:        es.values.TotalBalance,
-		ActiveBalance:       es.values.ActiveBalance,
-		FirstDepositIndex:   es.values.FirstDepositIndex,
+		ActiveValidators:    make([]EpochStatsPackedValidator, values.ActiveValidators),
+		SyncCommitteeDuties: values.SyncCommitteeDuties,
+		RandaoMix:           values.RandaoMix,
+		NextRandaoMix:       values.NextRandaoMix,
+		TotalBalance:        values.TotalBalance,
+		ActiveBalance:       values.ActiveBalance,
+		FirstDepositIndex:   values.FirstDepositIndex,
 	}
 
 	lastValidatorIndex := phase0.ValidatorIndex(0)
-	for i, validatorIndex := range es.values.ActiveIndices {
+	for i, validatorIndex := range values.ActiveIndices {
 		validatorOffset := uint32(validatorIndex - lastValidatorIndex)
 		lastValidatorIndex = validatorIndex
 
 		packedValues.ActiveValidators[i] = EpochStatsPackedValidator{
 			ValidatorIndexOffset: validatorOffset,
-			EffectiveBalanceEth:  es.values.EffectiveBalances[i],
+			EffectiveBalanceEth:  values.EffectiveBalances[i],
 		}
 	}
 
@@ -261,29 +266,30 @@ func (es *EpochStats) parsePackedSSZ(dynSsz *dynssz.DynSsz, chainState *consensu
 
 // packValues packs the EpochStats values.
 func (es *EpochStats) pruneValues() {
-	if es.values == nil {
+	values := es.values.Load()
+	if values == nil {
 		return
 	}
 
-	es.prunedValues = &EpochStatsValues{
-		RandaoMix:           es.values.RandaoMix,
-		NextRandaoMix:       es.values.NextRandaoMix,
+	es.prunedValues.Store(&EpochStatsValues{
+		RandaoMix:           values.RandaoMix,
+		NextRandaoMix:       values.NextRandaoMix,
 		EffectiveBalances:   nil, // prune
-		ProposerDuties:      es.values.ProposerDuties,
+		ProposerDuties:      values.ProposerDuties,
 		AttesterDuties:      nil, // prune
-		SyncCommitteeDuties: es.values.SyncCommitteeDuties,
-		ActiveValidators:    es.values.ActiveValidators,
-		TotalBalance:        es.values.TotalBalance,
-		ActiveBalance:       es.values.ActiveBalance,
-		EffectiveBalance:    es.values.EffectiveBalance,
-		FirstDepositIndex:   es.values.FirstDepositIndex,
-	}
+		SyncCommitteeDuties: values.SyncCommitteeDuties,
+		ActiveValidators:    values.ActiveValidators,
+		TotalBalance:        values.TotalBalance,
+		ActiveBalance:       values.ActiveBalance,
+		EffectiveBalance:    values.EffectiveBalance,
+		FirstDepositIndex:   values.FirstDepositIndex,
+	})
 
-	es.values = nil
+	es.values.Store(nil)
 }
 
 func (es *EpochStats) loadValuesFromDb(dynSsz *dynssz.DynSsz, chainState *consensus.ChainState) *EpochStatsValues {
-	if !es.isInDb {
+	if !es.isInDb.Load() {
 		return nil
 	}
 
@@ -302,7 +308,7 @@ func (es *EpochStats) loadValuesFromDb(dynSsz *dynssz.DynSsz, chainState *consen
 
 // processState processes the epoch state and computes proposer and attester duties.
 func (es *EpochStats) processState(indexer *Indexer) {
-	if es.dependentState == nil || es.dependentState.loadingStatus != 2 {
+	if es.dependentState == nil || es.dependentState.loadingStatus.Load() != 2 {
 		return
 	}
 	t1 := time.Now()
@@ -372,8 +378,8 @@ func (es *EpochStats) processState(indexer *Indexer) {
 	}
 	values.AttesterDuties = attesterDuties
 
-	es.values = values
-	es.precalcValues = nil
+	es.values.Store(values)
+	es.precalcValues.Store(nil)
 
 	packedSsz, _ := es.buildPackedSSZ(indexer.dynSsz)
 	dbDuty := &dbtypes.UnfinalizedDuty{
@@ -382,14 +388,14 @@ func (es *EpochStats) processState(indexer *Indexer) {
 		DutiesSSZ:     packedSsz,
 	}
 
-	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	err = db.RunDBTransaction(func(tx db.Tx) error {
 		return db.InsertUnfinalizedDuty(dbDuty, tx)
 	})
 	if err != nil {
 		indexer.logger.WithError(err).Errorf("failed storing epoch %v stats (%v / %v) to unfinalized duties", es.epoch, es.dependentRoot.String(), es.dependentState.stateRoot.String())
 	}
 
-	es.isInDb = true
+	es.isInDb.Store(true)
 
 	indexer.logger.Infof(
 		"processed epoch %v stats (root: %v / state: %v, validators: %v/%v, %v ms), %v bytes",
@@ -410,7 +416,7 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 	es.precalcBaseRoot = parentState.dependentRoot
 
 	return indexer.epochCache.withPrecomputeLock(func() error {
-		if es.precalcValues != nil {
+		if es.precalcValues.Load() != nil {
 			return nil
 		}
 		t1 := time.Now()
@@ -421,8 +427,8 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 				continue
 			}
 
-			if other.precalcValues != nil && bytes.Equal(other.precalcBaseRoot[:], parentState.dependentRoot[:]) {
-				es.precalcValues = other.precalcValues
+			if otherValues := other.precalcValues.Load(); otherValues != nil && bytes.Equal(other.precalcBaseRoot[:], parentState.dependentRoot[:]) {
+				es.precalcValues.Store(otherValues)
 				return nil
 			}
 		}
@@ -473,7 +479,7 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 		attesterDuties, _ := duties.GetAttesterDuties(chainState.GetSpecs(), beaconState, es.epoch)
 		values.AttesterDuties = attesterDuties
 
-		es.precalcValues = values
+		es.precalcValues.Store(values)
 
 		indexer.logger.Infof(
 			"precomputed epoch %v stats (root: %v), validators: %v/%v (%v ms)",
@@ -491,14 +497,14 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 // awaitStatsReady waits for the EpochStats values to be ready.
 func (s *EpochStats) awaitStatsReady(ctx context.Context, timeout time.Duration) bool {
 	s.readyChanMutex.Lock()
-	if s.readyChan == nil && !s.ready {
+	if s.readyChan == nil && !s.ready.Load() {
 		s.readyChan = make(chan bool)
 	}
 	s.readyChanMutex.Unlock()
 
 	timeoutTime := time.Now().Add(timeout)
 	for {
-		if s.ready {
+		if s.ready.Load() {
 			return true
 		}
 
@@ -519,16 +525,16 @@ func (es *EpochStats) GetValues(withPrecalc bool) *EpochStatsValues {
 		return nil
 	}
 
-	if es.values != nil {
-		return es.values
+	if values := es.values.Load(); values != nil {
+		return values
 	}
 
-	if es.prunedValues != nil {
-		return es.prunedValues
+	if prunedValues := es.prunedValues.Load(); prunedValues != nil {
+		return prunedValues
 	}
 
-	if es.precalcValues != nil && withPrecalc {
-		return es.precalcValues
+	if precalcValues := es.precalcValues.Load(); precalcValues != nil && withPrecalc {
+		return precalcValues
 	}
 
 	return nil
@@ -540,22 +546,22 @@ func (es *EpochStats) GetOrLoadValues(indexer *Indexer, withPrecalc bool, keepIn
 		return nil
 	}
 
-	if es.values != nil {
-		return es.values
+	if values := es.values.Load(); values != nil {
+		return values
 	}
 
-	if es.isInDb {
+	if es.isInDb.Load() {
 		values := es.loadValuesFromDb(indexer.dynSsz, indexer.consensusPool.GetChainState())
 		if values != nil {
 			if keepInCache {
-				es.values = values
+				es.values.Store(values)
 			}
 			return values
 		}
 	}
 
-	if es.precalcValues != nil && withPrecalc {
-		return es.precalcValues
+	if precalcValues := es.precalcValues.Load(); precalcValues != nil && withPrecalc {
+		return precalcValues
 	}
 
 	return nil
@@ -62,7 +62,7 @@ func (indexer *Indexer) aggregateEpochVotes(epoch phase0.Epoch, chainState *cons
 		targetRoot = *parentRoot
 	}
 
-	votesWithValues := epochStats != nil && epochStats.ready
+	votesWithValues := epochStats != nil && epochStats.ready.Load()
 
 	votesKey := getEpochVotesKey(epoch, targetRoot, blocks[len(blocks)-1].Root, uint8(len(blocks)), votesWithValues)
 	if cachedVotes, isOk := indexer.epochCache.votesCache.Get(votesKey); isOk {
@@ -0,0 +1,394 @@
+package beacon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/era"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const eraExportStateKey = "indexer.eraexport"
+
+const eraPartialFileSuffix = ".partial"
+
+// EraExportFile holds the details of an exported era file.
+type EraExportFile struct {
+	Era      uint64
+	FileName string
+	Size     int64
+	Partial  bool
+}
+
+// startEraExport starts the background export of finalized eras to the configured export directory.
+func (indexer *Indexer) startEraExport() {
+	exportDir := utils.Config.Indexer.EraExportDir
+	if exportDir == "" {
+		return
+	}
+
+	if indexer.consensusPool.GetChainState().GetSpecs().SlotsPerHistoricalRoot == 0 {
+		indexer.logger.Errorf("era export disabled: SLOTS_PER_HISTORICAL_ROOT not found in chain specs")
+		return
+	}
+
+	if err := os.MkdirAll(exportDir, 0o755); err != nil {
+		indexer.logger.WithError(err).Errorf("failed creating era export directory")
+		return
+	}
+
+	indexer.stopEraExport()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	indexer.eraExportCtxCancel = cancel
+
+	go indexer.runEraExportLoop(ctx)
+}
+
+// stopEraExport stops the background era export, the export of the current era file is aborted.
+func (indexer *Indexer) stopEraExport() {
+	if indexer.eraExportCtxCancel != nil {
+		indexer.eraExportCtxCancel()
+		indexer.eraExportCtxCancel = nil
+	}
+}
+
+func (indexer *Indexer) runEraExportLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("runEraExportLoop")
+
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	exportState := &dbtypes.IndexerEraExportState{}
+	db.GetExplorerState(eraExportStateKey, exportState)
+	if exportState.NextEra == 0 && !utils.Config.Indexer.EraExportStates {
+		// era 0 contains the genesis state only
+		exportState.NextEra = 1
+	}
+
+	indexer.eraExportMutex.Lock()
+	indexer.eraExportNextEra = exportState.NextEra
+	indexer.eraExportMutex.Unlock()
+
+	for {
+		// the era is complete when its boundary slot (start of the next era) is finalized
+		for ctx.Err() == nil && chainState.EpochOfSlot(phase0.Slot(exportState.NextEra*specs.SlotsPerHistoricalRoot)) < indexer.getLastFinalizedEpoch() {
+			t1 := time.Now()
+			blockCount, err := indexer.exportEra(ctx, exportState.NextEra, exportState.NextEra*specs.SlotsPerHistoricalRoot)
+			if err != nil {
+				indexer.logger.WithError(err).Warnf("failed exporting era %v", exportState.NextEra)
+				break
+			}
+
+			indexer.logger.Infof("exported era %v (%v blocks, %.3f sec)", exportState.NextEra, blockCount, time.Since(t1).Seconds())
+
+			// the partial export of the era is superseded by the complete era file
+			indexer.eraExportMutex.Lock()
+			os.Remove(indexer.getEraExportFilePath(exportState.NextEra, true))
+			delete(indexer.eraPartialEndSlots, exportState.NextEra)
+			indexer.eraExportNextEra = exportState.NextEra + 1
+			indexer.eraExportMutex.Unlock()
+
+			exportState.NextEra++
+			err = db.RunDBTransaction(func(tx db.Tx) error {
+				return db.SetExplorerState(eraExportStateKey, exportState, tx)
+			})
+			if err != nil {
+				indexer.logger.WithError(err).Errorf("error while saving era export state")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)):
+		}
+	}
+}
+
+// ExportPartialEra exports the finalized slots of the era that is currently in progress as partial era file.
+// partial era files are only written on demand (e.g. for a download via the admin api) and reused until more epochs are finalized.
+// complete eras and eras without finalized slots are left untouched.
+func (indexer *Indexer) ExportPartialEra(ctx context.Context, eraNumber uint64) error {
+	if utils.Config.Indexer.EraExportDir == "" {
+		return nil
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	indexer.eraExportMutex.Lock()
+	defer indexer.eraExportMutex.Unlock()
+
+	if eraNumber == 0 || eraNumber != indexer.eraExportNextEra {
+		return nil
+	}
+
+	endSlot := uint64(chainState.EpochToSlot(indexer.getLastFinalizedEpoch()))
+	if endSlot <= (eraNumber-1)*specs.SlotsPerHistoricalRoot || endSlot >= eraNumber*specs.SlotsPerHistoricalRoot {
+		return nil
+	}
+	if indexer.eraPartialEndSlots[eraNumber] == endSlot {
+		if _, err := os.Stat(indexer.getEraExportFilePath(eraNumber, true)); err == nil {
+			return nil
+		}
+	}
+
+	blockCount, err := indexer.exportEra(ctx, eraNumber, endSlot)
+	if err != nil {
+		return fmt.Errorf("failed exporting partial era %v: %v", eraNumber, err)
+	}
+
+	indexer.logger.Debugf("exported partial era %v up to slot %v (%v blocks)", eraNumber, endSlot, blockCount)
+	if indexer.eraPartialEndSlots == nil {
+		indexer.eraPartialEndSlots = map[uint64]uint64{}
+	}
+	indexer.eraPartialEndSlots[eraNumber] = endSlot
+
+	return nil
+}
+
+// exportEra writes the canonical blocks of the given era up to the end slot (and the state at the end slot if enabled) to an era file.
+// the end slot is the boundary slot of the era (start of the next era) for complete era files.
+func (indexer *Indexer) exportEra(ctx context.Context, eraNumber uint64, endSlot uint64) (int, error) {
+	chainState := indexer.consensusPool.GetChainState()
+	boundaryEpoch := chainState.EpochOfSlot(phase0.Slot(endSlot))
+
+	clients := indexer.getSyncClients(boundaryEpoch)
+	if len(clients) == 0 {
+		return 0, fmt.Errorf("no clients available")
+	}
+
+	var err error
+	for _, client := range clients {
+		var blockCount int
+		blockCount, err = indexer.exportEraFromClient(ctx, eraNumber, endSlot, client)
+		if err == nil || ctx.Err() != nil {
+			return blockCount, err
+		}
+
+		indexer.logger.WithField("client", client.client.GetName()).Debugf("failed exporting era %v: %v", eraNumber, err)
+	}
+
+	return 0, err
+}
+
+func (indexer *Indexer) exportEraFromClient(ctx context.Context, eraNumber uint64, endSlot uint64, client *Client) (int, error) {
+	specs := indexer.consensusPool.GetChainState().GetSpecs()
+
+	// era N contains the blocks of the previous SLOTS_PER_HISTORICAL_ROOT slots, era 0 contains the genesis state only
+	startSlot := uint64(0)
+	if eraNumber > 0 {
+		startSlot = (eraNumber - 1) * specs.SlotsPerHistoricalRoot
+	}
+	if endSlot < startSlot || endSlot > eraNumber*specs.SlotsPerHistoricalRoot {
+		return 0, fmt.Errorf("end slot %v out of era range", endSlot)
+	}
+
+	filePath := indexer.getEraExportFilePath(eraNumber, endSlot < eraNumber*specs.SlotsPerHistoricalRoot)
+	tmpFilePath := filePath + ".tmp"
+
+	file, err := os.Create(tmpFilePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(tmpFilePath)
+	}()
+
+	fileWriter := bufio.NewWriter(file)
+	eraWriter, err := era.NewWriter(fileWriter, startSlot, endSlot-startSlot)
+	if err != nil {
+		return 0, err
+	}
+
+	blockCount := 0
+	for slot := startSlot; slot < endSlot; slot++ {
+		if slot == 0 {
+			// the genesis block is not part of era files
+			continue
+		}
+
+		blockBody := indexer.getEraExportBlock(ctx, phase0.Slot(slot))
+		if blockBody == nil {
+			header, root, orphaned, err := LoadBeaconHeaderBySlot(ctx, client, phase0.Slot(slot))
+			if err != nil {
+				return 0, fmt.Errorf("error fetching slot %v header: %v", slot, err)
+			}
+			if header == nil || orphaned {
+				continue
+			}
+
+			blockBody, err = LoadBeaconBlock(ctx, client, root)
+			if err != nil {
+				return 0, fmt.Errorf("error fetching slot %v block: %v", slot, err)
+			}
+			if blockBody == nil {
+				return 0, fmt.Errorf("error fetching slot %v block: not found", slot)
+			}
+		}
+
+		version, blockSsz, err := marshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, blockBody, false)
+		if err != nil {
+			return 0, fmt.Errorf("error encoding slot %v block: %v", slot, err)
+		}
+		if version&jsonVersionFlag != 0 {
+			return 0, fmt.Errorf("error encoding slot %v block: ssz encoding disabled", slot)
+		}
+
+		if err := eraWriter.WriteBlock(slot, blockSsz); err != nil {
+			return 0, err
+		}
+		blockCount++
+	}
+
+	if utils.Config.Indexer.EraExportStates {
+		// the era state is the state at the boundary slot, it holds the deposit index for the first block of the next era
+		// (or the deposit index after that block, if there is a block at the boundary slot)
+		state, err := LoadBeaconStateBySlot(ctx, client, phase0.Slot(endSlot))
+		if err != nil {
+			return 0, fmt.Errorf("error fetching era state: %v", err)
+		}
+
+		stateSsz, err := marshalVersionedBeaconStateSSZ(indexer.dynSsz, state)
+		if err != nil {
+			return 0, fmt.Errorf("error encoding era state: %v", err)
+		}
+
+		if err := eraWriter.WriteState(endSlot, stateSsz); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := eraWriter.Finish(); err != nil {
+		return 0, err
+	}
+	if err := fileWriter.Flush(); err != nil {
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+
+	return blockCount, os.Rename(tmpFilePath, filePath)
+}
+
+// getEraExportBlock returns the canonical block of a finalized slot from the block cache or the block body store.
+// returns nil if the block is not available locally, the slot is loaded from the client then.
+func (indexer *Indexer) getEraExportBlock(ctx context.Context, slot phase0.Slot) *spec.VersionedSignedBeaconBlock {
+	for _, block := range indexer.blockCache.getBlocksBySlot(slot) {
+		if blockBody := block.GetBlock(); blockBody != nil && indexer.IsCanonicalBlock(block, nil) {
+			return blockBody
+		}
+	}
+
+	block, err := indexer.GetFinalizedBlockBySlot(ctx, slot)
+	if err != nil {
+		indexer.logger.Debugf("failed loading finalized block of slot %v from db: %v", slot, err)
+	}
+	if block != nil {
+		return block.GetBlock()
+	}
+
+	return nil
+}
+
+// getEraExportFilePath returns the export path of the era file for the given era.
+// partial era files don't use the .era extension, so they are not picked up by the era import.
+func (indexer *Indexer) getEraExportFilePath(eraNumber uint64, partial bool) string {
+	fileName := indexer.getEraExportFileName(eraNumber)
+	if partial {
+		fileName += eraPartialFileSuffix
+	}
+
+	return path.Join(utils.Config.Indexer.EraExportDir, fileName)
+}
+
+func (indexer *Indexer) getEraExportFileName(eraNumber uint64) string {
+	networkName := indexer.consensusPool.GetChainState().GetSpecs().ConfigName
+	if networkName == "" {
+		networkName = "dora"
+	}
+
+	return fmt.Sprintf("%v-%05d.era", networkName, eraNumber)
+}
+
+// GetEraExportFiles returns all exported era files ordered by era.
+func (indexer *Indexer) GetEraExportFiles() []*EraExportFile {
+	exportDir := utils.Config.Indexer.EraExportDir
+	if exportDir == "" {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return nil
+	}
+
+	eraFiles := []*EraExportFile{}
+	for _, dirEntry := range dirEntries {
+		fileName := dirEntry.Name()
+		partial := strings.HasSuffix(fileName, eraPartialFileSuffix)
+		if partial {
+			fileName = strings.TrimSuffix(fileName, eraPartialFileSuffix)
+		}
+		if dirEntry.IsDir() || !strings.HasSuffix(fileName, ".era") {
+			continue
+		}
+
+		var eraNumber uint64
+		nameParts := strings.Split(strings.TrimSuffix(fileName, ".era"), "-")
+		if _, err := fmt.Sscanf(nameParts[len(nameParts)-1], "%d", &eraNumber); err != nil {
+			continue
+		}
+		if fileName != indexer.getEraExportFileName(eraNumber) {
+			continue
+		}
+
+		fileInfo, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		eraFiles = append(eraFiles, &EraExportFile{
+			Era:      eraNumber,
+			FileName: dirEntry.Name(),
+			Size:     fileInfo.Size(),
+			Partial:  partial,
+		})
+	}
+
+	sort.Slice(eraFiles, func(i, j int) bool {
+		return eraFiles[i].Era < eraFiles[j].Era
+	})
+
+	return eraFiles
+}
+
+// GetEraExportFilePath returns the path of the exported era file for the given era or an empty string if it has not been exported.
+// the partial era file is returned if the era has not been completed yet.
+func (indexer *Indexer) GetEraExportFilePath(eraNumber uint64) string {
+	if utils.Config.Indexer.EraExportDir == "" {
+		return ""
+	}
+
+	for _, partial := range []bool{false, true} {
+		filePath := indexer.getEraExportFilePath(eraNumber, partial)
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,256 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/era"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// exportTestEras exports the given eras of the mock chain to a temporary directory.
+func exportTestEras(t *testing.T, indexer *Indexer, client *Client, withStates map[uint64]bool, eras ...uint64) string {
+	t.Helper()
+
+	utils.Config.Indexer.EraExportDir = t.TempDir()
+	for _, eraNumber := range eras {
+		utils.Config.Indexer.EraExportStates = withStates[eraNumber]
+		if _, err := indexer.exportEraFromClient(context.Background(), eraNumber, eraNumber*64, client); err != nil {
+			t.Fatalf("failed exporting era %v: %v", eraNumber, err)
+		}
+	}
+	utils.Config.Indexer.EraExportStates = false
+
+	return utils.Config.Indexer.EraExportDir
+}
+
+// TestEraFileRoundTrip exports an era of the mock chain and checks the slot indexes, blocks & state of the generated file.
+func TestEraFileRoundTrip(t *testing.T) {
+	node, indexer, client := newTestEraIndexer(t)
+	exportDir := exportTestEras(t, indexer, client, map[uint64]bool{2: true}, 2)
+
+	eraFile, err := era.Open(path.Join(exportDir, indexer.getEraExportFileName(2)))
+	if err != nil {
+		t.Fatalf("failed opening era file: %v", err)
+	}
+	defer eraFile.Close()
+
+	slotIndexes, err := eraFile.GetSlotIndexes()
+	if err != nil {
+		t.Fatalf("failed reading slot indexes: %v", err)
+	}
+	if len(slotIndexes) != 2 {
+		t.Fatalf("expected block & state slot index, got %v slot indexes", len(slotIndexes))
+	}
+
+	// the offsets are relative to the slot index entries
+	indexEntries := []*era.Entry{}
+	entriesByOffset := map[int64]*era.Entry{}
+	for _, entry := range eraFile.Entries() {
+		entriesByOffset[entry.Offset] = entry
+		if entry.Type == era.TypeSlotIndex {
+			indexEntries = append(indexEntries, entry)
+		}
+	}
+
+	blockIndex := slotIndexes[0]
+	if blockIndex.StartSlot != 64 || len(blockIndex.Offsets) != 64 {
+		t.Fatalf("expected block index for slots 64-127, got start slot %v with %v offsets", blockIndex.StartSlot, len(blockIndex.Offsets))
+	}
+	for idx, offset := range blockIndex.Offsets {
+		slot := blockIndex.StartSlot + uint64(idx)
+		if slot == 64 || slot == 100 {
+			if offset != 0 {
+				t.Errorf("slot %v: expected empty slot, got offset %v", slot, offset)
+			}
+			continue
+		}
+
+		entry := entriesByOffset[indexEntries[0].Offset+offset]
+		if entry == nil || entry.Type != era.TypeCompressedSignedBeaconBlock {
+			t.Errorf("slot %v: offset %v does not point to a block entry", slot, offset)
+			continue
+		}
+
+		blockSsz, err := eraFile.ReadCompressedEntry(entry)
+		if err != nil {
+			t.Fatalf("slot %v: failed reading block: %v", slot, err)
+		}
+		blockSlot, err := era.BlockSlot(blockSsz)
+		if err != nil || blockSlot != slot {
+			t.Errorf("slot %v: block entry has slot %v (%v)", slot, blockSlot, err)
+		}
+
+		block, err := indexer.loadEraBlock(eraFile, entry)
+		if err != nil {
+			t.Fatalf("slot %v: failed decoding block: %v", slot, err)
+		}
+		if expected := node.GetBlock(fmt.Sprintf("a%v", slot)); block.Root != expected.Root {
+			t.Errorf("slot %v: expected block root %v, got %v", slot, expected.Root.String(), block.Root.String())
+		}
+	}
+
+	// the state is the state at the boundary slot (first slot of the next era)
+	stateIndex := slotIndexes[1]
+	if stateIndex.StartSlot != 128 || len(stateIndex.Offsets) != 1 {
+		t.Fatalf("expected state index for slot 128, got start slot %v with %v offsets", stateIndex.StartSlot, len(stateIndex.Offsets))
+	}
+	stateEntry := entriesByOffset[indexEntries[1].Offset+stateIndex.Offsets[0]]
+	if stateEntry == nil || stateEntry.Type != era.TypeCompressedBeaconState {
+		t.Fatalf("state index offset %v does not point to a state entry", stateIndex.Offsets[0])
+	}
+
+	stateSsz, err := eraFile.ReadCompressedEntry(stateEntry)
+	if err != nil {
+		t.Fatalf("failed reading state: %v", err)
+	}
+	if stateSlot, err := era.StateSlot(stateSsz); err != nil || stateSlot != stateIndex.StartSlot {
+		t.Errorf("expected state slot %v, got %v (%v)", stateIndex.StartSlot, stateSlot, err)
+	}
+	if depositIndex, err := era.StateDepositIndex(stateSsz, 64); err != nil || depositIndex != testEraDepositIndex {
+		t.Errorf("expected state deposit index %v, got %v (%v)", testEraDepositIndex, depositIndex, err)
+	}
+	if latestBlockSlot, err := era.StateLatestBlockSlot(stateSsz); err != nil || latestBlockSlot != 128 {
+		t.Errorf("expected state latest block slot 128, got %v (%v)", latestBlockSlot, err)
+	}
+}
+
+// TestEraPartialExport exports the finalized slots of an incomplete era on demand and checks that the partial file is not imported.
+func TestEraPartialExport(t *testing.T) {
+	node, indexer, client := newTestEraIndexer(t)
+	if err := node.SetFinality(49, 49); err != nil {
+		t.Fatalf("failed setting mock chain finality: %v", err)
+	}
+
+	exportDir := t.TempDir()
+	utils.Config.Indexer.EraExportDir = exportDir
+	utils.Config.Indexer.EraExportStates = true
+	defer func() {
+		utils.Config.Indexer.EraExportStates = false
+	}()
+
+	awaitCondition(t, 10*time.Second, "client finality", func() bool {
+		finalizedEpoch, _, _, _ := client.client.GetFinalityCheckpoint()
+		return client.client.GetStatus() == consensus.ClientStatusOnline && finalizedEpoch == 49
+	})
+
+	// era 4 covers slots 192-255, the mock chain ends at slot 200 and is finalized up to slot 196
+	indexer.setLastFinalizedEpoch(49)
+	indexer.eraExportNextEra = 3
+	if err := indexer.ExportPartialEra(context.Background(), 4); err != nil {
+		t.Fatalf("failed exporting partial era: %v", err)
+	}
+	if len(indexer.GetEraExportFiles()) != 0 {
+		t.Fatalf("expected no partial export of an era that is not in progress")
+	}
+
+	indexer.eraExportNextEra = 4
+	if err := indexer.ExportPartialEra(context.Background(), 4); err != nil {
+		t.Fatalf("failed exporting partial era: %v", err)
+	}
+
+	eraFiles := indexer.GetEraExportFiles()
+	if len(eraFiles) != 1 || !eraFiles[0].Partial || eraFiles[0].Era != 4 {
+		t.Fatalf("expected partial era 4 file, got %v files", len(eraFiles))
+	}
+	if filePath := indexer.GetEraExportFilePath(4); path.Base(filePath) != indexer.getEraExportFileName(4)+eraPartialFileSuffix {
+		t.Errorf("expected partial era file path, got %v", filePath)
+	}
+
+	eraFile, err := era.Open(indexer.GetEraExportFilePath(4))
+	if err != nil {
+		t.Fatalf("failed opening partial era file: %v", err)
+	}
+	defer eraFile.Close()
+
+	slotIndexes, err := eraFile.GetSlotIndexes()
+	if err != nil {
+		t.Fatalf("failed reading slot indexes: %v", err)
+	}
+	if len(slotIndexes) != 2 || slotIndexes[0].StartSlot != 192 || len(slotIndexes[0].Offsets) != 4 || slotIndexes[1].StartSlot != 196 {
+		t.Fatalf("unexpected slot indexes of partial era file")
+	}
+
+	utils.Config.Indexer.EraImportDir = exportDir
+	indexer.runEraImport()
+
+	importState := &dbtypes.IndexerEraImportState{}
+	db.GetExplorerState(eraImportStateKey, importState)
+	if importState.LastFile != "" {
+		t.Errorf("expected partial era file to be skipped by the import, got last file %v", importState.LastFile)
+	}
+
+	// the partial file is reused until more epochs are finalized
+	fileInfo, _ := os.Stat(indexer.GetEraExportFilePath(4))
+	os.Chtimes(indexer.GetEraExportFilePath(4), time.Time{}, time.Unix(1, 0))
+	if err := indexer.ExportPartialEra(context.Background(), 4); err != nil {
+		t.Fatalf("failed exporting partial era: %v", err)
+	}
+	if newInfo, _ := os.Stat(indexer.GetEraExportFilePath(4)); newInfo == nil || fileInfo == nil || !newInfo.ModTime().Equal(time.Unix(1, 0)) {
+		t.Errorf("expected unchanged partial era file to be reused")
+	}
+}
+
+// TestEraImportDepositIndexCarryOver imports consecutive eras with & without states and checks the import state.
+func TestEraImportDepositIndexCarryOver(t *testing.T) {
+	node, indexer, client := newTestEraIndexer(t)
+
+	getImportState := func() *dbtypes.IndexerEraImportState {
+		importState := &dbtypes.IndexerEraImportState{}
+		if _, err := db.GetExplorerState(eraImportStateKey, importState); err != nil {
+			t.Fatalf("failed loading era import state: %v", err)
+		}
+		return importState
+	}
+
+	// era 1 without state: the deposit index before era 2 is unknown
+	utils.Config.Indexer.EraImportDir = exportTestEras(t, indexer, client, nil, 1)
+	indexer.runEraImport()
+
+	importState := getImportState()
+	if importState.NextSlot != 64 {
+		t.Errorf("era 1: expected next slot 64, got %v", importState.NextSlot)
+	}
+	if importState.DepositIndex != nil {
+		t.Errorf("era 1: expected unknown deposit index, got %v", *importState.DepositIndex)
+	}
+
+	// era 2 with state: the state provides the deposit index before era 3
+	// era 3 without state: the deposit index is carried over and counted through the blocks of era 3
+	importDir := exportTestEras(t, indexer, client, map[uint64]bool{2: true}, 2, 3)
+	utils.Config.Indexer.EraImportDir = importDir
+	indexer.runEraImport()
+
+	importState = getImportState()
+	if importState.LastFile != indexer.getEraExportFileName(3) {
+		t.Errorf("expected last imported file %v, got %v", indexer.getEraExportFileName(3), importState.LastFile)
+	}
+	if importState.NextSlot != 192 {
+		t.Errorf("era 3: expected next slot 192, got %v", importState.NextSlot)
+	}
+	if importState.DepositIndex == nil || *importState.DepositIndex != testEraDepositIndex {
+		t.Errorf("era 3: expected carried over deposit index %v, got %v", testEraDepositIndex, importState.DepositIndex)
+	}
+
+	// all blocks except the ones in epoch 0 are imported, epoch 0 is left to the synchronizer
+	for _, slot := range []int{4, 63, 65, 99, 101, 191} {
+		assertBlockStatus(t, node, fmt.Sprintf("a%v", slot), dbtypes.Canonical)
+	}
+	assertMissedSlot(t, 64)
+	assertMissedSlot(t, 100)
+	if db.IsEpochSynchronized(0) {
+		t.Errorf("expected epoch 0 to be left to the synchronizer")
+	}
+	for epoch := uint64(1); epoch < 48; epoch++ {
+		if !db.IsEpochSynchronized(epoch) {
+			t.Errorf("expected epoch %v to be imported", epoch)
+		}
+	}
+}
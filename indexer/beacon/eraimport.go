@@ -0,0 +1,324 @@
+package beacon
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/era"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const eraImportStateKey = "indexer.eraimport"
+
+// runEraImport imports all era files from the configured import directory that have not been imported yet.
+// the import runs before the synchronizer is started, so the synchronizer skips all imported epochs.
+func (indexer *Indexer) runEraImport() {
+	importDir := utils.Config.Indexer.EraImportDir
+	if importDir == "" || !indexer.writeDb {
+		return
+	}
+
+	dirEntries, err := os.ReadDir(importDir)
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("failed reading era import directory")
+		return
+	}
+
+	eraFiles := []string{}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), ".era") {
+			continue
+		}
+		eraFiles = append(eraFiles, dirEntry.Name())
+	}
+
+	// era file names contain the zero padded era number, so they can be imported in name order
+	sort.Strings(eraFiles)
+
+	importState := &dbtypes.IndexerEraImportState{}
+	db.GetExplorerState(eraImportStateKey, importState)
+
+	for _, fileName := range eraFiles {
+		if importState.LastFile != "" && fileName <= importState.LastFile {
+			continue
+		}
+
+		t1 := time.Now()
+		blockCount, err := indexer.importEraFile(path.Join(importDir, fileName), importState)
+		if err != nil {
+			indexer.logger.WithError(err).Errorf("failed importing era file %v", fileName)
+			return
+		}
+
+		importState.LastFile = fileName
+		err = db.RunDBTransaction(func(tx db.Tx) error {
+			return db.SetExplorerState(eraImportStateKey, importState, tx)
+		})
+		if err != nil {
+			indexer.logger.WithError(err).Errorf("error while saving era import state")
+			return
+		}
+
+		indexer.logger.Infof("imported era file %v (%v blocks, %.3f sec)", fileName, blockCount, time.Since(t1).Seconds())
+	}
+}
+
+// importEraFile imports the blocks of a single era file and updates the import state with the closing era state.
+func (indexer *Indexer) importEraFile(filePath string, importState *dbtypes.IndexerEraImportState) (int, error) {
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	eraFile, err := era.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer eraFile.Close()
+
+	slotIndexes, err := eraFile.GetSlotIndexes()
+	if err != nil {
+		return 0, err
+	}
+
+	var stateEntry *era.Entry
+	for _, entry := range eraFile.Entries() {
+		if entry.Type == era.TypeCompressedBeaconState {
+			stateEntry = entry
+		}
+	}
+	if stateEntry != nil && len(slotIndexes) > 0 {
+		// the last slot index refers to the era state
+		slotIndexes = slotIndexes[:len(slotIndexes)-1]
+	}
+
+	blockCount := 0
+	var depositIndex *uint64
+	if len(slotIndexes) > 0 {
+		// the first slot index refers to the blocks (missing in era 0, which contains the genesis state only)
+		blockIndex := slotIndexes[0]
+		if len(blockIndex.Offsets) == 0 {
+			return 0, fmt.Errorf("empty block index")
+		}
+
+		firstEpoch := chainState.EpochOfSlot(phase0.Slot(blockIndex.StartSlot))
+		lastEpoch := chainState.EpochOfSlot(phase0.Slot(blockIndex.StartSlot + uint64(len(blockIndex.Offsets)) - 1))
+		if lastEpoch >= indexer.getLastFinalizedEpoch() {
+			return 0, fmt.Errorf("era contains unfinalized epochs (last epoch: %v, finalized epoch: %v)", lastEpoch, indexer.getLastFinalizedEpoch())
+		}
+
+		// the deposit index is only known if the previous era has been imported
+		depositIndexInclFirst := false
+		if importState.DepositIndex != nil && importState.NextSlot == blockIndex.StartSlot {
+			depositIndexField := *importState.DepositIndex
+			depositIndex = &depositIndexField
+			depositIndexInclFirst = importState.DepositIndexInclNext
+		}
+
+		epoch := firstEpoch
+		epochBlocks := []*Block{}
+		for _, entry := range eraFile.Entries() {
+			if entry.Type != era.TypeCompressedSignedBeaconBlock {
+				continue
+			}
+
+			block, err := indexer.loadEraBlock(eraFile, entry)
+			if err != nil {
+				return 0, err
+			}
+
+			if depositIndexInclFirst && uint64(block.Slot) == blockIndex.StartSlot {
+				// the previous era state is the post state of this block, so its deposits are already included
+				deposits, _ := block.GetBlock().Deposits()
+				*depositIndex -= uint64(len(deposits))
+			}
+
+			blockEpoch := chainState.EpochOfSlot(block.Slot)
+			for ; epoch < blockEpoch; epoch++ {
+				if err := indexer.importEraEpoch(epoch, epochBlocks, depositIndex); err != nil {
+					return 0, err
+				}
+				epochBlocks = []*Block{}
+			}
+
+			epochBlocks = append(epochBlocks, block)
+			blockCount++
+		}
+
+		for ; epoch <= lastEpoch; epoch++ {
+			if err := indexer.importEraEpoch(epoch, epochBlocks, depositIndex); err != nil {
+				return 0, err
+			}
+			epochBlocks = []*Block{}
+		}
+
+		importState.NextSlot = blockIndex.StartSlot + uint64(len(blockIndex.Offsets))
+	}
+
+	depositIndexInclNext := false
+	if stateEntry != nil {
+		// the era state at the boundary slot holds the deposit index for the first block of the next era
+		stateSsz, err := eraFile.ReadCompressedEntry(stateEntry)
+		if err != nil {
+			return 0, err
+		}
+
+		stateDepositIndex, err := era.StateDepositIndex(stateSsz, specs.SlotsPerHistoricalRoot)
+		if err != nil {
+			return 0, err
+		}
+		depositIndex = &stateDepositIndex
+
+		stateSlot, err := era.StateSlot(stateSsz)
+		if err != nil {
+			return 0, err
+		}
+		latestBlockSlot, err := era.StateLatestBlockSlot(stateSsz)
+		if err != nil {
+			return 0, err
+		}
+
+		// if there is a block at the boundary slot, the state is its post state
+		depositIndexInclNext = latestBlockSlot == stateSlot
+
+		if len(slotIndexes) == 0 {
+			importState.NextSlot = stateSlot
+		}
+	}
+
+	// era files without state (exported without states) carry the counted deposit index over
+	importState.DepositIndex = depositIndex
+	importState.DepositIndexInclNext = depositIndexInclNext
+
+	return blockCount, nil
+}
+
+// loadEraBlock decodes a block entry of an era file.
+func (indexer *Indexer) loadEraBlock(eraFile *era.File, entry *era.Entry) (*Block, error) {
+	blockSsz, err := eraFile.ReadCompressedEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := era.BlockSlot(blockSsz)
+	if err != nil {
+		return nil, err
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	version := indexer.getEpochDataVersion(chainState.EpochOfSlot(phase0.Slot(slot)))
+
+	blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, uint64(version), blockSsz)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding block %v: %v", slot, err)
+	}
+
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot: phase0.Slot(slot),
+		},
+	}
+	header.Message.ProposerIndex, _ = blockBody.ProposerIndex()
+	header.Message.ParentRoot, _ = blockBody.ParentRoot()
+	header.Message.StateRoot, _ = blockBody.StateRoot()
+	header.Message.BodyRoot, err = blockBody.BodyRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed computing block %v body root: %v", slot, err)
+	}
+
+	// message offset (4) | signature (96) | message
+	copy(header.Signature[:], blockSsz[4:100])
+
+	blockRoot, err := header.Message.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed computing block %v root: %v", slot, err)
+	}
+
+	block := newBlock(indexer.dynSsz, blockRoot, phase0.Slot(slot))
+	block.SetHeader(header)
+	block.SetBlock(blockBody)
+
+	return block, nil
+}
+
+// importEraEpoch persists the blocks, child objects & epoch aggregation of an epoch imported from an era file.
+// epoch duties & votes are not available offline, they can be added later via the range backfill.
+func (indexer *Indexer) importEraEpoch(epoch phase0.Epoch, blocks []*Block, depositIndex *uint64) error {
+	if epoch == 0 || db.IsEpochSynchronized(uint64(epoch)) {
+		// the genesis block is not part of the era files, so epoch 0 is left to the synchronizer
+		// still count the deposits of skipped epochs to keep the deposit index in sync
+		if depositIndex != nil {
+			for _, block := range blocks {
+				deposits, _ := block.GetBlock().Deposits()
+				*depositIndex += uint64(len(deposits))
+			}
+		}
+		return nil
+	}
+
+	specs := indexer.consensusPool.GetChainState().GetSpecs()
+	canonicalBlockRoots := make([][]byte, len(blocks))
+	for idx, block := range blocks {
+		canonicalBlockRoots[idx] = block.Root[:]
+	}
+
+	return db.RunDBTransaction(func(tx db.Tx) error {
+		var blockErr error
+		dbEpoch := indexer.dbWriter.buildDbEpoch(epoch, blocks, nil, nil, func(block *Block, _ *uint64) {
+			if blockErr == nil {
+				_, blockErr = indexer.dbWriter.persistBlockData(tx, block, nil, depositIndex, false, nil)
+			}
+		})
+		if blockErr != nil {
+			return blockErr
+		}
+
+		if err := indexer.dbWriter.persistMissedSlots(tx, epoch, blocks, nil); err != nil {
+			return err
+		}
+
+		if err := db.UpdateMevBlockByEpoch(uint64(epoch), specs.SlotsPerEpoch, canonicalBlockRoots, tx); err != nil {
+			return fmt.Errorf("error while updating mev block proposal state: %v", err)
+		}
+
+		if err := db.InsertEpoch(dbEpoch, tx); err != nil {
+			return fmt.Errorf("error while saving epoch to db: %w", err)
+		}
+
+		if err := db.InvalidateChartRollups(uint64(epoch), tx); err != nil {
+			return fmt.Errorf("error while invalidating chart rollups: %w", err)
+		}
+
+		if err := db.UpdateClientDiversity(uint64(epoch), uint64(epoch), specs.SlotsPerEpoch, tx); err != nil {
+			return fmt.Errorf("error while updating client diversity: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// getEpochDataVersion returns the fork version of the blocks & states in the given epoch.
+func (indexer *Indexer) getEpochDataVersion(epoch phase0.Epoch) spec.DataVersion {
+	specs := indexer.consensusPool.GetChainState().GetSpecs()
+
+	switch {
+	case specs.ElectraForkEpoch != nil && epoch >= phase0.Epoch(*specs.ElectraForkEpoch):
+		return spec.DataVersionElectra
+	case specs.DenebForkEpoch != nil && epoch >= phase0.Epoch(*specs.DenebForkEpoch):
+		return spec.DataVersionDeneb
+	case specs.CappellaForkEpoch != nil && epoch >= phase0.Epoch(*specs.CappellaForkEpoch):
+		return spec.DataVersionCapella
+	case specs.BellatrixForkEpoch != nil && epoch >= phase0.Epoch(*specs.BellatrixForkEpoch):
+		return spec.DataVersionBellatrix
+	case specs.AltairForkEpoch != nil && epoch >= phase0.Epoch(*specs.AltairForkEpoch):
+		return spec.DataVersionAltair
+	default:
+		return spec.DataVersionPhase0
+	}
+}
@@ -0,0 +1,285 @@
+package beacon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/consensus/mockbeacon"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/era"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const testEraDepositIndex = 42
+
+// newTestEraIndexer creates an indexer (without starting it) for a mock chain with 3 eras in the past.
+// the mock chain uses 64 slots per era and 4 slots per epoch, so each era covers 16 epochs.
+func newTestEraIndexer(t *testing.T) (*mockbeacon.Node, *Indexer, *Client) {
+	t.Helper()
+
+	node, err := mockbeacon.NewNode(&mockbeacon.Config{
+		GenesisTime:    time.Now().Truncate(time.Second).Add(-300 * time.Second),
+		SecondsPerSlot: 1,
+		SlotsPerEpoch:  4,
+		ValidatorCount: 64,
+		DepositIndex:   testEraDepositIndex,
+	})
+	if err != nil {
+		t.Fatalf("failed creating mock beacon node: %v", err)
+	}
+	t.Cleanup(node.Close)
+
+	// the slots are in the past, so the chain is built right away
+	// slot 64 (first slot of era 2) & slot 100 are missed
+	if err := node.Replay(context.Background(), mockbeacon.ProduceRange(1, 200, "a", 64, 100)); err != nil {
+		t.Fatalf("failed building mock chain: %v", err)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	utils.Config = &types.Config{}
+	utils.Config.Database.Engine = "sqlite"
+	utils.Config.Database.Sqlite.File = filepath.Join(t.TempDir(), "dora.sqlite")
+
+	db.MustInitDB()
+	t.Cleanup(db.MustCloseDB)
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	pool := consensus.NewPool(ctx, logger.WithField("service", "cl-pool"))
+	poolClient, err := pool.AddEndpoint(&consensus.ClientConfig{
+		URL:  node.URL(),
+		Name: "mock",
+	})
+	if err != nil {
+		t.Fatalf("failed adding mock beacon node to pool: %v", err)
+	}
+
+	indexer := NewIndexer(logger.WithField("service", "cl-indexer"), pool)
+	client := indexer.AddClient(poolClient.GetIndex(), poolClient, 1, true, false)
+
+	awaitCondition(t, 10*time.Second, "chain specs", func() bool {
+		return pool.GetChainState().GetSpecs() != nil && pool.GetChainState().GetGenesis() != nil
+	})
+
+	staticSpec := map[string]any{}
+	specYaml, _ := yaml.Marshal(pool.GetChainState().GetSpecs())
+	yaml.Unmarshal(specYaml, &staticSpec)
+	indexer.dynSsz = dynssz.NewDynSsz(staticSpec)
+	indexer.setLastFinalizedEpoch(60)
+
+	return node, indexer, client
+}
+
+// buildTestEraBlock builds an altair block with the given operations for an era file fixture.
+func buildTestEraBlock(t *testing.T, slot phase0.Slot, deposits []*phase0.Deposit, exits []*phase0.SignedVoluntaryExit, proposerSlashings []*phase0.ProposerSlashing) (phase0.Root, []byte) {
+	t.Helper()
+
+	block := &altair.SignedBeaconBlock{
+		Message: &altair.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: phase0.ValidatorIndex(uint64(slot) % 64),
+			Body: &altair.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				ProposerSlashings: proposerSlashings,
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          deposits,
+				VoluntaryExits:    exits,
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
+			},
+		},
+	}
+
+	blockRoot, err := block.Message.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("slot %v: failed computing block root: %v", slot, err)
+	}
+	blockSsz, err := block.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("slot %v: failed encoding block: %v", slot, err)
+	}
+
+	return blockRoot, blockSsz
+}
+
+func buildTestEraDeposit(pubkeyByte byte, amount phase0.Gwei) *phase0.Deposit {
+	proof := make([][]byte, 33)
+	for idx := range proof {
+		proof[idx] = make([]byte, 32)
+	}
+
+	deposit := &phase0.Deposit{
+		Proof: proof,
+		Data: &phase0.DepositData{
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                amount,
+		},
+	}
+	deposit.Data.PublicKey[0] = pubkeyByte
+
+	return deposit
+}
+
+func buildTestEraSlashedHeader(slot phase0.Slot, proposerIndex phase0.ValidatorIndex, bodyByte byte) *phase0.SignedBeaconBlockHeader {
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+		},
+	}
+	header.Message.BodyRoot[0] = bodyByte
+
+	return header
+}
+
+// TestEraImportBlockOperations imports an era file built with the era writer and checks the imported slots & block operations.
+func TestEraImportBlockOperations(t *testing.T) {
+	_, indexer, _ := newTestEraIndexer(t)
+
+	// era 1 covers slots 64-127 (epochs 16-31)
+	blockSlots := []phase0.Slot{65, 70, 75, 80}
+	blockRoots := map[phase0.Slot]phase0.Root{}
+	blockSszs := map[phase0.Slot][]byte{}
+
+	blockRoots[65], blockSszs[65] = buildTestEraBlock(t, 65, []*phase0.Deposit{
+		buildTestEraDeposit(0x01, 32000000000),
+		buildTestEraDeposit(0x02, 1000000000),
+	}, nil, nil)
+	blockRoots[70], blockSszs[70] = buildTestEraBlock(t, 70, nil, []*phase0.SignedVoluntaryExit{
+		{Message: &phase0.VoluntaryExit{Epoch: 17, ValidatorIndex: 12}},
+	}, nil)
+	blockRoots[75], blockSszs[75] = buildTestEraBlock(t, 75, nil, nil, []*phase0.ProposerSlashing{
+		{
+			SignedHeader1: buildTestEraSlashedHeader(60, 21, 0x01),
+			SignedHeader2: buildTestEraSlashedHeader(60, 21, 0x02),
+		},
+	})
+	blockRoots[80], blockSszs[80] = buildTestEraBlock(t, 80, nil, nil, nil)
+
+	importDir := t.TempDir()
+	eraFile, err := os.Create(filepath.Join(importDir, indexer.getEraExportFileName(1)))
+	if err != nil {
+		t.Fatalf("failed creating era file: %v", err)
+	}
+
+	eraWriter, err := era.NewWriter(eraFile, 64, 64)
+	if err != nil {
+		t.Fatalf("failed creating era writer: %v", err)
+	}
+	for _, slot := range blockSlots {
+		if err := eraWriter.WriteBlock(uint64(slot), blockSszs[slot]); err != nil {
+			t.Fatalf("slot %v: failed writing block: %v", slot, err)
+		}
+	}
+	if err := eraWriter.Finish(); err != nil {
+		t.Fatalf("failed finishing era file: %v", err)
+	}
+	eraFile.Close()
+
+	// the deposit index before era 1 is known from a previous import
+	depositIndex := uint64(7)
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		return db.SetExplorerState(eraImportStateKey, &dbtypes.IndexerEraImportState{
+			NextSlot:     64,
+			DepositIndex: &depositIndex,
+		}, tx)
+	})
+	if err != nil {
+		t.Fatalf("failed setting era import state: %v", err)
+	}
+
+	utils.Config.Indexer.EraImportDir = importDir
+	indexer.runEraImport()
+
+	importState := &dbtypes.IndexerEraImportState{}
+	if _, err := db.GetExplorerState(eraImportStateKey, importState); err != nil {
+		t.Fatalf("failed loading era import state: %v", err)
+	}
+	if importState.LastFile != indexer.getEraExportFileName(1) || importState.NextSlot != 128 {
+		t.Fatalf("expected era 1 to be imported, got last file %v, next slot %v", importState.LastFile, importState.NextSlot)
+	}
+	if importState.DepositIndex == nil || *importState.DepositIndex != 9 {
+		t.Errorf("expected deposit index 9 after era 1, got %v", importState.DepositIndex)
+	}
+
+	for _, slot := range blockSlots {
+		root := blockRoots[slot]
+		dbSlot := db.GetSlotByRoot(root[:])
+		if dbSlot == nil {
+			t.Errorf("slot %v: block not found in db", slot)
+			continue
+		}
+		if dbSlot.Slot != uint64(slot) || dbSlot.Status != dbtypes.Canonical {
+			t.Errorf("slot %v: expected canonical block, got slot %v with status %v", slot, dbSlot.Slot, dbSlot.Status)
+		}
+	}
+	for _, slot := range []phase0.Slot{64, 66, 100, 127} {
+		assertMissedSlot(t, slot)
+	}
+	for epoch := uint64(16); epoch < 32; epoch++ {
+		if !db.IsEpochSynchronized(epoch) {
+			t.Errorf("expected epoch %v to be imported", epoch)
+		}
+	}
+
+	deposits, depositCount, err := db.GetDepositsFiltered(0, 10, 0, &dbtypes.DepositFilter{WithOrphaned: 1})
+	if err != nil {
+		t.Fatalf("failed loading deposits: %v", err)
+	}
+	if depositCount != 2 || len(deposits) != 2 {
+		t.Fatalf("expected 2 deposits, got %v", depositCount)
+	}
+	for _, deposit := range deposits {
+		if deposit.SlotNumber != 65 || deposit.Index == nil {
+			t.Errorf("deposit %v: expected indexed deposit in slot 65, got slot %v", deposit.SlotIndex, deposit.SlotNumber)
+			continue
+		}
+		expectedIndex := 7 + deposit.SlotIndex
+		expectedAmount := []uint64{32000000000, 1000000000}[deposit.SlotIndex]
+		if *deposit.Index != expectedIndex || deposit.Amount != expectedAmount || deposit.PublicKey[0] != byte(deposit.SlotIndex+1) {
+			t.Errorf("deposit %v: expected index %v & amount %v, got index %v & amount %v", deposit.SlotIndex, expectedIndex, expectedAmount, *deposit.Index, deposit.Amount)
+		}
+	}
+
+	voluntaryExits, exitCount, err := db.GetVoluntaryExitsFiltered(0, 10, 0, &dbtypes.VoluntaryExitFilter{WithOrphaned: 1})
+	if err != nil {
+		t.Fatalf("failed loading voluntary exits: %v", err)
+	}
+	if exitCount != 1 || len(voluntaryExits) != 1 || voluntaryExits[0].SlotNumber != 70 || voluntaryExits[0].ValidatorIndex != 12 {
+		t.Errorf("expected voluntary exit of validator 12 in slot 70, got %v exits", exitCount)
+	}
+
+	slashings, slashingCount, err := db.GetSlashingsFiltered(0, 10, 0, &dbtypes.SlashingFilter{WithOrphaned: 1})
+	if err != nil {
+		t.Fatalf("failed loading slashings: %v", err)
+	}
+	if slashingCount != 1 || len(slashings) != 1 {
+		t.Fatalf("expected 1 slashing, got %v", slashingCount)
+	}
+	if slashing := slashings[0]; slashing.SlotNumber != 75 || slashing.ValidatorIndex != 21 || slashing.SlasherIndex != 11 || slashing.Reason != dbtypes.ProposerSlashing {
+		t.Errorf("expected proposer slashing of validator 21 by validator 11 in slot 75, got slashing of validator %v by validator %v in slot %v", slashing.ValidatorIndex, slashing.SlasherIndex, slashing.SlotNumber)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
 // processFinalityEvent processes a finality event.
@@ -22,9 +21,9 @@ func (indexer *Indexer) processFinalityEvent(finalityEvent *v1.Finality) error {
 	indexer.logger.Infof("process finality event (epoch: %v, root: %v)", finalityEvent.Finalized.Epoch, finalityEvent.Finalized.Root.String())
 	startSynchronizer := false
 	synchronizeFromEpoch := phase0.Epoch(0)
-	oldLastFinalizedEpoch := indexer.lastFinalizedEpoch
+	oldLastFinalizedEpoch := indexer.getLastFinalizedEpoch()
 
-	for finalizeEpoch := indexer.lastFinalizedEpoch; finalizeEpoch < finalityEvent.Finalized.Epoch; finalizeEpoch++ {
+	for finalizeEpoch := indexer.getLastFinalizedEpoch(); finalizeEpoch < finalityEvent.Finalized.Epoch; finalizeEpoch++ {
 		readyClients := indexer.GetReadyClientsByCheckpoint(finalityEvent.Finalized.Root, true)
 		retryCount := 5
 
@@ -50,7 +49,7 @@ func (indexer *Indexer) processFinalityEvent(finalityEvent *v1.Finality) error {
 				synchronizeFromEpoch = finalizeEpoch
 				startSynchronizer = true
 			}
-			indexer.lastFinalizedEpoch = finalizeEpoch + 1
+			indexer.setLastFinalizedEpoch(finalizeEpoch + 1)
 			break
 		}
 
@@ -100,11 +99,11 @@ func (indexer *Indexer) processFinalityEvent(finalityEvent *v1.Finality) error {
 
 	if startSynchronizer {
 		indexer.startSynchronizer(synchronizeFromEpoch)
-	} else if !indexer.synchronizer.running && indexer.synchronizer.currentEpoch >= oldLastFinalizedEpoch && indexer.lastFinalizedEpoch > oldLastFinalizedEpoch {
-		indexer.synchronizer.currentEpoch = indexer.lastFinalizedEpoch
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	} else if !indexer.synchronizer.running && indexer.synchronizer.currentEpoch >= oldLastFinalizedEpoch && indexer.getLastFinalizedEpoch() > oldLastFinalizedEpoch {
+		indexer.synchronizer.currentEpoch = indexer.getLastFinalizedEpoch()
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
-				Epoch: uint64(indexer.lastFinalizedEpoch),
+				Epoch: uint64(indexer.getLastFinalizedEpoch()),
 			}, tx)
 		})
 		if err != nil {
@@ -142,16 +141,18 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 				client.logger.Warnf("failed loading finalized block body %v (%v): %v", block.Slot, block.Root.String(), err)
 			}
 
-			if block.block == nil {
+			if block.block.Load() == nil {
 				return true, fmt.Errorf("missing block body for canonical block %v (%v)", block.Slot, block.Root.String())
 			}
 			canonicalBlocks = append(canonicalBlocks, block)
 		} else {
-			if block.isInFinalizedDb {
+			// blocks of pruned epochs are already in db, but have been persisted as canonical blocks of their fork.
+			// their body has been restored from the unfinalized blocks above, so persist them again to fix the status.
+			if block.isInFinalizedDb && block.block.Load() == nil {
 				// orphaned block which is already in db, ignore
 				continue
 			}
-			if block.block == nil {
+			if block.block.Load() == nil {
 				indexer.logger.Warnf("missing block body for orphaned block %v (%v)", block.Slot, block.Root.String())
 				continue
 			}
@@ -255,11 +256,11 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 	if epochStats != nil {
 		// ensure epoch stats are loaded
 		// if the state is not yet loaded, we set it to high priority and wait for it to be loaded
-		if !epochStats.ready {
+		if !epochStats.ready.Load() {
 			if epochStats.dependentState == nil {
 				indexer.epochCache.addEpochStateRequest(epochStats)
 			}
-			if epochStats.dependentState != nil && epochStats.dependentState.loadingStatus != 2 && epochStats.dependentState.retryCount < 10 {
+			if epochStats.dependentState != nil && epochStats.dependentState.loadingStatus.Load() != 2 && epochStats.dependentState.retryCount.Load() < 10 {
 				indexer.logger.Infof("epoch %d state (%v) not yet loaded, waiting for state to be loaded", epoch, dependentRoot.String())
 				t1 := time.Now()
 				epochStats.dependentState.highPriority = true
@@ -300,7 +301,7 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 
 	// persist to db
 	deleteBeforeSlot := chainState.EpochToSlot(epoch + 1)
-	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
 		// persist canonical epoch data
 		if err := indexer.dbWriter.persistEpochData(tx, epoch, canonicalBlocks, epochStats, epochVotes); err != nil {
 			return fmt.Errorf("failed persisting epoch data for epoch %v: %v", epoch, err)
@@ -325,6 +326,13 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 			}
 		}
 
+		// rebuild client diversity aggregates, orphaned blocks might have been persisted as canonical before
+		if len(orphanedBlocks) > 0 {
+			if err := db.UpdateClientDiversity(uint64(epoch), uint64(epoch), specs.SlotsPerEpoch, tx); err != nil {
+				return fmt.Errorf("error while updating client diversity: %v", err)
+			}
+		}
+
 		// persist sync committee assignments
 		if err := indexer.dbWriter.persistSyncAssignments(tx, epoch, epochStats); err != nil {
 			return fmt.Errorf("error persisting sync committee assignments to db: %v", err)
@@ -362,7 +370,7 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 
 	t2dur := time.Since(t1)
 
-	indexer.lastFinalizedEpoch = epoch + 1
+	indexer.setLastFinalizedEpoch(epoch + 1)
 
 	// sleep 500 ms to give running UI threads time to fetch data from cache
 	time.Sleep(500 * time.Millisecond)
@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"sync/atomic"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/dbtypes"
 )
@@ -17,7 +19,7 @@ type Fork struct {
 	leafRoot   phase0.Root // Root of the leaf block.
 	parentFork ForkKey     // Parent fork.
 
-	headBlock *Block // Block at the head of the fork (in case it's known).
+	headBlock atomic.Pointer[Block] // Block at the head of the fork (in case it's known).
 }
 
 // newFork creates a new Fork instance.
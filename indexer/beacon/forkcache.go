@@ -10,7 +10,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 )
 
 // forkCache is a struct that represents the fork cache in the indexer.
@@ -51,7 +50,7 @@ func (cache *forkCache) loadForkState() error {
 }
 
 // updateForkState updates the fork state in the database.
-func (cache *forkCache) updateForkState(tx *sqlx.Tx) error {
+func (cache *forkCache) updateForkState(tx db.Tx) error {
 	err := db.SetExplorerState("indexer.forkstate", &dbtypes.IndexerForkState{
 		ForkId:    uint64(cache.lastForkId),
 		Finalized: uint64(cache.finalizedForkId),
@@ -150,7 +149,7 @@ func (cache *forkCache) getForkHeads() []*ForkHead {
 			forkHeads = append(forkHeads, &ForkHead{
 				ForkId: forkId,
 				Fork:   cache.forkMap[forkId],
-				Block:  fork.headBlock,
+				Block:  fork.headBlock.Load(),
 			})
 		}
 	}
@@ -198,7 +197,7 @@ func (cache *forkCache) setFinalizedEpoch(finalizedSlot phase0.Slot, justifiedRo
 
 	cache.finalizedForkId = closestForkId
 
-	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
 		return cache.updateForkState(tx)
 	})
 	if err != nil {
@@ -316,7 +315,7 @@ func (cache *forkCache) processBlock(block *Block) (ForkKey, error) {
 				parentForkId = ForkKey(blockHead.ForkId)
 			}
 		} else if parentBlock.fokChecked {
-			parentForkId = parentBlock.forkId
+			parentForkId = parentBlock.GetForkId()
 		}
 	}
 
@@ -364,7 +363,7 @@ func (cache *forkCache) processBlock(block *Block) (ForkKey, error) {
 			if parentForkId > 0 {
 				parentFork := cache.getForkById(parentForkId)
 				if parentFork != nil {
-					parentFork.headBlock = baseBlock
+					parentFork.headBlock.Store(baseBlock)
 				}
 			}
 
@@ -410,22 +409,24 @@ func (cache *forkCache) processBlock(block *Block) (ForkKey, error) {
 				break
 			}
 
-			if nextBlock.forkId == currentForkId {
+			if nextBlock.GetForkId() == currentForkId {
 				break
 			}
 
-			nextBlock.forkId = currentForkId
+			nextBlock.setForkId(currentForkId)
 			updatedBlocks = append(updatedBlocks, nextBlock.Root[:])
 		}
 
 		fork := cache.getForkById(currentForkId)
-		if fork != nil && (fork.headBlock == nil || fork.headBlock.Slot < nextBlock.Slot) {
-			fork.headBlock = nextBlock
+		if fork != nil {
+			if headBlock := fork.headBlock.Load(); headBlock == nil || headBlock.Slot < nextBlock.Slot {
+				fork.headBlock.Store(nextBlock)
+			}
 		}
 	}
 
 	if fork1 != nil || fork2 != nil || len(updatedBlocks) > 0 {
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			if fork1 != nil {
 				err := db.InsertFork(fork1.toDbFork(), tx)
 				if err != nil {
@@ -496,7 +497,7 @@ func (cache *forkCache) updateNewForkBlocks(fork *Fork, blocks []*Block, ignoreB
 			continue
 		}
 
-		block.forkId = fork.forkId
+		block.setForkId(fork.forkId)
 		updatedRoots = append(updatedRoots, block.Root[:])
 	}
 
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common/lru"
-	"github.com/jmoiron/sqlx"
 	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -35,8 +35,10 @@ type Indexer struct {
 	writeDb               bool
 	disableSync           bool
 	blockCompression      bool
+	persistBlockBodies    bool
 	inMemoryEpochs        uint16
 	maxParallelStateCalls uint16
+	maxParallelSyncEpochs uint16
 	cachePersistenceDelay uint16
 
 	// caches
@@ -45,19 +47,29 @@ type Indexer struct {
 	forkCache  *forkCache
 
 	// indexer state
-	clients               []*Client
-	dbWriter              *dbWriter
-	running               bool
-	backfillCompleteMutex sync.Mutex
-	backfillingCount      int
-	backfillComplete      bool
-	backfillCompleteChan  chan bool
-	lastFinalizedEpoch    phase0.Epoch
-	lastPrunedEpoch       phase0.Epoch
-	lastPruneRunEpoch     phase0.Epoch
-	lastPrecalcRunEpoch   phase0.Epoch
-	finalitySubscription  *consensus.Subscription[*v1.Finality]
-	wallclockSubscription *consensus.Subscription[*ethwallclock.Slot]
+	clientsMutex             sync.Mutex
+	clients                  []*Client
+	dbWriter                 *dbWriter
+	running                  bool
+	backfillCompleteMutex    sync.Mutex
+	backfillingCount         int
+	backfillComplete         bool
+	backfillCompleteChan     chan bool
+	rangeBackfillMutex       sync.Mutex
+	bootstrapMutex           sync.Mutex
+	bootstrapEpoch           phase0.Epoch
+	bootstrapBackfillStarted bool
+	rangeBackfill            *rangeBackfill
+	eraExportCtxCancel       context.CancelFunc
+	eraExportMutex           sync.Mutex
+	eraExportNextEra         uint64
+	eraPartialEndSlots       map[uint64]uint64
+	lastFinalizedEpoch       atomic.Uint64
+	lastPrunedEpoch          atomic.Uint64
+	lastPruneRunEpoch        phase0.Epoch
+	lastPrecalcRunEpoch      phase0.Epoch
+	finalitySubscription     *consensus.Subscription[*v1.Finality]
+	wallclockSubscription    *consensus.Subscription[*ethwallclock.Slot]
 
 	// canonical head state
 	canonicalHeadMutex   sync.Mutex
@@ -84,6 +96,10 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	if maxParallelStateCalls < 2 {
 		maxParallelStateCalls = 2
 	}
+	maxParallelSyncEpochs := uint16(utils.Config.Indexer.MaxParallelSyncEpochs)
+	if maxParallelSyncEpochs < 1 {
+		maxParallelSyncEpochs = 1
+	}
 	blockCompression := true
 	if utils.Config.KillSwitch.DisableBlockCompression {
 		blockCompression = false
@@ -97,8 +113,10 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 		writeDb:               !utils.Config.Indexer.DisableIndexWriter,
 		disableSync:           utils.Config.Indexer.DisableSynchronizer,
 		blockCompression:      blockCompression,
+		persistBlockBodies:    utils.Config.Indexer.PersistBlockBodies,
 		inMemoryEpochs:        inMemoryEpochs,
 		maxParallelStateCalls: maxParallelStateCalls,
+		maxParallelSyncEpochs: maxParallelSyncEpochs,
 		cachePersistenceDelay: cachePersistenceDelay,
 
 		clients:              make([]*Client, 0),
@@ -115,13 +133,29 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	return indexer
 }
 
+func (indexer *Indexer) getLastFinalizedEpoch() phase0.Epoch {
+	return phase0.Epoch(indexer.lastFinalizedEpoch.Load())
+}
+
+func (indexer *Indexer) setLastFinalizedEpoch(epoch phase0.Epoch) {
+	indexer.lastFinalizedEpoch.Store(uint64(epoch))
+}
+
+func (indexer *Indexer) getLastPrunedEpoch() phase0.Epoch {
+	return phase0.Epoch(indexer.lastPrunedEpoch.Load())
+}
+
+func (indexer *Indexer) setLastPrunedEpoch(epoch phase0.Epoch) {
+	indexer.lastPrunedEpoch.Store(uint64(epoch))
+}
+
 func (indexer *Indexer) getMinInMemoryEpoch() phase0.Epoch {
 	minInMemoryEpoch := phase0.Epoch(0)
-	if indexer.lastFinalizedEpoch > 0 {
-		minInMemoryEpoch = indexer.lastFinalizedEpoch - 1
+	if lastFinalizedEpoch := indexer.getLastFinalizedEpoch(); lastFinalizedEpoch > 0 {
+		minInMemoryEpoch = lastFinalizedEpoch - 1
 	}
-	if indexer.lastPrunedEpoch > 0 && indexer.lastPrunedEpoch > minInMemoryEpoch {
-		minInMemoryEpoch = indexer.lastPrunedEpoch - 1
+	if lastPrunedEpoch := indexer.getLastPrunedEpoch(); lastPrunedEpoch > 0 && lastPrunedEpoch > minInMemoryEpoch {
+		minInMemoryEpoch = lastPrunedEpoch - 1
 	}
 
 	return minInMemoryEpoch
@@ -185,11 +219,65 @@ func (indexer *Indexer) awaitBackfillComplete(ctx context.Context, timeout time.
 func (indexer *Indexer) AddClient(index uint16, client *consensus.Client, priority int, archive bool, skipValidators bool) *Client {
 	logger := indexer.logger.WithField("client", client.GetName())
 	indexerClient := newClient(index, client, priority, archive, skipValidators, indexer, logger)
+
+	indexer.clientsMutex.Lock()
 	indexer.clients = append(indexer.clients, indexerClient)
+	indexer.clientsMutex.Unlock()
+
+	if indexer.running {
+		// indexer already started, so start indexing for clients added at runtime right away
+		indexerClient.startIndexing()
+	}
 
 	return indexerClient
 }
 
+// RemoveClient stops indexing for the given client and removes it from the indexer.
+// the block & head event subscriptions of the client are detached and its head is no longer considered for fork tracking.
+func (indexer *Indexer) RemoveClient(indexerClient *Client) bool {
+	indexer.clientsMutex.Lock()
+	clients := make([]*Client, 0, len(indexer.clients))
+	found := false
+	for _, client := range indexer.clients {
+		if client == indexerClient {
+			found = true
+			continue
+		}
+		clients = append(clients, client)
+	}
+	if found {
+		indexer.clients = clients
+	}
+	indexer.clientsMutex.Unlock()
+
+	if !found {
+		return false
+	}
+
+	indexerClient.stopIndexing()
+	indexer.logger.Infof("removed client %v from indexer", indexerClient.client.GetName())
+
+	return true
+}
+
+// getClients returns a snapshot of the clients slice (copy-on-write, like the pool clients).
+func (indexer *Indexer) getClients() []*Client {
+	indexer.clientsMutex.Lock()
+	defer indexer.clientsMutex.Unlock()
+	return indexer.clients
+}
+
+// GetClientByPoolClient returns the indexer client for the given consensus pool client.
+func (indexer *Indexer) GetClientByPoolClient(client *consensus.Client) *Client {
+	for _, indexerClient := range indexer.getClients() {
+		if indexerClient.client == client {
+			return indexerClient
+		}
+	}
+
+	return nil
+}
+
 // StartIndexer starts the indexing process.
 func (indexer *Indexer) StartIndexer() {
 	if indexer.running {
@@ -208,20 +296,21 @@ func (indexer *Indexer) StartIndexer() {
 	indexer.dynSsz = dynssz.NewDynSsz(staticSpec)
 
 	// initialize synchronizer & restore state
-	indexer.synchronizer = newSynchronizer(indexer, indexer.logger.WithField("service", "synchronizer"))
 	finalizedSlot := chainState.GetFinalizedSlot()
-	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
-	indexer.lastFinalizedEpoch = finalizedEpoch
+	finalizedEpoch, finalizedRoot := chainState.GetFinalizedCheckpoint()
+	indexer.initBootstrapState(finalizedEpoch, finalizedRoot)
+	indexer.synchronizer = newSynchronizer(indexer, indexer.logger.WithField("service", "synchronizer"))
+	indexer.setLastFinalizedEpoch(finalizedEpoch)
 	indexer.lastPrecalcRunEpoch = chainState.CurrentEpoch()
 
 	pruneState := dbtypes.IndexerPruneState{}
 	db.GetExplorerState("indexer.prunestate", &pruneState)
-	indexer.lastPrunedEpoch = phase0.Epoch(pruneState.Epoch)
+	indexer.setLastPrunedEpoch(phase0.Epoch(pruneState.Epoch))
 
-	if indexer.lastPrunedEpoch < finalizedEpoch {
-		indexer.lastPrunedEpoch = finalizedEpoch
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
-			return indexer.updatePruningState(tx, indexer.lastPrunedEpoch)
+	if indexer.getLastPrunedEpoch() < finalizedEpoch {
+		indexer.setLastPrunedEpoch(finalizedEpoch)
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			return indexer.updatePruningState(tx, indexer.getLastPrunedEpoch())
 		})
 		if err != nil {
 			indexer.logger.WithError(err).Errorf("error while updating prune state")
@@ -265,10 +354,10 @@ func (indexer *Indexer) StartIndexer() {
 				return
 			}
 
-			epochStats.isInDb = true
+			epochStats.isInDb.Store(true)
 
 			restoredEpochStats++
-			if dbDuty.Epoch < uint64(indexer.lastPrunedEpoch) {
+			if dbDuty.Epoch < uint64(indexer.getLastPrunedEpoch()) {
 				epochStats.pruneValues()
 			}
 		}()
@@ -308,7 +397,7 @@ func (indexer *Indexer) StartIndexer() {
 	err = db.StreamUnfinalizedBlocks(uint64(finalizedSlot), func(dbBlock *dbtypes.UnfinalizedBlock) {
 
 		block, _ := indexer.blockCache.createOrGetBlock(phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
-		block.forkId = ForkKey(dbBlock.ForkId)
+		block.setForkId(ForkKey(dbBlock.ForkId))
 		block.fokChecked = true
 		block.processingStatus = dbBlock.Status
 		block.isInUnfinalizedDb = true
@@ -338,10 +427,10 @@ func (indexer *Indexer) StartIndexer() {
 			block.isInFinalizedDb = true
 		}
 
-		blockFork := indexer.forkCache.getForkById(block.forkId)
+		blockFork := indexer.forkCache.getForkById(block.GetForkId())
 		if blockFork != nil {
-			if blockFork.headBlock == nil || blockFork.headBlock.Slot < block.Slot {
-				blockFork.headBlock = block
+			if headBlock := blockFork.headBlock.Load(); headBlock == nil || headBlock.Slot < block.Slot {
+				blockFork.headBlock.Store(block)
 			}
 		}
 
@@ -359,7 +448,7 @@ func (indexer *Indexer) StartIndexer() {
 	}
 
 	// start indexing for all clients
-	for _, client := range indexer.clients {
+	for _, client := range indexer.getClients() {
 		client.startIndexing()
 	}
 
@@ -386,8 +475,20 @@ func (indexer *Indexer) StartIndexer() {
 
 		go indexer.runIndexerLoop()
 
+		// import era files from the import directory
+		indexer.runEraImport()
+
 		// start synchronizer
-		indexer.startSynchronizer(indexer.lastFinalizedEpoch)
+		indexer.startSynchronizer(indexer.getLastFinalizedEpoch())
+
+		// resume unfinished range backfill
+		indexer.resumeRangeBackfill()
+
+		// backfill epochs before the bootstrap checkpoint
+		indexer.startBootstrapBackfill()
+
+		// export finalized eras
+		indexer.startEraExport()
 	}()
 }
 
@@ -411,10 +512,10 @@ func (indexer *Indexer) runIndexerLoop() {
 				indexer.logger.WithError(err).Errorf("error processing finality event (epoch: %v, root: %v)", finalityEvent.Finalized.Epoch, finalityEvent.Finalized.Root.String())
 			}
 
-			if indexer.lastFinalizedEpoch > indexer.lastPrunedEpoch {
-				indexer.lastPrunedEpoch = indexer.lastFinalizedEpoch
-				err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
-					return indexer.updatePruningState(tx, indexer.lastPrunedEpoch)
+			if indexer.getLastFinalizedEpoch() > indexer.getLastPrunedEpoch() {
+				indexer.setLastPrunedEpoch(indexer.getLastFinalizedEpoch())
+				err := db.RunDBTransaction(func(tx db.Tx) error {
+					return indexer.updatePruningState(tx, indexer.getLastPrunedEpoch())
 				})
 				if err != nil {
 					indexer.logger.WithError(err).Errorf("error while updating prune state")
@@ -2,19 +2,28 @@ package beacon
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/rand/v2"
 	"sort"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/clienthealth"
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
+	dynssz "github.com/pk910/dynamic-ssz"
 )
 
+// GetDynSSZ returns the dynamic SSZ encoder initialized with the chain specs.
+func (indexer *Indexer) GetDynSSZ() *dynssz.DynSsz {
+	return indexer.dynSsz
+}
+
 // GetAllClients returns a slice of all clients in the indexer.
 func (indexer *Indexer) GetAllClients() []*Client {
-	clients := make([]*Client, len(indexer.clients))
-	copy(clients, indexer.clients)
+	indexerClients := indexer.getClients()
+	clients := make([]*Client, len(indexerClients))
+	copy(clients, indexerClients)
 	return clients
 }
 
@@ -22,7 +31,7 @@ func (indexer *Indexer) GetAllClients() []*Client {
 func (indexer *Indexer) GetReadyClientsByCheckpoint(finalizedRoot phase0.Root, preferArchive bool) []*Client {
 	clients := make([]*Client, 0)
 
-	for _, client := range indexer.clients {
+	for _, client := range indexer.getClients() {
 		if client.client.GetStatus() != consensus.ClientStatusOnline {
 			continue
 		}
@@ -35,17 +44,7 @@ func (indexer *Indexer) GetReadyClientsByCheckpoint(finalizedRoot phase0.Root, p
 		clients = append(clients, client)
 	}
 
-	sort.Slice(clients, func(i, j int) bool {
-		if preferArchive && clients[i].archive != clients[j].archive {
-			return clients[i].archive
-		}
-
-		if clients[i].priority != clients[j].priority {
-			return clients[i].priority > clients[j].priority
-		}
-
-		return rand.IntN(2) == 0
-	})
+	sortClients(clients, preferArchive)
 
 	return clients
 }
@@ -54,7 +53,7 @@ func (indexer *Indexer) GetReadyClientsByCheckpoint(finalizedRoot phase0.Root, p
 func (indexer *Indexer) GetReadyClientsByBlockRoot(blockRoot phase0.Root, preferArchive bool) []*Client {
 	clients := make([]*Client, 0)
 
-	for _, client := range indexer.clients {
+	for _, client := range indexer.getClients() {
 		if client.client.GetStatus() != consensus.ClientStatusOnline {
 			continue
 		}
@@ -65,21 +64,74 @@ func (indexer *Indexer) GetReadyClientsByBlockRoot(blockRoot phase0.Root, prefer
 		}
 	}
 
-	sort.Slice(clients, func(i, j int) bool {
-		if preferArchive && clients[i].archive != clients[j].archive {
-			return clients[i].archive
+	sortClients(clients, preferArchive)
+
+	return clients
+}
+
+// getSyncClients returns all online clients that can serve the finalized data of the given epoch, archive clients first.
+func (indexer *Indexer) getSyncClients(epoch phase0.Epoch) []*Client {
+	clients := make([]*Client, 0)
+
+	for _, client := range indexer.getClients() {
+		if client.client.GetStatus() != consensus.ClientStatusOnline {
+			continue
 		}
 
-		if clients[i].priority != clients[j].priority {
-			return clients[i].priority > clients[j].priority
+		if client.skipValidators {
+			continue
 		}
 
-		return rand.IntN(2) == 0
-	})
+		finalizedEpoch, _, _, _ := client.client.GetFinalityCheckpoint()
+		if finalizedEpoch < epoch {
+			continue
+		}
+
+		clients = append(clients, client)
+	}
+
+	sortClients(clients, true)
 
 	return clients
 }
 
+// sortClients sorts the clients by archive preference, health & priority.
+// clients with an open circuit breaker or a degraded health score are moved behind all healthy clients regardless of their priority.
+// clients with the same priority are ordered by their health score, clients with a similar score keep a random order to balance the load.
+func sortClients(clients []*Client, preferArchive bool) {
+	scores := make(map[*Client]float64, len(clients))
+	for _, client := range clients {
+		scores[client] = client.client.GetHealthScore()
+	}
+
+	rand.Shuffle(len(clients), func(i, j int) {
+		clients[i], clients[j] = clients[j], clients[i]
+	})
+
+	sort.SliceStable(clients, func(i, j int) bool {
+		cliA := clients[i]
+		cliB := clients[j]
+
+		if archiveA := cliA.IsArchive(); preferArchive && archiveA != cliB.IsArchive() {
+			return archiveA
+		}
+
+		if availableA := cliA.client.IsAvailable(); availableA != cliB.client.IsAvailable() {
+			return availableA
+		}
+
+		if degradedA := clienthealth.IsDegraded(scores[cliA]); degradedA != clienthealth.IsDegraded(scores[cliB]) {
+			return !degradedA
+		}
+
+		if priorityA, priorityB := cliA.GetPriority(), cliB.GetPriority(); priorityA != priorityB {
+			return priorityA > priorityB
+		}
+
+		return clienthealth.CompareScores(scores[cliA], scores[cliB]) < 0
+	})
+}
+
 // GetReadyClientByBlockRoot returns a single client that is ready for requests for the chain including the block root and preference for archive clients.
 func (indexer *Indexer) GetReadyClientByBlockRoot(blockRoot phase0.Root, preferArchive bool) *Client {
 	clients := indexer.GetReadyClientsByBlockRoot(blockRoot, preferArchive)
@@ -113,7 +165,7 @@ func (indexer *Indexer) GetReadyClient(preferArchive bool) *Client {
 // GetBlockCacheState returns the state of the block cache, including the last finalized epoch and the last pruned epoch.
 // this represents the internal cache state and might be behind the actual finalization checkpoint.
 func (indexer *Indexer) GetBlockCacheState() (finalizedEpoch phase0.Epoch, prunedEpoch phase0.Epoch) {
-	return indexer.lastFinalizedEpoch, indexer.lastPrunedEpoch
+	return indexer.getLastFinalizedEpoch(), indexer.getLastPrunedEpoch()
 }
 
 // GetForkHeads returns a slice of fork heads in the indexer.
@@ -163,19 +215,69 @@ func (indexer *Indexer) GetOrphanedBlockByRoot(blockRoot phase0.Root) (*Block, e
 		return nil, nil
 	}
 
-	if orphanedBlock.HeaderVer != 1 {
-		return nil, fmt.Errorf("failed unmarshal orphaned block header [%x] from db: unsupported header version", orphanedBlock.Root)
+	block, err := indexer.restoreDbBlock(blockRoot, orphanedBlock.HeaderVer, orphanedBlock.HeaderSSZ, orphanedBlock.BlockVer, orphanedBlock.BlockSSZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed restoring orphaned block: %v", err)
+	}
+
+	return block, nil
+}
+
+// GetFinalizedBlockByRoot returns the canonical finalized block with the given block root from the block body store.
+// returns nil if block bodies are not persisted or the block body has not been persisted yet.
+func (indexer *Indexer) GetFinalizedBlockByRoot(ctx context.Context, blockRoot phase0.Root) (*Block, error) {
+	if !indexer.persistBlockBodies {
+		return nil, nil
+	}
+
+	blockBody := db.WithContext(ctx).GetBlockBody(blockRoot[:])
+	if blockBody == nil {
+		return nil, nil
+	}
+
+	block, err := indexer.restoreDbBlock(blockRoot, blockBody.HeaderVer, blockBody.HeaderSSZ, blockBody.BlockVer, blockBody.BlockSSZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed restoring finalized block: %v", err)
+	}
+
+	return block, nil
+}
+
+// GetFinalizedBlockBySlot returns the canonical finalized block in the given slot from the block body store.
+// returns nil if block bodies are not persisted, the slot is missed or the block body has not been persisted yet.
+func (indexer *Indexer) GetFinalizedBlockBySlot(ctx context.Context, slot phase0.Slot) (*Block, error) {
+	if !indexer.persistBlockBodies {
+		return nil, nil
+	}
+
+	blockBody := db.WithContext(ctx).GetCanonicalBlockBodyBySlot(uint64(slot))
+	if blockBody == nil {
+		return nil, nil
+	}
+
+	block, err := indexer.restoreDbBlock(phase0.Root(blockBody.Root), blockBody.HeaderVer, blockBody.HeaderSSZ, blockBody.BlockVer, blockBody.BlockSSZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed restoring finalized block: %v", err)
+	}
+
+	return block, nil
+}
+
+// restoreDbBlock rebuilds a block from the ssz encoded header & body stored in the db.
+func (indexer *Indexer) restoreDbBlock(blockRoot phase0.Root, headerVer uint64, headerSSZ []byte, blockVer uint64, blockSSZ []byte) (*Block, error) {
+	if headerVer != 1 {
+		return nil, fmt.Errorf("failed unmarshal block header [%x] from db: unsupported header version", blockRoot)
 	}
 
 	header := &phase0.SignedBeaconBlockHeader{}
-	err := header.UnmarshalSSZ(orphanedBlock.HeaderSSZ)
+	err := header.UnmarshalSSZ(headerSSZ)
 	if err != nil {
-		return nil, fmt.Errorf("failed unmarshal orphaned block header [%x] from db: %v", orphanedBlock.Root, err)
+		return nil, fmt.Errorf("failed unmarshal block header [%x] from db: %v", blockRoot, err)
 	}
 
-	blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, orphanedBlock.BlockVer, orphanedBlock.BlockSSZ)
+	blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, blockVer, blockSSZ)
 	if err != nil {
-		return nil, fmt.Errorf("could not restore orphaned block body %v [%x] from db: %v", header.Message.Slot, orphanedBlock.Root, err)
+		return nil, fmt.Errorf("could not restore block body %v [%x] from db: %v", header.Message.Slot, blockRoot, err)
 	}
 
 	block := newBlock(indexer.dynSsz, blockRoot, header.Message.Slot)
@@ -203,7 +305,7 @@ func (indexer *Indexer) GetEpochStats(epoch phase0.Epoch, overrideForkId *ForkKe
 
 	if canonicalHead != nil {
 		for _, stats := range epochStats {
-			if !stats.ready {
+			if !stats.ready.Load() {
 				continue
 			}
 			if isInChain, distance := indexer.blockCache.getCanonicalDistance(stats.dependentRoot, canonicalHead.Root, 0); isInChain {
@@ -217,7 +319,7 @@ func (indexer *Indexer) GetEpochStats(epoch phase0.Epoch, overrideForkId *ForkKe
 		if bestEpochStats == nil {
 			// retry with non ready states
 			for _, stats := range epochStats {
-				if stats.ready {
+				if stats.ready.Load() {
 					continue
 				}
 				if isInChain, distance := indexer.blockCache.getCanonicalDistance(stats.dependentRoot, canonicalHead.Root, 0); isInChain {
@@ -0,0 +1,214 @@
+package beacon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// BlockCacheStatus holds the internal state of a block in the block cache.
+type BlockCacheStatus struct {
+	Slot             phase0.Slot
+	Root             phase0.Root
+	ForkId           ForkKey
+	HasHeader        bool
+	HasBody          bool
+	IsCanonical      bool
+	InFinalizedDb    bool
+	InUnfinalizedDb  bool
+	ProcessingStatus dbtypes.UnfinalizedBlockStatus
+	SeenBy           []string
+}
+
+// EpochCacheStatus holds the internal state of an epoch stats entry in the epoch cache.
+type EpochCacheStatus struct {
+	Epoch         phase0.Epoch
+	DependentRoot phase0.Root
+	Ready         bool
+	Pruned        bool
+	InDb          bool
+	RequestedBy   []string
+	StateStatus   string
+	StateClient   string
+	StateStarted  time.Time
+	StateRetries  uint64
+}
+
+// SynchronizerStatus holds the internal state of the synchronizer.
+type SynchronizerStatus struct {
+	Disabled         bool
+	Running          bool
+	CurrentEpoch     phase0.Epoch
+	RetryCount       int
+	ForceUpdateStart phase0.Epoch
+	ForceUpdateEnd   phase0.Epoch
+}
+
+// GetBlockCacheStatus returns the state of the latest blocks in the block cache, ordered by slot descending.
+func (indexer *Indexer) GetBlockCacheStatus(limit int) (blocks []*BlockCacheStatus, totalCount int) {
+	canonicalHead := indexer.GetCanonicalHead(nil)
+
+	indexer.blockCache.cacheMutex.RLock()
+	slots := make([]phase0.Slot, 0, len(indexer.blockCache.slotMap))
+	for slot := range indexer.blockCache.slotMap {
+		slots = append(slots, slot)
+	}
+	cachedBlocks := make([]*Block, 0, len(indexer.blockCache.rootMap))
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] > slots[j]
+	})
+	for _, slot := range slots {
+		cachedBlocks = append(cachedBlocks, indexer.blockCache.slotMap[slot]...)
+	}
+	indexer.blockCache.cacheMutex.RUnlock()
+
+	totalCount = len(cachedBlocks)
+	if limit > 0 && len(cachedBlocks) > limit {
+		cachedBlocks = cachedBlocks[:limit]
+	}
+
+	blocks = make([]*BlockCacheStatus, 0, len(cachedBlocks))
+	for _, block := range cachedBlocks {
+		blockStatus := &BlockCacheStatus{
+			Slot:             block.Slot,
+			Root:             block.Root,
+			ForkId:           block.GetForkId(),
+			HasHeader:        block.header.Load() != nil,
+			HasBody:          block.block.Load() != nil,
+			InFinalizedDb:    block.isInFinalizedDb,
+			InUnfinalizedDb:  block.isInUnfinalizedDb,
+			ProcessingStatus: block.processingStatus,
+		}
+		if canonicalHead != nil {
+			blockStatus.IsCanonical = indexer.blockCache.isCanonicalBlock(block.Root, canonicalHead.Root)
+		}
+		for _, client := range block.GetSeenBy() {
+			blockStatus.SeenBy = append(blockStatus.SeenBy, client.client.GetName())
+		}
+
+		blocks = append(blocks, blockStatus)
+	}
+
+	return blocks, totalCount
+}
+
+// GetEpochCacheStatus returns the state of all epoch stats in the epoch cache, ordered by epoch descending.
+func (indexer *Indexer) GetEpochCacheStatus() []*EpochCacheStatus {
+	indexer.epochCache.cacheMutex.RLock()
+	epochStatsList := make([]*EpochStats, 0, len(indexer.epochCache.statsMap))
+	for _, epochStats := range indexer.epochCache.statsMap {
+		epochStatsList = append(epochStatsList, epochStats)
+	}
+	indexer.epochCache.cacheMutex.RUnlock()
+
+	sort.Slice(epochStatsList, func(i, j int) bool {
+		if epochStatsList[i].epoch != epochStatsList[j].epoch {
+			return epochStatsList[i].epoch > epochStatsList[j].epoch
+		}
+		return epochStatsList[i].dependentRoot.String() < epochStatsList[j].dependentRoot.String()
+	})
+
+	epochs := make([]*EpochCacheStatus, 0, len(epochStatsList))
+	for _, epochStats := range epochStatsList {
+		epochStatus := &EpochCacheStatus{
+			Epoch:         epochStats.epoch,
+			DependentRoot: epochStats.dependentRoot,
+			Ready:         epochStats.ready.Load(),
+			Pruned:        epochStats.values.Load() == nil && epochStats.prunedValues.Load() != nil,
+			InDb:          epochStats.isInDb.Load(),
+			StateStatus:   "none",
+		}
+		for _, client := range epochStats.getRequestedBy() {
+			epochStatus.RequestedBy = append(epochStatus.RequestedBy, client.client.GetName())
+		}
+
+		if epochState := epochStats.dependentState; epochState != nil {
+			switch epochState.loadingStatus.Load() {
+			case 0:
+				epochStatus.StateStatus = "pending"
+			case 1:
+				epochStatus.StateStatus = "loading"
+			case 2:
+				epochStatus.StateStatus = "loaded"
+			default:
+				epochStatus.StateStatus = fmt.Sprintf("unknown (%v)", epochState.loadingStatus.Load())
+			}
+			if loadingClient := epochState.loadingClient; loadingClient != nil {
+				epochStatus.StateClient = loadingClient.client.GetName()
+			}
+			epochStatus.StateStarted = epochState.loadingStarted
+			epochStatus.StateRetries = epochState.retryCount.Load()
+		}
+
+		epochs = append(epochs, epochStatus)
+	}
+
+	return epochs
+}
+
+// GetSynchronizerStatus returns the state of the synchronizer.
+func (indexer *Indexer) GetSynchronizerStatus() *SynchronizerStatus {
+	if indexer.synchronizer == nil {
+		return &SynchronizerStatus{
+			Disabled: true,
+		}
+	}
+
+	indexer.synchronizer.stateMutex.Lock()
+	defer indexer.synchronizer.stateMutex.Unlock()
+
+	return &SynchronizerStatus{
+		Disabled:         indexer.disableSync,
+		Running:          indexer.synchronizer.running,
+		CurrentEpoch:     indexer.synchronizer.currentEpoch,
+		RetryCount:       indexer.synchronizer.retryCount,
+		ForceUpdateStart: indexer.synchronizer.forceUpdateStart,
+		ForceUpdateEnd:   indexer.synchronizer.forceUpdateEnd,
+	}
+}
+
+// ResyncFromEpoch restarts the synchronization from the given finalized epoch.
+// if forceUpdate is set, all epochs from the given epoch are synchronized again even if they are already in the db.
+// otherwise only missing epochs are synchronized.
+func (indexer *Indexer) ResyncFromEpoch(epoch phase0.Epoch, forceUpdate bool) error {
+	if err := indexer.checkResyncEpoch(epoch); err != nil {
+		return err
+	}
+
+	endEpoch := epoch
+	if forceUpdate {
+		endEpoch = phase0.Epoch(math.MaxUint64)
+	}
+
+	indexer.logger.Infof("resynchronizing from epoch %v (force update: %v)", epoch, forceUpdate)
+	return indexer.synchronizer.resyncEpochs(epoch, endEpoch)
+}
+
+// RerunEpochFinalization processes the given finalized epoch again.
+// blocks of finalized epochs are not kept in cache, so the epoch is reloaded from the clients by the synchronizer and all epoch data is written to the db again.
+func (indexer *Indexer) RerunEpochFinalization(epoch phase0.Epoch) error {
+	if err := indexer.checkResyncEpoch(epoch); err != nil {
+		return err
+	}
+
+	indexer.logger.Infof("re-running finalization for epoch %v", epoch)
+	return indexer.synchronizer.resyncEpochs(epoch, epoch+1)
+}
+
+func (indexer *Indexer) checkResyncEpoch(epoch phase0.Epoch) error {
+	if indexer.disableSync || indexer.synchronizer == nil {
+		return fmt.Errorf("synchronizer is disabled")
+	}
+	if !indexer.writeDb {
+		return fmt.Errorf("index writer is disabled")
+	}
+	if epoch >= indexer.getLastFinalizedEpoch() {
+		return fmt.Errorf("epoch %v is not finalized yet (finalized epoch: %v)", epoch, indexer.getLastFinalizedEpoch())
+	}
+
+	return nil
+}
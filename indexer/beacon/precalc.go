@@ -33,7 +33,7 @@ func (indexer *Indexer) precalcNextEpochStats(epoch phase0.Epoch) error {
 
 	// precompute epoch stats for the epoch if we have the parent epoch stats ready
 	epochStats := indexer.epochCache.createOrGetEpochStats(epoch, dependentBlock.Root, false)
-	if !epochStats.ready {
+	if !epochStats.ready.Load() {
 		var parentDependentBlock *Block
 		if chainState.EpochOfSlot(dependentBlock.Slot) == epoch-1 {
 			parentDependentBlock = indexer.blockCache.getDependentBlock(chainState, dependentBlock, nil)
@@ -11,7 +11,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
-	"github.com/jmoiron/sqlx"
 	"github.com/mashingan/smapping"
 )
 
@@ -25,14 +24,14 @@ func (indexer *Indexer) runCachePruning() error {
 		pruneToEpoch = 0
 	}
 
-	if pruneToEpoch < indexer.lastFinalizedEpoch {
-		pruneToEpoch = indexer.lastFinalizedEpoch
+	if pruneToEpoch < indexer.getLastFinalizedEpoch() {
+		pruneToEpoch = indexer.getLastFinalizedEpoch()
 	}
 
 	var totalPrunedEpochStats, totalPrunedEpochStates uint64
 
 	// process all epochs that are not yet pruned and can be pruned
-	for pruneEpoch := indexer.lastPrunedEpoch; pruneEpoch < pruneToEpoch; pruneEpoch++ {
+	for pruneEpoch := indexer.getLastPrunedEpoch(); pruneEpoch < pruneToEpoch; pruneEpoch++ {
 		if prunedEpochStats, prunedEpochStates, err := indexer.processEpochPruning(pruneEpoch); err != nil {
 			return fmt.Errorf("failed pruning epoch %d: %v", pruneEpoch, err)
 		} else {
@@ -49,7 +48,7 @@ func (indexer *Indexer) runCachePruning() error {
 	return nil
 }
 
-func (indexer *Indexer) updatePruningState(tx *sqlx.Tx, epoch phase0.Epoch) error {
+func (indexer *Indexer) updatePruningState(tx db.Tx, epoch phase0.Epoch) error {
 	err := db.SetExplorerState("indexer.prunestate", &dbtypes.IndexerPruneState{
 		Epoch: uint64(epoch),
 	}, tx)
@@ -116,11 +115,11 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 
 		// ensure epoch stats are loaded
 		// if the state is not yet loaded, we set it to high priority and wait for it to be loaded
-		if epochStats != nil && !epochStats.ready {
+		if epochStats != nil && !epochStats.ready.Load() {
 			if epochStats.dependentState == nil {
 				indexer.epochCache.addEpochStateRequest(epochStats)
 			}
-			if epochStats.dependentState != nil && epochStats.dependentState.loadingStatus != 2 && epochStats.dependentState.retryCount < 10 {
+			if epochStats.dependentState != nil && epochStats.dependentState.loadingStatus.Load() != 2 && epochStats.dependentState.retryCount.Load() < 10 {
 				indexer.logger.Infof("epoch %d state (%v) not yet loaded, waiting for state to be loaded", pruneEpoch, dependentRoot.String())
 				t2 := time.Now()
 				epochStats.dependentState.highPriority = true
@@ -190,7 +189,7 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 	t1 = time.Now()
 
 	// persist data in db
-	db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	db.RunDBTransaction(func(tx db.Tx) error {
 		persistedBlocks := map[phase0.Root]bool{}
 
 		for _, epochData := range epochData {
@@ -216,7 +215,7 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 
 			dbUnfinalizedEpoch.DependentRoot = epochData.dependentRoot[:]
 			dbUnfinalizedEpoch.EpochHeadRoot = epochData.chainHead.Root[:]
-			dbUnfinalizedEpoch.EpochHeadForkId = uint64(epochData.chainHead.forkId)
+			dbUnfinalizedEpoch.EpochHeadForkId = uint64(epochData.chainHead.GetForkId())
 
 			if epochData.epochStats != nil {
 				if epochData.epochStats.prunedEpochAggregations == nil {
@@ -245,7 +244,7 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 		return nil
 	})
 
-	indexer.lastPrunedEpoch = pruneEpoch + 1
+	indexer.setLastPrunedEpoch(pruneEpoch + 1)
 	t2dur := time.Since(t1)
 
 	// sleep 500 ms to give running UI threads time to fetch data from cache
@@ -256,8 +255,8 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 	for _, block := range pruningBlocks {
 		block.isInFinalizedDb = true
 		block.processingStatus = dbtypes.UnfinalizedBlockStatusPruned
-		block.setBlockIndex(block.block)
-		block.block = nil
+		block.setBlockIndex(block.block.Load())
+		block.block.Store(nil)
 	}
 
 	// clean up epoch stats cache
@@ -267,7 +266,7 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 			epochStats.dependentState = nil
 		}
 
-		if epochStats.ready && epochStats.prunedValues == nil {
+		if epochStats.ready.Load() && epochStats.prunedValues.Load() == nil {
 			epochStats.pruneValues()
 			prunedEpochStats++
 		}
@@ -339,7 +338,7 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 	}
 
 	if len(pruningData) > 0 {
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			for _, pruneBlock := range pruningData {
 				_, err := indexer.dbWriter.persistBlockData(tx, pruneBlock.block, pruneBlock.epochStats, nil, true, nil)
 				if err != nil {
@@ -366,7 +365,7 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 	for _, pruneBlock := range pruningData {
 		pruneBlock.block.isInFinalizedDb = true
 		pruneBlock.block.processingStatus = dbtypes.UnfinalizedBlockStatusPruned
-		pruneBlock.block.block = nil
+		pruneBlock.block.block.Store(nil)
 	}
 
 	// clean up epoch stats cache
@@ -375,7 +374,7 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 			epochStats.dependentState = nil
 		}
 
-		if epochStats.ready && epochStats.prunedValues == nil {
+		if epochStats.ready.Load() && epochStats.prunedValues.Load() == nil {
 			epochStats.pruneValues()
 			prunedEpochStats++
 		}
@@ -0,0 +1,717 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// RangeBackfillStage is a bit mask of the data that is written to the db by a range backfill.
+type RangeBackfillStage uint8
+
+const (
+	// RangeBackfillStageBlocks reindexes the slots (incl. missed slots), voluntary exits & slashings
+	RangeBackfillStageBlocks RangeBackfillStage = 1 << iota
+	// RangeBackfillStageDeposits reindexes the deposits & deposit requests
+	RangeBackfillStageDeposits
+	// RangeBackfillStageElRequests reindexes the consolidation & withdrawal requests
+	RangeBackfillStageElRequests
+	// RangeBackfillStageSyncDuties reindexes the sync committee assignments
+	RangeBackfillStageSyncDuties
+	// RangeBackfillStageEpochs reindexes the epoch aggregations (incl. votes)
+	RangeBackfillStageEpochs
+
+	RangeBackfillStageAll = RangeBackfillStageBlocks | RangeBackfillStageDeposits | RangeBackfillStageElRequests | RangeBackfillStageSyncDuties | RangeBackfillStageEpochs
+)
+
+var rangeBackfillStageNames = []struct {
+	stage RangeBackfillStage
+	name  string
+}{
+	{RangeBackfillStageBlocks, "blocks"},
+	{RangeBackfillStageDeposits, "deposits"},
+	{RangeBackfillStageElRequests, "elrequests"},
+	{RangeBackfillStageSyncDuties, "syncduties"},
+	{RangeBackfillStageEpochs, "epochs"},
+}
+
+// max number of attempts per epoch before the epoch is recorded as failed
+const rangeBackfillRetryLimit = 3
+
+// ParseRangeBackfillStages parses the given stage names to a stage mask.
+// an empty list selects all stages.
+func ParseRangeBackfillStages(names []string) (RangeBackfillStage, error) {
+	if len(names) == 0 {
+		return RangeBackfillStageAll, nil
+	}
+
+	stages := RangeBackfillStage(0)
+	for _, name := range names {
+		found := false
+		for _, stageName := range rangeBackfillStageNames {
+			if strings.EqualFold(stageName.name, name) {
+				stages |= stageName.stage
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown backfill stage: %v", name)
+		}
+	}
+
+	return stages, nil
+}
+
+// Names returns the names of all stages in the stage mask.
+func (stages RangeBackfillStage) Names() []string {
+	names := []string{}
+	for _, stageName := range rangeBackfillStageNames {
+		if stages&stageName.stage != 0 {
+			names = append(names, stageName.name)
+		}
+	}
+	return names
+}
+
+// RangeBackfillStatus holds the progress of a range backfill.
+type RangeBackfillStatus struct {
+	Running         bool
+	Completed       bool
+	StartEpoch      phase0.Epoch
+	EndEpoch        phase0.Epoch
+	Stages          []string
+	Concurrency     int
+	NextEpoch       phase0.Epoch
+	ProcessedEpochs uint64
+	FailedEpochs    []phase0.Epoch
+	StartTime       time.Time
+	LastError       string
+}
+
+// rangeBackfill reindexes a range of finalized epochs with a pool of workers, independently from the synchronizer.
+// the progress is persisted in the explorer_state table, so an interrupted backfill is resumed after a restart.
+type rangeBackfill struct {
+	indexer *Indexer
+	logger  logrus.FieldLogger
+
+	startEpoch  phase0.Epoch
+	endEpoch    phase0.Epoch
+	stages      RangeBackfillStage
+	concurrency int
+	startTime   time.Time
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	doneChan  chan bool
+
+	stateMutex      sync.Mutex
+	running         bool
+	completed       bool
+	nextEpoch       phase0.Epoch // all epochs before this one are processed
+	doneEpochs      map[phase0.Epoch]bool
+	processedEpochs uint64
+	failedEpochs    []phase0.Epoch
+	lastError       string
+}
+
+const rangeBackfillStateKey = "indexer.rangebackfill"
+
+// StartRangeBackfill starts reindexing the finalized epochs in range [startEpoch, endEpoch] for the given stages.
+// the epochs are processed by up to concurrency parallel workers, which spread their requests across all archive clients.
+func (indexer *Indexer) StartRangeBackfill(startEpoch phase0.Epoch, endEpoch phase0.Epoch, stages RangeBackfillStage, concurrency int) error {
+	if !indexer.writeDb {
+		return fmt.Errorf("index writer is disabled")
+	}
+	if startEpoch > endEpoch {
+		return fmt.Errorf("invalid epoch range: start epoch %v is after end epoch %v", startEpoch, endEpoch)
+	}
+	if endEpoch >= indexer.getLastFinalizedEpoch() {
+		return fmt.Errorf("epoch %v is not finalized yet (finalized epoch: %v)", endEpoch, indexer.getLastFinalizedEpoch())
+	}
+	if stages == 0 {
+		return fmt.Errorf("no backfill stages selected")
+	}
+
+	if concurrency <= 0 {
+		concurrency = len(indexer.getRangeBackfillClients(endEpoch))
+		if concurrency < 1 {
+			concurrency = 1
+		}
+	}
+
+	indexer.rangeBackfillMutex.Lock()
+	defer indexer.rangeBackfillMutex.Unlock()
+
+	if indexer.rangeBackfill != nil && indexer.rangeBackfill.isRunning() {
+		return fmt.Errorf("another range backfill is already running")
+	}
+
+	backfill := newRangeBackfill(indexer, &dbtypes.IndexerRangeBackfillState{
+		StartEpoch:  uint64(startEpoch),
+		EndEpoch:    uint64(endEpoch),
+		Stages:      stages.Names(),
+		Concurrency: uint64(concurrency),
+		NextEpoch:   uint64(startEpoch),
+	})
+	if err := backfill.persistState(); err != nil {
+		return fmt.Errorf("error while saving backfill state: %v", err)
+	}
+
+	indexer.rangeBackfill = backfill
+	backfill.start()
+
+	return nil
+}
+
+// StopRangeBackfill cancels the running range backfill and drops its persisted state.
+func (indexer *Indexer) StopRangeBackfill() error {
+	indexer.rangeBackfillMutex.Lock()
+	defer indexer.rangeBackfillMutex.Unlock()
+
+	if indexer.rangeBackfill == nil || !indexer.rangeBackfill.isRunning() {
+		return fmt.Errorf("no range backfill running")
+	}
+
+	indexer.rangeBackfill.stop()
+
+	return db.RunDBTransaction(func(tx db.Tx) error {
+		return db.SetExplorerState(rangeBackfillStateKey, nil, tx)
+	})
+}
+
+// GetRangeBackfillStatus returns the progress of the current or last range backfill, or nil if no backfill was started.
+func (indexer *Indexer) GetRangeBackfillStatus() *RangeBackfillStatus {
+	indexer.rangeBackfillMutex.Lock()
+	backfill := indexer.rangeBackfill
+	indexer.rangeBackfillMutex.Unlock()
+
+	if backfill == nil {
+		return nil
+	}
+
+	return backfill.getStatus()
+}
+
+// resumeRangeBackfill resumes an unfinished range backfill from the persisted state.
+func (indexer *Indexer) resumeRangeBackfill() {
+	if !indexer.writeDb {
+		return
+	}
+
+	backfillState := &dbtypes.IndexerRangeBackfillState{}
+	if _, err := db.GetExplorerState(rangeBackfillStateKey, backfillState); err != nil || len(backfillState.Stages) == 0 {
+		// no backfill state or backfill has been cancelled
+		return
+	}
+
+	indexer.rangeBackfillMutex.Lock()
+	defer indexer.rangeBackfillMutex.Unlock()
+
+	backfill := newRangeBackfill(indexer, backfillState)
+	indexer.rangeBackfill = backfill
+
+	if backfillState.Completed {
+		return
+	}
+
+	indexer.logger.Infof("resuming range backfill of epochs %v - %v from epoch %v", backfillState.StartEpoch, backfillState.EndEpoch, backfillState.NextEpoch)
+	backfill.start()
+}
+
+// getRangeBackfillClients returns the clients to use for the backfill of the given epoch.
+// historic states can only be loaded from archive clients, so other clients are only used if no archive client is available.
+func (indexer *Indexer) getRangeBackfillClients(epoch phase0.Epoch) []*Client {
+	clients := indexer.getSyncClients(epoch)
+
+	archiveClients := make([]*Client, 0, len(clients))
+	for _, client := range clients {
+		if client.IsArchive() {
+			archiveClients = append(archiveClients, client)
+		}
+	}
+	if len(archiveClients) > 0 {
+		return archiveClients
+	}
+
+	return clients
+}
+
+func newRangeBackfill(indexer *Indexer, state *dbtypes.IndexerRangeBackfillState) *rangeBackfill {
+	stages, err := ParseRangeBackfillStages(state.Stages)
+	if err != nil {
+		indexer.logger.Warnf("invalid range backfill state: %v", err)
+		stages = RangeBackfillStageAll
+	}
+
+	backfill := &rangeBackfill{
+		indexer:     indexer,
+		logger:      indexer.logger.WithField("service", "rangebackfill"),
+		startEpoch:  phase0.Epoch(state.StartEpoch),
+		endEpoch:    phase0.Epoch(state.EndEpoch),
+		stages:      stages,
+		concurrency: int(state.Concurrency),
+		completed:   state.Completed,
+		nextEpoch:   phase0.Epoch(state.NextEpoch),
+		doneEpochs:  map[phase0.Epoch]bool{},
+	}
+	if backfill.concurrency < 1 {
+		backfill.concurrency = 1
+	}
+	if backfill.nextEpoch < backfill.startEpoch {
+		backfill.nextEpoch = backfill.startEpoch
+	}
+	for _, epoch := range state.FailedEpochs {
+		backfill.failedEpochs = append(backfill.failedEpochs, phase0.Epoch(epoch))
+	}
+
+	return backfill
+}
+
+func (backfill *rangeBackfill) isRunning() bool {
+	backfill.stateMutex.Lock()
+	defer backfill.stateMutex.Unlock()
+	return backfill.running
+}
+
+func (backfill *rangeBackfill) start() {
+	backfill.stateMutex.Lock()
+	defer backfill.stateMutex.Unlock()
+
+	backfill.ctx, backfill.ctxCancel = context.WithCancel(context.Background())
+	backfill.doneChan = make(chan bool)
+	backfill.running = true
+	backfill.startTime = time.Now()
+
+	go backfill.run()
+}
+
+func (backfill *rangeBackfill) stop() {
+	backfill.ctxCancel()
+	<-backfill.doneChan
+}
+
+func (backfill *rangeBackfill) getStatus() *RangeBackfillStatus {
+	backfill.stateMutex.Lock()
+	defer backfill.stateMutex.Unlock()
+
+	return &RangeBackfillStatus{
+		Running:         backfill.running,
+		Completed:       backfill.completed,
+		StartEpoch:      backfill.startEpoch,
+		EndEpoch:        backfill.endEpoch,
+		Stages:          backfill.stages.Names(),
+		Concurrency:     backfill.concurrency,
+		NextEpoch:       backfill.nextEpoch,
+		ProcessedEpochs: backfill.processedEpochs,
+		FailedEpochs:    slices.Clone(backfill.failedEpochs),
+		StartTime:       backfill.startTime,
+		LastError:       backfill.lastError,
+	}
+}
+
+func (backfill *rangeBackfill) persistState() error {
+	backfill.stateMutex.Lock()
+	state := &dbtypes.IndexerRangeBackfillState{
+		StartEpoch:   uint64(backfill.startEpoch),
+		EndEpoch:     uint64(backfill.endEpoch),
+		Stages:       backfill.stages.Names(),
+		Concurrency:  uint64(backfill.concurrency),
+		NextEpoch:    uint64(backfill.nextEpoch),
+		FailedEpochs: make([]uint64, len(backfill.failedEpochs)),
+		Completed:    backfill.completed,
+	}
+	for idx, epoch := range backfill.failedEpochs {
+		state.FailedEpochs[idx] = uint64(epoch)
+	}
+	backfill.stateMutex.Unlock()
+
+	return db.RunDBTransaction(func(tx db.Tx) error {
+		return db.SetExplorerState(rangeBackfillStateKey, state, tx)
+	})
+}
+
+func (backfill *rangeBackfill) run() {
+	defer utils.HandleSubroutinePanic("rangeBackfill.run")
+	defer func() {
+		backfill.stateMutex.Lock()
+		backfill.running = false
+		backfill.stateMutex.Unlock()
+		close(backfill.doneChan)
+	}()
+
+	backfill.stateMutex.Lock()
+	firstEpoch := backfill.nextEpoch
+	backfill.stateMutex.Unlock()
+
+	backfill.logger.Infof("range backfill started (epochs %v - %v, next epoch: %v, stages: %v, concurrency: %v)", backfill.startEpoch, backfill.endEpoch, firstEpoch, strings.Join(backfill.stages.Names(), ","), backfill.concurrency)
+
+	epochChan := make(chan phase0.Epoch)
+	workerWg := sync.WaitGroup{}
+	for i := 0; i < backfill.concurrency; i++ {
+		workerWg.Add(1)
+		go func(workerIdx int) {
+			defer utils.HandleSubroutinePanic("rangeBackfill.worker")
+			defer workerWg.Done()
+
+			for epoch := range epochChan {
+				backfill.processEpochWithRetry(workerIdx, epoch)
+			}
+		}(i)
+	}
+
+	// the workers only stop early if they crashed outside of the epoch processing
+	workersDone := make(chan bool)
+	go func() {
+		workerWg.Wait()
+		close(workersDone)
+	}()
+
+	workersFailed := false
+	for epoch := firstEpoch; epoch <= backfill.endEpoch; epoch++ {
+		select {
+		case epochChan <- epoch:
+		case <-backfill.ctx.Done():
+		case <-workersDone:
+			workersFailed = true
+		}
+		if backfill.ctx.Err() != nil || workersFailed {
+			break
+		}
+	}
+	close(epochChan)
+	<-workersDone
+
+	if workersFailed {
+		backfill.stateMutex.Lock()
+		backfill.lastError = "all backfill workers stopped unexpectedly"
+		nextEpoch := backfill.nextEpoch
+		backfill.stateMutex.Unlock()
+
+		backfill.logger.Errorf("range backfill failed: all workers stopped unexpectedly (next epoch: %v)", nextEpoch)
+		return
+	}
+
+	if backfill.ctx.Err() != nil {
+		backfill.logger.Infof("range backfill aborted (next epoch: %v)", backfill.nextEpoch)
+		return
+	}
+
+	backfill.stateMutex.Lock()
+	backfill.completed = true
+	failedEpochs := slices.Clone(backfill.failedEpochs)
+	backfill.stateMutex.Unlock()
+
+	if err := backfill.persistState(); err != nil {
+		backfill.logger.Errorf("error while saving backfill state: %v", err)
+	}
+
+	backfill.logger.Infof("range backfill complete (epochs %v - %v, %v failed, %.3f sec)", backfill.startEpoch, backfill.endEpoch, len(failedEpochs), time.Since(backfill.startTime).Seconds())
+
+	backfill.indexer.onRangeBackfillComplete(backfill.startEpoch, backfill.endEpoch, failedEpochs)
+}
+
+// processEpochWithRetry processes a single epoch and retries failed attempts with other clients.
+func (backfill *rangeBackfill) processEpochWithRetry(workerIdx int, epoch phase0.Epoch) {
+	var err error
+
+	for retry := 0; retry < rangeBackfillRetryLimit; retry++ {
+		if backfill.ctx.Err() != nil {
+			return
+		}
+
+		clients := backfill.indexer.getRangeBackfillClients(epoch)
+		if len(clients) == 0 {
+			err = fmt.Errorf("no clients available")
+		} else {
+			// spread the workers across all clients, switch to the next client on each retry
+			client := clients[(workerIdx+retry)%len(clients)]
+			err = backfill.processEpochRecovered(epoch, client)
+			if err == nil {
+				break
+			}
+		}
+
+		if backfill.ctx.Err() != nil {
+			return
+		}
+
+		backfill.logger.Warnf("range backfill of epoch %v failed: %v (retry: %v)", epoch, err, retry)
+
+		select {
+		case <-backfill.ctx.Done():
+			return
+		case <-time.After(10 * time.Second):
+		}
+	}
+
+	backfill.completeEpoch(epoch, err)
+}
+
+// completeEpoch marks the epoch as processed and moves the resume checkpoint forward to the first unprocessed epoch.
+func (backfill *rangeBackfill) completeEpoch(epoch phase0.Epoch, err error) {
+	backfill.stateMutex.Lock()
+	backfill.processedEpochs++
+	if err != nil {
+		backfill.logger.Errorf("range backfill of epoch %v failed: %v - skipping epoch", epoch, err)
+		backfill.failedEpochs = append(backfill.failedEpochs, epoch)
+		sort.Slice(backfill.failedEpochs, func(i, j int) bool {
+			return backfill.failedEpochs[i] < backfill.failedEpochs[j]
+		})
+		backfill.lastError = fmt.Sprintf("epoch %v: %v", epoch, err)
+	}
+
+	backfill.doneEpochs[epoch] = true
+	checkpointMoved := false
+	for backfill.doneEpochs[backfill.nextEpoch] {
+		delete(backfill.doneEpochs, backfill.nextEpoch)
+		backfill.nextEpoch++
+		checkpointMoved = true
+	}
+	processedEpochs := backfill.processedEpochs
+	nextEpoch := backfill.nextEpoch
+	backfill.stateMutex.Unlock()
+
+	if checkpointMoved || err != nil {
+		if err := backfill.persistState(); err != nil {
+			backfill.logger.Errorf("error while saving backfill state: %v", err)
+		}
+	}
+
+	if processedEpochs%100 == 0 {
+		backfill.logger.Infof("range backfill progress: %v epochs processed, next epoch: %v / %v", processedEpochs, nextEpoch, backfill.endEpoch)
+	}
+}
+
+// processEpochRecovered processes a single epoch and turns panics into an epoch failure, so a broken epoch does not stop the worker.
+func (backfill *rangeBackfill) processEpochRecovered(epoch phase0.Epoch, client *Client) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			backfill.logger.Errorf("uncaught panic in range backfill of epoch %v: %v, stack: %v", epoch, recovered, string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	return backfill.processEpoch(epoch, client)
+}
+
+func (backfill *rangeBackfill) processEpoch(epoch phase0.Epoch, client *Client) error {
+	t1 := time.Now()
+	indexer := backfill.indexer
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	// the epoch votes are included in the blocks of this & next epoch
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := chainState.EpochStartSlot(epoch+1) - 1
+	if backfill.stages&RangeBackfillStageEpochs != 0 {
+		lastSlot = chainState.EpochStartSlot(epoch+2) - 1
+	}
+
+	// the sync committee assignments do not need any block bodies
+	loadBodies := backfill.stages&^RangeBackfillStageSyncDuties != 0
+
+	canonicalBlocks := []*Block{}
+	canonicalBlockRoots := [][]byte{}
+	nextEpochCanonicalBlocks := []*Block{}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := backfill.loadBlock(client, slot, loadBodies)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+
+		if chainState.EpochOfSlot(slot) == epoch {
+			canonicalBlocks = append(canonicalBlocks, block)
+			canonicalBlockRoots = append(canonicalBlockRoots, block.Root[:])
+		} else {
+			nextEpochCanonicalBlocks = append(nextEpochCanonicalBlocks, block)
+		}
+	}
+
+	// load epoch state, only the el requests can be written without epoch duties
+	var epochStats *EpochStats
+	if backfill.stages&^RangeBackfillStageElRequests != 0 {
+		var dependentRoot phase0.Root
+		if len(canonicalBlocks) > 0 {
+			if canonicalBlocks[0].Slot == 0 {
+				dependentRoot = canonicalBlocks[0].Root
+			} else {
+				dependentRoot = canonicalBlocks[0].GetHeader().Message.ParentRoot
+			}
+		} else {
+			dependentRoot = phase0.Root(db.GetHighestRootBeforeSlot(uint64(firstSlot), false))
+		}
+
+		epochState := newEpochState(dependentRoot)
+		err := epochState.loadState(backfill.ctx, client, nil)
+		if err != nil || epochState.loadingStatus.Load() != 2 {
+			return fmt.Errorf("error fetching epoch %v state: %v", epoch, err)
+		}
+
+		epochStats = newEpochStats(epoch, dependentRoot)
+		epochStats.dependentState = epochState
+		epochStats.processState(indexer)
+	}
+
+	var epochVotes *EpochVotes
+	if backfill.stages&RangeBackfillStageEpochs != 0 {
+		votingBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(votingBlocks, canonicalBlocks)
+		copy(votingBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		epochVotes = indexer.aggregateEpochVotes(epoch, chainState, votingBlocks, epochStats)
+		if epochVotes == nil {
+			return fmt.Errorf("failed computing votes for epoch %v", epoch)
+		}
+	}
+
+	if backfill.ctx.Err() != nil {
+		return backfill.ctx.Err()
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		var dbEpoch *dbtypes.Epoch
+		if loadBodies {
+			var blockErr error
+			dbEpoch = indexer.dbWriter.buildDbEpoch(epoch, canonicalBlocks, epochStats, epochVotes, func(block *Block, depositIndex *uint64) {
+				if blockErr == nil {
+					blockErr = backfill.persistBlock(tx, block, epochStats, depositIndex)
+				}
+			})
+			if blockErr != nil {
+				return blockErr
+			}
+		}
+
+		if backfill.stages&RangeBackfillStageBlocks != 0 {
+			if err := indexer.dbWriter.persistMissedSlots(tx, epoch, canonicalBlocks, epochStats); err != nil {
+				return err
+			}
+
+			if err := db.UpdateMevBlockByEpoch(uint64(epoch), specs.SlotsPerEpoch, canonicalBlockRoots, tx); err != nil {
+				return fmt.Errorf("error while updating mev block proposal state: %v", err)
+			}
+
+			if err := db.UpdateClientDiversity(uint64(epoch), uint64(epoch), specs.SlotsPerEpoch, tx); err != nil {
+				return fmt.Errorf("error while updating client diversity: %v", err)
+			}
+		}
+
+		if backfill.stages&RangeBackfillStageSyncDuties != 0 {
+			if err := indexer.dbWriter.persistSyncAssignments(tx, epoch, epochStats); err != nil {
+				return fmt.Errorf("error persisting sync committee assignments to db: %v", err)
+			}
+		}
+
+		if backfill.stages&RangeBackfillStageEpochs != 0 {
+			if err := db.InsertEpoch(dbEpoch, tx); err != nil {
+				return fmt.Errorf("error while saving epoch to db: %w", err)
+			}
+
+			if err := db.InvalidateChartRollups(uint64(epoch), tx); err != nil {
+				return fmt.Errorf("error while invalidating chart rollups: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	backfill.logger.WithField("client", client.client.GetName()).Debugf("range backfill of epoch %v complete (%v blocks, %.3f sec)", epoch, len(canonicalBlocks), time.Since(t1).Seconds())
+
+	return nil
+}
+
+// loadBlock loads the canonical block of the given slot from the client.
+// the block is not added to the block cache, so the backfill does not interfere with the live indexer.
+func (backfill *rangeBackfill) loadBlock(client *Client, slot phase0.Slot, loadBody bool) (*Block, error) {
+	blockHeader, blockRoot, orphaned, err := LoadBeaconHeaderBySlot(backfill.ctx, client, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching slot %v header: %v", slot, err)
+	}
+	if blockHeader == nil || orphaned {
+		return nil, nil
+	}
+
+	block := newBlock(backfill.indexer.dynSsz, blockRoot, slot)
+	block.SetHeader(blockHeader)
+
+	if slot > 0 && loadBody {
+		blockBody, err := LoadBeaconBlock(backfill.ctx, client, blockRoot)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching slot %v block: %v", slot, err)
+		}
+		if blockBody == nil {
+			return nil, fmt.Errorf("error fetching slot %v block: not found", slot)
+		}
+
+		block.SetBlock(blockBody)
+	}
+
+	return block, nil
+}
+
+// persistBlock writes the block data of the selected stages to the db.
+func (backfill *rangeBackfill) persistBlock(tx db.Tx, block *Block, epochStats *EpochStats, depositIndex *uint64) error {
+	dbw := backfill.indexer.dbWriter
+	canonicalForkId := ForkKey(0)
+
+	if backfill.stages&RangeBackfillStageBlocks != 0 {
+		dbBlock := dbw.buildDbBlock(block, epochStats, &canonicalForkId)
+		if dbBlock == nil {
+			return fmt.Errorf("error while building db block: %v", block.Slot)
+		}
+
+		if err := db.InsertSlot(dbBlock, tx); err != nil {
+			return fmt.Errorf("error inserting slot: %v", err)
+		}
+	}
+
+	if block.Slot == 0 {
+		return nil
+	}
+
+	if backfill.stages&RangeBackfillStageBlocks != 0 {
+		if err := dbw.persistBlockVoluntaryExits(tx, block, false, &canonicalForkId); err != nil {
+			return err
+		}
+		if err := dbw.persistBlockSlashings(tx, block, false, &canonicalForkId); err != nil {
+			return err
+		}
+	}
+
+	if backfill.stages&RangeBackfillStageDeposits != 0 {
+		if err := dbw.persistBlockDeposits(tx, block, depositIndex, false, &canonicalForkId); err != nil {
+			return err
+		}
+		if err := dbw.persistBlockDepositRequests(tx, block, false, &canonicalForkId); err != nil {
+			return err
+		}
+	}
+
+	if backfill.stages&RangeBackfillStageElRequests != 0 {
+		if err := dbw.persistBlockConsolidationRequests(tx, block, false, &canonicalForkId); err != nil {
+			return err
+		}
+		if err := dbw.persistBlockWithdrawalRequests(tx, block, false, &canonicalForkId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package beacon
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestRangeBackfillEpochPanic(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// the indexer has no consensus pool, so processing any epoch panics
+	indexer := &Indexer{
+		logger: logger,
+	}
+	backfill := newRangeBackfill(indexer, &dbtypes.IndexerRangeBackfillState{
+		StartEpoch: 10,
+		EndEpoch:   12,
+	})
+
+	err := backfill.processEpochRecovered(10, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "panic: ") {
+		t.Fatalf("expected the panic to be returned as epoch error, got %v", err)
+	}
+}
@@ -25,7 +25,9 @@ func LoadBeaconHeader(ctx context.Context, client *Client, root phase0.Root) (*p
 	ctx, cancel := context.WithTimeout(ctx, beaconHeaderRequestTimeout)
 	defer cancel()
 
+	startTime := time.Now()
 	header, err := client.client.GetRPCClient().GetBlockHeaderByBlockroot(ctx, root)
+	client.client.TrackRequest(startTime, err)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +40,9 @@ func LoadBeaconHeaderBySlot(ctx context.Context, client *Client, slot phase0.Slo
 	ctx, cancel := context.WithTimeout(ctx, beaconHeaderRequestTimeout)
 	defer cancel()
 
+	startTime := time.Now()
 	header, err := client.client.GetRPCClient().GetBlockHeaderBySlot(ctx, slot)
+	client.client.TrackRequest(startTime, err)
 	if err != nil {
 		return nil, phase0.Root{}, false, err
 	}
@@ -55,7 +59,9 @@ func LoadBeaconBlock(ctx context.Context, client *Client, root phase0.Root) (*sp
 	ctx, cancel := context.WithTimeout(ctx, beaconBodyRequestTimeout)
 	defer cancel()
 
+	startTime := time.Now()
 	body, err := client.client.GetRPCClient().GetBlockBodyByBlockroot(ctx, root)
+	client.client.TrackRequest(startTime, err)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +74,8 @@ func LoadBeaconState(ctx context.Context, client *Client, root phase0.Root) (*sp
 	ctx, cancel := context.WithTimeout(ctx, beaconStateRequestTimeout)
 	defer cancel()
 
+	// state requests are not tracked for the client health, their duration depends on the state size rather than the client
+
 	resState, err := client.client.GetRPCClient().GetState(ctx, fmt.Sprintf("0x%x", root[:]))
 	if err != nil {
 		return nil, err
@@ -75,3 +83,17 @@ func LoadBeaconState(ctx context.Context, client *Client, root phase0.Root) (*sp
 
 	return resState, nil
 }
+
+// LoadBeaconStateBySlot loads the beacon state at the given slot from the client.
+// the state of an empty slot is the state of the last block, advanced to the slot.
+func LoadBeaconStateBySlot(ctx context.Context, client *Client, slot phase0.Slot) (*spec.VersionedBeaconState, error) {
+	ctx, cancel := context.WithTimeout(ctx, beaconStateRequestTimeout)
+	defer cancel()
+
+	resState, err := client.client.GetRPCClient().GetState(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, err
+	}
+
+	return resState, nil
+}
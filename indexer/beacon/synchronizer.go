@@ -3,18 +3,14 @@ package beacon
 import (
 	"context"
 	"fmt"
-	"math/rand/v2"
-	"sort"
 	"sync"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/utils"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,12 +22,37 @@ type synchronizer struct {
 	syncCtxCancel context.CancelFunc
 	runMutex      sync.Mutex
 
-	stateMutex   sync.Mutex
-	running      bool
-	currentEpoch phase0.Epoch
+	stateMutex       sync.Mutex
+	running          bool
+	currentEpoch     phase0.Epoch
+	retryCount       int
+	forceUpdateStart phase0.Epoch // epochs in range [forceUpdateStart, forceUpdateEnd) are synchronized even if already in db
+	forceUpdateEnd   phase0.Epoch
 
-	cachedSlot   phase0.Slot
-	cachedBlocks map[phase0.Slot]*Block
+	cachedBlocksMutex sync.Mutex
+	cachedBlocks      map[phase0.Slot]*Block // blocks loaded by the epoch tasks, empty slots are not cached
+}
+
+// syncEpochTask loads the data of a single epoch in the background.
+// several tasks run in parallel, but their results are persisted in epoch order.
+type syncEpochTask struct {
+	epoch       phase0.Epoch
+	retry       int
+	lastTry     bool
+	client      *Client
+	clientCount int
+	done        chan bool
+	skipped     bool // epoch is already synchronized
+	data        *syncEpochData
+	err         error
+}
+
+// syncEpochData holds the loaded data of an epoch that is ready to be persisted.
+type syncEpochData struct {
+	canonicalBlocks     []*Block
+	canonicalBlockRoots [][]byte
+	epochStats          *EpochStats
+	epochVotes          *EpochVotes
 }
 
 func (indexer *Indexer) startSynchronizer(startEpoch phase0.Epoch) {
@@ -125,77 +146,95 @@ func (sync *synchronizer) runSync() {
 	}()
 
 	sync.cachedBlocks = make(map[phase0.Slot]*Block)
-	sync.cachedSlot = 0
+	sync.retryCount = 0
 	isComplete := false
-	retryCount := 0
 
-	sync.logger.Infof("synchronization started. head epoch: %v", sync.currentEpoch)
+	parallelEpochs := phase0.Epoch(sync.indexer.maxParallelSyncEpochs)
+	tasks := map[phase0.Epoch]*syncEpochTask{}
+	nextTaskEpoch := sync.currentEpoch
+
+	defer func() {
+		// wait for all pending tasks, so they don't interfere with the next run
+		for _, task := range tasks {
+			<-task.done
+		}
+	}()
+
+	sync.logger.Infof("synchronization started. head epoch: %v (parallel epochs: %v)", sync.currentEpoch, parallelEpochs)
 
 	for {
-		// synchronize next epoch
 		syncEpoch := sync.currentEpoch
-		syncClients := sync.getSyncClients(syncEpoch)
-		if len(syncClients) == 0 {
-			sync.logger.Warnf("no clients available for synchronization of epoch %v", syncEpoch)
+		if syncEpoch >= sync.indexer.getLastFinalizedEpoch() {
+			isComplete = true
+			break
+		}
 
-			// wait for 10 seconds before retrying
-			time.Sleep(10 * time.Second)
-			continue
+		// load the next epochs in the background while waiting for the current one
+		if nextTaskEpoch < syncEpoch {
+			nextTaskEpoch = syncEpoch
+		}
+		for nextTaskEpoch < syncEpoch+parallelEpochs && nextTaskEpoch < sync.indexer.getLastFinalizedEpoch() {
+			tasks[nextTaskEpoch] = sync.startEpochTask(nextTaskEpoch, 0, 0)
+			nextTaskEpoch++
 		}
 
-		if syncEpoch >= sync.indexer.lastFinalizedEpoch {
-			isComplete = true
+		task := tasks[syncEpoch]
+		select {
+		case <-task.done:
+		case <-sync.syncCtx.Done():
+		}
+		if sync.syncCtx.Err() != nil {
 			break
 		}
 
-		retryLimit := len(syncClients)
-		if retryLimit < 30 {
-			retryLimit = 30
+		if task.client == nil && !task.skipped {
+			// no clients available, wait for 10 seconds before retrying
+			sync.logger.Warnf("no clients available for synchronization of epoch %v", syncEpoch)
+			tasks[syncEpoch] = sync.startEpochTask(syncEpoch, task.retry, 10*time.Second)
+			continue
 		}
-		lastRetry := retryCount >= retryLimit
-		syncClient := syncClients[retryCount%len(syncClients)]
 
-		synclogger := sync.logger.WithFields(logrus.Fields{
-			"epoch":  syncEpoch,
-			"client": syncClient.client.GetName(),
-		})
-
-		if lastRetry {
-			synclogger.Infof("synchronizing epoch %v (retry: %v, last retry!)", syncEpoch, retryCount)
-		} else if retryCount > 0 {
-			synclogger.Infof("synchronizing epoch %v (retry: %v)", syncEpoch, retryCount)
-		} else {
-			synclogger.Infof("synchronizing epoch %v", syncEpoch)
+		err := task.err
+		if err == nil && task.data != nil {
+			err = sync.persistEpoch(syncEpoch, task.data)
 		}
 
-		done, err := sync.syncEpoch(syncEpoch, syncClient, lastRetry)
-		if done || lastRetry {
+		if err == nil || task.lastTry {
 			if err != nil {
 				sync.logger.Errorf("synchronization of epoch %v failed: %v - skipping epoch", syncEpoch, err)
 			}
-			retryCount = 0
+
+			delete(tasks, syncEpoch)
+			sync.cleanupCachedBlocks(syncEpoch)
+
 			sync.stateMutex.Lock()
-			syncEpoch++
-			sync.currentEpoch = syncEpoch
+			sync.retryCount = 0
+			sync.currentEpoch = syncEpoch + 1
 			sync.stateMutex.Unlock()
-			if syncEpoch >= sync.indexer.lastFinalizedEpoch {
-				isComplete = true
-				break
-			}
-		} else if err != nil {
-			synclogger.Warnf("synchronization of epoch %v failed: %v - Retrying in 10 sec...", syncEpoch, err)
-			retryCount++
-			time.Sleep(10 * time.Second)
+			continue
 		}
 
-		if sync.syncCtx.Err() != nil {
-			break
+		// retry with the next client, wait for 10 seconds once all clients have been tried
+		sync.stateMutex.Lock()
+		sync.retryCount++
+		retryCount := sync.retryCount
+		sync.stateMutex.Unlock()
+
+		retryDelay := time.Duration(0)
+		if retryCount%task.clientCount == 0 {
+			retryDelay = 10 * time.Second
 		}
+
+		sync.logger.WithFields(logrus.Fields{
+			"epoch":  syncEpoch,
+			"client": task.client.client.GetName(),
+		}).Warnf("synchronization of epoch %v failed: %v - Retrying in %v sec...", syncEpoch, err, retryDelay.Seconds())
+		tasks[syncEpoch] = sync.startEpochTask(syncEpoch, retryCount, retryDelay)
 	}
 
 	if isComplete {
 		sync.logger.Infof("synchronization complete. Head epoch: %v", sync.currentEpoch)
-		db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		db.RunDBTransaction(func(tx db.Tx) error {
 			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
 				Epoch: uint64(sync.currentEpoch),
 			}, tx)
@@ -207,46 +246,147 @@ func (sync *synchronizer) runSync() {
 	sync.running = false
 }
 
-func (sync *synchronizer) getSyncClients(epoch phase0.Epoch) []*Client {
-	archiveClients := make([]*Client, 0)
-	normalClients := make([]*Client, 0)
+// startEpochTask starts loading the given epoch in the background after the given delay.
+func (sync *synchronizer) startEpochTask(epoch phase0.Epoch, retry int, delay time.Duration) *syncEpochTask {
+	task := &syncEpochTask{
+		epoch: epoch,
+		retry: retry,
+		done:  make(chan bool),
+	}
+
+	go sync.runEpochTask(task, delay)
 
-	for _, client := range sync.indexer.clients {
-		if client.client.GetStatus() != consensus.ClientStatusOnline {
-			continue
-		}
+	return task
+}
 
-		if client.skipValidators {
-			continue
+func (sync *synchronizer) runEpochTask(task *syncEpochTask, delay time.Duration) {
+	defer func() {
+		if task.err == nil && task.data == nil && !task.skipped {
+			task.err = fmt.Errorf("synchronization of epoch %v aborted", task.epoch)
 		}
+		close(task.done)
+	}()
+	defer utils.HandleSubroutinePanic("runEpochTask")
 
-		finalizedEpoch, _, _, _ := client.client.GetFinalityCheckpoint()
-		if finalizedEpoch < epoch {
-			continue
+	if delay > 0 {
+		select {
+		case <-sync.syncCtx.Done():
+		case <-time.After(delay):
 		}
+	}
+	if sync.syncCtx.Err() != nil {
+		task.err = sync.syncCtx.Err()
+		return
+	}
 
-		if client.archive {
-			archiveClients = append(archiveClients, client)
-		} else {
-			normalClients = append(normalClients, client)
-		}
+	if !utils.Config.Indexer.ResyncForceUpdate && !sync.isForceUpdateEpoch(task.epoch) && db.WithContext(sync.syncCtx).IsEpochSynchronized(uint64(task.epoch)) {
+		task.skipped = true
+		return
 	}
 
-	sort.Slice(archiveClients, func(i, j int) bool {
-		if archiveClients[i].priority == archiveClients[j].priority {
-			return rand.UintN(1) == 0
-		}
-		return archiveClients[i].priority > archiveClients[j].priority
+	chainState := sync.indexer.consensusPool.GetChainState()
+	syncClients := sync.indexer.getSyncClients(task.epoch)
+	if len(syncClients) == 0 {
+		task.err = fmt.Errorf("no clients available")
+		return
+	}
+
+	retryLimit := len(syncClients)
+	if retryLimit < 30 {
+		retryLimit = 30
+	}
+	task.lastTry = task.retry >= retryLimit
+	task.clientCount = len(syncClients)
+
+	// spread the parallel epochs across all clients, switch to the next client on each retry
+	task.client = syncClients[(int(task.epoch)+task.retry)%len(syncClients)]
+	if task.retry > 0 {
+		// the failed attempt might have cached blocks of another client that is on a different fork
+		sync.clearCachedBlocks(chainState.EpochStartSlot(task.epoch), chainState.EpochStartSlot(task.epoch+2))
+	}
+
+	synclogger := sync.logger.WithFields(logrus.Fields{
+		"epoch":  task.epoch,
+		"client": task.client.client.GetName(),
 	})
 
-	sort.Slice(normalClients, func(i, j int) bool {
-		if normalClients[i].priority == normalClients[j].priority {
-			return rand.UintN(1) == 0
+	if task.lastTry {
+		synclogger.Infof("synchronizing epoch %v (retry: %v, last retry!)", task.epoch, task.retry)
+	} else if task.retry > 0 {
+		synclogger.Infof("synchronizing epoch %v (retry: %v)", task.epoch, task.retry)
+	} else {
+		synclogger.Infof("synchronizing epoch %v", task.epoch)
+	}
+
+	task.data, task.err = sync.loadEpochData(task.epoch, task.client, task.lastTry)
+}
+
+// cleanupCachedBlocks removes all cached blocks up to the given epoch.
+// the blocks of the next epoch are kept, as they are needed for the vote aggregation of the given epoch only.
+func (sync *synchronizer) cleanupCachedBlocks(epoch phase0.Epoch) {
+	chainState := sync.indexer.consensusPool.GetChainState()
+	nextEpochSlot := chainState.EpochStartSlot(epoch + 1)
+
+	sync.cachedBlocksMutex.Lock()
+	defer sync.cachedBlocksMutex.Unlock()
+
+	for slot := range sync.cachedBlocks {
+		if slot < nextEpochSlot {
+			delete(sync.cachedBlocks, slot)
+		}
+	}
+}
+
+// clearCachedBlocks removes the cached blocks in slot range [startSlot, endSlot).
+func (sync *synchronizer) clearCachedBlocks(startSlot phase0.Slot, endSlot phase0.Slot) {
+	sync.cachedBlocksMutex.Lock()
+	defer sync.cachedBlocksMutex.Unlock()
+
+	for slot := range sync.cachedBlocks {
+		if slot >= startSlot && slot < endSlot {
+			delete(sync.cachedBlocks, slot)
 		}
-		return normalClients[i].priority > normalClients[j].priority
+	}
+}
+
+// isForceUpdateEpoch checks if the epoch needs to be synchronized even if it is already in the db.
+func (sync *synchronizer) isForceUpdateEpoch(epoch phase0.Epoch) bool {
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+
+	return epoch >= sync.forceUpdateStart && epoch < sync.forceUpdateEnd
+}
+
+// resyncEpochs restarts the synchronization to synchronize the epochs in range [startEpoch, endEpoch) again, even if they are already in the db.
+// the synchronization continues from the current sync epoch if it is behind the start epoch.
+func (sync *synchronizer) resyncEpochs(startEpoch phase0.Epoch, endEpoch phase0.Epoch) error {
+	sync.stopSync()
+
+	sync.stateMutex.Lock()
+	syncEpoch := sync.currentEpoch
+	if startEpoch < syncEpoch {
+		syncEpoch = startEpoch
+	}
+	sync.stateMutex.Unlock()
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
+			Epoch: uint64(syncEpoch),
+		}, tx)
 	})
+	if err != nil {
+		return fmt.Errorf("error while updating sync state: %v", err)
+	}
 
-	return append(archiveClients, normalClients...)
+	sync.stateMutex.Lock()
+	sync.currentEpoch = syncEpoch
+	sync.forceUpdateStart = startEpoch
+	sync.forceUpdateEnd = endEpoch
+	sync.stateMutex.Unlock()
+
+	sync.startSync(syncEpoch)
+
+	return nil
 }
 
 func (sync *synchronizer) loadBlockHeader(client *Client, slot phase0.Slot) (*phase0.SignedBeaconBlockHeader, phase0.Root, error) {
@@ -267,69 +407,85 @@ func (sync *synchronizer) loadBlockBody(client *Client, root phase0.Root) (*spec
 	return LoadBeaconBlock(ctx, client, root)
 }
 
-func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, lastTry bool) (bool, error) {
-	if !utils.Config.Indexer.ResyncForceUpdate && db.IsEpochSynchronized(uint64(syncEpoch)) {
-		return true, nil
+// loadCachedBlock returns the canonical block of the given slot, the block is loaded from the client if not cached yet.
+// returns nil if the slot is empty. empty slots are not cached, as the client might just not have returned the header.
+func (sync *synchronizer) loadCachedBlock(client *Client, slot phase0.Slot) (*Block, error) {
+	sync.cachedBlocksMutex.Lock()
+	block, isCached := sync.cachedBlocks[slot]
+	sync.cachedBlocksMutex.Unlock()
+	if isCached {
+		return block, nil
 	}
 
-	chainState := sync.indexer.consensusPool.GetChainState()
-	specs := chainState.GetSpecs()
+	blockHeader, blockRoot, err := sync.loadBlockHeader(client, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching slot %v header: %v", slot, err)
+	}
 
-	// load headers & blocks from this & next epoch
-	firstSlot := chainState.EpochStartSlot(syncEpoch)
-	lastSlot := chainState.EpochStartSlot(syncEpoch+2) - 1
-	canonicalBlocks := []*Block{}
-	canonicalBlockRoots := [][]byte{}
-	nextEpochCanonicalBlocks := []*Block{}
+	if blockHeader != nil {
+		block = newBlock(sync.indexer.dynSsz, blockRoot, slot)
+		block.SetHeader(blockHeader)
 
-	var firstBlock *Block
-	for slot := firstSlot; slot <= lastSlot; slot++ {
-		if sync.cachedSlot < slot || sync.cachedBlocks[slot] == nil {
-			blockHeader, blockRoot, err := sync.loadBlockHeader(client, slot)
+		if slot > 0 {
+			blockBody, err := sync.loadBlockBody(client, phase0.Root(blockRoot))
 			if err != nil {
-				return false, fmt.Errorf("error fetching slot %v header: %v", slot, err)
-			}
-			if blockHeader == nil {
-				continue
+				return nil, fmt.Errorf("error fetching slot %v block: %v", slot, err)
 			}
-			if sync.syncCtx.Err() != nil {
-				return false, nil
+			if blockBody == nil {
+				return nil, fmt.Errorf("error fetching slot %v block: not found", slot)
 			}
 
-			block := newBlock(sync.indexer.dynSsz, blockRoot, slot)
-			block.SetHeader(blockHeader)
+			block.SetBlock(blockBody)
+		}
+	}
 
-			if slot > 0 {
-				blockBody, err := sync.loadBlockBody(client, phase0.Root(blockRoot))
-				if err != nil {
-					return false, fmt.Errorf("error fetching slot %v block: %v", slot, err)
-				}
-				if blockBody == nil {
-					return false, fmt.Errorf("error fetching slot %v block: not found", slot)
-				}
+	if block != nil {
+		sync.cachedBlocksMutex.Lock()
+		sync.cachedBlocks[slot] = block
+		sync.cachedBlocksMutex.Unlock()
+	}
 
-				block.SetBlock(blockBody)
-			}
+	return block, nil
+}
 
-			sync.cachedBlocks[slot] = block
+// loadEpochData loads the blocks, the dependent state & the vote aggregations of the given epoch.
+// with lastTry set, missing state or votes are accepted, so the epoch can at least be persisted partially.
+func (sync *synchronizer) loadEpochData(syncEpoch phase0.Epoch, client *Client, lastTry bool) (*syncEpochData, error) {
+	chainState := sync.indexer.consensusPool.GetChainState()
+
+	// load headers & blocks from this & next epoch
+	firstSlot := chainState.EpochStartSlot(syncEpoch)
+	lastSlot := chainState.EpochStartSlot(syncEpoch+2) - 1
+	epochData := &syncEpochData{
+		canonicalBlocks:     []*Block{},
+		canonicalBlockRoots: [][]byte{},
+	}
+	nextEpochCanonicalBlocks := []*Block{}
+
+	var firstBlock *Block
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := sync.loadCachedBlock(client, slot)
+		if err != nil {
+			return nil, err
+		}
+		if sync.syncCtx.Err() != nil {
+			return nil, sync.syncCtx.Err()
+		}
+		if block == nil {
+			continue
 		}
 
-		if firstBlock == nil && sync.cachedBlocks[slot] != nil {
-			firstBlock = sync.cachedBlocks[slot]
+		if firstBlock == nil {
+			firstBlock = block
 		}
 
 		if chainState.EpochOfSlot(slot) == syncEpoch {
-			canonicalBlocks = append(canonicalBlocks, sync.cachedBlocks[slot])
-			canonicalBlockRoots = append(canonicalBlockRoots, sync.cachedBlocks[slot].Root[:])
+			epochData.canonicalBlocks = append(epochData.canonicalBlocks, block)
+			epochData.canonicalBlockRoots = append(epochData.canonicalBlockRoots, block.Root[:])
 		} else {
-			nextEpochCanonicalBlocks = append(nextEpochCanonicalBlocks, sync.cachedBlocks[slot])
+			nextEpochCanonicalBlocks = append(nextEpochCanonicalBlocks, block)
 		}
 	}
-	sync.cachedSlot = lastSlot
-
-	if sync.syncCtx.Err() != nil {
-		return false, nil
-	}
 
 	// load epoch state
 	var dependentRoot phase0.Root
@@ -337,7 +493,7 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 		if firstBlock.Slot == 0 { // epoch 0 dependent root is the genesis block
 			dependentRoot = firstBlock.Root
 		} else {
-			dependentRoot = firstBlock.header.Message.ParentRoot
+			dependentRoot = firstBlock.GetHeader().Message.ParentRoot
 		}
 	} else {
 		// get from db
@@ -347,48 +503,52 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 
 	epochState := newEpochState(dependentRoot)
 	err := epochState.loadState(sync.syncCtx, client, nil)
-	if (err != nil || epochState.loadingStatus != 2) && !lastTry {
-		return false, fmt.Errorf("error fetching epoch %v state: %v", syncEpoch, err)
+	if (err != nil || epochState.loadingStatus.Load() != 2) && !lastTry {
+		return nil, fmt.Errorf("error fetching epoch %v state: %v", syncEpoch, err)
 	}
 
-	var epochStats *EpochStats
 	var epochStatsValues *EpochStatsValues
-	if epochState != nil && epochState.loadingStatus == 2 {
-		epochStats = newEpochStats(syncEpoch, dependentRoot)
-		epochStats.dependentState = epochState
-		epochStats.processState(sync.indexer)
-		epochStatsValues = epochStats.GetValues(false)
+	if epochState.loadingStatus.Load() == 2 {
+		epochData.epochStats = newEpochStats(syncEpoch, dependentRoot)
+		epochData.epochStats.dependentState = epochState
+		epochData.epochStats.processState(sync.indexer)
+		epochStatsValues = epochData.epochStats.GetValues(false)
 	}
 
 	if sync.syncCtx.Err() != nil {
-		return false, nil
+		return nil, sync.syncCtx.Err()
 	}
 
 	// process epoch vote aggregations
-	var epochVotes *EpochVotes
 	if epochStatsValues != nil {
-		votingBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
-		copy(votingBlocks, canonicalBlocks)
-		copy(votingBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
-		epochVotes = sync.indexer.aggregateEpochVotes(syncEpoch, chainState, votingBlocks, epochStats)
-		if epochVotes == nil && !lastTry {
-			return false, fmt.Errorf("failed computing votes for epoch %v", syncEpoch)
+		votingBlocks := make([]*Block, len(epochData.canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(votingBlocks, epochData.canonicalBlocks)
+		copy(votingBlocks[len(epochData.canonicalBlocks):], nextEpochCanonicalBlocks)
+		epochData.epochVotes = sync.indexer.aggregateEpochVotes(syncEpoch, chainState, votingBlocks, epochData.epochStats)
+		if epochData.epochVotes == nil && !lastTry {
+			return nil, fmt.Errorf("failed computing votes for epoch %v", syncEpoch)
 		}
 	}
 
-	// save blocks
-	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
-		err = sync.indexer.dbWriter.persistEpochData(tx, syncEpoch, canonicalBlocks, epochStats, epochVotes)
+	return epochData, nil
+}
+
+// persistEpoch writes the loaded epoch data to the db and moves the sync state checkpoint to the epoch.
+func (sync *synchronizer) persistEpoch(syncEpoch phase0.Epoch, epochData *syncEpochData) error {
+	specs := sync.indexer.consensusPool.GetChainState().GetSpecs()
+
+	return db.RunDBTransaction(func(tx db.Tx) error {
+		err := sync.indexer.dbWriter.persistEpochData(tx, syncEpoch, epochData.canonicalBlocks, epochData.epochStats, epochData.epochVotes)
 		if err != nil {
 			return fmt.Errorf("error persisting epoch data to db: %v", err)
 		}
 
 		// persist sync committee assignments
-		if err := sync.indexer.dbWriter.persistSyncAssignments(tx, syncEpoch, epochStats); err != nil {
+		if err := sync.indexer.dbWriter.persistSyncAssignments(tx, syncEpoch, epochData.epochStats); err != nil {
 			return fmt.Errorf("error persisting sync committee assignments to db: %v", err)
 		}
 
-		if err := db.UpdateMevBlockByEpoch(uint64(syncEpoch), specs.SlotsPerEpoch, canonicalBlockRoots, tx); err != nil {
+		if err := db.UpdateMevBlockByEpoch(uint64(syncEpoch), specs.SlotsPerEpoch, epochData.canonicalBlockRoots, tx); err != nil {
 			return fmt.Errorf("error while updating mev block proposal state: %v", err)
 		}
 
@@ -398,7 +558,7 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 		}
 
 		// delete unfinalized forks for canonical roots
-		if err := db.DeleteFinalizedForks(canonicalBlockRoots, tx); err != nil {
+		if err := db.DeleteFinalizedForks(epochData.canonicalBlockRoots, tx); err != nil {
 			return fmt.Errorf("failed deleting finalized forks: %v", err)
 		}
 
@@ -411,16 +571,4 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 
 		return nil
 	})
-	if err != nil {
-		return false, err
-	}
-
-	// cleanup cache (remove blocks from this epoch)
-	for slot := firstSlot; slot <= lastSlot; slot++ {
-		if sync.cachedBlocks[slot] != nil {
-			delete(sync.cachedBlocks, slot)
-		}
-	}
-
-	return true, nil
 }
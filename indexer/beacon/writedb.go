@@ -8,7 +8,6 @@ import (
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/utils"
-	"github.com/jmoiron/sqlx"
 	"github.com/juliangruber/go-intersect"
 )
 
@@ -22,7 +21,7 @@ func newDbWriter(indexer *Indexer) *dbWriter {
 	}
 }
 
-func (dbw *dbWriter) persistMissedSlots(tx *sqlx.Tx, epoch phase0.Epoch, blocks []*Block, epochStats *EpochStats) error {
+func (dbw *dbWriter) persistMissedSlots(tx db.Tx, epoch phase0.Epoch, blocks []*Block, epochStats *EpochStats) error {
 	chainState := dbw.indexer.consensusPool.GetChainState()
 	epochStatsValues := epochStats.GetValues(true)
 
@@ -56,7 +55,7 @@ func (dbw *dbWriter) persistMissedSlots(tx *sqlx.Tx, epoch phase0.Epoch, blocks
 	return nil
 }
 
-func (dbw *dbWriter) persistBlockData(tx *sqlx.Tx, block *Block, epochStats *EpochStats, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) (*dbtypes.Slot, error) {
+func (dbw *dbWriter) persistBlockData(tx db.Tx, block *Block, epochStats *EpochStats, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) (*dbtypes.Slot, error) {
 	// insert block
 	dbBlock := dbw.buildDbBlock(block, epochStats, overrideForkId)
 	if dbBlock == nil {
@@ -74,6 +73,29 @@ func (dbw *dbWriter) persistBlockData(tx *sqlx.Tx, block *Block, epochStats *Epo
 
 	block.isInFinalizedDb = true
 
+	// insert block body of canonical blocks, orphaned block bodies are stored separately
+	if dbw.indexer.persistBlockBodies && !orphaned {
+		header := block.GetHeader()
+		blockData := block.GetBlock()
+		if header != nil && blockData != nil {
+			blockBody, err := block.buildBlockBody(header, blockData, dbw.indexer.blockCompression)
+			if err != nil {
+				return nil, fmt.Errorf("error building block body: %v", err)
+			}
+
+			err = db.InsertBlockBody(blockBody, tx)
+			if err != nil {
+				return nil, fmt.Errorf("error inserting block body: %v", err)
+			}
+		}
+	}
+
+	// insert client timings, these are only available for blocks received via the event stream since startup
+	err = db.InsertBlockTimings(block.GetDbTimings(dbw.indexer.consensusPool.GetChainState()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting block timings: %v", err)
+	}
+
 	// insert child objects
 	if block.Slot > 0 {
 		err = dbw.persistBlockChildObjects(tx, block, depositIndex, orphaned, overrideForkId)
@@ -85,7 +107,7 @@ func (dbw *dbWriter) persistBlockData(tx *sqlx.Tx, block *Block, epochStats *Epo
 	return dbBlock, nil
 }
 
-func (dbw *dbWriter) persistBlockChildObjects(tx *sqlx.Tx, block *Block, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockChildObjects(tx db.Tx, block *Block, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) error {
 	var err error
 
 	// insert deposits (pre/early electra)
@@ -127,9 +149,9 @@ func (dbw *dbWriter) persistBlockChildObjects(tx *sqlx.Tx, block *Block, deposit
 	return nil
 }
 
-func (dbw *dbWriter) persistEpochData(tx *sqlx.Tx, epoch phase0.Epoch, blocks []*Block, epochStats *EpochStats, epochVotes *EpochVotes) error {
+func (dbw *dbWriter) persistEpochData(tx db.Tx, epoch phase0.Epoch, blocks []*Block, epochStats *EpochStats, epochVotes *EpochVotes) error {
 	if tx == nil {
-		return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.RunDBTransaction(func(tx db.Tx) error {
 			return dbw.persistEpochData(tx, epoch, blocks, epochStats, epochVotes)
 		})
 	}
@@ -154,10 +176,22 @@ func (dbw *dbWriter) persistEpochData(tx *sqlx.Tx, epoch phase0.Epoch, blocks []
 		return fmt.Errorf("error while saving epoch to db: %w", err)
 	}
 
+	// resynced epochs may have been rolled up already
+	err = db.InvalidateChartRollups(uint64(epoch), tx)
+	if err != nil {
+		return fmt.Errorf("error while invalidating chart rollups: %w", err)
+	}
+
+	// update client diversity aggregates
+	err = db.UpdateClientDiversity(uint64(epoch), uint64(epoch), dbw.indexer.consensusPool.GetChainState().GetSpecs().SlotsPerEpoch, tx)
+	if err != nil {
+		return fmt.Errorf("error while updating client diversity: %w", err)
+	}
+
 	return nil
 }
 
-func (dbw *dbWriter) persistSyncAssignments(tx *sqlx.Tx, epoch phase0.Epoch, epochStats *EpochStats) error {
+func (dbw *dbWriter) persistSyncAssignments(tx db.Tx, epoch phase0.Epoch, epochStats *EpochStats) error {
 	chainState := dbw.indexer.consensusPool.GetChainState()
 	specs := chainState.GetSpecs()
 
@@ -208,6 +242,7 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		dbw.indexer.logger.Warnf("error while building db blocks: block body not found: %v", block.Slot)
 		return nil
 	}
+	header := block.GetHeader()
 
 	var epochStatsValues *EpochStatsValues
 	if epochStats != nil {
@@ -229,13 +264,13 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 	executionWithdrawals, _ := blockBody.Withdrawals()
 
 	dbBlock := dbtypes.Slot{
-		Slot:                  uint64(block.header.Message.Slot),
-		Proposer:              uint64(block.header.Message.ProposerIndex),
+		Slot:                  uint64(header.Message.Slot),
+		Proposer:              uint64(header.Message.ProposerIndex),
 		Status:                dbtypes.Canonical,
-		ForkId:                uint64(block.forkId),
+		ForkId:                uint64(block.GetForkId()),
 		Root:                  block.Root[:],
-		ParentRoot:            block.header.Message.ParentRoot[:],
-		StateRoot:             block.header.Message.StateRoot[:],
+		ParentRoot:            header.Message.ParentRoot[:],
+		StateRoot:             header.Message.StateRoot[:],
 		Graffiti:              graffiti[:],
 		GraffitiText:          utils.GraffitiToString(graffiti[:]),
 		AttestationCount:      uint64(len(attestations)),
@@ -280,6 +315,8 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		}
 	}
 
+	dbBlock.ClClient, dbBlock.ElClient = utils.DetectProposerClients(dbBlock.GraffitiText, dbBlock.EthBlockExtraText)
+
 	return &dbBlock
 }
 
@@ -352,6 +389,7 @@ func (dbw *dbWriter) buildDbEpoch(epoch phase0.Epoch, blocks []*Block, epochStat
 			syncAggregate, _ := blockBody.SyncAggregate()
 			executionTransactions, _ := blockBody.ExecutionTransactions()
 			executionWithdrawals, _ := blockBody.Withdrawals()
+			blobKzgCommitments, _ := blockBody.BlobKZGCommitments()
 
 			dbEpoch.AttestationCount += uint64(len(attestations))
 			dbEpoch.DepositCount += uint64(len(deposits))
@@ -359,6 +397,7 @@ func (dbw *dbWriter) buildDbEpoch(epoch phase0.Epoch, blocks []*Block, epochStat
 			dbEpoch.AttesterSlashingCount += uint64(len(attesterSlashings))
 			dbEpoch.ProposerSlashingCount += uint64(len(proposerSlashings))
 			dbEpoch.BLSChangeCount += uint64(len(blsToExecChanges))
+			dbEpoch.BlobCount += uint64(len(blobKzgCommitments))
 
 			if syncAggregate != nil && epochStatsValues != nil {
 				votedCount := 0
@@ -387,7 +426,7 @@ func (dbw *dbWriter) buildDbEpoch(epoch phase0.Epoch, blocks []*Block, epochStat
 	return &dbEpoch
 }
 
-func (dbw *dbWriter) persistBlockDeposits(tx *sqlx.Tx, block *Block, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockDeposits(tx db.Tx, block *Block, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey) error {
 	// insert deposits
 	dbDeposits := dbw.buildDbDeposits(block, depositIndex, orphaned, overrideForkId)
 	if orphaned {
@@ -424,7 +463,7 @@ func (dbw *dbWriter) buildDbDeposits(block *Block, depositIndex *uint64, orphane
 			SlotIndex:             uint64(idx),
 			SlotRoot:              block.Root[:],
 			Orphaned:              orphaned,
-			ForkId:                uint64(block.forkId),
+			ForkId:                uint64(block.GetForkId()),
 			PublicKey:             deposit.Data.PublicKey[:],
 			WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
 			Amount:                uint64(deposit.Data.Amount),
@@ -444,7 +483,7 @@ func (dbw *dbWriter) buildDbDeposits(block *Block, depositIndex *uint64, orphane
 	return dbDeposits
 }
 
-func (dbw *dbWriter) persistBlockDepositRequests(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockDepositRequests(tx db.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert deposits
 	dbDeposits := dbw.buildDbDepositRequests(block, orphaned, overrideForkId)
 	if orphaned {
@@ -482,7 +521,7 @@ func (dbw *dbWriter) buildDbDepositRequests(block *Block, orphaned bool, overrid
 			SlotIndex:             uint64(idx),
 			SlotRoot:              block.Root[:],
 			Orphaned:              orphaned,
-			ForkId:                uint64(block.forkId),
+			ForkId:                uint64(block.GetForkId()),
 			PublicKey:             deposit.Pubkey[:],
 			WithdrawalCredentials: deposit.WithdrawalCredentials,
 			Amount:                uint64(deposit.Amount),
@@ -497,7 +536,7 @@ func (dbw *dbWriter) buildDbDepositRequests(block *Block, orphaned bool, overrid
 	return dbDeposits
 }
 
-func (dbw *dbWriter) persistBlockVoluntaryExits(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockVoluntaryExits(tx db.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert voluntary exits
 	dbVoluntaryExits := dbw.buildDbVoluntaryExits(block, orphaned, overrideForkId)
 	if len(dbVoluntaryExits) > 0 {
@@ -528,7 +567,7 @@ func (dbw *dbWriter) buildDbVoluntaryExits(block *Block, orphaned bool, override
 			SlotIndex:      uint64(idx),
 			SlotRoot:       block.Root[:],
 			Orphaned:       orphaned,
-			ForkId:         uint64(block.forkId),
+			ForkId:         uint64(block.GetForkId()),
 			ValidatorIndex: uint64(voluntaryExit.Message.ValidatorIndex),
 		}
 		if overrideForkId != nil {
@@ -541,7 +580,7 @@ func (dbw *dbWriter) buildDbVoluntaryExits(block *Block, orphaned bool, override
 	return dbVoluntaryExits
 }
 
-func (dbw *dbWriter) persistBlockSlashings(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockSlashings(tx db.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert slashings
 	dbSlashings := dbw.buildDbSlashings(block, orphaned, overrideForkId)
 	if len(dbSlashings) > 0 {
@@ -584,7 +623,7 @@ func (dbw *dbWriter) buildDbSlashings(block *Block, orphaned bool, overrideForkI
 			SlotIndex:      uint64(slashingIndex),
 			SlotRoot:       block.Root[:],
 			Orphaned:       orphaned,
-			ForkId:         uint64(block.forkId),
+			ForkId:         uint64(block.GetForkId()),
 			ValidatorIndex: uint64(proposerSlashing.SignedHeader1.Message.ProposerIndex),
 			SlasherIndex:   uint64(proposerIndex),
 			Reason:         dbtypes.ProposerSlashing,
@@ -632,7 +671,7 @@ func (dbw *dbWriter) buildDbSlashings(block *Block, orphaned bool, overrideForkI
 	return dbSlashings
 }
 
-func (dbw *dbWriter) persistBlockConsolidationRequests(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockConsolidationRequests(tx db.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert consolidation requests
 	dbConsolidations := dbw.buildDbConsolidationRequests(block, orphaned, overrideForkId)
 	if orphaned {
@@ -679,7 +718,7 @@ func (dbw *dbWriter) buildDbConsolidationRequests(block *Block, orphaned bool, o
 			SlotRoot:      block.Root[:],
 			SlotIndex:     uint64(idx),
 			Orphaned:      orphaned,
-			ForkId:        uint64(block.forkId),
+			ForkId:        uint64(block.GetForkId()),
 			SourceAddress: consolidation.SourceAddress[:],
 			SourcePubkey:  consolidation.SourcePubkey[:],
 			TargetPubkey:  consolidation.TargetPubkey[:],
@@ -700,7 +739,7 @@ func (dbw *dbWriter) buildDbConsolidationRequests(block *Block, orphaned bool, o
 	return dbConsolidations
 }
 
-func (dbw *dbWriter) persistBlockWithdrawalRequests(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+func (dbw *dbWriter) persistBlockWithdrawalRequests(tx db.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert deposits
 	dbWithdrawalRequests := dbw.buildDbWithdrawalRequests(block, orphaned, overrideForkId)
 
@@ -742,7 +781,7 @@ func (dbw *dbWriter) buildDbWithdrawalRequests(block *Block, orphaned bool, over
 			SlotRoot:        block.Root[:],
 			SlotIndex:       uint64(idx),
 			Orphaned:        orphaned,
-			ForkId:          uint64(block.forkId),
+			ForkId:          uint64(block.GetForkId()),
 			SourceAddress:   withdrawalRequest.SourceAddress[:],
 			ValidatorPubkey: withdrawalRequest.ValidatorPubkey[:],
 			Amount:          uint64(withdrawalRequest.Amount),
@@ -0,0 +1,222 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/snappy"
+)
+
+// EntryType is the 2 byte type identifier of an e2store entry.
+type EntryType [2]byte
+
+// e2store entry types used in era files.
+// see https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md
+var (
+	TypeEmpty                       = EntryType{0x00, 0x00}
+	TypeVersion                     = EntryType{0x65, 0x32}
+	TypeCompressedSignedBeaconBlock = EntryType{0x01, 0x00}
+	TypeCompressedBeaconState       = EntryType{0x02, 0x00}
+	TypeSlotIndex                   = EntryType{0x69, 0x32}
+)
+
+const headerSize = 8
+
+// Entry is a single record of an e2store file.
+type Entry struct {
+	Type   EntryType
+	Offset int64 // offset of the entry header in the file
+	Length uint32
+}
+
+// SlotIndex maps the slots of an era to the offsets of their entries.
+type SlotIndex struct {
+	StartSlot uint64
+	Offsets   []int64 // offsets relative to the slot index entry, 0 for empty slots
+}
+
+// File is a reader for era files.
+// era files are e2store files containing the snappy compressed blocks & the closing state of an era.
+type File struct {
+	reader  io.ReaderAt
+	closer  io.Closer
+	entries []*Entry
+}
+
+// Open opens the era file at the given path and scans its entries.
+func Open(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	eraFile, err := NewReader(file, stat.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	eraFile.closer = file
+	return eraFile, nil
+}
+
+// NewReader scans the entries of an era file from the given reader.
+func NewReader(reader io.ReaderAt, size int64) (*File, error) {
+	eraFile := &File{
+		reader: reader,
+	}
+
+	header := make([]byte, headerSize)
+	offset := int64(0)
+	for offset < size {
+		if _, err := reader.ReadAt(header, offset); err != nil {
+			return nil, fmt.Errorf("failed reading entry header at offset %v: %v", offset, err)
+		}
+
+		entry := &Entry{
+			Type:   EntryType{header[0], header[1]},
+			Offset: offset,
+			Length: binary.LittleEndian.Uint32(header[2:6]),
+		}
+		if header[6] != 0 || header[7] != 0 {
+			return nil, fmt.Errorf("invalid entry header at offset %v: reserved bytes not zero", offset)
+		}
+
+		offset += headerSize + int64(entry.Length)
+		if offset > size {
+			return nil, fmt.Errorf("invalid entry at offset %v: length exceeds file size", entry.Offset)
+		}
+
+		eraFile.entries = append(eraFile.entries, entry)
+	}
+
+	if len(eraFile.entries) == 0 || eraFile.entries[0].Type != TypeVersion {
+		return nil, fmt.Errorf("invalid era file: missing version entry")
+	}
+
+	return eraFile, nil
+}
+
+// Close closes the underlying file.
+func (f *File) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// Entries returns all entries of the era file in file order.
+func (f *File) Entries() []*Entry {
+	return f.entries
+}
+
+// ReadEntry returns the raw data of the given entry.
+func (f *File) ReadEntry(entry *Entry) ([]byte, error) {
+	data := make([]byte, entry.Length)
+	if _, err := f.reader.ReadAt(data, entry.Offset+headerSize); err != nil {
+		return nil, fmt.Errorf("failed reading entry at offset %v: %v", entry.Offset, err)
+	}
+	return data, nil
+}
+
+// ReadCompressedEntry returns the decompressed data of the given block or state entry.
+func (f *File) ReadCompressedEntry(entry *Entry) ([]byte, error) {
+	data, err := f.ReadEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// entries are compressed with the framed snappy format
+	decompressed, err := io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing entry at offset %v: %v", entry.Offset, err)
+	}
+	return decompressed, nil
+}
+
+// GetSlotIndexes returns the parsed slot indexes of the era file.
+// the last index refers to the era state, the one before (if any) to the blocks of the era.
+func (f *File) GetSlotIndexes() ([]*SlotIndex, error) {
+	slotIndexes := []*SlotIndex{}
+	for _, entry := range f.entries {
+		if entry.Type != TypeSlotIndex {
+			continue
+		}
+
+		data, err := f.ReadEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		// starting-slot | offset * count | count
+		if len(data) < 16 || len(data)%8 != 0 {
+			return nil, fmt.Errorf("invalid slot index at offset %v", entry.Offset)
+		}
+		count := binary.LittleEndian.Uint64(data[len(data)-8:])
+		if uint64(len(data)) != 16+count*8 {
+			return nil, fmt.Errorf("invalid slot index at offset %v: count mismatch", entry.Offset)
+		}
+
+		slotIndex := &SlotIndex{
+			StartSlot: binary.LittleEndian.Uint64(data[0:8]),
+			Offsets:   make([]int64, count),
+		}
+		for i := uint64(0); i < count; i++ {
+			slotIndex.Offsets[i] = int64(binary.LittleEndian.Uint64(data[8+i*8 : 16+i*8]))
+		}
+
+		slotIndexes = append(slotIndexes, slotIndex)
+	}
+
+	return slotIndexes, nil
+}
+
+// BlockSlot returns the slot of a ssz encoded signed beacon block without decoding it.
+func BlockSlot(ssz []byte) (uint64, error) {
+	// message offset (4) | signature (96) | message: slot (8) ...
+	if len(ssz) < 108 {
+		return 0, fmt.Errorf("block ssz too short")
+	}
+	return binary.LittleEndian.Uint64(ssz[100:108]), nil
+}
+
+// StateSlot returns the slot of a ssz encoded beacon state without decoding it.
+func StateSlot(ssz []byte) (uint64, error) {
+	// genesis_time (8) | genesis_validators_root (32) | slot (8) ...
+	if len(ssz) < 48 {
+		return 0, fmt.Errorf("state ssz too short")
+	}
+	return binary.LittleEndian.Uint64(ssz[40:48]), nil
+}
+
+// StateLatestBlockSlot returns the slot of the latest block header of a ssz encoded beacon state without decoding it.
+// it equals the state slot if the state is the post state of a block at that slot.
+func StateLatestBlockSlot(ssz []byte) (uint64, error) {
+	// genesis_time (8) | genesis_validators_root (32) | slot (8) | fork (16) | latest_block_header: slot (8) ...
+	if len(ssz) < 72 {
+		return 0, fmt.Errorf("state ssz too short")
+	}
+	return binary.LittleEndian.Uint64(ssz[64:72]), nil
+}
+
+// StateDepositIndex returns the eth1_deposit_index of a ssz encoded beacon state without decoding it.
+// the field offset is the same for all forks, but depends on the SLOTS_PER_HISTORICAL_ROOT preset.
+func StateDepositIndex(ssz []byte, slotsPerHistoricalRoot uint64) (uint64, error) {
+	// genesis_time (8) | genesis_validators_root (32) | slot (8) | fork (16) | latest_block_header (112) |
+	// block_roots & state_roots (2 * 32 * SLOTS_PER_HISTORICAL_ROOT) | historical_roots offset (4) | eth1_data (72) |
+	// eth1_data_votes offset (4) | eth1_deposit_index (8) ...
+	offset := 256 + 64*slotsPerHistoricalRoot
+	if uint64(len(ssz)) < offset+8 {
+		return 0, fmt.Errorf("state ssz too short")
+	}
+	return binary.LittleEndian.Uint64(ssz[offset : offset+8]), nil
+}
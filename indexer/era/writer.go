@@ -0,0 +1,125 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// Writer writes era files.
+// entries must be written in order: blocks (ascending slots), state (optional), followed by Finish to write the slot indexes.
+type Writer struct {
+	writer       io.Writer
+	offset       int64
+	startSlot    uint64
+	blockOffsets []int64
+	stateSlot    uint64
+	stateOffset  int64
+}
+
+// NewWriter creates a new era file writer for the given slot range and writes the version entry.
+// the slot count is 0 for era files without blocks (genesis era).
+func NewWriter(writer io.Writer, startSlot uint64, slotCount uint64) (*Writer, error) {
+	eraWriter := &Writer{
+		writer:       writer,
+		startSlot:    startSlot,
+		blockOffsets: make([]int64, slotCount),
+		stateOffset:  -1,
+	}
+
+	if err := eraWriter.writeEntry(TypeVersion, nil); err != nil {
+		return nil, err
+	}
+
+	return eraWriter, nil
+}
+
+// WriteBlock writes the ssz encoded signed beacon block of the given slot.
+func (w *Writer) WriteBlock(slot uint64, ssz []byte) error {
+	if slot < w.startSlot || slot >= w.startSlot+uint64(len(w.blockOffsets)) {
+		return fmt.Errorf("block slot %v out of era range", slot)
+	}
+	if w.stateOffset >= 0 {
+		return fmt.Errorf("blocks must be written before the state")
+	}
+
+	w.blockOffsets[slot-w.startSlot] = w.offset
+	return w.writeCompressedEntry(TypeCompressedSignedBeaconBlock, ssz)
+}
+
+// WriteState writes the ssz encoded beacon state of the given slot.
+func (w *Writer) WriteState(slot uint64, ssz []byte) error {
+	if w.stateOffset >= 0 {
+		return fmt.Errorf("state already written")
+	}
+
+	w.stateSlot = slot
+	w.stateOffset = w.offset
+	return w.writeCompressedEntry(TypeCompressedBeaconState, ssz)
+}
+
+// Finish writes the slot indexes of the blocks & state.
+func (w *Writer) Finish() error {
+	if len(w.blockOffsets) > 0 {
+		if err := w.writeSlotIndex(w.startSlot, w.blockOffsets); err != nil {
+			return err
+		}
+	}
+
+	if w.stateOffset >= 0 {
+		if err := w.writeSlotIndex(w.stateSlot, []int64{w.stateOffset}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer) writeSlotIndex(startSlot uint64, offsets []int64) error {
+	// starting-slot | offset * count | count
+	// offsets are relative to the slot index entry, 0 for empty slots
+	data := make([]byte, 16+len(offsets)*8)
+	binary.LittleEndian.PutUint64(data[0:8], startSlot)
+	for i, offset := range offsets {
+		if offset != 0 {
+			offset -= w.offset
+		}
+		binary.LittleEndian.PutUint64(data[8+i*8:16+i*8], uint64(offset))
+	}
+	binary.LittleEndian.PutUint64(data[len(data)-8:], uint64(len(offsets)))
+
+	return w.writeEntry(TypeSlotIndex, data)
+}
+
+func (w *Writer) writeCompressedEntry(entryType EntryType, ssz []byte) error {
+	// entries are compressed with the framed snappy format
+	buf := &bytes.Buffer{}
+	snappyWriter := snappy.NewBufferedWriter(buf)
+	if _, err := snappyWriter.Write(ssz); err != nil {
+		return err
+	}
+	if err := snappyWriter.Close(); err != nil {
+		return err
+	}
+
+	return w.writeEntry(entryType, buf.Bytes())
+}
+
+func (w *Writer) writeEntry(entryType EntryType, data []byte) error {
+	header := make([]byte, headerSize)
+	copy(header[0:2], entryType[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+
+	if _, err := w.writer.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.writer.Write(data); err != nil {
+		return err
+	}
+
+	w.offset += headerSize + int64(len(data))
+	return nil
+}
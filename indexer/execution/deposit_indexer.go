@@ -16,7 +16,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/jmoiron/sqlx"
 	blsu "github.com/protolambda/bls12-381-util"
 	zrnt_common "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/protolambda/ztyp/tree"
@@ -133,18 +132,37 @@ func (ds *DepositIndexer) loadState() {
 	ds.state = &syncState
 }
 
+// GetState returns a copy of the current indexer state (nil if not loaded yet).
+func (ds *DepositIndexer) GetState() *dbtypes.DepositIndexerState {
+	state := ds.state
+	if state == nil {
+		return nil
+	}
+
+	stateCopy := *state
+	return &stateCopy
+}
+
 func (ds *DepositIndexer) loadFilteredLogs(ctx context.Context, client *execution.Client, query ethereum.FilterQuery) ([]types.Log, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	return client.GetRPCClient().GetEthClient().FilterLogs(ctx, query)
+	startTime := time.Now()
+	logs, err := client.GetRPCClient().GetEthClient().FilterLogs(ctx, query)
+	if err != nil {
+		// only failures are tracked, the duration of log queries depends on the requested block range
+		client.TrackRequest(startTime, err)
+	}
+	return logs, err
 }
 
 func (ds *DepositIndexer) loadTransactionByHash(ctx context.Context, client *execution.Client, hash common.Hash) (*types.Transaction, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	startTime := time.Now()
 	tx, _, err := client.GetRPCClient().GetEthClient().TransactionByHash(ctx, hash)
+	client.TrackRequest(startTime, err)
 	return tx, err
 }
 
@@ -152,7 +170,10 @@ func (ds *DepositIndexer) loadHeaderByNumber(ctx context.Context, client *execut
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	return client.GetRPCClient().GetHeaderByNumber(ctx, number)
+	startTime := time.Now()
+	header, err := client.GetRPCClient().GetHeaderByNumber(ctx, number)
+	client.TrackRequest(startTime, err)
+	return header, err
 }
 
 func (ds *DepositIndexer) processFinalizedBlocks(finalizedBlockNumber uint64) error {
@@ -455,7 +476,7 @@ func (ds *DepositIndexer) checkDepositValidity(depositTx *dbtypes.DepositTx) {
 }
 
 func (ds *DepositIndexer) persistFinalizedDepositTxs(toBlockNumber uint64, deposits []*dbtypes.DepositTx) error {
-	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	return db.RunDBTransaction(func(tx db.Tx) error {
 		if len(deposits) > 0 {
 			err := db.InsertDepositTxs(deposits, tx)
 			if err != nil {
@@ -478,7 +499,7 @@ func (ds *DepositIndexer) persistFinalizedDepositTxs(toBlockNumber uint64, depos
 }
 
 func (ds *DepositIndexer) persistRecentDepositTxs(deposits []*dbtypes.DepositTx) error {
-	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	return db.RunDBTransaction(func(tx db.Tx) error {
 		err := db.InsertDepositTxs(deposits, tx)
 		if err != nil {
 			return fmt.Errorf("error while inserting deposit txs: %v", err)
@@ -1,10 +1,11 @@
 package execution
 
 import (
-	"math/rand/v2"
 	"sort"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/clienthealth"
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/clients/execution"
 	"github.com/ethpandaops/dora/indexer/beacon"
@@ -17,6 +18,7 @@ type IndexerCtx struct {
 	executionPool    *execution.Pool
 	consensusPool    *consensus.Pool
 	chainState       *consensus.ChainState
+	clientsMutex     sync.RWMutex
 	executionClients map[*execution.Client]*indexerElClientInfo
 }
 
@@ -38,12 +40,41 @@ func NewIndexerCtx(logger logrus.FieldLogger, executionPool *execution.Pool, con
 }
 
 func (ictx *IndexerCtx) AddClientInfo(client *execution.Client, priority int, archive bool) {
+	ictx.clientsMutex.Lock()
+	defer ictx.clientsMutex.Unlock()
+
 	ictx.executionClients[client] = &indexerElClientInfo{
 		priority: priority,
 		archive:  archive,
 	}
 }
 
+// GetClientInfo returns the priority & archive flag of the given client.
+func (ictx *IndexerCtx) GetClientInfo(client *execution.Client) (priority int, archive bool) {
+	clientInfo := ictx.getClientInfo(client)
+	return clientInfo.priority, clientInfo.archive
+}
+
+func (ictx *IndexerCtx) RemoveClientInfo(client *execution.Client) {
+	ictx.clientsMutex.Lock()
+	defer ictx.clientsMutex.Unlock()
+
+	delete(ictx.executionClients, client)
+}
+
+func (ictx *IndexerCtx) getClientInfo(client *execution.Client) *indexerElClientInfo {
+	ictx.clientsMutex.RLock()
+	defer ictx.clientsMutex.RUnlock()
+
+	clientInfo := ictx.executionClients[client]
+	if clientInfo == nil {
+		// client has been removed in the meantime
+		return &indexerElClientInfo{}
+	}
+
+	return clientInfo
+}
+
 func (ictx *IndexerCtx) getFinalizedClients(clientType execution.ClientType) []*execution.Client {
 	_, finalizedRoot := ictx.consensusPool.GetChainState().GetJustifiedCheckpoint()
 
@@ -63,18 +94,29 @@ func (ictx *IndexerCtx) getFinalizedClients(clientType execution.ClientType) []*
 }
 
 func (ictx *IndexerCtx) sortClients(clientA *execution.Client, clientB *execution.Client, preferArchive bool) bool {
-	clientAInfo := ictx.executionClients[clientA]
-	clientBInfo := ictx.executionClients[clientB]
+	clientAInfo := ictx.getClientInfo(clientA)
+	clientBInfo := ictx.getClientInfo(clientB)
 
 	if preferArchive && clientAInfo.archive != clientBInfo.archive {
 		return clientAInfo.archive
 	}
 
+	if availableA := clientA.IsAvailable(); availableA != clientB.IsAvailable() {
+		return availableA
+	}
+
+	scoreA := clientA.GetHealthScore()
+	scoreB := clientB.GetHealthScore()
+	if degradedA := clienthealth.IsDegraded(scoreA); degradedA != clienthealth.IsDegraded(scoreB) {
+		return !degradedA
+	}
+
 	if clientAInfo.priority != clientBInfo.priority {
 		return clientAInfo.priority > clientBInfo.priority
 	}
 
-	return rand.IntN(2) == 0
+	// clients with a similar health score keep the random order from the pool
+	return clienthealth.CompareScores(scoreA, scoreB) < 0
 }
 
 type forkWithClients struct {
@@ -120,7 +162,7 @@ func (ictx *IndexerCtx) getForksWithClients(clientType execution.ClientType) []*
 	for _, forkWithClients := range forksWithClients {
 		forkWithClients.canonical = canonicalHead != nil && canonicalHead.GetForkId() == forkWithClients.forkId
 
-		sort.Slice(forkWithClients.clients, func(i, j int) bool {
+		sort.SliceStable(forkWithClients.clients, func(i, j int) bool {
 			return ictx.sortClients(forkWithClients.clients[i], forkWithClients.clients[j], true)
 		})
 	}
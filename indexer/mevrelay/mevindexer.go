@@ -15,7 +15,6 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/dora/clients/consensus"
@@ -38,6 +37,13 @@ type MevIndexer struct {
 	lastLoadedSlot      map[uint8]uint64
 }
 
+// MevRelayStatus holds the indexing state of a relay.
+type MevRelayStatus struct {
+	Index          uint8
+	Name           string
+	LastLoadedSlot uint64
+}
+
 type mevIndexerBlockCache struct {
 	updated bool
 	block   *dbtypes.MevBlock
@@ -65,6 +71,28 @@ func (mev *MevIndexer) StartUpdater() {
 	go mev.runUpdaterLoop()
 }
 
+// GetRelayStatus returns the last loaded slot for each configured relay.
+func (mev *MevIndexer) GetRelayStatus() []*MevRelayStatus {
+	mev.mevBlockCacheMutex.Lock()
+	defer mev.mevBlockCacheMutex.Unlock()
+
+	relayStatus := make([]*MevRelayStatus, 0, len(utils.Config.MevIndexer.Relays))
+	for _, relay := range utils.Config.MevIndexer.Relays {
+		relayStatus = append(relayStatus, &MevRelayStatus{
+			Index:          relay.Index,
+			Name:           relay.Name,
+			LastLoadedSlot: mev.lastLoadedSlot[relay.Index],
+		})
+	}
+
+	return relayStatus
+}
+
+// GetLastRefresh returns the time of the last successful relay update.
+func (mev *MevIndexer) GetLastRefresh() time.Time {
+	return mev.lastRefresh
+}
+
 func (mev *MevIndexer) runUpdaterLoop() {
 	defer utils.HandleSubroutinePanic("MevIndexer.runUpdaterLoop")
 
@@ -402,7 +430,7 @@ func (mev *MevIndexer) updateMevBlocks(updatedMevBlocks []*dbtypes.MevBlock) err
 		return nil
 	}
 
-	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	err := db.RunDBTransaction(func(tx db.Tx) error {
 		return db.InsertMevBlocks(updatedMevBlocks, tx)
 	})
 	if err != nil {
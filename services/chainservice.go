@@ -5,29 +5,38 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	"github.com/jmoiron/sqlx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/clients/execution"
-	"github.com/ethpandaops/dora/clients/sshtunnel"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
 	execindexer "github.com/ethpandaops/dora/indexer/execution"
+	"github.com/ethpandaops/dora/indexer/mevrelay"
 	"github.com/ethpandaops/dora/utils"
 	"github.com/sirupsen/logrus"
 )
 
 type ChainService struct {
-	logger         logrus.FieldLogger
-	consensusPool  *consensus.Pool
-	executionPool  *execution.Pool
-	beaconIndexer  *beacon.Indexer
-	validatorNames *ValidatorNames
+	logger              logrus.FieldLogger
+	consensusPool       *consensus.Pool
+	executionPool       *execution.Pool
+	beaconIndexer       *beacon.Indexer
+	executionIndexerCtx *execindexer.IndexerCtx
+	depositIndexer      *execindexer.DepositIndexer
+	mevRelayIndexer     *mevrelay.MevIndexer
+	validatorNames      *ValidatorNames
+	endpointsMutex      sync.Mutex
+	endpointSources     []*endpointDiscoverySource
+	receiptsCache       *lru.Cache[common.Hash, []*ethtypes.Receipt]
 }
 
 var GlobalBeaconService *ChainService
@@ -44,31 +53,33 @@ func StartChainService(ctx context.Context, logger logrus.FieldLogger) error {
 	beaconIndexer := beacon.NewIndexer(logger.WithField("service", "cl-indexer"), consensusPool)
 	executionIndexerCtx := execindexer.NewIndexerCtx(logger.WithField("service", "el-indexer"), executionPool, consensusPool, beaconIndexer)
 
-	// add consensus clients
-	for index, endpoint := range utils.Config.BeaconApi.Endpoints {
-		endpointConfig := &consensus.ClientConfig{
-			URL:     endpoint.Url,
-			Name:    endpoint.Name,
-			Headers: endpoint.Headers,
-		}
-
-		if endpoint.Ssh != nil {
-			endpointConfig.SshConfig = &sshtunnel.SshConfig{
-				Host:     endpoint.Ssh.Host,
-				Port:     endpoint.Ssh.Port,
-				User:     endpoint.Ssh.User,
-				Password: endpoint.Ssh.Password,
-				Keyfile:  endpoint.Ssh.Keyfile,
-			}
-		}
+	chainService := &ChainService{
+		logger:              logger,
+		consensusPool:       consensusPool,
+		executionPool:       executionPool,
+		beaconIndexer:       beaconIndexer,
+		executionIndexerCtx: executionIndexerCtx,
+		receiptsCache:       lru.NewCache[common.Hash, []*ethtypes.Receipt](32),
+	}
 
-		client, err := consensusPool.AddEndpoint(endpointConfig)
+	// add consensus clients
+	for _, endpoint := range utils.Config.BeaconApi.Endpoints {
+		client, err := consensusPool.AddEndpoint(getConsensusClientConfig(&endpoint))
 		if err != nil {
 			logger.Errorf("could not add beacon client '%v' to pool: %v", endpoint.Name, err)
 			continue
 		}
 
-		beaconIndexer.AddClient(uint16(index), client, endpoint.Priority, endpoint.Archive, endpoint.SkipValidators)
+		beaconIndexer.AddClient(client.GetIndex(), client, endpoint.Priority, endpoint.Archive, endpoint.SkipValidators)
+	}
+
+	// add clients from the watched endpoints file & discovery sources
+	chainService.initEndpointDiscovery()
+	for _, source := range chainService.endpointSources {
+		err := source.refresh(ctx)
+		if err != nil {
+			logger.Errorf("could not load endpoints from %v: %v", source.provider.GetName(), err)
+		}
 	}
 
 	if len(consensusPool.GetAllEndpoints()) == 0 {
@@ -77,23 +88,7 @@ func StartChainService(ctx context.Context, logger logrus.FieldLogger) error {
 
 	// add execution clients
 	for _, endpoint := range utils.Config.ExecutionApi.Endpoints {
-		endpointConfig := &execution.ClientConfig{
-			URL:     endpoint.Url,
-			Name:    endpoint.Name,
-			Headers: endpoint.Headers,
-		}
-
-		if endpoint.Ssh != nil {
-			endpointConfig.SshConfig = &sshtunnel.SshConfig{
-				Host:     endpoint.Ssh.Host,
-				Port:     endpoint.Ssh.Port,
-				User:     endpoint.Ssh.User,
-				Password: endpoint.Ssh.Password,
-				Keyfile:  endpoint.Ssh.Keyfile,
-			}
-		}
-
-		client, err := executionPool.AddEndpoint(endpointConfig)
+		client, err := executionPool.AddEndpoint(getExecutionClientConfig(&endpoint))
 		if err != nil {
 			logger.Errorf("could not add execution client '%v' to pool: %v", endpoint.Name, err)
 			continue
@@ -108,7 +103,7 @@ func StartChainService(ctx context.Context, logger logrus.FieldLogger) error {
 
 	// reset sync state if configured
 	if utils.Config.Indexer.ResyncFromEpoch != nil {
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			syncState := &dbtypes.IndexerSyncState{
 				Epoch: *utils.Config.Indexer.ResyncFromEpoch,
 			}
@@ -117,7 +112,7 @@ func StartChainService(ctx context.Context, logger logrus.FieldLogger) error {
 		if err != nil {
 			return fmt.Errorf("failed resetting sync state: %v", err)
 		}
-		logger.Warnf("Reset explorer synchronization status to epoch %v as configured! Please remove this setting again.", *utils.Config.Indexer.ResyncFromEpoch)
+		logger.Warnf("Reset explorer synchronization status to epoch %v as configured! Please remove this setting again (resyncs can be triggered via /admin/indexer without restart).", *utils.Config.Indexer.ResyncFromEpoch)
 	}
 
 	// await validator names & beacon pool readiness
@@ -146,15 +141,21 @@ func StartChainService(ctx context.Context, logger logrus.FieldLogger) error {
 	}()
 
 	// add execution indexers
-	execindexer.NewDepositIndexer(executionIndexerCtx)
-
-	GlobalBeaconService = &ChainService{
-		logger:         logger,
-		consensusPool:  consensusPool,
-		executionPool:  executionPool,
-		beaconIndexer:  beaconIndexer,
-		validatorNames: validatorNames,
+	chainService.depositIndexer = execindexer.NewDepositIndexer(executionIndexerCtx)
+
+	// start mev relay indexer
+	if len(utils.Config.MevIndexer.Relays) > 0 {
+		chainService.mevRelayIndexer = mevrelay.NewMevIndexer(logger.WithField("service", "mev-indexer"), beaconIndexer, consensusPool.GetChainState())
+		chainService.mevRelayIndexer.StartUpdater()
 	}
+
+	chainService.validatorNames = validatorNames
+	GlobalBeaconService = chainService
+
+	for _, source := range chainService.endpointSources {
+		go source.runRefreshLoop(ctx)
+	}
+
 	return nil
 }
 
@@ -162,6 +163,18 @@ func (bs *ChainService) GetBeaconIndexer() *beacon.Indexer {
 	return bs.beaconIndexer
 }
 
+func (bs *ChainService) GetDepositIndexer() *execindexer.DepositIndexer {
+	return bs.depositIndexer
+}
+
+func (bs *ChainService) GetMevRelayIndexer() *mevrelay.MevIndexer {
+	return bs.mevRelayIndexer
+}
+
+func (bs *ChainService) GetValidatorNames() *ValidatorNames {
+	return bs.validatorNames
+}
+
 func (bs *ChainService) GetConsensusClients() []*consensus.Client {
 	return bs.consensusPool.GetAllEndpoints()
 }
@@ -11,7 +11,10 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/ethpandaops/dora/clients/execution"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
@@ -25,6 +28,17 @@ type CombinedBlockResponse struct {
 	Orphaned bool
 }
 
+// GetBlockTimings returns the arrival times of a block on the connected clients, either from the block cache or the db.
+func (bs *ChainService) GetBlockTimings(ctx context.Context, blockroot phase0.Root) []*dbtypes.BlockTiming {
+	if block := bs.beaconIndexer.GetBlockByRoot(blockroot); block != nil {
+		if timings := block.GetDbTimings(bs.consensusPool.GetChainState()); len(timings) > 0 {
+			return timings
+		}
+	}
+
+	return db.WithContext(ctx).GetBlockTimingsByRoot(blockroot[:])
+}
+
 func (bs *ChainService) GetBlockBlob(ctx context.Context, blockroot phase0.Root, commitment deneb.KZGCommitment) (*deneb.BlobSidecar, error) {
 	client := bs.beaconIndexer.GetReadyClientByBlockRoot(blockroot, true)
 	if client == nil {
@@ -68,6 +82,13 @@ func (bs *ChainService) GetSlotDetailsByBlockroot(ctx context.Context, blockroot
 			Block:    blockInfo.GetBlock(),
 			Orphaned: true,
 		}
+	} else if blockInfo := bs.getFinalizedBlockByRoot(ctx, blockroot); blockInfo != nil {
+		result = &CombinedBlockResponse{
+			Root:     blockInfo.Root,
+			Header:   blockInfo.GetHeader(),
+			Block:    blockInfo.GetBlock(),
+			Orphaned: false,
+		}
 	} else {
 		var header *phase0.SignedBeaconBlockHeader
 		var err error
@@ -99,7 +120,7 @@ func (bs *ChainService) GetSlotDetailsByBlockroot(ctx context.Context, blockroot
 
 		var block *spec.VersionedSignedBeaconBlock
 		for retry := headRetry; retry < headRetry+3; retry++ {
-			client := clients[headRetry%len(clients)]
+			client := clients[retry%len(clients)]
 			block, err = beacon.LoadBeaconBlock(ctx, client, blockroot)
 			if block != nil {
 				break
@@ -146,6 +167,13 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 			Block:    cachedBlock.GetBlock(),
 			Orphaned: isOrphaned,
 		}
+	} else if blockInfo := bs.getFinalizedBlockBySlot(ctx, slot); blockInfo != nil {
+		result = &CombinedBlockResponse{
+			Root:     blockInfo.Root,
+			Header:   blockInfo.GetHeader(),
+			Block:    blockInfo.GetBlock(),
+			Orphaned: false,
+		}
 	} else {
 
 		var header *phase0.SignedBeaconBlockHeader
@@ -178,7 +206,7 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 
 		var block *spec.VersionedSignedBeaconBlock
 		for retry := headRetry; retry < headRetry+3; retry++ {
-			client := clients[headRetry%len(clients)]
+			client := clients[retry%len(clients)]
 			block, err = beacon.LoadBeaconBlock(ctx, client, blockRoot)
 			if block != nil {
 				break
@@ -205,6 +233,30 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 	return result, nil
 }
 
+// getFinalizedBlockByRoot loads a finalized block from the block body store.
+// a block body that cannot be restored is logged and left to the beacon node fallback.
+func (bs *ChainService) getFinalizedBlockByRoot(ctx context.Context, blockroot phase0.Root) *beacon.Block {
+	block, err := bs.beaconIndexer.GetFinalizedBlockByRoot(ctx, blockroot)
+	if err != nil {
+		logrus.WithError(err).Warnf("Error loading finalized block body for root 0x%x from db", blockroot)
+		return nil
+	}
+
+	return block
+}
+
+// getFinalizedBlockBySlot loads the canonical finalized block of a slot from the block body store.
+// a block body that cannot be restored is logged and left to the beacon node fallback.
+func (bs *ChainService) getFinalizedBlockBySlot(ctx context.Context, slot phase0.Slot) *beacon.Block {
+	block, err := bs.beaconIndexer.GetFinalizedBlockBySlot(ctx, slot)
+	if err != nil {
+		logrus.WithError(err).Warnf("Error loading finalized block body for slot %v from db", slot)
+		return nil
+	}
+
+	return block
+}
+
 func (bs *ChainService) GetBlobSidecarsByBlockRoot(ctx context.Context, blockroot []byte) ([]*deneb.BlobSidecar, error) {
 	client := bs.beaconIndexer.GetReadyClientByBlockRoot(phase0.Root(blockroot), true)
 	if client == nil {
@@ -214,7 +266,7 @@ func (bs *ChainService) GetBlobSidecarsByBlockRoot(ctx context.Context, blockroo
 	return client.GetClient().GetRPCClient().GetBlobSidecarsByBlockroot(ctx, blockroot)
 }
 
-func (bs *ChainService) GetDbBlocks(firstSlot uint64, limit int32, withMissing bool, withOrphaned bool) []*dbtypes.Slot {
+func (bs *ChainService) GetDbBlocks(ctx context.Context, firstSlot uint64, limit int32, withMissing bool, withOrphaned bool) []*dbtypes.Slot {
 	chainState := bs.consensusPool.GetChainState()
 	resBlocks := make([]*dbtypes.Slot, limit)
 	resIdx := 0
@@ -306,7 +358,7 @@ func (bs *ChainService) GetDbBlocks(firstSlot uint64, limit int32, withMissing b
 	}
 
 	if resIdx < int(limit) {
-		dbBlocks := db.GetSlots(uint64(slot), uint32(limit-int32(resIdx)), withMissing, withOrphaned)
+		dbBlocks := db.WithContext(ctx).GetSlots(uint64(slot), uint32(limit-int32(resIdx)), withMissing, withOrphaned)
 		for _, dbBlock := range dbBlocks {
 
 			if withMissing {
@@ -346,7 +398,7 @@ func (bs *ChainService) GetDbBlocks(firstSlot uint64, limit int32, withMissing b
 	return resBlocks
 }
 
-func (bs *ChainService) GetDbBlocksForSlots(firstSlot uint64, slotLimit uint32, withMissing bool, withOrphaned bool) []*dbtypes.Slot {
+func (bs *ChainService) GetDbBlocksForSlots(ctx context.Context, firstSlot uint64, slotLimit uint32, withMissing bool, withOrphaned bool) []*dbtypes.Slot {
 	resBlocks := make([]*dbtypes.Slot, 0)
 
 	chainState := bs.consensusPool.GetChainState()
@@ -436,7 +488,7 @@ func (bs *ChainService) GetDbBlocksForSlots(firstSlot uint64, slotLimit uint32,
 	}
 
 	if uint64(slot) > lastSlot {
-		dbBlocks := db.GetSlotsRange(uint64(slot), lastSlot, withMissing, withOrphaned)
+		dbBlocks := db.WithContext(ctx).GetSlotsRange(uint64(slot), lastSlot, withMissing, withOrphaned)
 
 		for _, dbBlock := range dbBlocks {
 			if withMissing {
@@ -483,7 +535,7 @@ type cachedDbBlock struct {
 	block    *beacon.Block
 }
 
-func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx uint64, pageSize uint32) []*dbtypes.AssignedSlot {
+func (bs *ChainService) GetDbBlocksByFilter(ctx context.Context, filter *dbtypes.BlockFilter, pageIdx uint64, pageSize uint32) []*dbtypes.AssignedSlot {
 	cachedMatches := make([]cachedDbBlock, 0)
 
 	chainState := bs.consensusPool.GetChainState()
@@ -665,16 +717,16 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 	dbCacheOffset := uint64(pageSize) - (cachedMatchesLen % uint64(pageSize))
 	var dbBlocks []*dbtypes.AssignedSlot
 	if dbPage == 0 {
-		dbBlocks = db.GetFilteredSlots(filter, uint64(idxMinSlot), 0, uint32(dbCacheOffset)+1)
+		dbBlocks = db.WithContext(ctx).GetFilteredSlots(filter, uint64(idxMinSlot), 0, uint32(dbCacheOffset)+1)
 	} else {
-		dbBlocks = db.GetFilteredSlots(filter, uint64(idxMinSlot), (dbPage-1)*uint64(pageSize)+dbCacheOffset, pageSize+1)
+		dbBlocks = db.WithContext(ctx).GetFilteredSlots(filter, uint64(idxMinSlot), (dbPage-1)*uint64(pageSize)+dbCacheOffset, pageSize+1)
 	}
 	resBlocks = append(resBlocks, dbBlocks...)
 
 	return resBlocks
 }
 
-func (bs *ChainService) GetDbBlocksByParentRoot(parentRoot phase0.Root) []*dbtypes.Slot {
+func (bs *ChainService) GetDbBlocksByParentRoot(ctx context.Context, parentRoot phase0.Root) []*dbtypes.Slot {
 	parentBlock := bs.beaconIndexer.GetBlockByRoot(parentRoot)
 	cachedMatches := bs.beaconIndexer.GetBlockByParentRoot(parentRoot)
 	resBlocks := make([]*dbtypes.Slot, len(cachedMatches))
@@ -682,12 +734,12 @@ func (bs *ChainService) GetDbBlocksByParentRoot(parentRoot phase0.Root) []*dbtyp
 		resBlocks[idx] = block.GetDbBlock(bs.beaconIndexer)
 	}
 	if parentBlock == nil {
-		resBlocks = append(resBlocks, db.GetSlotsByParentRoot(parentRoot[:])...)
+		resBlocks = append(resBlocks, db.WithContext(ctx).GetSlotsByParentRoot(parentRoot[:])...)
 	}
 	return resBlocks
 }
 
-func (bs *ChainService) CheckBlockOrphanedStatus(blockRoot phase0.Root) dbtypes.SlotStatus {
+func (bs *ChainService) CheckBlockOrphanedStatus(ctx context.Context, blockRoot phase0.Root) dbtypes.SlotStatus {
 	cachedBlock := bs.beaconIndexer.GetBlockByRoot(blockRoot)
 	if cachedBlock != nil {
 		if bs.beaconIndexer.IsCanonicalBlock(cachedBlock, nil) {
@@ -696,10 +748,37 @@ func (bs *ChainService) CheckBlockOrphanedStatus(blockRoot phase0.Root) dbtypes.
 			return dbtypes.Orphaned
 		}
 	}
-	dbRefs := db.GetSlotStatus([][]byte{blockRoot[:]})
+	dbRefs := db.WithContext(ctx).GetSlotStatus([][]byte{blockRoot[:]})
 	if len(dbRefs) > 0 {
 		return dbRefs[0].Status
 	}
 
 	return dbtypes.Missing
 }
+
+// GetBlockReceipts returns the receipts of the execution block with the given hash from the first online execution client.
+// receipts of a block never change, so they are cached to avoid loading them for each slot page build.
+func (bs *ChainService) GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*ethtypes.Receipt, error) {
+	if receipts, found := bs.receiptsCache.Get(blockHash); found {
+		return receipts, nil
+	}
+
+	var client *execution.Client
+	for _, elClient := range bs.executionPool.GetAllEndpoints() {
+		if elClient.GetStatus() == execution.ClientStatusOnline {
+			client = elClient
+			break
+		}
+	}
+	if client == nil {
+		return nil, fmt.Errorf("no execution clients available")
+	}
+
+	receipts, err := client.GetRPCClient().GetBlockReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("error loading block receipts from %v: %v", client.GetName(), err)
+	}
+
+	bs.receiptsCache.Add(blockHash, receipts)
+	return receipts, nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/clients/sshtunnel"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// EndpointUpdate holds the runtime changeable settings of an endpoint.
+// nil fields are left unchanged.
+type EndpointUpdate struct {
+	Priority *int
+	Archive  *bool
+}
+
+func getConsensusClientConfig(endpoint *types.EndpointConfig) *consensus.ClientConfig {
+	endpointConfig := &consensus.ClientConfig{
+		URL:     endpoint.Url,
+		Name:    endpoint.Name,
+		Headers: endpoint.Headers,
+	}
+
+	if endpoint.Ssh != nil {
+		endpointConfig.SshConfig = &sshtunnel.SshConfig{
+			Host:     endpoint.Ssh.Host,
+			Port:     endpoint.Ssh.Port,
+			User:     endpoint.Ssh.User,
+			Password: endpoint.Ssh.Password,
+			Keyfile:  endpoint.Ssh.Keyfile,
+		}
+	}
+
+	return endpointConfig
+}
+
+func getExecutionClientConfig(endpoint *types.EndpointConfig) *execution.ClientConfig {
+	endpointConfig := &execution.ClientConfig{
+		URL:     endpoint.Url,
+		Name:    endpoint.Name,
+		Headers: endpoint.Headers,
+	}
+
+	if endpoint.Ssh != nil {
+		endpointConfig.SshConfig = &sshtunnel.SshConfig{
+			Host:     endpoint.Ssh.Host,
+			Port:     endpoint.Ssh.Port,
+			User:     endpoint.Ssh.User,
+			Password: endpoint.Ssh.Password,
+			Keyfile:  endpoint.Ssh.Keyfile,
+		}
+	}
+
+	return endpointConfig
+}
+
+// GetConsensusIndexerClient returns the beacon indexer client for the given consensus pool client.
+func (bs *ChainService) GetConsensusIndexerClient(client *consensus.Client) *beacon.Client {
+	return bs.beaconIndexer.GetClientByPoolClient(client)
+}
+
+// GetExecutionClientInfo returns the priority & archive flag of the given execution client.
+func (bs *ChainService) GetExecutionClientInfo(client *execution.Client) (priority int, archive bool) {
+	return bs.executionIndexerCtx.GetClientInfo(client)
+}
+
+func (bs *ChainService) getConsensusClientByName(name string) *consensus.Client {
+	for _, client := range bs.consensusPool.GetAllEndpoints() {
+		if client.GetName() == name {
+			return client
+		}
+	}
+	return nil
+}
+
+func (bs *ChainService) getExecutionClientByName(name string) *execution.Client {
+	for _, client := range bs.executionPool.GetAllEndpoints() {
+		if client.GetName() == name {
+			return client
+		}
+	}
+	return nil
+}
+
+// AddConsensusEndpoint adds a new consensus client to the pool and starts indexing it.
+func (bs *ChainService) AddConsensusEndpoint(endpoint *types.EndpointConfig) (*consensus.Client, error) {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	if endpoint.Url == "" {
+		return nil, fmt.Errorf("missing endpoint url")
+	}
+	if endpoint.Name == "" {
+		endpoint.Name = utils.GetDefaultEndpointName(endpoint.Url, len(bs.consensusPool.GetAllEndpoints()))
+	}
+	if bs.getConsensusClientByName(endpoint.Name) != nil {
+		return nil, fmt.Errorf("consensus client with name '%v' already exists", endpoint.Name)
+	}
+
+	client, err := bs.consensusPool.AddEndpoint(getConsensusClientConfig(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not add beacon client '%v' to pool: %v", endpoint.Name, err)
+	}
+
+	bs.beaconIndexer.AddClient(client.GetIndex(), client, endpoint.Priority, endpoint.Archive, endpoint.SkipValidators)
+	bs.logger.Infof("added consensus client %v (%v)", endpoint.Name, endpoint.Url)
+
+	return client, nil
+}
+
+// RemoveConsensusEndpoint stops the consensus client with the given name and removes it from the pool & indexer.
+func (bs *ChainService) RemoveConsensusEndpoint(name string) error {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	client := bs.getConsensusClientByName(name)
+	if client == nil {
+		return fmt.Errorf("consensus client '%v' not found", name)
+	}
+	if len(bs.consensusPool.GetAllEndpoints()) <= 1 {
+		return fmt.Errorf("cannot remove the last consensus client")
+	}
+
+	// detach the indexer first, so it does not process events of the stopped client
+	if indexerClient := bs.beaconIndexer.GetClientByPoolClient(client); indexerClient != nil {
+		bs.beaconIndexer.RemoveClient(indexerClient)
+	}
+
+	bs.consensusPool.RemoveEndpoint(client)
+	bs.logger.Infof("removed consensus client %v", name)
+
+	return nil
+}
+
+// UpdateConsensusEndpoint updates the priority & archive flag of the consensus client with the given name.
+func (bs *ChainService) UpdateConsensusEndpoint(name string, update *EndpointUpdate) error {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	client := bs.getConsensusClientByName(name)
+	if client == nil {
+		return fmt.Errorf("consensus client '%v' not found", name)
+	}
+
+	indexerClient := bs.beaconIndexer.GetClientByPoolClient(client)
+	if indexerClient == nil {
+		return fmt.Errorf("consensus client '%v' not attached to indexer", name)
+	}
+
+	if update.Priority != nil {
+		indexerClient.SetPriority(*update.Priority)
+	}
+	if update.Archive != nil {
+		indexerClient.SetArchive(*update.Archive)
+	}
+
+	return nil
+}
+
+// AddExecutionEndpoint adds a new execution client to the pool.
+func (bs *ChainService) AddExecutionEndpoint(endpoint *types.EndpointConfig) (*execution.Client, error) {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	if endpoint.Url == "" {
+		return nil, fmt.Errorf("missing endpoint url")
+	}
+	if endpoint.Name == "" {
+		endpoint.Name = utils.GetDefaultEndpointName(endpoint.Url, len(bs.executionPool.GetAllEndpoints()))
+	}
+	if bs.getExecutionClientByName(endpoint.Name) != nil {
+		return nil, fmt.Errorf("execution client with name '%v' already exists", endpoint.Name)
+	}
+
+	client, err := bs.executionPool.AddEndpoint(getExecutionClientConfig(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not add execution client '%v' to pool: %v", endpoint.Name, err)
+	}
+
+	bs.executionIndexerCtx.AddClientInfo(client, endpoint.Priority, endpoint.Archive)
+	bs.logger.Infof("added execution client %v (%v)", endpoint.Name, endpoint.Url)
+
+	return client, nil
+}
+
+// RemoveExecutionEndpoint stops the execution client with the given name and removes it from the pool.
+func (bs *ChainService) RemoveExecutionEndpoint(name string) error {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	client := bs.getExecutionClientByName(name)
+	if client == nil {
+		return fmt.Errorf("execution client '%v' not found", name)
+	}
+
+	bs.executionPool.RemoveEndpoint(client)
+	bs.executionIndexerCtx.RemoveClientInfo(client)
+	bs.logger.Infof("removed execution client %v", name)
+
+	return nil
+}
+
+// UpdateExecutionEndpoint updates the priority & archive flag of the execution client with the given name.
+func (bs *ChainService) UpdateExecutionEndpoint(name string, update *EndpointUpdate) error {
+	bs.endpointsMutex.Lock()
+	defer bs.endpointsMutex.Unlock()
+
+	client := bs.getExecutionClientByName(name)
+	if client == nil {
+		return fmt.Errorf("execution client '%v' not found", name)
+	}
+
+	priority, archive := bs.executionIndexerCtx.GetClientInfo(client)
+	if update.Priority != nil {
+		priority = *update.Priority
+	}
+	if update.Archive != nil {
+		archive = *update.Archive
+	}
+	bs.executionIndexerCtx.AddClientInfo(client, priority, archive)
+
+	return nil
+}
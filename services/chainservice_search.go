@@ -0,0 +1,543 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// SearchResultType is the kind of entity a search result points to.
+// the order of the types is used to rank results with the same score.
+type SearchResultType uint8
+
+const (
+	SearchResultSlot SearchResultType = iota
+	SearchResultBlockRoot
+	SearchResultStateRoot
+	SearchResultExecBlock
+	SearchResultTransaction
+	SearchResultEpoch
+	SearchResultValidator
+	SearchResultBuilder
+	SearchResultDeposit
+	SearchResultWithdrawalAddress
+	SearchResultDepositAddress
+	SearchResultValidatorName
+	SearchResultGraffiti
+)
+
+var searchResultTypeNames = map[SearchResultType]string{
+	SearchResultSlot:              "slot",
+	SearchResultBlockRoot:         "block_root",
+	SearchResultStateRoot:         "state_root",
+	SearchResultExecBlock:         "exec_block",
+	SearchResultTransaction:       "transaction",
+	SearchResultEpoch:             "epoch",
+	SearchResultValidator:         "validator",
+	SearchResultBuilder:           "builder",
+	SearchResultDeposit:           "deposit",
+	SearchResultWithdrawalAddress: "withdrawal_address",
+	SearchResultDepositAddress:    "deposit_address",
+	SearchResultValidatorName:     "validator_name",
+	SearchResultGraffiti:          "graffiti",
+}
+
+func (t SearchResultType) String() string {
+	return searchResultTypeNames[t]
+}
+
+// search result scores, exact identifier matches always rank above text matches.
+const (
+	SearchScoreExact     = 100
+	SearchScoreTextExact = 90
+	SearchScorePrefix    = 70
+	SearchScoreContains  = 50
+	SearchScoreFuzzy     = 30
+)
+
+// SearchResult is a single ranked result of the unified search.
+type SearchResult struct {
+	Type        SearchResultType
+	Score       int
+	Title       string
+	Description string
+	Link        string
+	Orphaned    bool
+}
+
+type chainSearch struct {
+	bs        *ChainService
+	ctx       context.Context
+	limit     int
+	typeahead bool
+	results   []*SearchResult
+	links     map[string]*SearchResult
+}
+
+var searchHexRE = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// Search runs the unified search across all entity types and returns the results ranked by match quality.
+// numbers are matched against slots, epochs, validator indices & execution block numbers,
+// hashes against block roots, state roots, execution block hashes & transactions,
+// pubkeys against validators, builders & deposits, addresses against withdrawal & deposit addresses
+// and any text against validator names (incl. fuzzy matches) & graffitis.
+func (bs *ChainService) Search(ctx context.Context, query string, limit int) []*SearchResult {
+	return bs.runSearch(ctx, query, limit, false)
+}
+
+// SearchAhead runs the unified search for the search box suggestions, which are requested on every keystroke.
+// it only runs the cheap lookups of the full search, validator pubkey prefixes & fuzzy validator name matches are skipped.
+func (bs *ChainService) SearchAhead(ctx context.Context, query string, limit int) []*SearchResult {
+	return bs.runSearch(ctx, query, limit, true)
+}
+
+func (bs *ChainService) runSearch(ctx context.Context, query string, limit int, typeahead bool) []*SearchResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	search := &chainSearch{
+		bs:        bs,
+		ctx:       ctx,
+		limit:     limit,
+		typeahead: typeahead,
+		links:     map[string]*SearchResult{},
+	}
+
+	isIdentifier := false
+	if number, err := strconv.ParseUint(query, 10, 64); err == nil {
+		search.searchNumber(number)
+	}
+
+	hexQuery := strings.TrimPrefix(strings.TrimPrefix(query, "0x"), "0X")
+	if searchHexRE.MatchString(hexQuery) {
+		hexQuery = strings.ToLower(hexQuery)
+		hexBytes, _ := hex.DecodeString(hexQuery)
+
+		switch len(hexQuery) {
+		case 64:
+			isIdentifier = true
+			search.searchHash(hexBytes)
+		case 96:
+			isIdentifier = true
+			search.searchPubkey(hexBytes)
+		case 40:
+			isIdentifier = true
+			search.searchAddress(hexBytes)
+		}
+
+		if len(hexQuery) >= 6 && len(hexQuery) < 96 && !typeahead {
+			search.searchPubkeyPrefix(hexQuery)
+		}
+	}
+
+	if !isIdentifier {
+		search.searchValidatorNames(query)
+		search.searchGraffiti(query)
+	}
+
+	results := search.results
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Type < results[b].Type
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// addResult adds a result to the result list, results with the same link are merged and keep the best score.
+func (search *chainSearch) addResult(result *SearchResult) {
+	if existing := search.links[result.Link]; existing != nil {
+		if result.Score > existing.Score {
+			*existing = *result
+		}
+		return
+	}
+
+	search.links[result.Link] = result
+	search.results = append(search.results, result)
+}
+
+func (search *chainSearch) addBlockResult(resultType SearchResultType, slot phase0.Slot, root []byte, orphaned bool, title string, description string) {
+	link := fmt.Sprintf("/slot/%v", slot)
+	if orphaned {
+		link = fmt.Sprintf("/slot/0x%x", root)
+	}
+
+	search.addResult(&SearchResult{
+		Type:        resultType,
+		Score:       SearchScoreExact,
+		Title:       title,
+		Description: description,
+		Link:        link,
+		Orphaned:    orphaned,
+	})
+}
+
+func (search *chainSearch) searchNumber(number uint64) {
+	indexer := search.bs.beaconIndexer
+	chainState := search.bs.consensusPool.GetChainState()
+
+	// slots & execution blocks from the block cache
+	for _, block := range indexer.GetBlocksBySlot(phase0.Slot(number)) {
+		search.addBlockResult(SearchResultSlot, block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil), fmt.Sprintf("Slot %v", block.Slot), fmt.Sprintf("Block 0x%x", block.Root[:]))
+	}
+	for _, block := range indexer.GetBlocksByExecutionBlockNumber(number) {
+		if blockIndex := block.GetBlockIndex(); blockIndex != nil {
+			search.addBlockResult(SearchResultExecBlock, block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil), fmt.Sprintf("Execution Block %v", blockIndex.ExecutionNumber), fmt.Sprintf("Slot %v, Hash 0x%x", block.Slot, blockIndex.ExecutionHash[:]))
+		}
+	}
+
+	// finalized slots & execution blocks from the db
+	for _, slot := range db.WithContext(search.ctx).SearchSlotsByNumber(number, uint32(search.limit)) {
+		orphaned := slot.Status == dbtypes.Orphaned
+		if slot.Slot == number {
+			search.addBlockResult(SearchResultSlot, phase0.Slot(slot.Slot), slot.Root, orphaned, fmt.Sprintf("Slot %v", slot.Slot), fmt.Sprintf("Block 0x%x", slot.Root))
+		}
+		if slot.ExecNumber != nil && *slot.ExecNumber == number {
+			search.addBlockResult(SearchResultExecBlock, phase0.Slot(slot.Slot), slot.Root, orphaned, fmt.Sprintf("Execution Block %v", number), fmt.Sprintf("Slot %v, Hash 0x%x", slot.Slot, slot.ExecHash))
+		}
+	}
+
+	// slots without a block (e.g. missed slots)
+	if phase0.Slot(number) <= chainState.CurrentSlot() {
+		search.addResult(&SearchResult{
+			Type:        SearchResultSlot,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Slot %v", number),
+			Description: fmt.Sprintf("Epoch %v", chainState.EpochOfSlot(phase0.Slot(number))),
+			Link:        fmt.Sprintf("/slot/%v", number),
+		})
+	}
+
+	if phase0.Epoch(number) <= chainState.CurrentEpoch() {
+		search.addResult(&SearchResult{
+			Type:        SearchResultEpoch,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Epoch %v", number),
+			Description: fmt.Sprintf("Slots %v - %v", chainState.EpochToSlot(phase0.Epoch(number)), chainState.EpochToSlot(phase0.Epoch(number)+1)-1),
+			Link:        fmt.Sprintf("/epoch/%v", number),
+		})
+	}
+
+	if number < uint64(len(search.bs.GetCachedValidatorSet())) {
+		search.addResult(&SearchResult{
+			Type:        SearchResultValidator,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Validator %v", number),
+			Description: search.bs.GetValidatorName(number),
+			Link:        fmt.Sprintf("/validator/%v", number),
+		})
+	}
+}
+
+func (search *chainSearch) searchHash(hash []byte) {
+	indexer := search.bs.beaconIndexer
+
+	// unfinalized blocks from the block cache
+	if block := indexer.GetBlockByRoot(phase0.Root(hash)); block != nil {
+		search.addBlockResult(SearchResultBlockRoot, block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil), fmt.Sprintf("Block 0x%x", block.Root[:]), fmt.Sprintf("Slot %v", block.Slot))
+	}
+	if block := indexer.GetBlockByStateRoot(phase0.Root(hash)); block != nil {
+		search.addBlockResult(SearchResultStateRoot, block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil), fmt.Sprintf("State 0x%x", hash), fmt.Sprintf("Slot %v, Block 0x%x", block.Slot, block.Root[:]))
+	}
+	search.addExecBlockResults(indexer.GetBlocksByExecutionBlockHash(phase0.Hash32(hash)), hash)
+
+	// finalized blocks from the db
+	for _, slot := range db.WithContext(search.ctx).SearchSlotsByHash(hash, uint32(search.limit)) {
+		orphaned := slot.Status == dbtypes.Orphaned
+		if bytes.Equal(slot.Root, hash) {
+			search.addBlockResult(SearchResultBlockRoot, phase0.Slot(slot.Slot), slot.Root, orphaned, fmt.Sprintf("Block 0x%x", slot.Root), fmt.Sprintf("Slot %v", slot.Slot))
+		}
+		if bytes.Equal(slot.StateRoot, hash) {
+			search.addBlockResult(SearchResultStateRoot, phase0.Slot(slot.Slot), slot.Root, orphaned, fmt.Sprintf("State 0x%x", hash), fmt.Sprintf("Slot %v, Block 0x%x", slot.Slot, slot.Root))
+		}
+		if bytes.Equal(slot.ExecHash, hash) {
+			search.addBlockResult(SearchResultExecBlock, phase0.Slot(slot.Slot), slot.Root, orphaned, fmt.Sprintf("Execution Block 0x%x", hash), fmt.Sprintf("Slot %v", slot.Slot))
+		}
+	}
+
+	if len(search.results) == 0 {
+		search.searchTransaction(hash)
+	}
+}
+
+func (search *chainSearch) addExecBlockResults(blocks []*beacon.Block, hash []byte) {
+	indexer := search.bs.beaconIndexer
+	for _, block := range blocks {
+		search.addBlockResult(SearchResultExecBlock, block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil), fmt.Sprintf("Execution Block 0x%x", hash), fmt.Sprintf("Slot %v", block.Slot))
+	}
+}
+
+// searchTransaction resolves a transaction hash to the block including it via the execution clients.
+// transactions are not indexed, so this lookup is only done if the hash didn't match any block.
+func (search *chainSearch) searchTransaction(hash []byte) {
+	var client *execution.Client
+	for _, elClient := range search.bs.GetExecutionClients() {
+		if elClient.GetStatus() == execution.ClientStatusOnline {
+			client = elClient
+			break
+		}
+	}
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(search.ctx, 5*time.Second)
+	defer cancel()
+
+	receipt, err := client.GetRPCClient().GetTransactionReceipt(ctx, common.Hash(hash))
+	if err != nil || receipt == nil {
+		return
+	}
+
+	addResult := func(slot phase0.Slot, root []byte, orphaned bool) {
+		link := fmt.Sprintf("/slot/%v#transactions", slot)
+		if orphaned {
+			link = fmt.Sprintf("/slot/0x%x#transactions", root)
+		}
+
+		search.addResult(&SearchResult{
+			Type:        SearchResultTransaction,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Transaction 0x%x", hash),
+			Description: fmt.Sprintf("Slot %v, Execution Block %v", slot, receipt.BlockNumber),
+			Link:        link,
+			Orphaned:    orphaned,
+		})
+	}
+
+	indexer := search.bs.beaconIndexer
+	for _, block := range indexer.GetBlocksByExecutionBlockHash(phase0.Hash32(receipt.BlockHash)) {
+		addResult(block.Slot, block.Root[:], !indexer.IsCanonicalBlock(block, nil))
+	}
+	for _, slot := range db.WithContext(search.ctx).SearchSlotsByHash(receipt.BlockHash[:], uint32(search.limit)) {
+		if bytes.Equal(slot.ExecHash, receipt.BlockHash[:]) {
+			addResult(phase0.Slot(slot.Slot), slot.Root, slot.Status == dbtypes.Orphaned)
+		}
+	}
+}
+
+func (search *chainSearch) searchPubkey(pubkey []byte) {
+	validator := search.bs.GetCachedValidatorPubkeyMap()[phase0.BLSPubKey(pubkey)]
+	if validator != nil {
+		search.addResult(&SearchResult{
+			Type:        SearchResultValidator,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Validator %v", validator.Index),
+			Description: search.bs.GetValidatorName(uint64(validator.Index)),
+			Link:        fmt.Sprintf("/validator/%v", validator.Index),
+		})
+	}
+
+	_, mevBlockCount, err := db.WithContext(search.ctx).GetMevBlocksFiltered(0, 1, &dbtypes.MevBlockFilter{
+		BuilderPubkey: pubkey,
+	})
+	if err == nil && mevBlockCount > 0 {
+		search.addResult(&SearchResult{
+			Type:        SearchResultBuilder,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Builder 0x%x", pubkey),
+			Description: fmt.Sprintf("%v MEV blocks", mevBlockCount),
+			Link:        fmt.Sprintf("/mev/blocks?f&f.builder=0x%x", pubkey),
+		})
+	}
+
+	_, depositCount, err := db.WithContext(search.ctx).GetDepositTxsFiltered(0, 1, 0, &dbtypes.DepositTxFilter{
+		PublicKey: pubkey,
+	})
+	if err == nil && depositCount > 0 {
+		search.addResult(&SearchResult{
+			Type:        SearchResultDeposit,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Deposits for 0x%x", pubkey),
+			Description: fmt.Sprintf("%v deposit transactions", depositCount),
+			Link:        fmt.Sprintf("/validators/initiated_deposits?f&f.pubkey=0x%x", pubkey),
+		})
+	}
+}
+
+// searchPubkeyPrefix matches the hex query against the beginning of all validator pubkeys.
+func (search *chainSearch) searchPubkeyPrefix(hexQuery string) {
+	prefixBytes, _ := hex.DecodeString(hexQuery[:len(hexQuery)-len(hexQuery)%2])
+	var lastNibble byte
+	hasLastNibble := len(hexQuery)%2 == 1
+	if hasLastNibble {
+		nibble, _ := strconv.ParseUint(hexQuery[len(hexQuery)-1:], 16, 8)
+		lastNibble = byte(nibble)
+	}
+
+	matchCount := 0
+	for _, validator := range search.bs.GetCachedValidatorSet() {
+		pubkey := validator.Validator.PublicKey[:]
+		if !bytes.HasPrefix(pubkey, prefixBytes) {
+			continue
+		}
+		if hasLastNibble && pubkey[len(prefixBytes)]>>4 != lastNibble {
+			continue
+		}
+
+		search.addResult(&SearchResult{
+			Type:        SearchResultValidator,
+			Score:       SearchScorePrefix,
+			Title:       fmt.Sprintf("Validator %v", validator.Index),
+			Description: fmt.Sprintf("0x%x", pubkey),
+			Link:        fmt.Sprintf("/validator/%v", validator.Index),
+		})
+
+		matchCount++
+		if matchCount >= search.limit {
+			break
+		}
+	}
+}
+
+func (search *chainSearch) searchAddress(address []byte) {
+	withdrawalCount := 0
+	for _, validator := range search.bs.GetCachedValidatorSet() {
+		withdrawalCreds := validator.Validator.WithdrawalCredentials
+		if len(withdrawalCreds) == 32 && withdrawalCreds[0] != 0x00 && bytes.Equal(withdrawalCreds[12:], address) {
+			withdrawalCount++
+		}
+	}
+	if withdrawalCount > 0 {
+		search.addResult(&SearchResult{
+			Type:        SearchResultWithdrawalAddress,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Withdrawal Address 0x%x", address),
+			Description: fmt.Sprintf("%v validators", withdrawalCount),
+			Link:        fmt.Sprintf("/validators?f&f.withdrawal=0x%x", address),
+		})
+	}
+
+	_, depositCount, err := db.WithContext(search.ctx).GetDepositTxsFiltered(0, 1, 0, &dbtypes.DepositTxFilter{
+		Address: address,
+	})
+	if err == nil && depositCount > 0 {
+		search.addResult(&SearchResult{
+			Type:        SearchResultDepositAddress,
+			Score:       SearchScoreExact,
+			Title:       fmt.Sprintf("Deposit Address 0x%x", address),
+			Description: fmt.Sprintf("%v deposit transactions", depositCount),
+			Link:        fmt.Sprintf("/validators/initiated_deposits?f&f.address=0x%x", address),
+		})
+	}
+}
+
+func (search *chainSearch) searchValidatorNames(query string) {
+	lowerQuery := strings.ToLower(query)
+
+	// allow one typo per 4 characters for fuzzy matches
+	maxDistance := len(lowerQuery) / 4
+	if search.typeahead {
+		maxDistance = 0
+	}
+
+	for name, count := range search.bs.validatorNames.GetValidatorNameCounts() {
+		lowerName := strings.ToLower(name)
+
+		score := getTextMatchScore(lowerName, lowerQuery)
+		if score == 0 && maxDistance > 0 {
+			// compare with the beginning of the name too, so partially typed names can match
+			distance := getEditDistance(lowerName, lowerQuery)
+			if nameRunes := []rune(lowerName); len(nameRunes) > len(lowerQuery) {
+				if prefixDistance := getEditDistance(string(nameRunes[:len(lowerQuery)]), lowerQuery); prefixDistance < distance {
+					distance = prefixDistance
+				}
+			}
+			if distance <= maxDistance {
+				score = SearchScoreFuzzy - distance
+			}
+		}
+		if score == 0 {
+			continue
+		}
+
+		search.addResult(&SearchResult{
+			Type:        SearchResultValidatorName,
+			Score:       score,
+			Title:       name,
+			Description: fmt.Sprintf("%v validators", count),
+			Link:        fmt.Sprintf("/validators?f&f.name=%v", url.QueryEscape(name)),
+		})
+	}
+}
+
+func (search *chainSearch) searchGraffiti(query string) {
+	lowerQuery := strings.ToLower(query)
+
+	for _, graffiti := range db.WithContext(search.ctx).SearchGraffiti(query, uint32(search.limit)) {
+		score := getTextMatchScore(strings.ToLower(graffiti.Graffiti), lowerQuery)
+		if score == 0 {
+			// graffiti_text matched case-insensitive in the db
+			score = SearchScoreContains
+		}
+
+		search.addResult(&SearchResult{
+			Type:        SearchResultGraffiti,
+			Score:       score,
+			Title:       graffiti.Graffiti,
+			Description: fmt.Sprintf("%v blocks", graffiti.Count),
+			Link:        fmt.Sprintf("/slots/filtered?f&f.missing=1&f.orphaned=1&f.graffiti=%v", url.QueryEscape(graffiti.Graffiti)),
+		})
+	}
+}
+
+// getTextMatchScore returns the score for a lowercased text containing the lowercased query, or 0 if it doesn't.
+func getTextMatchScore(text string, query string) int {
+	switch {
+	case text == query:
+		return SearchScoreTextExact
+	case strings.HasPrefix(text, query):
+		return SearchScorePrefix
+	case strings.Contains(text, query):
+		return SearchScoreContains
+	}
+	return 0
+}
+
+// getEditDistance returns the levenshtein distance between both strings.
+func getEditDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prevRow := make([]int, len(rb)+1)
+	currRow := make([]int, len(rb)+1)
+	for j := range prevRow {
+		prevRow[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		currRow[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			currRow[j] = min(prevRow[j]+1, currRow[j-1]+1, prevRow[j-1]+cost)
+		}
+		prevRow, currRow = currRow, prevRow
+	}
+
+	return prevRow[len(rb)]
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func newTestValidatorNames(names map[string]string) *ValidatorNames {
+	vn := NewValidatorNames(nil)
+	vn.namesByIndex = map[uint64]*validatorNameEntry{}
+	vn.namesByWithdrawal = map[common.Address]*validatorNameEntry{}
+	vn.namesByDepositOrigin = map[common.Address]*validatorNameEntry{}
+	vn.namesByDepositTarget = map[common.Address]*validatorNameEntry{}
+	vn.parseNamesMap(names)
+	vn.updateNameCounts()
+
+	return vn
+}
+
+func TestValidatorNameCountsCached(t *testing.T) {
+	vn := newTestValidatorNames(map[string]string{
+		"1-3": "Lighthouse Team",
+		"5-5": "Nimbus Team",
+	})
+
+	// the cached counts stay available while the names are locked by the updater
+	vn.namesMutex.Lock()
+	nameCounts := vn.GetValidatorNameCounts()
+	vn.namesMutex.Unlock()
+
+	if nameCounts["Lighthouse Team"] != 3 || nameCounts["Nimbus Team"] != 1 {
+		t.Errorf("unexpected name counts: %v", nameCounts)
+	}
+}
+
+func TestSearchValidatorNamesTypeahead(t *testing.T) {
+	bs := &ChainService{
+		validatorNames: newTestValidatorNames(map[string]string{
+			"5-5": "Nimbus Team",
+		}),
+	}
+
+	tests := []struct {
+		query     string
+		typeahead bool
+		expected  int
+	}{
+		{"nimbus", false, 1},
+		{"nimbus", true, 1},
+		{"nimbys team", false, 1},
+		{"nimbys team", true, 0},
+	}
+
+	for _, test := range tests {
+		search := &chainSearch{
+			bs:        bs,
+			ctx:       context.Background(),
+			limit:     10,
+			typeahead: test.typeahead,
+			links:     map[string]*SearchResult{},
+		}
+		search.searchValidatorNames(test.query)
+
+		if len(search.results) != test.expected {
+			t.Errorf("query %v (typeahead: %v): expected %v results, got %v", test.query, test.typeahead, test.expected, len(search.results))
+		}
+	}
+}
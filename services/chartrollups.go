@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ChartRollupService aggregates the finalized epochs into hourly & daily rollups for the network charts.
+// the rollups are built one day at a time, so charts over months of data only need to read a few hundred rows.
+type ChartRollupService struct {
+	logger logrus.FieldLogger
+}
+
+var GlobalChartRollupService *ChartRollupService
+
+var errChartRollupsInvalidated = errors.New("chart rollups have been invalidated")
+
+// StartChartRollupService is used to start the global chart rollup service
+func StartChartRollupService(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalChartRollupService != nil {
+		return nil
+	}
+
+	GlobalChartRollupService = &ChartRollupService{
+		logger: logger.WithField("service", "chart-rollups"),
+	}
+
+	go GlobalChartRollupService.runRollupLoop(ctx)
+	go GlobalChartRollupService.runBlobCountBackfill(ctx)
+	return nil
+}
+
+// IsRollupPending checks if there are synchronized epochs that have not been aggregated yet.
+func (crs *ChartRollupService) IsRollupPending() bool {
+	syncState := dbtypes.IndexerSyncState{}
+	db.GetExplorerState("indexer.syncstate", &syncState)
+
+	rollupState := dbtypes.ChartRollupState{}
+	db.GetExplorerState(db.ChartRollupStateKey, &rollupState)
+
+	// the latest day is rolled up again with every finalized epoch, so only report larger gaps
+	chainState := GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return false
+	}
+	epochsPerDay := uint64(24*time.Hour) / uint64(specs.SecondsPerSlot*time.Duration(specs.SlotsPerEpoch))
+	return rollupState.Epoch+epochsPerDay < syncState.Epoch
+}
+
+func (crs *ChartRollupService) runRollupLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("ChartRollupService.runRollupLoop")
+
+	for {
+		if err := crs.updateRollups(ctx); err != nil {
+			crs.logger.Warnf("chart rollup failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(1 * time.Minute):
+		}
+	}
+}
+
+// updateRollups aggregates all synchronized epochs that have not been rolled up yet.
+func (crs *ChartRollupService) updateRollups(ctx context.Context) error {
+	chainState := GlobalBeaconService.GetChainState()
+	if chainState.GetSpecs() == nil {
+		return nil
+	}
+
+	// epochs before the sync state epoch are persisted in the db
+	syncState := dbtypes.IndexerSyncState{}
+	db.GetExplorerState("indexer.syncstate", &syncState)
+
+	rollupState := dbtypes.ChartRollupState{}
+	db.GetExplorerState(db.ChartRollupStateKey, &rollupState)
+
+	rolledUpDays := 0
+	for rollupState.Epoch < syncState.Epoch && ctx.Err() == nil {
+		nextEpoch, rollupCount, err := crs.rollupDay(chainState, phase0.Epoch(rollupState.Epoch), phase0.Epoch(syncState.Epoch-1))
+		if errors.Is(err, errChartRollupsInvalidated) {
+			// an already rolled up epoch has been rewritten, continue from the rewound state
+			db.GetExplorerState(db.ChartRollupStateKey, &rollupState)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		rollupState.Epoch = uint64(nextEpoch)
+		if rollupCount == 0 {
+			continue
+		}
+		rolledUpDays++
+
+		// keep the load on the db low
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if rolledUpDays > 1 {
+		crs.logger.Infof("rolled up %v days of epochs (next epoch: %v)", rolledUpDays, rollupState.Epoch)
+	}
+
+	return nil
+}
+
+// rollupDay rebuilds the daily rollup & the hourly rollups of the day that contains the given epoch.
+// returns the first epoch of the next day, or the epoch after maxEpoch if the day is not complete yet.
+func (crs *ChartRollupService) rollupDay(chainState *consensus.ChainState, epoch phase0.Epoch, maxEpoch phase0.Epoch) (phase0.Epoch, int, error) {
+	dayTime := getChartRollupTime(chainState.EpochToTime(epoch), dbtypes.ChartRollupPeriodDaily)
+	firstEpoch := getFirstEpochAfterTime(chainState, time.Unix(int64(dayTime), 0))
+	lastEpoch := getFirstEpochAfterTime(chainState, time.Unix(int64(dayTime+uint64(dbtypes.ChartRollupPeriodDaily)), 0)) - 1
+	if lastEpoch > maxEpoch {
+		lastEpoch = maxEpoch
+	}
+
+	dbEpochs := db.GetEpochs(uint64(lastEpoch), uint32(lastEpoch-firstEpoch+1))
+	if dbEpochs == nil {
+		return 0, 0, fmt.Errorf("failed loading epochs %v - %v", firstEpoch, lastEpoch)
+	}
+
+	dailyRollup := &chartRollupAggregator{}
+	hourlyRollups := map[uint64]*chartRollupAggregator{}
+	hourlyTimes := []uint64{}
+
+	// epochs are returned in descending order
+	for i := len(dbEpochs) - 1; i >= 0; i-- {
+		dbEpoch := dbEpochs[i]
+		if dbEpoch.Epoch < uint64(firstEpoch) {
+			continue
+		}
+
+		hourTime := getChartRollupTime(chainState.EpochToTime(phase0.Epoch(dbEpoch.Epoch)), dbtypes.ChartRollupPeriodHourly)
+		hourlyRollup := hourlyRollups[hourTime]
+		if hourlyRollup == nil {
+			hourlyRollup = &chartRollupAggregator{}
+			hourlyRollups[hourTime] = hourlyRollup
+			hourlyTimes = append(hourlyTimes, hourTime)
+		}
+
+		dailyRollup.addEpoch(dbEpoch)
+		hourlyRollup.addEpoch(dbEpoch)
+	}
+
+	rollups := []*dbtypes.ChartRollup{}
+	if dailyRollup.epochCount > 0 {
+		rollups = append(rollups, dailyRollup.getRollup(dbtypes.ChartRollupPeriodDaily, dayTime))
+	}
+	for _, hourTime := range hourlyTimes {
+		rollups = append(rollups, hourlyRollups[hourTime].getRollup(dbtypes.ChartRollupPeriodHourly, hourTime))
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		// the indexer rewinds the state when it rewrites a rolled up epoch, don't skip over these epochs
+		rollupState := dbtypes.ChartRollupState{}
+		if _, err := db.GetExplorerStateForUpdate(db.ChartRollupStateKey, &rollupState, tx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if rollupState.Epoch != uint64(epoch) {
+			return errChartRollupsInvalidated
+		}
+
+		if err := db.InsertChartRollups(rollups, tx); err != nil {
+			return err
+		}
+
+		return db.SetExplorerState(db.ChartRollupStateKey, &dbtypes.ChartRollupState{
+			Epoch: uint64(lastEpoch + 1),
+		}, tx)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return lastEpoch + 1, len(rollups), nil
+}
+
+// getChartRollupTime returns the start time of the rollup bucket that contains the given time.
+func getChartRollupTime(t time.Time, period uint32) uint64 {
+	unixTime := uint64(t.Unix())
+	return unixTime - unixTime%uint64(period)
+}
+
+// getFirstEpochAfterTime returns the first epoch that starts at or after the given time.
+func getFirstEpochAfterTime(chainState *consensus.ChainState, t time.Time) phase0.Epoch {
+	epoch := chainState.EpochOfSlot(chainState.TimeToSlot(t))
+	if chainState.EpochToTime(epoch).Before(t) {
+		epoch++
+	}
+	return epoch
+}
+
+type chartRollupAggregator struct {
+	firstEpoch          uint64
+	lastEpoch           uint64
+	epochCount          uint32
+	validatorCount      uint64
+	validatorBalance    uint64
+	participationEpochs uint32
+	targetParticipation float64
+	headParticipation   float64
+	totalParticipation  float64
+	syncEpochs          uint32
+	syncParticipation   float64
+	blockCount          uint32
+	orphanedCount       uint32
+	depositCount        uint32
+	exitCount           uint32
+	blobCount           uint32
+}
+
+// addEpoch adds an epoch to the aggregation, epochs need to be added in ascending order.
+func (agg *chartRollupAggregator) addEpoch(epoch *dbtypes.Epoch) {
+	if agg.epochCount == 0 {
+		agg.firstEpoch = epoch.Epoch
+	}
+	agg.lastEpoch = epoch.Epoch
+	agg.epochCount++
+
+	// epochs without epoch stats have no validator set & participation values
+	if epoch.Eligible > 0 {
+		agg.validatorCount = epoch.ValidatorCount
+		agg.validatorBalance = epoch.ValidatorBalance
+		agg.participationEpochs++
+		agg.targetParticipation += float64(epoch.VotedTarget) / float64(epoch.Eligible)
+		agg.headParticipation += float64(epoch.VotedHead) / float64(epoch.Eligible)
+		agg.totalParticipation += float64(epoch.VotedTotal) / float64(epoch.Eligible)
+	}
+	if epoch.SyncParticipation > 0 {
+		agg.syncEpochs++
+		agg.syncParticipation += float64(epoch.SyncParticipation)
+	}
+
+	agg.blockCount += uint32(epoch.BlockCount)
+	agg.orphanedCount += uint32(epoch.OrphanedCount)
+	agg.depositCount += uint32(epoch.DepositCount)
+	agg.exitCount += uint32(epoch.ExitCount)
+	agg.blobCount += uint32(epoch.BlobCount)
+}
+
+func (agg *chartRollupAggregator) getRollup(period uint32, bucketTime uint64) *dbtypes.ChartRollup {
+	rollup := &dbtypes.ChartRollup{
+		Period:           period,
+		Time:             bucketTime,
+		FirstEpoch:       agg.firstEpoch,
+		LastEpoch:        agg.lastEpoch,
+		EpochCount:       agg.epochCount,
+		ValidatorCount:   agg.validatorCount,
+		ValidatorBalance: agg.validatorBalance,
+		BlockCount:       agg.blockCount,
+		OrphanedCount:    agg.orphanedCount,
+		DepositCount:     agg.depositCount,
+		ExitCount:        agg.exitCount,
+		BlobCount:        agg.blobCount,
+	}
+	if agg.participationEpochs > 0 {
+		rollup.TargetParticipation = float32(agg.targetParticipation / float64(agg.participationEpochs))
+		rollup.HeadParticipation = float32(agg.headParticipation / float64(agg.participationEpochs))
+		rollup.TotalParticipation = float32(agg.totalParticipation / float64(agg.participationEpochs))
+	}
+	if agg.syncEpochs > 0 {
+		rollup.SyncParticipation = float32(agg.syncParticipation / float64(agg.syncEpochs))
+	}
+
+	return rollup
+}
+
+const chartRollupBlobBackfillStateKey = "chartrollups.blobbackfill"
+
+// runBlobCountBackfill fills the blob counts of the epochs that have been persisted before the blob count was tracked.
+// the backfill range is recorded by the schema migration that added the blob count column.
+func (crs *ChartRollupService) runBlobCountBackfill(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("ChartRollupService.runBlobCountBackfill")
+
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{}
+	if _, err := db.GetExplorerState(chartRollupBlobBackfillStateKey, backfillState); err != nil || backfillState.NextEpoch >= backfillState.EndEpoch {
+		return
+	}
+
+	crs.logger.Infof("backfilling blob counts of epochs %v - %v", backfillState.NextEpoch, backfillState.EndEpoch-1)
+
+	for ctx.Err() == nil && backfillState.NextEpoch < backfillState.EndEpoch {
+		chainState := GlobalBeaconService.GetChainState()
+		specs := chainState.GetSpecs()
+		if specs == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+			}
+			continue
+		}
+
+		err := crs.backfillBlobCounts(backfillState, specs.DenebForkEpoch, 10, func(epoch uint64) (uint64, error) {
+			return crs.loadEpochBlobCount(ctx, chainState, phase0.Epoch(epoch))
+		})
+		if err != nil {
+			crs.logger.Warnf("blob count backfill failed: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(1 * time.Minute):
+			}
+			continue
+		}
+
+		// keep the load on the db & beacon nodes low
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if backfillState.NextEpoch >= backfillState.EndEpoch {
+		crs.logger.Infof("blob count backfill complete")
+	}
+}
+
+// backfillBlobCounts fills the blob counts of the next batch of epochs and rewinds the rollups of these epochs.
+// epochs before the deneb fork have no blobs, so they are skipped.
+func (crs *ChartRollupService) backfillBlobCounts(backfillState *dbtypes.ChartRollupBlobBackfillState, denebEpoch *uint64, batchSize uint64, loadBlobCount func(epoch uint64) (uint64, error)) error {
+	firstEpoch := backfillState.NextEpoch
+	if denebEpoch == nil || *denebEpoch >= backfillState.EndEpoch {
+		firstEpoch = backfillState.EndEpoch
+	} else if firstEpoch < *denebEpoch {
+		firstEpoch = *denebEpoch
+	}
+
+	nextEpoch := firstEpoch + batchSize
+	if nextEpoch > backfillState.EndEpoch {
+		nextEpoch = backfillState.EndEpoch
+	}
+
+	dbEpochs := []*dbtypes.Epoch{}
+	if firstEpoch < nextEpoch {
+		for _, dbEpoch := range db.GetEpochs(nextEpoch-1, uint32(nextEpoch-firstEpoch)) {
+			if dbEpoch.Epoch < firstEpoch {
+				continue
+			}
+
+			blobCount, err := loadBlobCount(dbEpoch.Epoch)
+			if err != nil {
+				return fmt.Errorf("failed loading blob count of epoch %v: %v", dbEpoch.Epoch, err)
+			}
+
+			dbEpoch.BlobCount = blobCount
+			dbEpochs = append(dbEpochs, dbEpoch)
+		}
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		for _, dbEpoch := range dbEpochs {
+			if err := db.InsertEpoch(dbEpoch, tx); err != nil {
+				return err
+			}
+		}
+
+		if len(dbEpochs) > 0 {
+			if err := db.InvalidateChartRollups(firstEpoch, tx); err != nil {
+				return err
+			}
+		}
+
+		return db.SetExplorerState(chartRollupBlobBackfillStateKey, &dbtypes.ChartRollupBlobBackfillState{
+			NextEpoch: nextEpoch,
+			EndEpoch:  backfillState.EndEpoch,
+		}, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	backfillState.NextEpoch = nextEpoch
+	return nil
+}
+
+// loadEpochBlobCount counts the blobs of the canonical blocks of an epoch.
+func (crs *ChartRollupService) loadEpochBlobCount(ctx context.Context, chainState *consensus.ChainState, epoch phase0.Epoch) (uint64, error) {
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := chainState.EpochStartSlot(epoch+1) - 1
+
+	slots := db.WithContext(ctx).GetSlotsRange(uint64(lastSlot), uint64(firstSlot), false, false)
+	if slots == nil {
+		return 0, fmt.Errorf("failed loading blocks of epoch %v", epoch)
+	}
+
+	blobCount := uint64(0)
+	for _, slot := range slots {
+		if slot.Block == nil {
+			continue
+		}
+
+		blockData, err := GlobalBeaconService.GetSlotDetailsByBlockroot(ctx, phase0.Root(slot.Block.Root))
+		if err != nil {
+			return 0, err
+		}
+		if blockData == nil || blockData.Block == nil {
+			return 0, fmt.Errorf("block %v not found", slot.Slot)
+		}
+
+		commitments, err := blockData.Block.BlobKZGCommitments()
+		if err != nil {
+			return 0, err
+		}
+		blobCount += uint64(len(commitments))
+	}
+
+	return blobCount, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/db/dbtest"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestGetChartRollupTime(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		period   uint32
+		expected uint64
+	}{
+		{time.Unix(7199, 0), dbtypes.ChartRollupPeriodHourly, 3600},
+		{time.Unix(7200, 0), dbtypes.ChartRollupPeriodHourly, 7200},
+		{time.Unix(86399, 0), dbtypes.ChartRollupPeriodDaily, 0},
+		{time.Date(2024, 3, 13, 13, 55, 35, 0, time.UTC), dbtypes.ChartRollupPeriodDaily, uint64(time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC).Unix())},
+		{time.Date(2024, 3, 13, 13, 55, 35, 0, time.UTC), dbtypes.ChartRollupPeriodHourly, uint64(time.Date(2024, 3, 13, 13, 0, 0, 0, time.UTC).Unix())},
+	}
+
+	for _, test := range tests {
+		if bucketTime := getChartRollupTime(test.time, test.period); bucketTime != test.expected {
+			t.Errorf("bucket of %v (period %v): expected %v, got %v", test.time.Unix(), test.period, test.expected, bucketTime)
+		}
+	}
+}
+
+func TestChartRollupAggregator(t *testing.T) {
+	agg := &chartRollupAggregator{}
+	agg.addEpoch(&dbtypes.Epoch{Epoch: 10, ValidatorCount: 100, ValidatorBalance: 1000, Eligible: 100, VotedTarget: 100, VotedHead: 80, VotedTotal: 100, BlockCount: 30, OrphanedCount: 1, DepositCount: 2, BlobCount: 12, SyncParticipation: 0.9})
+	// epoch without epoch stats, it only counts for the block based values
+	agg.addEpoch(&dbtypes.Epoch{Epoch: 11, BlockCount: 31, ExitCount: 1, BlobCount: 6})
+	agg.addEpoch(&dbtypes.Epoch{Epoch: 12, ValidatorCount: 101, ValidatorBalance: 1010, Eligible: 200, VotedTarget: 100, VotedHead: 100, VotedTotal: 150, BlockCount: 32, SyncParticipation: 0.7})
+
+	rollup := agg.getRollup(dbtypes.ChartRollupPeriodHourly, 3600)
+
+	if rollup.Period != dbtypes.ChartRollupPeriodHourly || rollup.Time != 3600 || rollup.FirstEpoch != 10 || rollup.LastEpoch != 12 || rollup.EpochCount != 3 {
+		t.Errorf("unexpected rollup range: %+v", rollup)
+	}
+	if rollup.ValidatorCount != 101 || rollup.ValidatorBalance != 1010 {
+		t.Errorf("expected the validator set of the last epoch, got %v / %v", rollup.ValidatorCount, rollup.ValidatorBalance)
+	}
+	if rollup.BlockCount != 93 || rollup.OrphanedCount != 1 || rollup.DepositCount != 2 || rollup.ExitCount != 1 || rollup.BlobCount != 18 {
+		t.Errorf("unexpected rollup counts: %+v", rollup)
+	}
+
+	expectedParticipation := map[string][2]float64{
+		"target": {float64(rollup.TargetParticipation), 0.75},
+		"head":   {float64(rollup.HeadParticipation), 0.65},
+		"total":  {float64(rollup.TotalParticipation), 0.875},
+		"sync":   {float64(rollup.SyncParticipation), 0.8},
+	}
+	for name, values := range expectedParticipation {
+		if math.Abs(values[0]-values[1]) > 1e-6 {
+			t.Errorf("expected %v participation %v, got %v", name, values[1], values[0])
+		}
+	}
+
+	emptyRollup := (&chartRollupAggregator{}).getRollup(dbtypes.ChartRollupPeriodDaily, 0)
+	if emptyRollup.EpochCount != 0 || emptyRollup.TargetParticipation != 0 || emptyRollup.SyncParticipation != 0 {
+		t.Errorf("unexpected empty rollup: %+v", emptyRollup)
+	}
+}
+
+func TestBackfillBlobCounts(t *testing.T) {
+	store := dbtest.NewStore()
+	useTestStore(t, store)
+	crs := &ChartRollupService{
+		logger: newTestLogger(),
+	}
+
+	for epoch := uint64(0); epoch < 30; epoch++ {
+		store.InsertEpoch(&dbtypes.Epoch{Epoch: epoch, BlockCount: 32}, nil)
+	}
+	store.SetExplorerState(db.ChartRollupStateKey, &dbtypes.ChartRollupState{Epoch: 25}, nil)
+
+	loadedEpochs := []uint64{}
+	loadBlobCount := func(epoch uint64) (uint64, error) {
+		loadedEpochs = append(loadedEpochs, epoch)
+		return epoch * 2, nil
+	}
+
+	denebEpoch := uint64(12)
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{NextEpoch: 0, EndEpoch: 28}
+	for backfillState.NextEpoch < backfillState.EndEpoch {
+		if err := crs.backfillBlobCounts(backfillState, &denebEpoch, 10, loadBlobCount); err != nil {
+			t.Fatalf("backfill failed: %v", err)
+		}
+	}
+
+	// epochs before deneb are skipped, the first batch starts at the fork
+	sort.Slice(loadedEpochs, func(i, j int) bool {
+		return loadedEpochs[i] < loadedEpochs[j]
+	})
+	if len(loadedEpochs) != 16 || loadedEpochs[0] != 12 || loadedEpochs[15] != 27 {
+		t.Errorf("expected epochs 12 - 27 to be loaded, got %v", loadedEpochs)
+	}
+	for _, epoch := range store.GetEpochs(29, 30) {
+		expected := uint64(0)
+		if epoch.Epoch >= 12 && epoch.Epoch < 28 {
+			expected = epoch.Epoch * 2
+		}
+		if epoch.BlobCount != expected || epoch.BlockCount != 32 {
+			t.Errorf("epoch %v: expected %v blobs, got %v (blocks: %v)", epoch.Epoch, expected, epoch.BlobCount, epoch.BlockCount)
+		}
+	}
+
+	// the rollups of the backfilled epochs are rebuilt
+	rollupState := &dbtypes.ChartRollupState{}
+	store.GetExplorerState(db.ChartRollupStateKey, rollupState)
+	if rollupState.Epoch != 12 {
+		t.Errorf("expected the rollups to be rewound to epoch 12, got %v", rollupState.Epoch)
+	}
+
+	persistedState := &dbtypes.ChartRollupBlobBackfillState{}
+	store.GetExplorerState(chartRollupBlobBackfillStateKey, persistedState)
+	if persistedState.NextEpoch != 28 || persistedState.EndEpoch != 28 {
+		t.Errorf("expected the backfill to be completed, got %+v", persistedState)
+	}
+}
+
+func TestBackfillBlobCountsBeforeDeneb(t *testing.T) {
+	store := dbtest.NewStore()
+	useTestStore(t, store)
+	crs := &ChartRollupService{
+		logger: newTestLogger(),
+	}
+
+	loadBlobCount := func(epoch uint64) (uint64, error) {
+		return 0, fmt.Errorf("unexpected blob count load of epoch %v", epoch)
+	}
+
+	// no blobs on chains without deneb
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{NextEpoch: 0, EndEpoch: 100}
+	if err := crs.backfillBlobCounts(backfillState, nil, 10, loadBlobCount); err != nil || backfillState.NextEpoch != 100 {
+		t.Errorf("expected the backfill to complete right away, got next epoch %v (err: %v)", backfillState.NextEpoch, err)
+	}
+
+	denebEpoch := uint64(100)
+	backfillState = &dbtypes.ChartRollupBlobBackfillState{NextEpoch: 0, EndEpoch: 100}
+	if err := crs.backfillBlobCounts(backfillState, &denebEpoch, 10, loadBlobCount); err != nil || backfillState.NextEpoch != 100 {
+		t.Errorf("expected the backfill to complete right away, got next epoch %v (err: %v)", backfillState.NextEpoch, err)
+	}
+}
+
+func TestBackfillBlobCountsKeepsStateOnError(t *testing.T) {
+	store := dbtest.NewStore()
+	useTestStore(t, store)
+	crs := &ChartRollupService{
+		logger: newTestLogger(),
+	}
+
+	store.InsertEpoch(&dbtypes.Epoch{Epoch: 5}, nil)
+
+	denebEpoch := uint64(0)
+	backfillState := &dbtypes.ChartRollupBlobBackfillState{NextEpoch: 0, EndEpoch: 10}
+	err := crs.backfillBlobCounts(backfillState, &denebEpoch, 10, func(epoch uint64) (uint64, error) {
+		return 0, fmt.Errorf("beacon node unavailable")
+	})
+	if err == nil || backfillState.NextEpoch != 0 {
+		t.Errorf("expected the failed batch to be retried, got next epoch %v (err: %v)", backfillState.NextEpoch, err)
+	}
+}
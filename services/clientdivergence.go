@@ -0,0 +1,448 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ConsensusDivergenceChecker continuously compares the view of all consensus clients and reports disagreements.
+type ConsensusDivergenceChecker struct {
+	logger         logrus.FieldLogger
+	mutex          sync.RWMutex
+	report         *ConsensusDivergenceReport
+	firstSeen      map[string]time.Time
+	lastStateEpoch phase0.Epoch
+	stateResults   []*ConsensusDivergence
+}
+
+// ConsensusDivergenceReport is the result of the latest comparison round.
+type ConsensusDivergenceReport struct {
+	CheckTime   time.Time
+	Slot        phase0.Slot
+	StateEpoch  phase0.Epoch
+	StateRoot   phase0.Root
+	Clients     int
+	Divergences []*ConsensusDivergence
+}
+
+// ConsensusDivergence describes a single disagreement between the majority and one or more minority client groups.
+type ConsensusDivergence struct {
+	Check           string
+	FirstSlot       phase0.Slot
+	FirstSeen       time.Time
+	MajorityValue   string
+	MajorityClients []string
+	Minorities      []*ConsensusDivergenceMinority
+}
+
+type ConsensusDivergenceMinority struct {
+	Value   string
+	Clients []string
+}
+
+var GlobalConsensusDivergence *ConsensusDivergenceChecker
+
+// StartConsensusDivergenceChecker is used to start the global consensus client divergence checker
+func StartConsensusDivergenceChecker(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalConsensusDivergence != nil {
+		return nil
+	}
+
+	GlobalConsensusDivergence = &ConsensusDivergenceChecker{
+		logger:    logger.WithField("service", "cl-compare"),
+		firstSeen: map[string]time.Time{},
+	}
+
+	go GlobalConsensusDivergence.runCheckLoop(ctx)
+	return nil
+}
+
+// GetReport returns the result of the latest comparison round.
+func (dc *ConsensusDivergenceChecker) GetReport() *ConsensusDivergenceReport {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.report
+}
+
+func (dc *ConsensusDivergenceChecker) runCheckLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("ConsensusDivergenceChecker.runCheckLoop")
+
+	slotSubscription := GlobalBeaconService.consensusPool.SubscribeWallclockSlotEvent(1)
+	defer slotSubscription.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-slotSubscription.Channel():
+		}
+
+		// give clients some time to process the block of the previous slot
+		chainState := GlobalBeaconService.GetChainState()
+		time.Sleep(chainState.GetSpecs().SecondsPerSlot / 3)
+
+		err := dc.runCheck(ctx)
+		if err != nil {
+			dc.logger.Warnf("consensus client comparison failed: %v", err)
+		}
+	}
+}
+
+func (dc *ConsensusDivergenceChecker) runCheck(ctx context.Context) error {
+	chainState := GlobalBeaconService.GetChainState()
+	indexer := GlobalBeaconService.GetBeaconIndexer()
+	currentSlot := chainState.CurrentSlot()
+	currentEpoch := chainState.EpochOfSlot(currentSlot)
+
+	clients := []*beacon.Client{}
+	for _, client := range indexer.GetAllClients() {
+		if client.GetClient().GetStatus() == consensus.ClientStatusOnline {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) < 2 {
+		return nil
+	}
+
+	report := &ConsensusDivergenceReport{
+		CheckTime:   time.Now(),
+		Slot:        currentSlot,
+		Clients:     len(clients),
+		Divergences: []*ConsensusDivergence{},
+	}
+
+	report.Divergences = append(report.Divergences, dc.checkHeads()...)
+	report.Divergences = append(report.Divergences, dc.checkCheckpoints(clients)...)
+
+	// state checks are more expensive, so they're done once per epoch against the majority head state
+	headForks := GlobalBeaconService.GetConsensusClientForks()
+	if len(headForks) > 0 && (currentEpoch > dc.lastStateEpoch || dc.report == nil) {
+		stateResults, stateRoot, err := dc.checkStateFields(ctx, currentEpoch, headForks[0])
+		if err != nil {
+			dc.logger.Warnf("state comparison for epoch %v failed: %v", currentEpoch, err)
+		} else {
+			dc.lastStateEpoch = currentEpoch
+			dc.stateResults = stateResults
+			report.StateRoot = stateRoot
+		}
+	} else if dc.report != nil {
+		report.StateRoot = dc.report.StateRoot
+	}
+	report.StateEpoch = dc.lastStateEpoch
+	report.Divergences = append(report.Divergences, dc.stateResults...)
+
+	// keep track of the first time each divergence was seen
+	firstSeen := map[string]time.Time{}
+	for _, divergence := range report.Divergences {
+		key := fmt.Sprintf("%v-%v", divergence.Check, divergence.FirstSlot)
+		if seenTime, found := dc.firstSeen[key]; found {
+			divergence.FirstSeen = seenTime
+		} else {
+			divergence.FirstSeen = report.CheckTime
+			minorityClients := []string{}
+			for _, minority := range divergence.Minorities {
+				minorityClients = append(minorityClients, minority.Clients...)
+			}
+			dc.logger.Warnf("detected %v divergence at slot %v (minority clients: %v)", divergence.Check, divergence.FirstSlot, strings.Join(minorityClients, ", "))
+		}
+		firstSeen[key] = divergence.FirstSeen
+	}
+
+	dc.mutex.Lock()
+	dc.report = report
+	dc.firstSeen = firstSeen
+	dc.mutex.Unlock()
+
+	return nil
+}
+
+// checkHeads compares the head forks of all clients and reports the clients that are not following the majority chain.
+func (dc *ConsensusDivergenceChecker) checkHeads() []*ConsensusDivergence {
+	headForks := GlobalBeaconService.GetConsensusClientForks()
+	if len(headForks) < 2 {
+		return nil
+	}
+
+	indexer := GlobalBeaconService.GetBeaconIndexer()
+	majorityFork := headForks[0]
+	divergences := []*ConsensusDivergence{}
+
+	for _, fork := range headForks[1:] {
+		// ignore stale heads of offline clients
+		forkClients := []*beacon.Client{}
+		for _, client := range fork.AllClients {
+			if client.GetClient().GetStatus() == consensus.ClientStatusOnline {
+				forkClients = append(forkClients, client)
+			}
+		}
+		if len(forkClients) == 0 {
+			continue
+		}
+
+		// walk back from the fork head until we hit a block of the majority chain
+		firstSlot := fork.Slot
+		blockRoot := fork.Root
+		for {
+			block := indexer.GetBlockByRoot(blockRoot)
+			if block == nil {
+				break
+			}
+
+			if isInChain, _ := indexer.GetBlockDistance(blockRoot, majorityFork.Root); isInChain {
+				break
+			}
+
+			firstSlot = block.Slot
+			parentRoot := block.GetParentRoot()
+			if parentRoot == nil {
+				break
+			}
+			blockRoot = *parentRoot
+		}
+
+		divergences = append(divergences, &ConsensusDivergence{
+			Check:           "head",
+			FirstSlot:       firstSlot,
+			MajorityValue:   fmt.Sprintf("%v (0x%x)", majorityFork.Slot, majorityFork.Root),
+			MajorityClients: getBeaconClientNames(majorityFork.AllClients),
+			Minorities: []*ConsensusDivergenceMinority{
+				{
+					Value:   fmt.Sprintf("%v (0x%x)", fork.Slot, fork.Root),
+					Clients: getBeaconClientNames(fork.AllClients),
+				},
+			},
+		})
+	}
+
+	return divergences
+}
+
+// checkCheckpoints compares the justified & finalized checkpoints of all clients.
+// clients that are behind are not reported, only clients with a different root for the same epoch.
+func (dc *ConsensusDivergenceChecker) checkCheckpoints(clients []*beacon.Client) []*ConsensusDivergence {
+	chainState := GlobalBeaconService.GetChainState()
+	finalizedValues := map[phase0.Epoch]map[string][]string{}
+	justifiedValues := map[phase0.Epoch]map[string][]string{}
+
+	for _, client := range clients {
+		finalizedEpoch, finalizedRoot, justifiedEpoch, justifiedRoot := client.GetClient().GetFinalityCheckpoint()
+		clientName := client.GetClient().GetName()
+
+		if finalizedValues[finalizedEpoch] == nil {
+			finalizedValues[finalizedEpoch] = map[string][]string{}
+		}
+		finalizedKey := fmt.Sprintf("0x%x", finalizedRoot)
+		finalizedValues[finalizedEpoch][finalizedKey] = append(finalizedValues[finalizedEpoch][finalizedKey], clientName)
+
+		if justifiedValues[justifiedEpoch] == nil {
+			justifiedValues[justifiedEpoch] = map[string][]string{}
+		}
+		justifiedKey := fmt.Sprintf("0x%x", justifiedRoot)
+		justifiedValues[justifiedEpoch][justifiedKey] = append(justifiedValues[justifiedEpoch][justifiedKey], clientName)
+	}
+
+	divergences := []*ConsensusDivergence{}
+	for _, checkpoint := range []struct {
+		name   string
+		values map[phase0.Epoch]map[string][]string
+	}{
+		{"finalized", finalizedValues},
+		{"justified", justifiedValues},
+	} {
+		for epoch, values := range checkpoint.values {
+			if divergence := buildConsensusDivergence(checkpoint.name, chainState.EpochToSlot(epoch), values); divergence != nil {
+				divergences = append(divergences, divergence)
+			}
+		}
+	}
+
+	return divergences
+}
+
+// checkStateFields compares proposer duties, validator count & a sample of validator balances of all clients following the majority fork.
+func (dc *ConsensusDivergenceChecker) checkStateFields(ctx context.Context, epoch phase0.Epoch, majorityFork *ConsensusClientFork) ([]*ConsensusDivergence, phase0.Root, error) {
+	chainState := GlobalBeaconService.GetChainState()
+	indexer := GlobalBeaconService.GetBeaconIndexer()
+
+	headBlock := indexer.GetBlockByRoot(majorityFork.Root)
+	if headBlock == nil {
+		return nil, phase0.Root{}, fmt.Errorf("majority head block 0x%x not found", majorityFork.Root)
+	}
+	headHeader := headBlock.GetHeader()
+	if headHeader == nil {
+		return nil, phase0.Root{}, fmt.Errorf("majority head block header 0x%x not found", majorityFork.Root)
+	}
+	stateRoot := headHeader.Message.StateRoot
+	stateRef := fmt.Sprintf("0x%x", stateRoot)
+
+	// balance sample, evenly spread over the validator set
+	// the sample is extended by a probe window beyond the known validator count to detect validator count mismatches
+	validatorCount := uint64(len(GlobalBeaconService.GetCachedValidatorSet()))
+	sampleSize := utils.Config.ClientCompare.BalanceSampleSize
+	if sampleSize == 0 {
+		sampleSize = 64
+	}
+	sampleIndices := []phase0.ValidatorIndex{}
+	if validatorCount > 0 {
+		step := validatorCount / sampleSize
+		if step == 0 {
+			step = 1
+		}
+		for index := uint64(0); index < validatorCount; index += step {
+			sampleIndices = append(sampleIndices, phase0.ValidatorIndex(index))
+		}
+	}
+	probeStart := uint64(0)
+	if validatorCount > 16 {
+		probeStart = validatorCount - 16
+	}
+	for index := probeStart; index < validatorCount+16; index++ {
+		sampleIndices = append(sampleIndices, phase0.ValidatorIndex(index))
+	}
+
+	dutiesValues := map[string][]string{}
+	countValues := map[string][]string{}
+	balanceValues := map[string][]string{}
+
+	for _, client := range majorityFork.ReadyClients {
+		clientName := client.GetClient().GetName()
+		rpcClient := client.GetClient().GetRPCClient()
+
+		reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		duties, err := rpcClient.GetProposerDuties(reqCtx, epoch)
+		cancel()
+		if err != nil {
+			dc.logger.Debugf("failed loading proposer duties from %v: %v", clientName, err)
+		} else {
+			sort.Slice(duties, func(a, b int) bool {
+				return duties[a].Slot < duties[b].Slot
+			})
+			dutiesStr := make([]string, len(duties))
+			for idx, duty := range duties {
+				dutiesStr[idx] = fmt.Sprintf("%v:%v", duty.Slot, duty.ValidatorIndex)
+			}
+			dutiesKey := strings.Join(dutiesStr, ",")
+			dutiesValues[dutiesKey] = append(dutiesValues[dutiesKey], clientName)
+		}
+
+		reqCtx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		balances, err := rpcClient.GetValidatorBalances(reqCtx, stateRef, sampleIndices)
+		cancel()
+		if err != nil {
+			dc.logger.Debugf("failed loading validator balances from %v: %v", clientName, err)
+			continue
+		}
+
+		maxIndex := uint64(0)
+		balancesStr := []string{}
+		for _, index := range sampleIndices {
+			balance, found := balances[index]
+			if !found {
+				continue
+			}
+			if uint64(index)+1 > maxIndex {
+				maxIndex = uint64(index) + 1
+			}
+			if uint64(index) < validatorCount {
+				balancesStr = append(balancesStr, fmt.Sprintf("%v:%v", index, balance))
+			}
+		}
+
+		countKey := fmt.Sprintf("%v", maxIndex)
+		countValues[countKey] = append(countValues[countKey], clientName)
+
+		balanceKey := strings.Join(balancesStr, ",")
+		balanceValues[balanceKey] = append(balanceValues[balanceKey], clientName)
+	}
+
+	epochSlot := chainState.EpochToSlot(epoch)
+	divergences := []*ConsensusDivergence{}
+	if divergence := buildConsensusDivergence("proposer_duties", epochSlot, dutiesValues); divergence != nil {
+		divergences = append(divergences, divergence)
+	}
+	if divergence := buildConsensusDivergence("validator_count", headBlock.Slot, countValues); divergence != nil {
+		divergences = append(divergences, divergence)
+	}
+	if divergence := buildConsensusDivergence("balances", headBlock.Slot, balanceValues); divergence != nil {
+		// balances lists are too long to display, so reduce them to the differing entries
+		shortenBalanceDivergence(divergence)
+		divergences = append(divergences, divergence)
+	}
+
+	return divergences, stateRoot, nil
+}
+
+// buildConsensusDivergence groups the client values and returns a divergence if not all clients agree.
+func buildConsensusDivergence(check string, slot phase0.Slot, values map[string][]string) *ConsensusDivergence {
+	if len(values) < 2 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		countA := len(values[keys[a]])
+		countB := len(values[keys[b]])
+		if countA != countB {
+			return countA > countB
+		}
+		return keys[a] < keys[b]
+	})
+
+	divergence := &ConsensusDivergence{
+		Check:           check,
+		FirstSlot:       slot,
+		MajorityValue:   keys[0],
+		MajorityClients: values[keys[0]],
+		Minorities:      make([]*ConsensusDivergenceMinority, 0, len(keys)-1),
+	}
+	for _, key := range keys[1:] {
+		divergence.Minorities = append(divergence.Minorities, &ConsensusDivergenceMinority{
+			Value:   key,
+			Clients: values[key],
+		})
+	}
+
+	return divergence
+}
+
+func shortenBalanceDivergence(divergence *ConsensusDivergence) {
+	majorityEntries := strings.Split(divergence.MajorityValue, ",")
+	majoritySet := map[string]bool{}
+	for _, entry := range majorityEntries {
+		majoritySet[entry] = true
+	}
+
+	for _, minority := range divergence.Minorities {
+		differing := []string{}
+		for _, entry := range strings.Split(minority.Value, ",") {
+			if !majoritySet[entry] {
+				differing = append(differing, entry)
+			}
+		}
+		minority.Value = strings.Join(differing, ",")
+	}
+
+	divergence.MajorityValue = fmt.Sprintf("%v sampled balances", len(majorityEntries))
+}
+
+func getBeaconClientNames(clients []*beacon.Client) []string {
+	names := make([]string, len(clients))
+	for idx, client := range clients {
+		names[idx] = client.GetClient().GetName()
+	}
+	return names
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ClientDiversityService classifies the proposer clients of blocks that have been persisted before the
+// client classification was introduced. new blocks are classified & aggregated by the indexer on finalization.
+type ClientDiversityService struct {
+	logger          logrus.FieldLogger
+	backfillPending atomic.Bool
+}
+
+var GlobalClientDiversityService *ClientDiversityService
+
+// StartClientDiversityService is used to start the global client diversity service
+func StartClientDiversityService(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalClientDiversityService != nil {
+		return nil
+	}
+
+	GlobalClientDiversityService = &ClientDiversityService{
+		logger: logger.WithField("service", "client-diversity"),
+	}
+	GlobalClientDiversityService.backfillPending.Store(true)
+
+	go GlobalClientDiversityService.runClassificationBackfill(ctx)
+	return nil
+}
+
+// IsBackfillPending checks if the classification backfill of previously persisted blocks is still running.
+func (cds *ClientDiversityService) IsBackfillPending() bool {
+	if cds == nil {
+		return false
+	}
+	return cds.backfillPending.Load()
+}
+
+func (cds *ClientDiversityService) runClassificationBackfill(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("ClientDiversityService.runClassificationBackfill")
+
+	totalClassified := 0
+	for ctx.Err() == nil {
+		specs := GlobalBeaconService.GetChainState().GetSpecs()
+		if specs == nil {
+			// wait for the chain specs, the aggregates are built per epoch
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+			}
+			continue
+		}
+
+		classified, err := cds.classifySlots(ctx, specs.SlotsPerEpoch, 1000)
+		if err != nil {
+			cds.logger.Warnf("client classification backfill failed: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(1 * time.Minute):
+			}
+			continue
+		}
+
+		totalClassified += classified
+		if classified == 0 {
+			cds.backfillPending.Store(false)
+			break
+		}
+
+		// keep the load on the db low
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if totalClassified > 0 {
+		cds.logger.Infof("client classification backfill complete (%v blocks)", totalClassified)
+	}
+}
+
+// classifySlots classifies the next batch of unclassified blocks and rebuilds the aggregates of the affected epochs.
+func (cds *ClientDiversityService) classifySlots(ctx context.Context, slotsPerEpoch uint64, batchSize uint32) (int, error) {
+	slots, err := db.WithContext(ctx).GetUnclassifiedSlots(batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(slots) == 0 {
+		return 0, nil
+	}
+
+	err = db.RunDBTransaction(func(tx db.Tx) error {
+		for _, slot := range slots {
+			clClient, elClient := utils.DetectProposerClients(slot.GraffitiText, slot.EthBlockExtraText)
+			if err := db.UpdateSlotClients(slot.Slot, slot.Root, clClient, elClient, tx); err != nil {
+				return err
+			}
+		}
+
+		firstEpoch := slots[0].Slot / slotsPerEpoch
+		lastEpoch := slots[len(slots)-1].Slot / slotsPerEpoch
+		return db.UpdateClientDiversity(firstEpoch, lastEpoch, slotsPerEpoch, tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(slots), nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethpandaops/dora/db/dbtest"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+func TestClassifySlots(t *testing.T) {
+	store := dbtest.NewStore()
+	useTestStore(t, store)
+	cds := &ClientDiversityService{
+		logger: newTestLogger(),
+	}
+
+	store.AddUnclassifiedSlots(
+		&dbtypes.UnclassifiedSlot{Slot: 1, GraffitiText: "GEa1b2LHc3d4"},
+		&dbtypes.UnclassifiedSlot{Slot: 2, GraffitiText: "teku", EthBlockExtraText: "reth/v1.0"},
+		&dbtypes.UnclassifiedSlot{Slot: 40},
+	)
+
+	classified, err := cds.classifySlots(context.Background(), 32, 2)
+	if err != nil || classified != 2 {
+		t.Fatalf("expected 2 classified slots, got %v (err: %v)", classified, err)
+	}
+	if clClient, elClient, ok := store.GetSlotClients(1); !ok || clClient != utils.ClClientLighthouse || elClient != utils.ElClientGeth {
+		t.Errorf("unexpected clients of slot 1: %v/%v", clClient, elClient)
+	}
+	if clClient, elClient, ok := store.GetSlotClients(2); !ok || clClient != utils.ClClientTeku || elClient != utils.ElClientReth {
+		t.Errorf("unexpected clients of slot 2: %v/%v", clClient, elClient)
+	}
+	if _, _, ok := store.GetSlotClients(40); ok {
+		t.Errorf("expected slot 40 to be left for the next batch")
+	}
+
+	// blocks without any client hint are classified as unknown, so they are not picked up again
+	if classified, err := cds.classifySlots(context.Background(), 32, 2); err != nil || classified != 1 {
+		t.Fatalf("expected 1 classified slot, got %v (err: %v)", classified, err)
+	}
+	if classified, err := cds.classifySlots(context.Background(), 32, 2); err != nil || classified != 0 {
+		t.Fatalf("expected no remaining slots, got %v (err: %v)", classified, err)
+	}
+	if store.Commits != 2 {
+		t.Errorf("expected 2 transactions, got %v", store.Commits)
+	}
+}
+
+func TestClientDiversityBackfillPending(t *testing.T) {
+	var cds *ClientDiversityService
+	if cds.IsBackfillPending() {
+		t.Errorf("expected no pending backfill without service")
+	}
+
+	cds = &ClientDiversityService{}
+	cds.backfillPending.Store(true)
+	if !cds.IsBackfillPending() {
+		t.Errorf("expected pending backfill")
+	}
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// EndpointDiscoveryProvider is a source of consensus & execution endpoints that may change over time.
+type EndpointDiscoveryProvider interface {
+	GetName() string
+	LoadEndpoints(ctx context.Context) (*types.EndpointsFileConfig, error)
+}
+
+// endpointDiscoverySource periodically loads the endpoints from a provider and keeps the clients in sync with them.
+// only endpoints that have been added by the source are managed by it, endpoints from the main config,
+// the admin api or other sources are left untouched.
+type endpointDiscoverySource struct {
+	chainService       *ChainService
+	logger             logrus.FieldLogger
+	provider           EndpointDiscoveryProvider
+	defaults           *types.EndpointDiscoverySourceConfig
+	interval           time.Duration
+	consensusEndpoints map[string]*types.EndpointConfig
+	executionEndpoints map[string]*types.EndpointConfig
+	consensusSkipped   map[string]*types.EndpointConfig
+	executionSkipped   map[string]*types.EndpointConfig
+}
+
+// initEndpointDiscovery creates the discovery sources for the watched endpoints file & all configured discovery sources.
+func (bs *ChainService) initEndpointDiscovery() {
+	if utils.Config.EndpointsFile.Path != "" {
+		provider := &endpointsFileProvider{
+			filePath: utils.Config.EndpointsFile.Path,
+		}
+		bs.addEndpointDiscoverySource(provider, nil, utils.Config.EndpointsFile.WatchInterval)
+	}
+
+	for idx := range utils.Config.EndpointDiscovery.Sources {
+		sourceConfig := &utils.Config.EndpointDiscovery.Sources[idx]
+
+		var provider EndpointDiscoveryProvider
+		switch sourceConfig.Type {
+		case "inventory":
+			provider = &inventoryEndpointsProvider{
+				name:    sourceConfig.Name,
+				url:     sourceConfig.Url,
+				headers: sourceConfig.Headers,
+			}
+		case "srv":
+			provider = &srvEndpointsProvider{
+				name:            sourceConfig.Name,
+				beaconRecord:    sourceConfig.BeaconRecord,
+				executionRecord: sourceConfig.ExecutionRecord,
+				scheme:          sourceConfig.Scheme,
+			}
+		case "directory":
+			provider = &directoryEndpointsProvider{
+				name: sourceConfig.Name,
+				path: sourceConfig.Path,
+			}
+		default:
+			bs.logger.Errorf("unknown endpoint discovery source type: %v", sourceConfig.Type)
+			continue
+		}
+
+		bs.addEndpointDiscoverySource(provider, sourceConfig, utils.Config.EndpointDiscovery.RefreshInterval)
+	}
+}
+
+func (bs *ChainService) addEndpointDiscoverySource(provider EndpointDiscoveryProvider, defaults *types.EndpointDiscoverySourceConfig, interval time.Duration) {
+	bs.endpointSources = append(bs.endpointSources, &endpointDiscoverySource{
+		chainService:       bs,
+		logger:             bs.logger.WithField("service", "endpoint-discovery").WithField("source", provider.GetName()),
+		provider:           provider,
+		defaults:           defaults,
+		interval:           interval,
+		consensusEndpoints: map[string]*types.EndpointConfig{},
+		executionEndpoints: map[string]*types.EndpointConfig{},
+		consensusSkipped:   map[string]*types.EndpointConfig{},
+		executionSkipped:   map[string]*types.EndpointConfig{},
+	})
+}
+
+func (source *endpointDiscoverySource) runRefreshLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("endpointDiscoverySource.runRefreshLoop")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(source.interval):
+		}
+
+		err := source.refresh(ctx)
+		if err != nil {
+			source.logger.Warnf("failed refreshing endpoints: %v", err)
+		}
+	}
+}
+
+// refresh loads the endpoints from the provider and applies the changes to the client pools.
+// the managed endpoints are left untouched if the provider fails, so temporary outages of the source do not remove clients.
+func (source *endpointDiscoverySource) refresh(ctx context.Context) error {
+	endpoints, err := source.provider.LoadEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	source.logger.Debugf("loaded endpoints (%v consensus, %v execution endpoints)", len(endpoints.BeaconApi), len(endpoints.ExecutionApi))
+
+	source.applyEndpoints(endpoints.BeaconApi, source.consensusEndpoints, source.consensusSkipped, endpointDiscoveryHandlers{
+		add: func(endpoint *types.EndpointConfig) error {
+			_, err := source.chainService.AddConsensusEndpoint(endpoint)
+			return err
+		},
+		remove: source.chainService.RemoveConsensusEndpoint,
+		update: source.chainService.UpdateConsensusEndpoint,
+	})
+	source.applyEndpoints(endpoints.ExecutionApi, source.executionEndpoints, source.executionSkipped, endpointDiscoveryHandlers{
+		add: func(endpoint *types.EndpointConfig) error {
+			_, err := source.chainService.AddExecutionEndpoint(endpoint)
+			return err
+		},
+		remove: source.chainService.RemoveExecutionEndpoint,
+		update: source.chainService.UpdateExecutionEndpoint,
+	})
+
+	return nil
+}
+
+type endpointDiscoveryHandlers struct {
+	add    func(endpoint *types.EndpointConfig) error
+	remove func(name string) error
+	update func(name string, update *EndpointUpdate) error
+}
+
+// applyDefaults applies the per source defaults to a discovered endpoint.
+// settings from the discovered endpoint take precedence over the source defaults.
+func (source *endpointDiscoverySource) applyDefaults(endpoint *types.EndpointConfig) {
+	if source.defaults == nil {
+		return
+	}
+
+	if endpoint.Priority == 0 {
+		endpoint.Priority = source.defaults.Priority
+	}
+	if source.defaults.Archive {
+		endpoint.Archive = true
+	}
+	if source.defaults.SkipValidators {
+		endpoint.SkipValidators = true
+	}
+	if len(source.defaults.Headers) > 0 {
+		headers := map[string]string{}
+		for key, value := range source.defaults.Headers {
+			headers[key] = value
+		}
+		for key, value := range endpoint.Headers {
+			headers[key] = value
+		}
+		endpoint.Headers = headers
+	}
+}
+
+// applyEndpoints reconciles the managed endpoints with the discovered endpoints.
+// endpoints with changed connection settings are re-added, priority & archive changes are applied in place.
+// endpoints that cannot be added (e.g. name collisions with configured endpoints) are skipped and only retried after they changed.
+func (source *endpointDiscoverySource) applyEndpoints(endpoints []types.EndpointConfig, managed map[string]*types.EndpointConfig, skipped map[string]*types.EndpointConfig, handlers endpointDiscoveryHandlers) {
+	discoveredEndpoints := map[string]*types.EndpointConfig{}
+	for idx := range endpoints {
+		endpoint := &endpoints[idx]
+		if endpoint.Url == "" {
+			continue
+		}
+		if endpoint.Name == "" {
+			endpoint.Name = getDiscoveredEndpointName(endpoint.Url, idx)
+		}
+		source.applyDefaults(endpoint)
+		discoveredEndpoints[endpoint.Name] = endpoint
+	}
+
+	// remove endpoints that are no longer discovered or need to be re-added
+	for name, current := range managed {
+		endpoint := discoveredEndpoints[name]
+		if endpoint != nil && isSameEndpointConnection(current, endpoint) {
+			continue
+		}
+
+		err := handlers.remove(name)
+		if err != nil {
+			source.logger.Warnf("could not remove endpoint %v: %v", name, err)
+			continue
+		}
+		delete(managed, name)
+	}
+
+	for name, skippedEndpoint := range skipped {
+		endpoint := discoveredEndpoints[name]
+		if endpoint == nil || !isSameEndpointConnection(skippedEndpoint, endpoint) {
+			delete(skipped, name)
+		}
+	}
+
+	for name, endpoint := range discoveredEndpoints {
+		current := managed[name]
+		if current == nil {
+			if skipped[name] != nil {
+				continue
+			}
+
+			err := handlers.add(endpoint)
+			if err != nil {
+				source.logger.Warnf("could not add endpoint %v, skipping it until it changes: %v", name, err)
+				skipped[name] = endpoint
+				continue
+			}
+			managed[name] = endpoint
+			continue
+		}
+
+		if current.Priority != endpoint.Priority || current.Archive != endpoint.Archive {
+			err := handlers.update(name, &EndpointUpdate{
+				Priority: &endpoint.Priority,
+				Archive:  &endpoint.Archive,
+			})
+			if err != nil {
+				source.logger.Warnf("could not update endpoint %v: %v", name, err)
+				continue
+			}
+			managed[name] = endpoint
+		}
+	}
+}
+
+// getDiscoveredEndpointName returns the default name for a discovered endpoint without name.
+// unlike the configured endpoints, discovered endpoints often run on the same host, so the port is part of the name.
+func getDiscoveredEndpointName(endpointUrl string, idx int) string {
+	url, _ := url.Parse(endpointUrl)
+	if url != nil && url.Host != "" {
+		return url.Host
+	}
+
+	return utils.GetDefaultEndpointName(endpointUrl, idx)
+}
+
+// isSameEndpointConnection checks if two endpoint configs only differ in settings that can be changed at runtime.
+func isSameEndpointConnection(a *types.EndpointConfig, b *types.EndpointConfig) bool {
+	if a.Url != b.Url || a.SkipValidators != b.SkipValidators {
+		return false
+	}
+
+	if len(a.Headers) != len(b.Headers) {
+		return false
+	}
+	for key, value := range a.Headers {
+		if b.Headers[key] != value {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(a.Ssh, b.Ssh)
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// endpointsFileProvider loads the endpoints from the watched endpoints file.
+type endpointsFileProvider struct {
+	filePath string
+}
+
+func (provider *endpointsFileProvider) GetName() string {
+	return "endpoints-file"
+}
+
+func (provider *endpointsFileProvider) LoadEndpoints(ctx context.Context) (*types.EndpointsFileConfig, error) {
+	return loadEndpointsFile(provider.filePath)
+}
+
+func loadEndpointsFile(filePath string) (*types.EndpointsFileConfig, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading endpoints file %v: %v", filePath, err)
+	}
+
+	endpointsFile := &types.EndpointsFileConfig{}
+	err = yaml.Unmarshal(data, endpointsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding endpoints file %v: %v", filePath, err)
+	}
+
+	return endpointsFile, nil
+}
+
+// directoryEndpointsProvider loads the endpoints from all yaml & json files in a directory.
+// each file uses the endpoints file format, so nodes can be added & removed by adding & removing files.
+type directoryEndpointsProvider struct {
+	name string
+	path string
+}
+
+func (provider *directoryEndpointsProvider) GetName() string {
+	return provider.name
+}
+
+func (provider *directoryEndpointsProvider) LoadEndpoints(ctx context.Context) (*types.EndpointsFileConfig, error) {
+	entries, err := os.ReadDir(provider.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading endpoints directory %v: %v", provider.path, err)
+	}
+
+	endpoints := &types.EndpointsFileConfig{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		// json is a subset of yaml, so all files can be decoded by the yaml decoder
+		endpointsFile, err := loadEndpointsFile(filepath.Join(provider.path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints.BeaconApi = append(endpoints.BeaconApi, endpointsFile.BeaconApi...)
+		endpoints.ExecutionApi = append(endpoints.ExecutionApi, endpointsFile.ExecutionApi...)
+	}
+
+	return endpoints, nil
+}
+
+// inventoryEndpointsProvider loads the endpoints from a json inventory api.
+// supports the endpoints file format and the devnet node inventory format (ethereum_pairs).
+type inventoryEndpointsProvider struct {
+	name    string
+	url     string
+	headers map[string]string
+}
+
+type inventoryEndpointsResponse struct {
+	BeaconApi     []types.EndpointConfig        `json:"beaconapi"`
+	ExecutionApi  []types.EndpointConfig        `json:"executionapi"`
+	EthereumPairs map[string]*inventoryNodePair `json:"ethereum_pairs"`
+}
+
+type inventoryNodePair struct {
+	Consensus *struct {
+		Client    string `json:"client"`
+		BeaconUri string `json:"beacon_uri"`
+	} `json:"consensus"`
+	Execution *struct {
+		Client string `json:"client"`
+		RpcUri string `json:"rpc_uri"`
+	} `json:"execution"`
+}
+
+func (provider *inventoryEndpointsProvider) GetName() string {
+	return provider.name
+}
+
+func (provider *inventoryEndpointsProvider) LoadEndpoints(ctx context.Context) (*types.EndpointsFileConfig, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", provider.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range provider.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch inventory (%v): %v", utils.GetRedactedUrl(provider.url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(provider.url), data)
+	}
+
+	inventoryResponse := &inventoryEndpointsResponse{}
+	err = json.NewDecoder(resp.Body).Decode(inventoryResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing inventory response: %v", err)
+	}
+
+	endpoints := &types.EndpointsFileConfig{
+		BeaconApi:    inventoryResponse.BeaconApi,
+		ExecutionApi: inventoryResponse.ExecutionApi,
+	}
+
+	pairNames := make([]string, 0, len(inventoryResponse.EthereumPairs))
+	for name := range inventoryResponse.EthereumPairs {
+		pairNames = append(pairNames, name)
+	}
+	sort.Strings(pairNames)
+
+	for _, name := range pairNames {
+		pair := inventoryResponse.EthereumPairs[name]
+		if pair.Consensus != nil && pair.Consensus.BeaconUri != "" {
+			endpoints.BeaconApi = append(endpoints.BeaconApi, types.EndpointConfig{
+				Name: name,
+				Url:  pair.Consensus.BeaconUri,
+			})
+		}
+		if pair.Execution != nil && pair.Execution.RpcUri != "" {
+			endpoints.ExecutionApi = append(endpoints.ExecutionApi, types.EndpointConfig{
+				Name: name,
+				Url:  pair.Execution.RpcUri,
+			})
+		}
+	}
+
+	return endpoints, nil
+}
+
+// srvEndpointsProvider discovers the endpoints via dns srv records.
+type srvEndpointsProvider struct {
+	name            string
+	beaconRecord    string
+	executionRecord string
+	scheme          string
+}
+
+func (provider *srvEndpointsProvider) GetName() string {
+	return provider.name
+}
+
+func (provider *srvEndpointsProvider) LoadEndpoints(ctx context.Context) (*types.EndpointsFileConfig, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	endpoints := &types.EndpointsFileConfig{}
+
+	if provider.beaconRecord != "" {
+		beaconEndpoints, err := provider.lookupEndpoints(ctx, provider.beaconRecord)
+		if err != nil {
+			return nil, err
+		}
+		endpoints.BeaconApi = beaconEndpoints
+	}
+
+	if provider.executionRecord != "" {
+		executionEndpoints, err := provider.lookupEndpoints(ctx, provider.executionRecord)
+		if err != nil {
+			return nil, err
+		}
+		endpoints.ExecutionApi = executionEndpoints
+	}
+
+	return endpoints, nil
+}
+
+func (provider *srvEndpointsProvider) lookupEndpoints(ctx context.Context, record string) ([]types.EndpointConfig, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", record)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving srv record %v: %v", record, err)
+	}
+
+	endpoints := make([]types.EndpointConfig, 0, len(addrs))
+	for _, addr := range addrs {
+		// no name, the endpoint is named by host & port of the url (see getDiscoveredEndpointName)
+		target := strings.TrimSuffix(addr.Target, ".")
+		endpoints = append(endpoints, types.EndpointConfig{
+			Url: fmt.Sprintf("%v://%v", provider.scheme, net.JoinHostPort(target, fmt.Sprintf("%v", addr.Port))),
+		})
+	}
+
+	return endpoints, nil
+}
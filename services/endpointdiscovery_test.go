@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethpandaops/dora/types"
+)
+
+// testEndpointPool is a fake client pool with endpoints from the main config that are not managed by the discovery source.
+type testEndpointPool struct {
+	endpoints map[string]*types.EndpointConfig
+	addCalls  int
+}
+
+func (pool *testEndpointPool) handlers() endpointDiscoveryHandlers {
+	return endpointDiscoveryHandlers{
+		add: func(endpoint *types.EndpointConfig) error {
+			pool.addCalls++
+			if pool.endpoints[endpoint.Name] != nil {
+				return fmt.Errorf("client with name '%v' already exists", endpoint.Name)
+			}
+			pool.endpoints[endpoint.Name] = endpoint
+			return nil
+		},
+		remove: func(name string) error {
+			delete(pool.endpoints, name)
+			return nil
+		},
+		update: func(name string, update *EndpointUpdate) error {
+			return nil
+		},
+	}
+}
+
+func TestApplyEndpointsNameCollision(t *testing.T) {
+	source := &endpointDiscoverySource{
+		logger: newTestLogger(),
+	}
+	pool := &testEndpointPool{
+		endpoints: map[string]*types.EndpointConfig{
+			"lighthouse": {Name: "lighthouse", Url: "http://config:5052"},
+		},
+	}
+	managed := map[string]*types.EndpointConfig{}
+	skipped := map[string]*types.EndpointConfig{}
+
+	discovered := func(url string) []types.EndpointConfig {
+		return []types.EndpointConfig{
+			{Name: "lighthouse", Url: url},
+			{Name: "teku", Url: "http://discovered:5051"},
+		}
+	}
+
+	source.applyEndpoints(discovered("http://discovered:5052"), managed, skipped, pool.handlers())
+	if managed["teku"] == nil || managed["lighthouse"] != nil {
+		t.Fatalf("expected only teku to be managed, got %v managed endpoints", len(managed))
+	}
+	if skipped["lighthouse"] == nil {
+		t.Fatalf("expected colliding endpoint to be skipped")
+	}
+	if pool.endpoints["lighthouse"].Url != "http://config:5052" {
+		t.Errorf("expected configured endpoint to be left untouched")
+	}
+
+	// the skipped endpoint is not retried on unchanged refreshes
+	addCalls := pool.addCalls
+	source.applyEndpoints(discovered("http://discovered:5052"), managed, skipped, pool.handlers())
+	if pool.addCalls != addCalls {
+		t.Errorf("expected skipped endpoint not to be re-added, got %v add calls", pool.addCalls-addCalls)
+	}
+
+	// the skipped endpoint is retried after it changed
+	delete(pool.endpoints, "lighthouse")
+	source.applyEndpoints(discovered("http://discovered:5053"), managed, skipped, pool.handlers())
+	if managed["lighthouse"] == nil || skipped["lighthouse"] != nil {
+		t.Errorf("expected changed endpoint to be added")
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const (
+	// number of blocks to wait before comparing a block height, avoids reporting short lived reorgs near the head
+	executionDivergenceConfirmations = 2
+	// maximum number of block heights to compare per check round
+	executionDivergenceBatchSize = 32
+	// number of divergent blocks to keep in memory
+	executionDivergenceHistorySize = 100
+)
+
+// ExecutionDivergenceChecker compares the block headers of all execution clients for each block height.
+type ExecutionDivergenceChecker struct {
+	logger             logrus.FieldLogger
+	mutex              sync.RWMutex
+	lastCheckedNumber  uint64
+	lastCheckTime      time.Time
+	lastCheckedClients int
+	divergences        []*ExecutionDivergence
+	divergenceDispatch execution.Dispatcher[*ExecutionDivergence]
+}
+
+// ExecutionDivergence describes a block height where the execution clients returned different headers.
+type ExecutionDivergence struct {
+	Number     uint64
+	DetectTime time.Time
+	Groups     []*ExecutionDivergenceGroup
+}
+
+// ExecutionDivergenceGroup is a group of clients that returned the same header for a block height.
+type ExecutionDivergenceGroup struct {
+	Hash         common.Hash
+	StateRoot    common.Hash
+	ReceiptsRoot common.Hash
+	GasUsed      uint64
+	Clients      []string
+}
+
+var GlobalExecutionDivergence *ExecutionDivergenceChecker
+
+// StartExecutionDivergenceChecker is used to start the global execution client divergence checker
+func StartExecutionDivergenceChecker(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalExecutionDivergence != nil {
+		return nil
+	}
+
+	GlobalExecutionDivergence = &ExecutionDivergenceChecker{
+		logger:      logger.WithField("service", "el-compare"),
+		divergences: []*ExecutionDivergence{},
+	}
+
+	go GlobalExecutionDivergence.runCheckLoop(ctx)
+	return nil
+}
+
+// SubscribeDivergenceEvent returns a subscription that receives all newly detected divergences.
+func (dc *ExecutionDivergenceChecker) SubscribeDivergenceEvent(capacity int) *execution.Subscription[*ExecutionDivergence] {
+	return dc.divergenceDispatch.Subscribe(capacity)
+}
+
+// GetStatus returns the last compared block number, the time of the last check round and the number of compared clients.
+func (dc *ExecutionDivergenceChecker) GetStatus() (uint64, time.Time, int) {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.lastCheckedNumber, dc.lastCheckTime, dc.lastCheckedClients
+}
+
+// GetDivergences returns the recently detected divergences, newest first.
+func (dc *ExecutionDivergenceChecker) GetDivergences() []*ExecutionDivergence {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+
+	divergences := make([]*ExecutionDivergence, len(dc.divergences))
+	for idx, divergence := range dc.divergences {
+		divergences[len(dc.divergences)-idx-1] = divergence
+	}
+	return divergences
+}
+
+func (dc *ExecutionDivergenceChecker) runCheckLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("ExecutionDivergenceChecker.runCheckLoop")
+
+	slotSubscription := GlobalBeaconService.consensusPool.SubscribeWallclockSlotEvent(1)
+	defer slotSubscription.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-slotSubscription.Channel():
+		}
+
+		err := dc.runCheck(ctx)
+		if err != nil {
+			dc.logger.Warnf("execution client comparison failed: %v", err)
+		}
+	}
+}
+
+func (dc *ExecutionDivergenceChecker) runCheck(ctx context.Context) error {
+	clients := []*execution.Client{}
+	maxHead := uint64(0)
+	for _, client := range GlobalBeaconService.GetExecutionClients() {
+		if client.GetStatus() != execution.ClientStatusOnline {
+			continue
+		}
+
+		clients = append(clients, client)
+		if headNumber, _ := client.GetLastHead(); headNumber > maxHead {
+			maxHead = headNumber
+		}
+	}
+	if len(clients) < 2 || maxHead <= executionDivergenceConfirmations {
+		return nil
+	}
+
+	targetNumber := maxHead - executionDivergenceConfirmations
+	if targetNumber <= dc.lastCheckedNumber {
+		return nil
+	}
+
+	startNumber := dc.lastCheckedNumber + 1
+	if dc.lastCheckedNumber == 0 || targetNumber-dc.lastCheckedNumber > executionDivergenceBatchSize {
+		// start with the latest blocks on startup or when falling too far behind
+		if targetNumber > executionDivergenceBatchSize {
+			startNumber = targetNumber - executionDivergenceBatchSize + 1
+		} else {
+			startNumber = 1
+		}
+	}
+
+	for number := startNumber; number <= targetNumber; number++ {
+		divergence, checkedClients, err := dc.checkBlock(ctx, clients, number)
+		if err != nil {
+			return fmt.Errorf("error comparing block %v: %v", number, err)
+		}
+
+		dc.mutex.Lock()
+		dc.lastCheckedNumber = number
+		dc.lastCheckTime = time.Now()
+		dc.lastCheckedClients = checkedClients
+		if divergence != nil {
+			dc.divergences = append(dc.divergences, divergence)
+			if len(dc.divergences) > executionDivergenceHistorySize {
+				dc.divergences = dc.divergences[len(dc.divergences)-executionDivergenceHistorySize:]
+			}
+		}
+		dc.mutex.Unlock()
+
+		if divergence != nil {
+			minorityClients := []string{}
+			for _, group := range divergence.Groups[1:] {
+				minorityClients = append(minorityClients, group.Clients...)
+			}
+			dc.logger.Warnf("detected execution divergence at block %v (minority clients: %v)", number, strings.Join(minorityClients, ", "))
+			dc.divergenceDispatch.Fire(divergence)
+		}
+	}
+
+	return nil
+}
+
+// checkBlock loads the header for the given block number from all clients that reached the height and groups the results.
+func (dc *ExecutionDivergenceChecker) checkBlock(ctx context.Context, clients []*execution.Client, number uint64) (*ExecutionDivergence, int, error) {
+	groups := []*ExecutionDivergenceGroup{}
+	checkedClients := 0
+
+	for _, client := range clients {
+		if headNumber, _ := client.GetLastHead(); headNumber < number {
+			continue
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		header, err := client.GetRPCClient().GetHeaderByNumber(reqCtx, number)
+		cancel()
+		if err != nil || header == nil {
+			dc.logger.Debugf("failed loading header %v from %v: %v", number, client.GetName(), err)
+			continue
+		}
+		checkedClients++
+
+		var matchingGroup *ExecutionDivergenceGroup
+		blockHash := header.Hash()
+		for _, group := range groups {
+			if group.Hash == blockHash && group.StateRoot == header.Root && group.ReceiptsRoot == header.ReceiptHash && group.GasUsed == header.GasUsed {
+				matchingGroup = group
+				break
+			}
+		}
+		if matchingGroup == nil {
+			matchingGroup = &ExecutionDivergenceGroup{
+				Hash:         blockHash,
+				StateRoot:    header.Root,
+				ReceiptsRoot: header.ReceiptHash,
+				GasUsed:      header.GasUsed,
+			}
+			groups = append(groups, matchingGroup)
+		}
+		matchingGroup.Clients = append(matchingGroup.Clients, client.GetName())
+	}
+
+	if len(groups) < 2 {
+		return nil, checkedClients, nil
+	}
+
+	// majority group first
+	sort.SliceStable(groups, func(a, b int) bool {
+		return len(groups[a].Clients) > len(groups[b].Clients)
+	})
+
+	return &ExecutionDivergence{
+		Number:     number,
+		DetectTime: time.Now(),
+		Groups:     groups,
+	}, checkedClients, nil
+}
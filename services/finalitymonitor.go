@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// FinalityIncidentMinDistance is the number of epochs the finalized checkpoint needs to lag behind the wallclock epoch to record an incident.
+const FinalityIncidentMinDistance = 4
+
+// number of entities with the highest estimated inactivity leak that are stored per incident
+const finalityIncidentMaxEntities = 25
+
+// FinalityMonitor watches the distance between the wallclock & finalized epoch and records periods of non-finality.
+type FinalityMonitor struct {
+	logger   logrus.FieldLogger
+	mutex    sync.RWMutex
+	incident *dbtypes.FinalityIncident
+}
+
+var GlobalFinalityMonitor *FinalityMonitor
+
+// StartFinalityMonitor is used to start the global finality monitor
+func StartFinalityMonitor(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalFinalityMonitor != nil {
+		return nil
+	}
+
+	GlobalFinalityMonitor = &FinalityMonitor{
+		logger: logger.WithField("service", "finality-monitor"),
+	}
+
+	// resume ongoing incident from db
+	GlobalFinalityMonitor.incident = db.GetOngoingFinalityIncident()
+
+	go GlobalFinalityMonitor.runMonitorLoop(ctx)
+	return nil
+}
+
+// GetOngoingIncident returns a copy of the currently ongoing incident or nil if the chain is finalizing.
+func (fm *FinalityMonitor) GetOngoingIncident() *dbtypes.FinalityIncident {
+	fm.mutex.RLock()
+	defer fm.mutex.RUnlock()
+	if fm.incident == nil {
+		return nil
+	}
+	incident := *fm.incident
+	return &incident
+}
+
+func (fm *FinalityMonitor) runMonitorLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("FinalityMonitor.runMonitorLoop")
+
+	finalitySubscription := GlobalBeaconService.consensusPool.SubscribeFinalizedEvent(10)
+	defer finalitySubscription.Unsubscribe()
+
+	epochSubscription := GlobalBeaconService.consensusPool.SubscribeWallclockEpochEvent(1)
+	defer epochSubscription.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-finalitySubscription.Channel():
+		case <-epochSubscription.Channel():
+		}
+
+		err := fm.updateIncident(ctx)
+		if err != nil {
+			fm.logger.Warnf("finality incident update failed: %v", err)
+		}
+	}
+}
+
+func (fm *FinalityMonitor) updateIncident(ctx context.Context) error {
+	chainState := GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return nil
+	}
+
+	currentEpoch := chainState.CurrentEpoch()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	distance := uint64(0)
+	if currentEpoch > finalizedEpoch {
+		distance = uint64(currentEpoch - finalizedEpoch)
+	}
+
+	fm.mutex.RLock()
+	incident := fm.incident
+	fm.mutex.RUnlock()
+
+	if incident == nil {
+		if distance < FinalityIncidentMinDistance {
+			return nil
+		}
+
+		incident = newFinalityIncident(currentEpoch, finalizedEpoch)
+		fm.logger.Warnf("non-finality incident started at epoch %v (finalized epoch: %v)", incident.StartEpoch, finalizedEpoch)
+		fm.updateIncidentStats(ctx, incident, currentEpoch, finalizedEpoch)
+	} else {
+		incident = fm.GetOngoingIncident()
+
+		if distance < FinalityIncidentMinDistance {
+			endEpoch := uint64(currentEpoch)
+			endFinalizedEpoch := uint64(finalizedEpoch)
+			incident.EndEpoch = &endEpoch
+			incident.EndFinalizedEpoch = &endFinalizedEpoch
+			fm.logger.Infof("non-finality incident ended at epoch %v (finalized epoch: %v, max distance: %v)", currentEpoch, finalizedEpoch, incident.MaxDistance)
+		} else if uint64(currentEpoch) > incident.LastEpoch {
+			fm.updateIncidentStats(ctx, incident, currentEpoch, finalizedEpoch)
+		} else {
+			return nil
+		}
+	}
+
+	err := db.RunDBTransaction(func(tx db.Tx) error {
+		return db.InsertFinalityIncident(incident, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	fm.mutex.Lock()
+	if incident.EndEpoch != nil {
+		fm.incident = nil
+	} else {
+		fm.incident = incident
+	}
+	fm.mutex.Unlock()
+
+	return nil
+}
+
+// newFinalityIncident creates an incident for the current non-finality period.
+// the chain stopped finalizing right after the last finalized epoch, so the incident starts at finalizedEpoch+1.
+func newFinalityIncident(currentEpoch phase0.Epoch, finalizedEpoch phase0.Epoch) *dbtypes.FinalityIncident {
+	return &dbtypes.FinalityIncident{
+		StartEpoch:     uint64(finalizedEpoch) + 1,
+		LastEpoch:      uint64(currentEpoch),
+		FinalizedEpoch: uint64(finalizedEpoch),
+	}
+}
+
+// getInactivityLeakEpochs returns the number of epochs the inactivity leak has been active up to the current epoch.
+// the leak is active when the finality delay (previous epoch - finalized epoch) exceeds MIN_EPOCHS_TO_INACTIVITY_PENALTY.
+func getInactivityLeakEpochs(currentEpoch phase0.Epoch, finalizedEpoch phase0.Epoch, minEpochsToInactivityPenalty uint64) uint64 {
+	leakStart := uint64(finalizedEpoch) + minEpochsToInactivityPenalty + 1
+	if uint64(currentEpoch) <= leakStart {
+		return 0
+	}
+	return uint64(currentEpoch) - leakStart
+}
+
+// GetInactivityLeakEstimate returns the total inactivity penalty of a validator that has been offline for all leaking epochs.
+// an offline validators inactivity score grows by INACTIVITY_SCORE_BIAS each leaking epoch, which results in a penalty of
+// effective_balance * n / INACTIVITY_PENALTY_QUOTIENT in the n-th leaking epoch.
+func GetInactivityLeakEstimate(effectiveBalance uint64, penaltyQuotient uint64, leakEpochs uint64) uint64 {
+	return effectiveBalance / penaltyQuotient * leakEpochs * (leakEpochs + 1) / 2
+}
+
+// updateIncidentStats updates the incident with the participation, fork & inactivity leak stats of the current epoch.
+func (fm *FinalityMonitor) updateIncidentStats(ctx context.Context, incident *dbtypes.FinalityIncident, currentEpoch phase0.Epoch, finalizedEpoch phase0.Epoch) {
+	chainState := GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	distance := uint64(currentEpoch - finalizedEpoch)
+	incident.LastEpoch = uint64(currentEpoch)
+	if uint32(distance) > incident.MaxDistance {
+		incident.MaxDistance = uint32(distance)
+	}
+
+	minLeakEpochs := specs.MinEpochsToInactivityPenalty
+	if minLeakEpochs == 0 {
+		minLeakEpochs = 4
+	}
+	incident.LeakEpochs = uint32(getInactivityLeakEpochs(currentEpoch, finalizedEpoch, minLeakEpochs))
+
+	// participation of the last epoch with complete attestation inclusion window
+	if currentEpoch >= 2 {
+		dbEpochs := GlobalBeaconService.GetDbEpochs(ctx, uint64(currentEpoch-2), 1)
+		if len(dbEpochs) > 0 && dbEpochs[0].Eligible > 0 {
+			participation := float32(float64(dbEpochs[0].VotedTarget) / float64(dbEpochs[0].Eligible) * 100)
+			incident.ParticipationAvg = (incident.ParticipationAvg*float32(incident.ParticipationEpochs) + participation) / float32(incident.ParticipationEpochs+1)
+			if incident.ParticipationEpochs == 0 || participation < incident.ParticipationMin {
+				incident.ParticipationMin = participation
+			}
+			incident.ParticipationEpochs++
+		}
+	}
+
+	// keep the fork snapshot with the most forks observed during the incident
+	clientForks := GlobalBeaconService.GetConsensusClientForks()
+	if uint32(len(clientForks)) >= incident.MaxForks {
+		forks := make([]*dbtypes.FinalityIncidentFork, len(clientForks))
+		for idx, clientFork := range clientForks {
+			fork := &dbtypes.FinalityIncidentFork{
+				HeadSlot: uint64(clientFork.Slot),
+				HeadRoot: clientFork.Root[:],
+				Clients:  make([]string, len(clientFork.AllClients)),
+			}
+			for cidx, client := range clientFork.AllClients {
+				fork.Clients[cidx] = client.GetClient().GetName()
+			}
+			forks[idx] = fork
+		}
+
+		forksJson, err := json.Marshal(forks)
+		if err == nil {
+			incident.MaxForks = uint32(len(clientForks))
+			incident.Forks = string(forksJson)
+		}
+	}
+
+	fm.updateLeakEstimates(incident, currentEpoch)
+}
+
+// updateLeakEstimates estimates the inactivity penalties of validators that have been offline during the whole incident.
+func (fm *FinalityMonitor) updateLeakEstimates(incident *dbtypes.FinalityIncident, currentEpoch phase0.Epoch) {
+	epochLimit := uint64(currentEpoch) - incident.StartEpoch
+	if epochLimit == 0 {
+		epochLimit = 1
+	}
+
+	activityMap, aggregationCount := GlobalBeaconService.GetValidatorActivity(epochLimit, false)
+	if aggregationCount == 0 {
+		return
+	}
+
+	penaltyQuotient := GlobalBeaconService.GetChainState().GetSpecs().GetInactivityPenaltyQuotient(currentEpoch)
+	leakEpochs := uint64(incident.LeakEpochs)
+
+	entities := map[string]*dbtypes.FinalityIncidentEntity{}
+	offlineValidators := uint64(0)
+	offlineBalance := uint64(0)
+	leakEstimate := uint64(0)
+
+	for _, validator := range GlobalBeaconService.GetCachedValidatorSet() {
+		if validator.Validator.ActivationEpoch > currentEpoch || validator.Validator.ExitEpoch <= currentEpoch {
+			continue
+		}
+		if activityMap[validator.Index] > 0 {
+			continue
+		}
+
+		effectiveBalance := uint64(validator.Validator.EffectiveBalance)
+		validatorLeak := GetInactivityLeakEstimate(effectiveBalance, penaltyQuotient, leakEpochs)
+
+		offlineValidators++
+		offlineBalance += effectiveBalance
+		leakEstimate += validatorLeak
+
+		entityName := GlobalBeaconService.GetValidatorName(uint64(validator.Index))
+		if entityName == "" {
+			entityName = "Unnamed validators"
+		}
+		entity := entities[entityName]
+		if entity == nil {
+			entity = &dbtypes.FinalityIncidentEntity{
+				Name: entityName,
+			}
+			entities[entityName] = entity
+		}
+		entity.OfflineValidators++
+		entity.OfflineBalance += effectiveBalance
+		entity.LeakEstimate += validatorLeak
+	}
+
+	entityList := make([]*dbtypes.FinalityIncidentEntity, 0, len(entities))
+	for _, entity := range entities {
+		entityList = append(entityList, entity)
+	}
+	sort.Slice(entityList, func(a, b int) bool {
+		if entityList[a].OfflineBalance != entityList[b].OfflineBalance {
+			return entityList[a].OfflineBalance > entityList[b].OfflineBalance
+		}
+		return entityList[a].Name < entityList[b].Name
+	})
+	if len(entityList) > finalityIncidentMaxEntities {
+		entityList = entityList[:finalityIncidentMaxEntities]
+	}
+
+	entitiesJson, err := json.Marshal(entityList)
+	if err != nil {
+		fm.logger.Warnf("failed encoding leak entities: %v", err)
+		return
+	}
+
+	incident.OfflineValidators = offlineValidators
+	incident.OfflineBalance = offlineBalance
+	incident.LeakEstimate = leakEstimate
+	incident.LeakEntities = string(entitiesJson)
+}
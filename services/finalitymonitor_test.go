@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestNewFinalityIncident(t *testing.T) {
+	incident := newFinalityIncident(phase0.Epoch(104), phase0.Epoch(100))
+
+	if incident.StartEpoch != 101 {
+		t.Errorf("expected incident to start after the finalized epoch 100, got %v", incident.StartEpoch)
+	}
+	if incident.LastEpoch != 104 || incident.FinalizedEpoch != 100 {
+		t.Errorf("expected last epoch 104 & finalized epoch 100, got %v & %v", incident.LastEpoch, incident.FinalizedEpoch)
+	}
+}
+
+func TestGetInactivityLeakEpochs(t *testing.T) {
+	tests := []struct {
+		currentEpoch   phase0.Epoch
+		finalizedEpoch phase0.Epoch
+		expected       uint64
+	}{
+		// finality delay (current - 1 - finalized) needs to exceed MIN_EPOCHS_TO_INACTIVITY_PENALTY (4)
+		{100, 100, 0},
+		{104, 100, 0},
+		{105, 100, 0},
+		{106, 100, 1},
+		{120, 100, 15},
+		// finality progressed during the incident
+		{120, 110, 5},
+		{120, 118, 0},
+	}
+
+	for _, test := range tests {
+		if leakEpochs := getInactivityLeakEpochs(test.currentEpoch, test.finalizedEpoch, 4); leakEpochs != test.expected {
+			t.Errorf("leak epochs at epoch %v (finalized %v): expected %v, got %v", test.currentEpoch, test.finalizedEpoch, test.expected, leakEpochs)
+		}
+	}
+}
+
+func TestGetInactivityLeakEstimate(t *testing.T) {
+	effectiveBalance := uint64(32_000_000_000)
+
+	tests := []struct {
+		penaltyQuotient uint64
+		leakEpochs      uint64
+		expected        uint64
+	}{
+		{1 << 24, 0, 0},
+		// 1907 gwei in the first leaking epoch, 2 * 1907 in the second
+		{1 << 24, 1, 1907},
+		{1 << 24, 2, 5721},
+		{1 << 24, 100, 9630350},
+		{3 * (1 << 24), 100, 3206750},
+		{1 << 26, 100, 2403800},
+	}
+
+	for _, test := range tests {
+		if estimate := GetInactivityLeakEstimate(effectiveBalance, test.penaltyQuotient, test.leakEpochs); estimate != test.expected {
+			t.Errorf("leak estimate for %v epochs (quotient %v): expected %v, got %v", test.leakEpochs, test.penaltyQuotient, test.expected, estimate)
+		}
+	}
+}
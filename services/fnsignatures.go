@@ -15,11 +15,19 @@ import (
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/types"
 	"github.com/ethpandaops/dora/utils"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
 type TxSignaturesService struct {
+	registry *TxSignatureRegistry
+	sources  []TxSignaturesSource
+}
+
+// TxSignaturesSource is a source that is able to resolve function signatures.
+// sources are queried in order until the first one resolves the signature.
+type TxSignaturesSource interface {
+	GetName() string
+	LookupSignature(lookup *TxSignaturesLookup) error
 }
 
 var GlobalTxSignaturesService *TxSignaturesService
@@ -43,7 +51,18 @@ func StartTxSignaturesService() error {
 		concurrencyLimit = 10
 	}
 
-	GlobalTxSignaturesService = &TxSignaturesService{}
+	registry := loadTxSignatureRegistry()
+	sources := []TxSignaturesSource{
+		&txSigLocalSource{registry: registry},
+	}
+	if !utils.Config.TxSignature.Disable4Bytes {
+		sources = append(sources, &txSig4BytesSource{})
+	}
+
+	GlobalTxSignaturesService = &TxSignaturesService{
+		registry: registry,
+		sources:  sources,
+	}
 
 	if !utils.Config.TxSignature.DisableLookupLoop {
 		go GlobalTxSignaturesService.runLookupLoop()
@@ -68,6 +87,24 @@ func (tss *TxSignaturesService) LookupSignatures(sigBytes []types.TxSignatureByt
 		lookups[bytes] = lookup
 	}
 
+	// check local signature registry
+	if len(unresolvedLookups) > 0 {
+		nonfoundLookups := make([]*TxSignaturesLookup, 0)
+		nonfoundLookupBytes := make([]types.TxSignatureBytes, 0)
+		for _, l := range unresolvedLookups {
+			if method := tss.registry.LookupFunction(l.Bytes); method != nil {
+				l.Status = types.TxSigStatusFound
+				l.Signature = method.Sig
+				l.Name = method.RawName
+				continue
+			}
+			nonfoundLookups = append(nonfoundLookups, l)
+			nonfoundLookupBytes = append(nonfoundLookupBytes, l.Bytes)
+		}
+		unresolvedLookups = nonfoundLookups
+		unresolvedLookupBytes = nonfoundLookupBytes
+	}
+
 	// check known signatures in DB
 	if len(unresolvedLookups) > 0 {
 		for _, dbSigEntry := range db.GetTxFunctionSignaturesByBytes(unresolvedLookupBytes) {
@@ -154,7 +191,7 @@ func (tss *TxSignaturesService) LookupSignatures(sigBytes []types.TxSignatureByt
 		}
 
 		//logger_tss.Infof("starting db transaction (pending sig)")
-		db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		db.RunDBTransaction(func(tx db.Tx) error {
 			err := db.InsertPendingFunctionSignatures(pendingLookups, tx)
 			if err != nil {
 				logger_tss.Warnf("error saving pending signature: %v", err)
@@ -240,7 +277,7 @@ func (tss *TxSignaturesService) processPendingSignatures() {
 	}
 
 	if len(pendingSigBytes) > 0 {
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			err := db.DeletePendingFunctionSignatures(pendingSigBytes, tx)
 			if err != nil {
 				logger_tss.Warnf("error deleting pending signature: %v", err)
@@ -270,12 +307,13 @@ func (tss *TxSignaturesService) processPendingSignatures() {
 func (tss *TxSignaturesService) lookupSignature(lookup *TxSignaturesLookup) error {
 	var resErr error
 
-	if !utils.Config.TxSignature.Disable4Bytes {
-		err := tss.lookup4Bytes(lookup)
+	for _, source := range tss.sources {
+		err := source.LookupSignature(lookup)
 		if err != nil {
-			resErr = fmt.Errorf("4bytes lookup failed: %w", err)
+			resErr = fmt.Errorf("%v lookup failed: %w", source.GetName(), err)
 		} else if lookup.Status == types.TxSigStatusFound {
-			return nil
+			resErr = nil
+			break
 		}
 	}
 
@@ -284,6 +322,40 @@ func (tss *TxSignaturesService) lookupSignature(lookup *TxSignaturesLookup) erro
 	return resErr
 }
 
+// GetRegistry returns the local signature registry, used to decode call data & event logs.
+func (tss *TxSignaturesService) GetRegistry() *TxSignatureRegistry {
+	return tss.registry
+}
+
+// txSigLocalSource resolves signatures from the local signature registry (bundled db, signature files & ABIs).
+type txSigLocalSource struct {
+	registry *TxSignatureRegistry
+}
+
+func (source *txSigLocalSource) GetName() string {
+	return "local"
+}
+
+func (source *txSigLocalSource) LookupSignature(lookup *TxSignaturesLookup) error {
+	method := source.registry.LookupFunction(lookup.Bytes)
+	if method == nil {
+		lookup.Status = types.TxSigStatusUnknown
+		return nil
+	}
+
+	lookup.Status = types.TxSigStatusFound
+	lookup.Signature = method.Sig
+	lookup.Name = method.RawName
+	return nil
+}
+
+// txSig4BytesSource resolves signatures via https://www.4byte.directory/
+type txSig4BytesSource struct{}
+
+func (source *txSig4BytesSource) GetName() string {
+	return "4bytes"
+}
+
 type txSigLookup_4bytesResponse struct {
 	Count   int `json:"count"`
 	Results []struct {
@@ -292,7 +364,7 @@ type txSigLookup_4bytesResponse struct {
 	} `json:"results"`
 }
 
-func (tss *TxSignaturesService) lookup4Bytes(lookup *TxSignaturesLookup) error {
+func (source *txSig4BytesSource) LookupSignature(lookup *TxSignaturesLookup) error {
 	// lookup signature via https://www.4byte.directory/
 	url := fmt.Sprintf("https://www.4byte.directory/api/v1/signatures/?format=json&hex_signature=0x%x", lookup.Bytes)
 
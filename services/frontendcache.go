@@ -110,7 +110,13 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 	errorChan := make(chan error)
 	isTimedOut := false
 
-	callCtx, callCtxCancel := context.WithCancel(context.Background())
+	callTimeout := utils.Config.Frontend.PageCallTimeout
+	if callTimeout == 0 {
+		callTimeout = 30 * time.Second
+	}
+
+	// the call context is passed to the db queries of the page call, so they get cancelled when the page call times out
+	callCtx, callCtxCancel := context.WithTimeout(context.Background(), callTimeout)
 	defer callCtxCancel()
 	pageCall.CallCtx = callCtx
 
@@ -153,11 +159,6 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 		}
 	}(callIdx)
 
-	callTimeout := utils.Config.Frontend.PageCallTimeout
-	if callTimeout == 0 {
-		callTimeout = 30 * time.Second
-	}
-
 	select {
 	case returnValue := <-returnChan:
 		return returnValue, nil
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// RetentionService prunes historic data according to the configured retention settings.
+// all pruning steps work in small transactions, so the pruner doesn't block the indexer for long.
+type RetentionService struct {
+	logger     logrus.FieldLogger
+	stateMutex sync.RWMutex
+	state      *dbtypes.RetentionState
+}
+
+var GlobalRetentionService *RetentionService
+
+// StartRetentionService is used to start the global data retention service
+func StartRetentionService(ctx context.Context, logger logrus.FieldLogger) error {
+	if GlobalRetentionService != nil {
+		return nil
+	}
+
+	retentionService := &RetentionService{
+		logger: logger.WithField("service", "retention"),
+		state:  &dbtypes.RetentionState{},
+	}
+
+	if _, err := db.GetExplorerState("retention.state", retentionService.state); err != nil {
+		retentionService.state = &dbtypes.RetentionState{}
+	}
+
+	GlobalRetentionService = retentionService
+
+	go retentionService.runPruningLoop(ctx)
+	return nil
+}
+
+// GetMevBlocksPrunedBefore returns the slot before which mev blocks have been pruned.
+func (rs *RetentionService) GetMevBlocksPrunedBefore() phase0.Slot {
+	if rs == nil {
+		return 0
+	}
+
+	rs.stateMutex.RLock()
+	defer rs.stateMutex.RUnlock()
+	return phase0.Slot(rs.state.MevBlocksBefore)
+}
+
+// GetMissedSlotsPrunedBefore returns the slot before which missed slots have been compacted into ranges.
+func (rs *RetentionService) GetMissedSlotsPrunedBefore() phase0.Slot {
+	if rs == nil {
+		return 0
+	}
+
+	rs.stateMutex.RLock()
+	defer rs.stateMutex.RUnlock()
+	return phase0.Slot(rs.state.MissedSlotsBefore)
+}
+
+// IsBlobDataPruned checks if the blob data of the given slot is outside of the blob retention window.
+// blob data is not stored in the db, it's loaded from the beacon nodes which drop it after their own retention period.
+func (rs *RetentionService) IsBlobDataPruned(slot phase0.Slot) bool {
+	blobEpochs := utils.Config.Retention.BlobEpochs
+	if blobEpochs == 0 {
+		return false
+	}
+
+	chainState := GlobalBeaconService.GetChainState()
+	currentEpoch := chainState.CurrentEpoch()
+	return currentEpoch > phase0.Epoch(blobEpochs) && chainState.EpochOfSlot(slot) < currentEpoch-phase0.Epoch(blobEpochs)
+}
+
+func (rs *RetentionService) runPruningLoop(ctx context.Context) {
+	defer utils.HandleSubroutinePanic("RetentionService.runPruningLoop")
+
+	interval := utils.Config.Retention.Interval
+	if interval == 0 {
+		interval = 10 * time.Minute
+	}
+
+	for {
+		if err := rs.runPruning(ctx); err != nil {
+			rs.logger.Warnf("data retention pruning failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (rs *RetentionService) runPruning(ctx context.Context) error {
+	batchSize := utils.Config.Retention.BatchSize
+	if batchSize == 0 {
+		batchSize = 1000
+	}
+
+	chainState := GlobalBeaconService.GetChainState()
+	if chainState.GetSpecs() == nil {
+		return nil
+	}
+
+	currentEpoch := chainState.CurrentEpoch()
+	finalizedEpoch, _ := GlobalBeaconService.GetFinalizedEpoch()
+
+	if mevBlockEpochs := phase0.Epoch(utils.Config.Retention.MevBlockEpochs); mevBlockEpochs > 0 && currentEpoch > mevBlockEpochs {
+		if err := rs.pruneMevBlocks(ctx, chainState.EpochToSlot(currentEpoch-mevBlockEpochs), batchSize); err != nil {
+			return fmt.Errorf("failed pruning mev blocks: %v", err)
+		}
+	}
+
+	if blockBodyEpochs := phase0.Epoch(utils.Config.Retention.BlockBodyEpochs); blockBodyEpochs > 0 && finalizedEpoch > blockBodyEpochs {
+		if err := rs.pruneBlockBodies(ctx, chainState.EpochToSlot(finalizedEpoch-blockBodyEpochs), batchSize); err != nil {
+			return fmt.Errorf("failed pruning block bodies: %v", err)
+		}
+	}
+
+	if missedSlotEpochs := phase0.Epoch(utils.Config.Retention.MissedSlotEpochs); missedSlotEpochs > 0 && finalizedEpoch > missedSlotEpochs {
+		if err := rs.compactMissedSlots(ctx, chainState.EpochToSlot(finalizedEpoch-missedSlotEpochs), batchSize); err != nil {
+			return fmt.Errorf("failed compacting missed slots: %v", err)
+		}
+	}
+
+	if signatureAge := utils.Config.Retention.PendingSignatureAge; signatureAge > 0 {
+		if err := rs.prunePendingSignatures(ctx, time.Now().Add(-signatureAge), batchSize); err != nil {
+			return fmt.Errorf("failed pruning pending signatures: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// updateState updates the retention state within the given transaction.
+func (rs *RetentionService) updateState(tx db.Tx, update func(state *dbtypes.RetentionState)) error {
+	rs.stateMutex.Lock()
+	defer rs.stateMutex.Unlock()
+
+	newState := *rs.state
+	update(&newState)
+
+	if err := db.SetExplorerState("retention.state", &newState, tx); err != nil {
+		return err
+	}
+
+	rs.state = &newState
+	return nil
+}
+
+func (rs *RetentionService) pruneMevBlocks(ctx context.Context, beforeSlot phase0.Slot, batchSize uint32) error {
+	totalDeleted := int64(0)
+	for ctx.Err() == nil {
+		deleted := int64(0)
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			var err error
+			deleted, err = db.DeleteMevBlocksBefore(uint64(beforeSlot), batchSize, tx)
+			if err != nil {
+				return err
+			}
+
+			if deleted < int64(batchSize) {
+				return rs.updateState(tx, func(state *dbtypes.RetentionState) {
+					state.MevBlocksBefore = uint64(beforeSlot)
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		totalDeleted += deleted
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+
+	if totalDeleted > 0 {
+		rs.logger.Infof("pruned %v mev blocks before slot %v", totalDeleted, beforeSlot)
+	}
+
+	return ctx.Err()
+}
+
+// pruneBlockBodies deletes the persisted block bodies before the given slot.
+// slot pages of pruned blocks fall back to loading the block from the beacon nodes.
+func (rs *RetentionService) pruneBlockBodies(ctx context.Context, beforeSlot phase0.Slot, batchSize uint32) error {
+	totalDeleted := int64(0)
+	for ctx.Err() == nil {
+		deleted := int64(0)
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			var err error
+			deleted, err = db.DeleteBlockBodiesBefore(uint64(beforeSlot), batchSize, tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		totalDeleted += deleted
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+
+	if totalDeleted > 0 {
+		rs.logger.Infof("pruned %v block bodies before slot %v", totalDeleted, beforeSlot)
+	}
+
+	return ctx.Err()
+}
+
+// compactMissedSlots replaces the missed slot rows before the given slot with ranges of consecutive missed slots.
+// the proposer of compacted missed slots is lost, the UI shows them as missed slots with pruned proposer.
+// each batch reads its missed slots within the writer transaction, so lagging replicas can't cause duplicate ranges.
+func (rs *RetentionService) compactMissedSlots(ctx context.Context, beforeSlot phase0.Slot, batchSize uint32) error {
+	totalCompacted := 0
+	for ctx.Err() == nil {
+		compacted := 0
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			missedSlots, err := db.GetMissedSlotsBefore(uint64(beforeSlot), batchSize, tx)
+			if err != nil {
+				return err
+			}
+
+			missedRanges := buildMissedSlotRanges(missedSlots)
+
+			// extend a previously compacted range that ends right before the first missed slot of this batch
+			if len(missedRanges) > 0 && missedRanges[0].FirstSlot > 0 {
+				prevRange, err := db.GetMissedSlotRangeEndingAt(missedRanges[0].FirstSlot-1, tx)
+				if err != nil {
+					return err
+				}
+				if prevRange != nil {
+					missedRanges[0].FirstSlot = prevRange.FirstSlot
+				}
+			}
+
+			for _, missedRange := range missedRanges {
+				if err := db.InsertMissedSlotRange(missedRange, tx); err != nil {
+					return err
+				}
+
+				if err := db.DeleteMissedSlotsInRange(missedRange.FirstSlot, missedRange.LastSlot, tx); err != nil {
+					return err
+				}
+			}
+
+			compacted = len(missedSlots)
+			if compacted < int(batchSize) {
+				return rs.updateState(tx, func(state *dbtypes.RetentionState) {
+					state.MissedSlotsBefore = uint64(beforeSlot)
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		totalCompacted += compacted
+		if compacted < int(batchSize) {
+			break
+		}
+	}
+
+	if totalCompacted > 0 {
+		rs.logger.Infof("compacted %v missed slots before slot %v", totalCompacted, beforeSlot)
+	}
+
+	return ctx.Err()
+}
+
+// buildMissedSlotRanges groups the given ascending missed slots into ranges of consecutive slots.
+func buildMissedSlotRanges(missedSlots []uint64) []*dbtypes.MissedSlotRange {
+	missedRanges := []*dbtypes.MissedSlotRange{}
+	for _, slot := range missedSlots {
+		if len(missedRanges) > 0 && missedRanges[len(missedRanges)-1].LastSlot+1 == slot {
+			missedRanges[len(missedRanges)-1].LastSlot = slot
+			continue
+		}
+
+		missedRanges = append(missedRanges, &dbtypes.MissedSlotRange{
+			FirstSlot: slot,
+			LastSlot:  slot,
+		})
+	}
+	return missedRanges
+}
+
+func (rs *RetentionService) prunePendingSignatures(ctx context.Context, beforeTime time.Time, batchSize uint32) error {
+	totalDeleted := int64(0)
+	for ctx.Err() == nil {
+		deleted := int64(0)
+		err := db.RunDBTransaction(func(tx db.Tx) error {
+			var err error
+			deleted, err = db.DeletePendingFunctionSignaturesBefore(uint64(beforeTime.Unix()), batchSize, tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		totalDeleted += deleted
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+
+	if totalDeleted > 0 {
+		rs.logger.Infof("pruned %v pending tx signature lookups", totalDeleted)
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/db/dbtest"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func newTestLogger() logrus.FieldLogger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	return logger
+}
+
+// useTestStore replaces the active db store for the duration of the test.
+func useTestStore(t *testing.T, store db.Store) {
+	prevStore := db.GetStore()
+	db.SetStore(store)
+	t.Cleanup(func() {
+		db.SetStore(prevStore)
+	})
+}
+
+func newTestRetentionService(t *testing.T, store db.Store) *RetentionService {
+	useTestStore(t, store)
+
+	return &RetentionService{
+		logger: newTestLogger(),
+		state:  &dbtypes.RetentionState{},
+	}
+}
+
+func formatMissedSlotRanges(missedRanges []*dbtypes.MissedSlotRange) string {
+	formatted := ""
+	for _, missedRange := range missedRanges {
+		formatted += fmt.Sprintf("[%v-%v]", missedRange.FirstSlot, missedRange.LastSlot)
+	}
+	return formatted
+}
+
+func TestBuildMissedSlotRanges(t *testing.T) {
+	tests := []struct {
+		slots    []uint64
+		expected string
+	}{
+		{nil, ""},
+		{[]uint64{5}, "[5-5]"},
+		{[]uint64{1, 2, 3, 5, 6, 10}, "[1-3][5-6][10-10]"},
+		{[]uint64{0, 2, 4}, "[0-0][2-2][4-4]"},
+	}
+
+	for _, test := range tests {
+		if ranges := formatMissedSlotRanges(buildMissedSlotRanges(test.slots)); ranges != test.expected {
+			t.Errorf("ranges of %v: expected %v, got %v", test.slots, test.expected, ranges)
+		}
+	}
+}
+
+func TestCompactMissedSlots(t *testing.T) {
+	store := dbtest.NewStore()
+	rs := newTestRetentionService(t, store)
+
+	store.AddMissedSlots(1, 2, 3, 5, 6, 10, 25)
+	if err := rs.compactMissedSlots(context.Background(), 20, 3); err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+
+	if ranges := formatMissedSlotRanges(store.GetMissedSlotRanges(0, 100)); ranges != "[1-3][5-6][10-10]" {
+		t.Errorf("unexpected missed slot ranges: %v", ranges)
+	}
+	if missedSlots, _ := store.GetMissedSlotsBefore(100, 100, nil); len(missedSlots) != 1 || missedSlots[0] != 25 {
+		t.Errorf("expected only missed slot 25 to be left, got %v", missedSlots)
+	}
+
+	// two full batches and a final empty batch that stores the retention state
+	if store.Commits != 3 {
+		t.Errorf("expected 3 transactions, got %v", store.Commits)
+	}
+	if rs.GetMissedSlotsPrunedBefore() != 20 {
+		t.Errorf("expected missed slots to be compacted before slot 20, got %v", rs.GetMissedSlotsPrunedBefore())
+	}
+}
+
+func TestCompactMissedSlotsExtendsPreviousRange(t *testing.T) {
+	store := dbtest.NewStore()
+	rs := newTestRetentionService(t, store)
+
+	store.AddMissedSlots(1, 2, 3)
+	if err := rs.compactMissedSlots(context.Background(), 4, 10); err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+
+	store.AddMissedSlots(4, 5, 8)
+	if err := rs.compactMissedSlots(context.Background(), 10, 10); err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+
+	if ranges := formatMissedSlotRanges(store.GetMissedSlotRanges(0, 100)); ranges != "[1-5][8-8]" {
+		t.Errorf("unexpected missed slot ranges: %v", ranges)
+	}
+}
+
+type failingRangeStore struct {
+	*dbtest.Store
+}
+
+func (store *failingRangeStore) InsertMissedSlotRange(missedRange *dbtypes.MissedSlotRange, tx db.Tx) error {
+	return fmt.Errorf("insert failed")
+}
+
+func TestCompactMissedSlotsKeepsStateOnError(t *testing.T) {
+	store := dbtest.NewStore()
+	rs := newTestRetentionService(t, &failingRangeStore{Store: store})
+
+	store.AddMissedSlots(1, 2)
+	if err := rs.compactMissedSlots(context.Background(), 20, 10); err == nil {
+		t.Fatalf("expected compaction error")
+	}
+
+	if store.Rollbacks != 1 || store.Commits != 0 {
+		t.Errorf("expected the transaction to be rolled back, commits: %v, rollbacks: %v", store.Commits, store.Rollbacks)
+	}
+	if rs.GetMissedSlotsPrunedBefore() != 0 {
+		t.Errorf("expected the retention state to be unchanged, got %v", rs.GetMissedSlotsPrunedBefore())
+	}
+	if missedSlots, _ := store.GetMissedSlotsBefore(100, 100, nil); len(missedSlots) != 2 {
+		t.Errorf("expected the missed slots to be kept, got %v", missedSlots)
+	}
+}
@@ -0,0 +1,560 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethpandaops/dora/config"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// TxSignatureRegistry is an in-memory registry of known function & event signatures.
+// it is filled from the bundled signature database, user supplied signature files and contract ABIs.
+// events are stored as list per topic, as events with the same signature may differ in their indexed arguments
+// (eg. ERC20 & ERC721 Transfer events).
+type TxSignatureRegistry struct {
+	mutex     sync.RWMutex
+	functions map[types.TxSignatureBytes]*abi.Method
+	events    map[common.Hash][]*abi.Event
+	contracts map[common.Address]*abi.ABI
+}
+
+// TxDecodedArg is a single decoded call or event argument.
+type TxDecodedArg struct {
+	Name    string
+	Type    string
+	Value   string
+	Indexed bool
+}
+
+// TxDecodedLog is a decoded event log.
+type TxDecodedLog struct {
+	Index     uint64
+	Address   common.Address
+	Name      string
+	Signature string
+	Topics    []common.Hash
+	Data      []byte
+	Args      []*TxDecodedArg
+}
+
+// abiFileArtifact is the format of compiler artifacts (hardhat, foundry, truffle), which contain the ABI in an "abi" field.
+type abiFileArtifact struct {
+	Address string          `json:"address"`
+	Abi     json.RawMessage `json:"abi"`
+}
+
+func newTxSignatureRegistry() *TxSignatureRegistry {
+	return &TxSignatureRegistry{
+		functions: map[types.TxSignatureBytes]*abi.Method{},
+		events:    map[common.Hash][]*abi.Event{},
+		contracts: map[common.Address]*abi.ABI{},
+	}
+}
+
+// loadTxSignatureRegistry creates the signature registry from all configured local sources.
+func loadTxSignatureRegistry() *TxSignatureRegistry {
+	registry := newTxSignatureRegistry()
+
+	if !utils.Config.TxSignature.DisableBundledDb {
+		count, err := registry.loadSignatureText(config.TxSignaturesTxt, "bundled signature database")
+		if err != nil {
+			logger_tss.Warnf("error loading bundled signature database: %v", err)
+		}
+		logger_tss.Debugf("loaded %v signatures from bundled signature database", count)
+	}
+
+	for _, path := range utils.Config.TxSignature.SignatureFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger_tss.Warnf("error reading signature file %v: %v", path, err)
+			continue
+		}
+
+		count, err := registry.loadSignatureText(string(data), path)
+		if err != nil {
+			logger_tss.Warnf("error loading signature file %v: %v", path, err)
+		}
+		logger_tss.Infof("loaded %v signatures from %v", count, path)
+	}
+
+	for _, path := range utils.Config.TxSignature.AbiFiles {
+		count, err := registry.loadAbiPath(path)
+		if err != nil {
+			logger_tss.Warnf("error loading abi files from %v: %v", path, err)
+		}
+		logger_tss.Infof("loaded %v contract ABIs from %v", count, path)
+	}
+
+	return registry
+}
+
+// loadSignatureText loads human readable abi definitions (one function or event per line).
+// invalid lines are skipped, so a single bad definition doesn't drop the rest of the file.
+func (registry *TxSignatureRegistry) loadSignatureText(text string, source string) (int, error) {
+	count := 0
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		if err := registry.addSignature(line); err != nil {
+			logger_tss.Warnf("skipping invalid signature in %v (line %v): %v", source, lineNum, err)
+			continue
+		}
+		count++
+	}
+
+	return count, scanner.Err()
+}
+
+// addSignature parses a human readable abi definition like "function transfer(address to, uint256 amount)" or "event Transfer(address indexed from, ...)".
+// definitions without prefix are treated as function signatures.
+func (registry *TxSignatureRegistry) addSignature(definition string) error {
+	isEvent := false
+	switch {
+	case strings.HasPrefix(definition, "event "):
+		isEvent = true
+		definition = strings.TrimPrefix(definition, "event ")
+	case strings.HasPrefix(definition, "function "):
+		definition = strings.TrimPrefix(definition, "function ")
+	}
+
+	argsStart := strings.Index(definition, "(")
+	argsEnd := strings.LastIndex(definition, ")")
+	if argsStart <= 0 || argsEnd < argsStart {
+		return fmt.Errorf("invalid signature: %v", definition)
+	}
+
+	name := strings.TrimSpace(definition[:argsStart])
+	params, err := parseAbiParams(definition[argsStart+1 : argsEnd])
+	if err != nil {
+		return fmt.Errorf("invalid signature %v: %v", definition, err)
+	}
+
+	args := make(abi.Arguments, len(params))
+	for idx, param := range params {
+		argType, err := abi.NewType(param.Type, "", param.Components)
+		if err != nil {
+			return fmt.Errorf("invalid type %v in %v: %v", param.Type, definition, err)
+		}
+		args[idx] = abi.Argument{
+			Name:    param.Name,
+			Type:    argType,
+			Indexed: param.Indexed,
+		}
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if isEvent {
+		event := abi.NewEvent(name, name, false, args)
+		registry.addEvent(&event)
+	} else {
+		method := abi.NewMethod(name, name, abi.Function, "", false, false, args, nil)
+		registry.functions[types.TxSignatureBytes(method.ID)] = &method
+	}
+
+	return nil
+}
+
+// parseAbiParams parses a comma separated list of human readable abi parameters, including nested tuples.
+func parseAbiParams(paramsStr string) ([]abi.ArgumentMarshaling, error) {
+	params := []abi.ArgumentMarshaling{}
+	paramsStr = strings.TrimSpace(paramsStr)
+	if paramsStr == "" {
+		return params, nil
+	}
+
+	depth := 0
+	start := 0
+	parts := []string{}
+	for idx, char := range paramsStr {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, paramsStr[start:idx])
+				start = idx + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	parts = append(parts, paramsStr[start:])
+
+	for idx, part := range parts {
+		part = strings.TrimSpace(part)
+		param := abi.ArgumentMarshaling{}
+
+		var typeStr, rest string
+		if strings.HasPrefix(part, "(") {
+			closeIdx := strings.LastIndex(part, ")")
+			components, err := parseAbiParams(part[1:closeIdx])
+			if err != nil {
+				return nil, err
+			}
+			for cidx := range components {
+				if components[cidx].Name == "" {
+					components[cidx].Name = fmt.Sprintf("field%v", cidx)
+				}
+			}
+			param.Components = components
+
+			suffixEnd := closeIdx + 1
+			for suffixEnd < len(part) && part[suffixEnd] != ' ' {
+				suffixEnd++
+			}
+			typeStr = "tuple" + part[closeIdx+1:suffixEnd]
+			rest = part[suffixEnd:]
+		} else {
+			fields := strings.SplitN(part, " ", 2)
+			typeStr = fields[0]
+			if len(fields) > 1 {
+				rest = fields[1]
+			}
+		}
+
+		for _, field := range strings.Fields(rest) {
+			switch field {
+			case "indexed":
+				param.Indexed = true
+			case "memory", "calldata", "storage", "payable":
+			default:
+				param.Name = field
+			}
+		}
+
+		param.Type = typeStr
+		if param.Name == "" {
+			param.Name = fmt.Sprintf("arg%v", idx)
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+// loadAbiPath loads contract ABIs from a json file or all json files in a directory.
+func (registry *TxSignatureRegistry) loadAbiPath(path string) (int, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	if !stat.IsDir() {
+		return 1, registry.loadAbiFile(path)
+	}
+
+	count := 0
+	err = filepath.WalkDir(path, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			return nil
+		}
+
+		if err := registry.loadAbiFile(filePath); err != nil {
+			logger_tss.Warnf("error loading abi file %v: %v", filePath, err)
+			return nil
+		}
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+// loadAbiFile loads a single ABI json file.
+// the file may either contain a plain ABI array or a compiler artifact with "abi" (and optional "address") field.
+// if the file name is a contract address (0x....json), the ABI is bound to that contract.
+func (registry *TxSignatureRegistry) loadAbiFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	abiData := data
+	addressStr := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		artifact := &abiFileArtifact{}
+		if err := json.Unmarshal(data, artifact); err != nil {
+			return fmt.Errorf("error parsing artifact: %v", err)
+		}
+		if len(artifact.Abi) == 0 {
+			return fmt.Errorf("no abi found in artifact")
+		}
+		abiData = artifact.Abi
+		if artifact.Address != "" {
+			addressStr = artifact.Address
+		}
+	}
+
+	contractAbi, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		return fmt.Errorf("error parsing abi: %v", err)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	// all methods & events are added to the global signature lists, so they're resolved for other contracts too
+	for _, method := range contractAbi.Methods {
+		method := method
+		registry.functions[types.TxSignatureBytes(method.ID)] = &method
+	}
+	for _, event := range contractAbi.Events {
+		event := event
+		registry.addEvent(&event)
+	}
+
+	if common.IsHexAddress(addressStr) {
+		registry.contracts[common.HexToAddress(addressStr)] = &contractAbi
+	}
+
+	return nil
+}
+
+// addEvent adds an event definition to the list of its topic.
+// a known definition with the same number of indexed arguments is replaced. the caller must hold the write lock.
+func (registry *TxSignatureRegistry) addEvent(event *abi.Event) {
+	indexedCount := getEventIndexedCount(event)
+	events := registry.events[event.ID]
+	for idx, knownEvent := range events {
+		if getEventIndexedCount(knownEvent) == indexedCount {
+			events[idx] = event
+			return
+		}
+	}
+
+	registry.events[event.ID] = append(events, event)
+}
+
+func getEventIndexedCount(event *abi.Event) int {
+	count := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			count++
+		}
+	}
+	return count
+}
+
+// LookupFunction returns the function definition for the given selector.
+func (registry *TxSignatureRegistry) LookupFunction(sigBytes types.TxSignatureBytes) *abi.Method {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return registry.functions[sigBytes]
+}
+
+// LookupEvent returns the event definition for the given topic, that matches the number of topics of the log.
+func (registry *TxSignatureRegistry) LookupEvent(topic common.Hash, topicCount int) *abi.Event {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return registry.getTopicEvent(topic, topicCount)
+}
+
+// getTopicEvent returns the event of the topic with one indexed argument per additional topic. the caller must hold the read lock.
+func (registry *TxSignatureRegistry) getTopicEvent(topic common.Hash, topicCount int) *abi.Event {
+	for _, event := range registry.events[topic] {
+		if getEventIndexedCount(event) == topicCount-1 {
+			return event
+		}
+	}
+	return nil
+}
+
+// getContractMethod returns the method from the contract specific ABI if available, or from the global signature list.
+func (registry *TxSignatureRegistry) getContractMethod(contract *common.Address, sigBytes types.TxSignatureBytes) *abi.Method {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	if contract != nil {
+		if contractAbi := registry.contracts[*contract]; contractAbi != nil {
+			if method, err := contractAbi.MethodById(sigBytes[:]); err == nil {
+				return method
+			}
+		}
+	}
+
+	return registry.functions[sigBytes]
+}
+
+// getContractEvent returns the event from the contract specific ABI if available, or from the global signature list.
+func (registry *TxSignatureRegistry) getContractEvent(contract common.Address, topic common.Hash, topicCount int) *abi.Event {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	if contractAbi := registry.contracts[contract]; contractAbi != nil {
+		if event, err := contractAbi.EventByID(topic); err == nil && getEventIndexedCount(event) == topicCount-1 {
+			return event
+		}
+	}
+
+	return registry.getTopicEvent(topic, topicCount)
+}
+
+// DecodeCallData decodes the call arguments of a transaction.
+// the signature text is used as fallback if the method is not known to the registry (eg. resolved via 4byte.directory).
+func (registry *TxSignatureRegistry) DecodeCallData(contract *common.Address, signature string, data []byte) ([]*TxDecodedArg, error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	method := registry.getContractMethod(contract, types.TxSignatureBytes(data[0:4]))
+	if method == nil && signature != "" {
+		tmpRegistry := newTxSignatureRegistry()
+		if err := tmpRegistry.addSignature(signature); err != nil {
+			return nil, err
+		}
+		method = tmpRegistry.functions[types.TxSignatureBytes(data[0:4])]
+	}
+	if method == nil {
+		return nil, nil
+	}
+
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding call data for %v: %v", method.Sig, err)
+	}
+
+	args := make([]*TxDecodedArg, len(values))
+	for idx, value := range values {
+		args[idx] = &TxDecodedArg{
+			Name:  method.Inputs[idx].Name,
+			Type:  method.Inputs[idx].Type.String(),
+			Value: formatAbiValue(value),
+		}
+	}
+
+	return args, nil
+}
+
+// DecodeLog decodes an event log. logs with unknown events are returned with name & args unset.
+func (registry *TxSignatureRegistry) DecodeLog(log *ethtypes.Log) *TxDecodedLog {
+	decodedLog := &TxDecodedLog{
+		Index:   uint64(log.Index),
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}
+	if len(log.Topics) == 0 {
+		return decodedLog
+	}
+
+	event := registry.getContractEvent(log.Address, log.Topics[0], len(log.Topics))
+	if event == nil {
+		return decodedLog
+	}
+	decodedLog.Name = event.Name
+	decodedLog.Signature = event.Sig
+
+	values, err := event.Inputs.NonIndexed().UnpackValues(log.Data)
+	if err != nil {
+		return decodedLog
+	}
+
+	args := make([]*TxDecodedArg, 0, len(event.Inputs))
+	topicIdx := 1
+	valueIdx := 0
+	for _, input := range event.Inputs {
+		arg := &TxDecodedArg{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+		}
+
+		if input.Indexed {
+			if topicIdx >= len(log.Topics) {
+				return decodedLog
+			}
+			topic := log.Topics[topicIdx]
+			topicIdx++
+
+			switch input.Type.T {
+			case abi.AddressTy:
+				arg.Value = common.BytesToAddress(topic[:]).String()
+			case abi.IntTy, abi.UintTy, abi.BoolTy:
+				topicValues, err := abi.Arguments{{Type: input.Type}}.UnpackValues(topic[:])
+				if err == nil && len(topicValues) == 1 {
+					arg.Value = formatAbiValue(topicValues[0])
+				} else {
+					arg.Value = topic.String()
+				}
+			default:
+				// dynamic types are stored as hash in topics
+				arg.Value = topic.String()
+			}
+		} else {
+			if valueIdx >= len(values) {
+				return decodedLog
+			}
+			arg.Value = formatAbiValue(values[valueIdx])
+			valueIdx++
+		}
+
+		args = append(args, arg)
+	}
+	decodedLog.Args = args
+
+	return decodedLog
+}
+
+// formatAbiValue formats a decoded abi value for display.
+func formatAbiValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.String()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return fmt.Sprintf("0x%x", v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bytes), rv)
+			return fmt.Sprintf("0x%x", bytes)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]string, rv.Len())
+		for idx := 0; idx < rv.Len(); idx++ {
+			items[idx] = formatAbiValue(rv.Index(idx).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, rv.NumField())
+		for idx := 0; idx < rv.NumField(); idx++ {
+			fields[idx] = fmt.Sprintf("%v: %v", rv.Type().Field(idx).Name, formatAbiValue(rv.Field(idx).Interface()))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+
+	return fmt.Sprintf("%v", value)
+}
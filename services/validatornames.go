@@ -20,7 +20,6 @@ import (
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
 	"github.com/ethpandaops/dora/utils"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
@@ -35,12 +34,15 @@ type ValidatorNames struct {
 	lastResolvedMapUpdate time.Time
 	lastInventoryRefresh  time.Time
 	updaterRunning        bool
+	updateMutex           sync.Mutex // serializes the updater loop & forced refreshes
 	namesMutex            sync.RWMutex
 	namesByIndex          map[uint64]*validatorNameEntry
 	namesByWithdrawal     map[common.Address]*validatorNameEntry
 	namesByDepositOrigin  map[common.Address]*validatorNameEntry
 	namesByDepositTarget  map[common.Address]*validatorNameEntry
 	resolvedNamesByIndex  map[uint64]*validatorNameEntry
+	nameCountsMutex       sync.RWMutex
+	nameCounts            map[string]uint64 // rebuilt after each (re)load & resolve
 }
 
 type validatorNameEntry struct {
@@ -80,6 +82,9 @@ func (vn *ValidatorNames) runUpdaterLoop() {
 }
 
 func (vn *ValidatorNames) runUpdater() error {
+	vn.updateMutex.Lock()
+	defer vn.updateMutex.Unlock()
+
 	needUpdate := false
 
 	if utils.Config.Frontend.ValidatorNamesRefreshInterval > 0 && time.Since(vn.lastInventoryRefresh) > utils.Config.Frontend.ValidatorNamesRefreshInterval {
@@ -111,6 +116,23 @@ func (vn *ValidatorNames) runUpdater() error {
 	return nil
 }
 
+// RefreshValidatorNames reloads the validator names from the configured sources, resolves the names by withdrawal & deposit addresses and updates the db.
+func (vn *ValidatorNames) RefreshValidatorNames() error {
+	vn.updateMutex.Lock()
+	defer vn.updateMutex.Unlock()
+
+	logger_vn.Infof("force refreshing validator names")
+	<-vn.LoadValidatorNames()
+
+	_, err := vn.resolveNames()
+	if err != nil {
+		return err
+	}
+	vn.lastResolvedMapUpdate = time.Now()
+
+	return vn.UpdateDb()
+}
+
 func (vn *ValidatorNames) resolveNames() (bool, error) {
 	validatorSet := vn.beaconIndexer.GetCanonicalValidatorSet(nil)
 	if validatorSet == nil {
@@ -200,7 +222,11 @@ func (vn *ValidatorNames) resolveNames() (bool, error) {
 	}
 
 	if hasUpdates {
+		vn.namesMutex.Lock()
 		vn.resolvedNamesByIndex = newResolvedNames
+		vn.namesMutex.Unlock()
+
+		vn.updateNameCounts()
 	}
 
 	return hasUpdates, nil
@@ -253,6 +279,35 @@ func (vn *ValidatorNames) GetValidatorNamesCount() uint64 {
 	return uint64(len(maps.Keys(vn.namesByIndex)) + len(maps.Keys(vn.namesByWithdrawal)))
 }
 
+// GetValidatorNameCounts returns all distinct validator names with the number of validators using them.
+// the counts are built when the names are (re)loaded or resolved, the returned map must not be modified.
+func (vn *ValidatorNames) GetValidatorNameCounts() map[string]uint64 {
+	vn.nameCountsMutex.RLock()
+	defer vn.nameCountsMutex.RUnlock()
+
+	return vn.nameCounts
+}
+
+// updateNameCounts rebuilds the cached validator name counts from the loaded & resolved names.
+func (vn *ValidatorNames) updateNameCounts() {
+	nameCounts := map[string]uint64{}
+
+	vn.namesMutex.RLock()
+	for _, name := range vn.namesByIndex {
+		nameCounts[name.name]++
+	}
+	for index, name := range vn.resolvedNamesByIndex {
+		if vn.namesByIndex[index] == nil {
+			nameCounts[name.name]++
+		}
+	}
+	vn.namesMutex.RUnlock()
+
+	vn.nameCountsMutex.Lock()
+	vn.nameCounts = nameCounts
+	vn.nameCountsMutex.Unlock()
+}
+
 func (vn *ValidatorNames) LoadValidatorNames() chan bool {
 	vn.loadingMutex.Lock()
 	defer vn.loadingMutex.Unlock()
@@ -295,6 +350,8 @@ func (vn *ValidatorNames) LoadValidatorNames() chan bool {
 				logger_vn.WithError(err).Errorf("error while loading validator names inventory")
 			}
 		}
+
+		vn.updateNameCounts()
 	}()
 
 	return vn.loading
@@ -482,7 +539,7 @@ func (vn *ValidatorNames) UpdateDb() error {
 			removeIndexes = append(removeIndexes, index)
 		}
 
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := db.RunDBTransaction(func(tx db.Tx) error {
 			if len(updateNames) > 0 {
 				err := db.InsertValidatorNames(updateNames, tx)
 				if err != nil {
@@ -131,7 +131,7 @@ $_network.create = function (container, data){
       );
       // Add style to nodes
       stylesheet.selector('#' + data.nodes[i].id).css({
-          'background-image': '/identicon?key=' + data.nodes[i].id
+          'background-image': window.doraBasePath + '/identicon?key=' + data.nodes[i].id
       });
     }
   }
@@ -118,67 +118,18 @@
       return settings;
     }
 
-    var bhSlots = new Bloodhound({
+    var bhAll = new Bloodhound({
       datumTokenizer: Bloodhound.tokenizers.whitespace,
       queryTokenizer: Bloodhound.tokenizers.whitespace,
       identify: function (obj) {
-        return obj.slot
+        return obj.link
       },
       remote: {
-        url: "/search/slots?q=",
+        url: window.doraBasePath + "/search/all?q=",
         prepare: prepareQueryFn,
         maxPendingRequests: requestNum,
       },
     });
-    var bhExecBlocks = new Bloodhound({
-      datumTokenizer: Bloodhound.tokenizers.whitespace,
-      queryTokenizer: Bloodhound.tokenizers.whitespace,
-      identify: function (obj) {
-        return obj.slot
-      },
-      remote: {
-        url: "/search/execblocks?q=",
-        prepare: prepareQueryFn,
-        maxPendingRequests: requestNum,
-      },
-    });
-    var bhEpochs = new Bloodhound({
-      datumTokenizer: Bloodhound.tokenizers.whitespace,
-      queryTokenizer: Bloodhound.tokenizers.whitespace,
-      identify: function (obj) {
-        return obj.epoch
-      },
-      remote: {
-        url: "/search/epochs?q=",
-        prepare: prepareQueryFn,
-        maxPendingRequests: requestNum,
-      },
-    });
-    var bhGraffiti = new Bloodhound({
-      datumTokenizer: Bloodhound.tokenizers.whitespace,
-      queryTokenizer: Bloodhound.tokenizers.whitespace,
-      identify: function (obj) {
-        return obj.graffiti
-      },
-      remote: {
-        url: "/search/graffiti?q=",
-        prepare: prepareQueryFn,
-        maxPendingRequests: requestNum,
-      },
-    });
-    var bhValNames = new Bloodhound({
-      datumTokenizer: Bloodhound.tokenizers.whitespace,
-      queryTokenizer: Bloodhound.tokenizers.whitespace,
-      identify: function (obj) {
-        return obj.name
-      },
-      remote: {
-        url: "/search/valname?q=",
-        prepare: prepareQueryFn,
-        maxPendingRequests: requestNum,
-      },
-    });
-
 
     searchEl.typeahead(
       {
@@ -188,70 +139,17 @@
         autoselect: false,
       },
       {
-        limit: 5,
-        name: "slot",
-        source: bhSlots,
-        display: "root",
-        templates: {
-          header: '<h3 class="h5">Slots:</h3>',
-          suggestion: function (data) {
-            var status = "";
-            if (data.orphaned) {
-              status = `<span class="search-cell"><span class="badge rounded-pill text-bg-info">Orphaned</span></span>`;
-            }
-            return `<div class="text-monospace"><div class="search-table"><span class="search-cell">${data.slot}:</span><span class="search-cell search-truncate">${data.root}</span>${status}</div></div>`;
-          },
-        },
-      },
-      {
-        limit: 5,
-        name: "execblocks",
-        source: bhExecBlocks,
-        display: "root",
+        limit: 10,
+        name: "all",
+        source: bhAll,
+        display: "title",
         templates: {
-          header: '<h3 class="h5">Slots (by execution block):</h3>',
           suggestion: function (data) {
             var status = "";
             if (data.orphaned) {
               status = `<span class="search-cell"><span class="badge rounded-pill text-bg-info">Orphaned</span></span>`;
             }
-            return `<div class="text-monospace"><div class="search-table"><span class="search-cell">${data.slot}:</span><span class="search-cell search-truncate"><nobr>Block ${data.exec_number} / ${data.exec_hash}</nobr></span>${status}</div></div>`;
-          },
-        },
-      },
-      {
-        limit: 5,
-        name: "name",
-        source: bhValNames,
-        display: "name",
-        templates: {
-          header: '<h3 class="h5">Slots (by validator name):</h3>',
-          suggestion: function (data) {
-            return `<div class="text-monospace" style="display:flex"><div class="text-truncate" style="flex:1 1 auto;">${data.name}</div><div style="max-width:fit-content;white-space:nowrap;">${data.count}</div></div>`
-          },
-        },
-      },
-      {
-        limit: 5,
-        name: "epoch",
-        source: bhEpochs,
-        display: "epoch",
-        templates: {
-          header: '<h3 class="h5">Epochs:</h3>',
-          suggestion: function (data) {
-            return `<div class="text-monospace">${data.epoch}</div>`
-          },
-        },
-      },
-      {
-        limit: 5,
-        name: "graffiti",
-        source: bhGraffiti,
-        display: "graffiti",
-        templates: {
-          header: '<h3 class="h5">Blocks (by graffitis):</h3>',
-          suggestion: function (data) {
-            return `<div class="text-monospace" style="display:flex"><div class="text-truncate" style="flex:1 1 auto;">${data.graffiti}</div><div style="max-width:fit-content;white-space:nowrap;">${data.count}</div></div>`
+            return `<div class="text-monospace"><div class="search-table"><span class="search-cell"><span class="badge rounded-pill text-bg-secondary">${data.type_label}</span></span><span class="search-cell search-truncate">${data.title}</span><span class="search-cell search-truncate text-secondary">${data.description || ""}</span>${status}</div></div>`;
           },
         },
       }
@@ -270,24 +168,8 @@
     })
   
     searchEl.on("typeahead:select", function (ev, sug) {
-      if (sug.root !== undefined) {
-        if (sug.orphaned) {
-          window.location = "/slot/" + sug.root
-        } else {
-          window.location = "/slot/" + sug.slot
-        }
-      } else if (sug.epoch !== undefined) {
-        window.location = "/epoch/" + sug.epoch
-      } else if (sug.graffiti !== undefined) {
-        // sug.graffiti is html-escaped to prevent xss, we need to unescape it
-        var el = document.createElement("textarea")
-        el.innerHTML = sug.graffiti
-        window.location = "/slots/filtered?f&f.orphaned=1&f.graffiti=" + encodeURIComponent(el.value)
-      } else if (sug.name !== undefined) {
-          // sug.name is html-escaped to prevent xss, we need to unescape it
-          var el = document.createElement("textarea")
-          el.innerHTML = sug.name
-          window.location = "/slots/filtered?f&f.missing=1&f.orphaned=1&f.pname=" + encodeURIComponent(el.value)
+      if (sug.link !== undefined) {
+        window.location = window.doraBasePath + sug.link
       } else {
         console.log("invalid typeahead-selection", sug)
       }
@@ -55,7 +55,7 @@
     isRefreshing = true;
 
     try {
-      var pageData = await $.get("/index/data");
+      var pageData = await $.get(window.doraBasePath + "/index/data");
       updateModel(pageData);
 
       //console.log(pageData)
@@ -135,9 +135,9 @@
       return `<span class="validator-label validator-index"><i class="fas ` + icon + `"></i> unknown</span>`;
     }
     if(name != "") {
-      return `<span class="validator-label validator-name" data-bs-toggle="tooltip" data-bs-placement="top" data-bs-title="` + idx + `"><i class="fas ` + icon + `"></i> <a href="/validator/` + idx + `">` + escapeHtml(name) + `</a></span>`;
+      return `<span class="validator-label validator-name" data-bs-toggle="tooltip" data-bs-placement="top" data-bs-title="` + idx + `"><i class="fas ` + icon + `"></i> <a href="` + window.doraBasePath + `/validator/` + idx + `">` + escapeHtml(name) + `</a></span>`;
     }
-    return `<span class="validator-label validator-index"><i class="fas ` + icon + `"></i> <a href="/validator/` + idx + `">` + idx + `</a></span>`
+    return `<span class="validator-label validator-index"><i class="fas ` + icon + `"></i> <a href="` + window.doraBasePath + `/validator/` + idx + `">` + idx + `</a></span>`
   }
 
   function base64ToHex(str) {
@@ -15,6 +15,9 @@ type Config struct {
 	Server struct {
 		Port string `yaml:"port" envconfig:"FRONTEND_SERVER_PORT"`
 		Host string `yaml:"host" envconfig:"FRONTEND_SERVER_HOST"`
+
+		// unix socket to listen on instead of host & port
+		Socket string `yaml:"socket" envconfig:"FRONTEND_SERVER_SOCKET"`
 	} `yaml:"server"`
 
 	Chain struct {
@@ -31,6 +34,9 @@ type Config struct {
 		Pprof   bool `yaml:"pprof" envconfig:"FRONTEND_PPROF"`
 		Minify  bool `yaml:"minify" envconfig:"FRONTEND_MINIFY"`
 
+		// path prefix the frontend is served under (eg. "/mainnet"), all links & routes are prefixed with it
+		BasePath string `yaml:"basePath" envconfig:"FRONTEND_BASE_PATH"`
+
 		SiteDomain      string `yaml:"siteDomain" envconfig:"FRONTEND_SITE_DOMAIN"`
 		SiteLogo        string `yaml:"siteLogo" envconfig:"FRONTEND_SITE_LOGO"`
 		SiteName        string `yaml:"siteName" envconfig:"FRONTEND_SITE_NAME"`
@@ -68,6 +74,11 @@ type Config struct {
 		RedisCachePrefix     string `yaml:"redisCachePrefix" envconfig:"BEACONAPI_REDIS_CACHE_PREFIX"`
 	} `yaml:"beaconapi"`
 
+	BeaconApiProxy struct {
+		Enabled            bool `yaml:"enabled" envconfig:"BEACONAPI_PROXY_ENABLED"`
+		DisableFallthrough bool `yaml:"disableFallthrough" envconfig:"BEACONAPI_PROXY_DISABLE_FALLTHROUGH"`
+	} `yaml:"beaconApiProxy"`
+
 	ExecutionApi struct {
 		Endpoint  string           `yaml:"endpoint" envconfig:"EXECUTIONAPI_ENDPOINT"`
 		Endpoints []EndpointConfig `yaml:"endpoints"`
@@ -75,25 +86,61 @@ type Config struct {
 		DepositLogBatchSize int `yaml:"depositLogBatchSize" envconfig:"EXECUTIONAPI_DEPOSIT_LOG_BATCH_SIZE"`
 	} `yaml:"executionapi"`
 
+	EndpointsFile struct {
+		Path          string        `yaml:"path" envconfig:"ENDPOINTS_FILE_PATH"`
+		WatchInterval time.Duration `yaml:"watchInterval" envconfig:"ENDPOINTS_FILE_WATCH_INTERVAL"`
+	} `yaml:"endpointsFile"`
+
+	EndpointDiscovery struct {
+		RefreshInterval time.Duration                   `yaml:"refreshInterval" envconfig:"ENDPOINT_DISCOVERY_REFRESH_INTERVAL"`
+		Sources         []EndpointDiscoverySourceConfig `yaml:"sources"`
+	} `yaml:"endpointDiscovery"`
+
+	AdminApi struct {
+		Enabled   bool   `yaml:"enabled" envconfig:"ADMINAPI_ENABLED"`
+		AuthToken string `yaml:"authToken" envconfig:"ADMINAPI_AUTH_TOKEN"`
+	} `yaml:"adminApi"`
+
 	Indexer struct {
 		ResyncFromEpoch   *uint64 `yaml:"resyncFromEpoch" envconfig:"INDEXER_RESYNC_FROM_EPOCH"`
 		ResyncForceUpdate bool    `yaml:"resyncForceUpdate" envconfig:"INDEXER_RESYNC_FORCE_UPDATE"`
 
+		BootstrapFromCheckpoint bool    `yaml:"bootstrapFromCheckpoint" envconfig:"INDEXER_BOOTSTRAP_FROM_CHECKPOINT"`
+		BootstrapEpoch          *uint64 `yaml:"bootstrapEpoch" envconfig:"INDEXER_BOOTSTRAP_EPOCH"`
+		BootstrapBackfill       bool    `yaml:"bootstrapBackfill" envconfig:"INDEXER_BOOTSTRAP_BACKFILL"`
+
+		EraImportDir    string `yaml:"eraImportDir" envconfig:"INDEXER_ERA_IMPORT_DIR"`
+		EraExportDir    string `yaml:"eraExportDir" envconfig:"INDEXER_ERA_EXPORT_DIR"`
+		EraExportStates bool   `yaml:"eraExportStates" envconfig:"INDEXER_ERA_EXPORT_STATES"`
+
+		PersistBlockBodies bool `yaml:"persistBlockBodies" envconfig:"INDEXER_PERSIST_BLOCK_BODIES"`
+
 		InMemoryEpochs                  uint16 `yaml:"inMemoryEpochs" envconfig:"INDEXER_IN_MEMORY_EPOCHS"`
 		CachePersistenceDelay           uint16 `yaml:"cachePersistenceDelay" envconfig:"INDEXER_CACHE_PERSISTENCE_DELAY"`
 		DisableIndexWriter              bool   `yaml:"disableIndexWriter" envconfig:"INDEXER_DISABLE_INDEX_WRITER"`
 		DisableSynchronizer             bool   `yaml:"disableSynchronizer" envconfig:"INDEXER_DISABLE_SYNCHRONIZER"`
 		SyncEpochCooldown               uint   `yaml:"syncEpochCooldown" envconfig:"INDEXER_SYNC_EPOCH_COOLDOWN"`
 		MaxParallelValidatorSetRequests uint   `yaml:"maxParallelValidatorSetRequests" envconfig:"INDEXER_MAX_PARALLEL_VALIDATOR_SET_REQUESTS"`
+		MaxParallelSyncEpochs           uint   `yaml:"maxParallelSyncEpochs" envconfig:"INDEXER_MAX_PARALLEL_SYNC_EPOCHS"`
 	} `yaml:"indexer"`
 
+	ClientCompare struct {
+		ConsensusEnabled  bool   `yaml:"consensusEnabled" envconfig:"CLIENT_COMPARE_CONSENSUS_ENABLED"`
+		BalanceSampleSize uint64 `yaml:"balanceSampleSize" envconfig:"CLIENT_COMPARE_BALANCE_SAMPLE_SIZE"`
+		ExecutionEnabled  bool   `yaml:"executionEnabled" envconfig:"CLIENT_COMPARE_EXECUTION_ENABLED"`
+	} `yaml:"clientCompare"`
+
 	TxSignature struct {
-		DisableLookupLoop bool          `yaml:"disableLookupLoop" envconfig:"TXSIG_DISABLE_LOOKUP_LOOP"`
-		LookupInterval    time.Duration `yaml:"lookupInterval" envconfig:"TXSIG_LOOKUP_INTERVAL"`
-		LookupBatchSize   uint64        `yaml:"lookupBatchSize" envconfig:"TXSIG_LOOKUP_INTERVAL"`
-		ConcurrencyLimit  uint64        `yaml:"concurrencyLimit" envconfig:"TXSIG_CONCURRENCY_LIMIT"`
-		Disable4Bytes     bool          `yaml:"disable4Bytes" envconfig:"TXSIG_DISABLE_4BYTES"`
-		RecheckTimeout    time.Duration `yaml:"recheckTimeout" envconfig:"TXSIG_RECHECK_TIMEOUT"`
+		DisableLookupLoop  bool          `yaml:"disableLookupLoop" envconfig:"TXSIG_DISABLE_LOOKUP_LOOP"`
+		LookupInterval     time.Duration `yaml:"lookupInterval" envconfig:"TXSIG_LOOKUP_INTERVAL"`
+		LookupBatchSize    uint64        `yaml:"lookupBatchSize" envconfig:"TXSIG_LOOKUP_INTERVAL"`
+		ConcurrencyLimit   uint64        `yaml:"concurrencyLimit" envconfig:"TXSIG_CONCURRENCY_LIMIT"`
+		Disable4Bytes      bool          `yaml:"disable4Bytes" envconfig:"TXSIG_DISABLE_4BYTES"`
+		RecheckTimeout     time.Duration `yaml:"recheckTimeout" envconfig:"TXSIG_RECHECK_TIMEOUT"`
+		DisableBundledDb   bool          `yaml:"disableBundledDb" envconfig:"TXSIG_DISABLE_BUNDLED_DB"`
+		SignatureFiles     []string      `yaml:"signatureFiles"`
+		AbiFiles           []string      `yaml:"abiFiles"`
+		DisableLogDecoding bool          `yaml:"disableLogDecoding" envconfig:"TXSIG_DISABLE_LOG_DECODING"`
 	} `yaml:"txsig"`
 
 	MevIndexer struct {
@@ -101,6 +148,16 @@ type Config struct {
 		RefreshInterval time.Duration    `yaml:"refreshInterval" envconfig:"MEVINDEXER_REFRESH_INTERVAL"`
 	} `yaml:"mevIndexer"`
 
+	Retention struct {
+		Interval            time.Duration `yaml:"interval" envconfig:"RETENTION_INTERVAL"`
+		BatchSize           uint32        `yaml:"batchSize" envconfig:"RETENTION_BATCH_SIZE"`
+		BlobEpochs          uint64        `yaml:"blobEpochs" envconfig:"RETENTION_BLOB_EPOCHS"`
+		MevBlockEpochs      uint64        `yaml:"mevBlockEpochs" envconfig:"RETENTION_MEV_BLOCK_EPOCHS"`
+		BlockBodyEpochs     uint64        `yaml:"blockBodyEpochs" envconfig:"RETENTION_BLOCK_BODY_EPOCHS"`
+		MissedSlotEpochs    uint64        `yaml:"missedSlotEpochs" envconfig:"RETENTION_MISSED_SLOT_EPOCHS"`
+		PendingSignatureAge time.Duration `yaml:"pendingSignatureAge" envconfig:"RETENTION_PENDING_SIGNATURE_AGE"`
+	} `yaml:"retention"`
+
 	Database struct {
 		Engine string `yaml:"engine" envconfig:"DATABASE_ENGINE"`
 		Sqlite struct {
@@ -126,8 +183,25 @@ type Config struct {
 			MaxOpenConns int    `yaml:"maxOpenConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_OPEN_CONNS"`
 			MaxIdleConns int    `yaml:"maxIdleConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_IDLE_CONNS"`
 		} `yaml:"pgsqlWriter"`
+		PgsqlReplicas []PgsqlReplicaConfig `yaml:"pgsqlReplicas"`
+		Replication   struct {
+			HealthCheckInterval time.Duration `yaml:"healthCheckInterval" envconfig:"DATABASE_REPLICATION_HEALTH_CHECK_INTERVAL"`
+			MaxLagSlots         uint64        `yaml:"maxLagSlots" envconfig:"DATABASE_REPLICATION_MAX_LAG_SLOTS"`
+		} `yaml:"replication"`
+		QueryTimeout time.Duration `yaml:"queryTimeout" envconfig:"DATABASE_QUERY_TIMEOUT"`
+		Analytics    struct {
+			Engine     string `yaml:"engine" envconfig:"DATABASE_ANALYTICS_ENGINE"`
+			Clickhouse struct {
+				Url      string `yaml:"url" envconfig:"DATABASE_ANALYTICS_CLICKHOUSE_URL"`
+				Database string `yaml:"database" envconfig:"DATABASE_ANALYTICS_CLICKHOUSE_DATABASE"`
+				Username string `yaml:"user" envconfig:"DATABASE_ANALYTICS_CLICKHOUSE_USERNAME"`
+				Password string `yaml:"password" envconfig:"DATABASE_ANALYTICS_CLICKHOUSE_PASSWORD"`
+			} `yaml:"clickhouse"`
+		} `yaml:"analytics"`
 	} `yaml:"database"`
 
+	Networks []NetworkConfig `yaml:"networks"`
+
 	KillSwitch struct {
 		DisableSSZEncoding      bool `yaml:"disableSSZEncoding" envconfig:"KILLSWITCH_DISABLE_SSZ_ENCODING"`
 		DisableSSZRequests      bool `yaml:"disableSSZRequests" envconfig:"KILLSWITCH_DISABLE_SSZ_REQUESTS"`
@@ -145,6 +219,37 @@ type EndpointConfig struct {
 	Headers        map[string]string  `yaml:"headers"`
 }
 
+// EndpointsFileConfig is the format of the watched endpoints file, that allows adding & removing endpoints at runtime.
+type EndpointsFileConfig struct {
+	BeaconApi    []EndpointConfig `yaml:"beaconapi"`
+	ExecutionApi []EndpointConfig `yaml:"executionapi"`
+}
+
+type EndpointDiscoverySourceConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // inventory, srv or directory
+
+	Url             string `yaml:"url"`             // inventory: json inventory url
+	BeaconRecord    string `yaml:"beaconRecord"`    // srv: dns srv record of the beacon nodes
+	ExecutionRecord string `yaml:"executionRecord"` // srv: dns srv record of the execution nodes
+	Scheme          string `yaml:"scheme"`          // srv: url scheme for discovered endpoints
+	Path            string `yaml:"path"`            // directory: directory with endpoint files
+
+	// defaults for all endpoints discovered by this source
+	Priority       int               `yaml:"priority"`
+	Archive        bool              `yaml:"archive"`
+	SkipValidators bool              `yaml:"skipValidators"`
+	Headers        map[string]string `yaml:"headers"`
+}
+
+type NetworkConfig struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"displayName"`
+	ConfigPath  string `yaml:"config"`
+	PathPrefix  string `yaml:"pathPrefix"`
+	Host        string `yaml:"host"`
+}
+
 type EndpointSshConfig struct {
 	Host     string `yaml:"host"`
 	Port     string `yaml:"port"`
@@ -166,6 +271,23 @@ type SqliteDatabaseConfig struct {
 	MaxIdleConns int
 }
 
+type ClickhouseDatabaseConfig struct {
+	Url      string
+	Database string
+	Username string
+	Password string
+}
+
+type PgsqlReplicaConfig struct {
+	Username     string `yaml:"user"`
+	Password     string `yaml:"password"`
+	Name         string `yaml:"name"`
+	Host         string `yaml:"host"`
+	Port         string `yaml:"port"`
+	MaxOpenConns int    `yaml:"maxOpenConns"`
+	MaxIdleConns int    `yaml:"maxIdleConns"`
+}
+
 type PgsqlDatabaseConfig struct {
 	Username     string
 	Password     string
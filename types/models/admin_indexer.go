@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+)
+
+// AdminIndexerPageData is a struct to hold info for the indexer admin page
+type AdminIndexerPageData struct {
+	LoginRequired bool   `json:"login_required"`
+	LoginFailed   bool   `json:"login_failed"`
+	ActionResult  string `json:"action_result"`
+	ActionError   string `json:"action_error"`
+
+	CurrentEpoch        uint64 `json:"current_epoch"`
+	FinalizedEpoch      uint64 `json:"finalized_epoch"`
+	CacheFinalizedEpoch uint64 `json:"cache_finalized_epoch"`
+	PrunedEpoch         uint64 `json:"pruned_epoch"`
+
+	SyncDisabled     bool   `json:"sync_disabled"`
+	SyncRunning      bool   `json:"sync_running"`
+	SyncCurrentEpoch uint64 `json:"sync_current_epoch"`
+	SyncRetryCount   uint64 `json:"sync_retry_count"`
+	SyncForceUpdate  bool   `json:"sync_force_update"`
+	SyncForceStart   uint64 `json:"sync_force_start"`
+	SyncForceEnd     uint64 `json:"sync_force_end"`
+
+	BackfillStarted     bool   `json:"backfill_started"`
+	BackfillRunning     bool   `json:"backfill_running"`
+	BackfillCompleted   bool   `json:"backfill_completed"`
+	BackfillStartEpoch  uint64 `json:"backfill_start_epoch"`
+	BackfillEndEpoch    uint64 `json:"backfill_end_epoch"`
+	BackfillNextEpoch   uint64 `json:"backfill_next_epoch"`
+	BackfillStages      string `json:"backfill_stages"`
+	BackfillFailedCount uint64 `json:"backfill_failed_count"`
+
+	Blocks          []*AdminIndexerPageDataBlock `json:"blocks"`
+	BlockCount      uint64                       `json:"block_count"`
+	BlockTotalCount uint64                       `json:"block_total_count"`
+	Epochs          []*AdminIndexerPageDataEpoch `json:"epochs"`
+	EpochCount      uint64                       `json:"epoch_count"`
+	Forks           []*AdminIndexerPageDataFork  `json:"forks"`
+	ForkCount       uint64                       `json:"fork_count"`
+
+	DepositStateLoaded  bool                            `json:"deposit_state_loaded"`
+	DepositFinalBlock   uint64                          `json:"deposit_final_block"`
+	DepositHeadBlock    uint64                          `json:"deposit_head_block"`
+	DepositIndex        uint64                          `json:"deposit_index"`
+	MevIndexerEnabled   bool                            `json:"mev_indexer_enabled"`
+	MevLastRefresh      time.Time                       `json:"mev_last_refresh"`
+	MevRelays           []*AdminIndexerPageDataMevRelay `json:"mev_relays"`
+	PendingTxSigCount   uint64                          `json:"pending_txsig_count"`
+	PendingTxSigs       []*AdminIndexerPageDataTxSig    `json:"pending_txsigs"`
+	ValidatorNamesCount uint64                          `json:"validator_names_count"`
+}
+
+type AdminIndexerPageDataBlock struct {
+	Slot             uint64   `json:"slot"`
+	Root             []byte   `json:"root"`
+	ForkId           uint64   `json:"fork_id"`
+	HasHeader        bool     `json:"has_header"`
+	HasBody          bool     `json:"has_body"`
+	IsCanonical      bool     `json:"canonical"`
+	InFinalizedDb    bool     `json:"in_finalized_db"`
+	InUnfinalizedDb  bool     `json:"in_unfinalized_db"`
+	ProcessingStatus string   `json:"processing_status"`
+	SeenBy           []string `json:"seen_by"`
+}
+
+type AdminIndexerPageDataEpoch struct {
+	Epoch         uint64    `json:"epoch"`
+	DependentRoot []byte    `json:"dependent_root"`
+	Ready         bool      `json:"ready"`
+	Pruned        bool      `json:"pruned"`
+	InDb          bool      `json:"in_db"`
+	RequestedBy   []string  `json:"requested_by"`
+	StateStatus   string    `json:"state_status"`
+	StateClient   string    `json:"state_client"`
+	StateStarted  time.Time `json:"state_started"`
+	StateRetries  uint64    `json:"state_retries"`
+}
+
+type AdminIndexerPageDataFork struct {
+	ForkId     uint64 `json:"fork_id"`
+	ParentFork uint64 `json:"parent_fork"`
+	BaseSlot   uint64 `json:"base_slot"`
+	BaseRoot   []byte `json:"base_root"`
+	LeafSlot   uint64 `json:"leaf_slot"`
+	LeafRoot   []byte `json:"leaf_root"`
+	HeadSlot   uint64 `json:"head_slot"`
+	HeadRoot   []byte `json:"head_root"`
+}
+
+type AdminIndexerPageDataMevRelay struct {
+	Index          uint8  `json:"index"`
+	Name           string `json:"name"`
+	LastLoadedSlot uint64 `json:"last_loaded_slot"`
+}
+
+type AdminIndexerPageDataTxSig struct {
+	Bytes     []byte    `json:"bytes"`
+	QueueTime time.Time `json:"queue_time"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// ChartsPageData is a struct to hold info for the network charts page
+type ChartsPageData struct {
+	Range         string                 `json:"range"`
+	Ranges        []*ChartsPageDataRange `json:"ranges"`
+	PeriodLabel   string                 `json:"period_label"`
+	FirstTime     time.Time              `json:"first_time"`
+	LastTime      time.Time              `json:"last_time"`
+	PointCount    uint64                 `json:"point_count"`
+	Charts        []*ChartsPageDataChart `json:"charts"`
+	RollupPending bool                   `json:"rollup_pending"`
+}
+
+type ChartsPageDataRange struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Active bool   `json:"active"`
+}
+
+type ChartsPageDataChart struct {
+	Id       string                        `json:"id"`
+	Title    string                        `json:"title"`
+	MinLabel string                        `json:"min_label"`
+	MaxLabel string                        `json:"max_label"`
+	Series   []*ChartsPageDataSeries       `json:"series"`
+	Tooltips []*ChartsPageDataPointTooltip `json:"tooltips"`
+}
+
+type ChartsPageDataSeries struct {
+	Label  string `json:"label"`
+	Color  string `json:"color"`
+	Latest string `json:"latest"`
+	Points string `json:"points"`
+}
+
+type ChartsPageDataPointTooltip struct {
+	X     float64 `json:"x"`
+	Width float64 `json:"width"`
+	Title string  `json:"title"`
+}
@@ -9,6 +9,27 @@ type ClientsCLPageData struct {
 	Clients     []*ClientsCLPageDataClient `json:"clients"`
 	ClientCount uint64                     `json:"client_count"`
 	PeerMap     *ClientCLPageDataPeerMap   `json:"peer_map"`
+
+	DivergenceEnabled    bool                           `json:"divergence_enabled"`
+	DivergenceCheckTime  time.Time                      `json:"divergence_check_time"`
+	DivergenceCheckSlot  uint64                         `json:"divergence_check_slot"`
+	DivergenceStateEpoch uint64                         `json:"divergence_state_epoch"`
+	DivergenceStateRoot  []byte                         `json:"divergence_state_root"`
+	Divergences          []*ClientsCLPageDataDivergence `json:"divergences"`
+}
+
+type ClientsCLPageDataDivergence struct {
+	Check           string                                 `json:"check"`
+	FirstSlot       uint64                                 `json:"first_slot"`
+	FirstSeen       time.Time                              `json:"first_seen"`
+	MajorityValue   string                                 `json:"majority_value"`
+	MajorityClients []string                               `json:"majority_clients"`
+	Minorities      []*ClientsCLPageDataDivergenceMinority `json:"minorities"`
+}
+
+type ClientsCLPageDataDivergenceMinority struct {
+	Value   string   `json:"value"`
+	Clients []string `json:"clients"`
 }
 
 type ClientsCLPageDataClient struct {
@@ -0,0 +1,51 @@
+package models
+
+// ClientsDiversityPageData is a struct to hold info for the client diversity page
+type ClientsDiversityPageData struct {
+	FirstEpoch      uint64                                `json:"first_epoch"`
+	LastEpoch       uint64                                `json:"last_epoch"`
+	RangePresets    []*ClientsDiversityPageDataPreset     `json:"range_presets"`
+	TotalBlocks     uint64                                `json:"total_blocks"`
+	ClClients       []*ClientsDiversityPageDataShare      `json:"cl_clients"`
+	ElClients       []*ClientsDiversityPageDataShare      `json:"el_clients"`
+	Combinations    []*ClientsDiversityPageDataCombo      `json:"combinations"`
+	Entities        []*ClientsDiversityPageDataEntity     `json:"entities"`
+	EntityCount     uint64                                `json:"entity_count"`
+	Timeline        []*ClientsDiversityPageDataTimeBucket `json:"timeline"`
+	BackfillPending bool                                  `json:"backfill_pending"`
+}
+
+type ClientsDiversityPageDataPreset struct {
+	Label      string `json:"label"`
+	FirstEpoch uint64 `json:"first_epoch"`
+	Active     bool   `json:"active"`
+}
+
+type ClientsDiversityPageDataShare struct {
+	Client string  `json:"client"`
+	Color  string  `json:"color"`
+	Blocks uint64  `json:"blocks"`
+	Share  float64 `json:"share"`
+}
+
+type ClientsDiversityPageDataCombo struct {
+	ClClient string  `json:"cl_client"`
+	ElClient string  `json:"el_client"`
+	Blocks   uint64  `json:"blocks"`
+	Share    float64 `json:"share"`
+}
+
+type ClientsDiversityPageDataEntity struct {
+	Name      string                           `json:"name"`
+	Blocks    uint64                           `json:"blocks"`
+	ClClients []*ClientsDiversityPageDataShare `json:"cl_clients"`
+	ElClients []*ClientsDiversityPageDataShare `json:"el_clients"`
+}
+
+type ClientsDiversityPageDataTimeBucket struct {
+	FirstEpoch uint64                           `json:"first_epoch"`
+	LastEpoch  uint64                           `json:"last_epoch"`
+	Blocks     uint64                           `json:"blocks"`
+	ClClients  []*ClientsDiversityPageDataShare `json:"cl_clients"`
+	ElClients  []*ClientsDiversityPageDataShare `json:"el_clients"`
+}
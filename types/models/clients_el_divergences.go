@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// ClientsELDivergencesPageData is a struct to hold info for the execution client divergences page
+type ClientsELDivergencesPageData struct {
+	LastCheckedNumber  uint64                                    `json:"last_checked_number"`
+	LastCheckTime      time.Time                                 `json:"last_check_time"`
+	LastCheckedClients uint64                                    `json:"last_checked_clients"`
+	Divergences        []*ClientsELDivergencesPageDataDivergence `json:"divergences"`
+	DivergenceCount    uint64                                    `json:"divergence_count"`
+}
+
+type ClientsELDivergencesPageDataDivergence struct {
+	Number     uint64                               `json:"number"`
+	DetectTime time.Time                            `json:"detect_time"`
+	Groups     []*ClientsELDivergencesPageDataGroup `json:"groups"`
+}
+
+type ClientsELDivergencesPageDataGroup struct {
+	IsMajority   bool     `json:"majority"`
+	Hash         []byte   `json:"hash"`
+	StateRoot    []byte   `json:"state_root"`
+	ReceiptsRoot []byte   `json:"receipts_root"`
+	GasUsed      uint64   `json:"gas_used"`
+	Clients      []string `json:"clients"`
+}
@@ -21,6 +21,8 @@ type EpochsPageData struct {
 	NextPageIndex    uint64 `json:"next_page_index"`
 	NextPageEpoch    uint64 `json:"next_page_epoch"`
 	LastPageEpoch    uint64 `json:"last_page_epoch"`
+
+	NotIndexedBefore uint64 `json:"not_indexed_before"`
 }
 
 type EpochsPageDataEpoch struct {
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// FinalityPageData is a struct to hold info for the finality incidents page
+type FinalityPageData struct {
+	CurrentEpoch      uint64                      `json:"current_epoch"`
+	FinalizedEpoch    uint64                      `json:"finalized_epoch"`
+	FinalizationDelay uint64                      `json:"finalization_delay"`
+	IsFinalizing      bool                        `json:"is_finalizing"`
+	Incidents         []*FinalityPageDataIncident `json:"incidents"`
+	IncidentCount     uint64                      `json:"incident_count"`
+
+	TotalPages       uint64 `json:"total_pages"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+}
+
+type FinalityPageDataIncident struct {
+	StartEpoch        uint64    `json:"start_epoch"`
+	StartTime         time.Time `json:"start_time"`
+	EndEpoch          uint64    `json:"end_epoch"`
+	EndTime           time.Time `json:"end_time"`
+	Ongoing           bool      `json:"ongoing"`
+	Duration          uint64    `json:"duration"`
+	FinalizedEpoch    uint64    `json:"finalized_epoch"`
+	MaxDistance       uint32    `json:"max_distance"`
+	LeakEpochs        uint32    `json:"leak_epochs"`
+	ParticipationAvg  float64   `json:"participation_avg"`
+	ParticipationMin  float64   `json:"participation_min"`
+	MaxForks          uint32    `json:"max_forks"`
+	OfflineValidators uint64    `json:"offline_validators"`
+	LeakEstimate      uint64    `json:"leak_estimate"`
+}
+
+// FinalityIncidentPageData is a struct to hold info for the finality incident details page
+type FinalityIncidentPageData struct {
+	Incident            *FinalityPageDataIncident         `json:"incident"`
+	LastEpoch           uint64                            `json:"last_epoch"`
+	EndFinalizedEpoch   uint64                            `json:"end_finalized_epoch"`
+	ParticipationEpochs uint32                            `json:"participation_epochs"`
+	Forks               []*FinalityIncidentPageDataFork   `json:"forks"`
+	OfflineBalance      uint64                            `json:"offline_balance"`
+	ValidatorLeak       uint64                            `json:"validator_leak"`
+	ValidatorBalance    uint64                            `json:"validator_balance"`
+	Entities            []*FinalityIncidentPageDataEntity `json:"entities"`
+}
+
+type FinalityIncidentPageDataFork struct {
+	HeadSlot uint64   `json:"head_slot"`
+	HeadRoot []byte   `json:"head_root"`
+	Clients  []string `json:"clients"`
+}
+
+type FinalityIncidentPageDataEntity struct {
+	Name              string `json:"name"`
+	OfflineValidators uint64 `json:"offline_validators"`
+	OfflineBalance    uint64 `json:"offline_balance"`
+	LeakEstimate      uint64 `json:"leak_estimate"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// MevAnalyticsPageData is a struct to hold info for the mev analytics page
+type MevAnalyticsPageData struct {
+	Range        string                 `json:"range"`
+	Ranges       []*ChartsPageDataRange `json:"ranges"`
+	FirstSlot    uint64                 `json:"first_slot"`
+	LastSlot     uint64                 `json:"last_slot"`
+	FirstTime    time.Time              `json:"first_time"`
+	LastTime     time.Time              `json:"last_time"`
+	PrunedBefore uint64                 `json:"pruned_before"`
+
+	BlockCount      uint64  `json:"block_count"`
+	RelayBlockCount uint64  `json:"relay_block_count"`
+	RelayPercent    float64 `json:"relay_percent"`
+	LocalBlockCount uint64  `json:"local_block_count"`
+	LocalPercent    float64 `json:"local_percent"`
+	PayloadCount    uint64  `json:"payload_count"`
+	MissedCount     uint64  `json:"missed_count"`
+	MissedPercent   float64 `json:"missed_percent"`
+	ReplacedCount   uint64  `json:"replaced_count"`
+	TotalValue      uint64  `json:"total_value"`
+	AvgValue        uint64  `json:"avg_value"`
+	MedianValue     uint64  `json:"median_value"`
+
+	Builders     []*MevAnalyticsPageDataBuilder `json:"builders"`
+	BuilderCount uint64                         `json:"builder_count"`
+	Relays       []*MevAnalyticsPageDataRelay   `json:"relays"`
+	Entities     []*MevAnalyticsPageDataEntity  `json:"entities"`
+	EntityCount  uint64                         `json:"entity_count"`
+	Charts       []*ChartsPageDataChart         `json:"charts"`
+}
+
+type MevAnalyticsPageDataBuilder struct {
+	Pubkey     []byte  `json:"pubkey"`
+	BlockCount uint64  `json:"block_count"`
+	Share      float64 `json:"share"`
+	TotalValue uint64  `json:"total_value"`
+	AvgValue   uint64  `json:"avg_value"`
+	LastSlot   uint64  `json:"last_slot"`
+}
+
+type MevAnalyticsPageDataRelay struct {
+	Index      uint64  `json:"index"`
+	Name       string  `json:"name"`
+	BlockCount uint64  `json:"block_count"`
+	Share      float64 `json:"share"`
+	TotalValue uint64  `json:"total_value"`
+	AvgValue   uint64  `json:"avg_value"`
+}
+
+type MevAnalyticsPageDataEntity struct {
+	Name       string  `json:"name"`
+	BlockCount uint64  `json:"block_count"`
+	MevCount   uint64  `json:"mev_count"`
+	MevPercent float64 `json:"mev_percent"`
+	MevValue   uint64  `json:"mev_value"`
+}
+
+// MevBuilderPageData is a struct to hold info for the mev builder page
+type MevBuilderPageData struct {
+	Pubkey    []byte                 `json:"pubkey"`
+	Range     string                 `json:"range"`
+	Ranges    []*ChartsPageDataRange `json:"ranges"`
+	FirstTime time.Time              `json:"first_time"`
+	LastTime  time.Time              `json:"last_time"`
+
+	BlockCount    uint64  `json:"block_count"`
+	Share         float64 `json:"share"`
+	PayloadCount  uint64  `json:"payload_count"`
+	MissedCount   uint64  `json:"missed_count"`
+	ReplacedCount uint64  `json:"replaced_count"`
+	TotalValue    uint64  `json:"total_value"`
+	AvgValue      uint64  `json:"avg_value"`
+	MedianValue   uint64  `json:"median_value"`
+
+	Relays     []*MevAnalyticsPageDataRelay `json:"relays"`
+	Charts     []*ChartsPageDataChart       `json:"charts"`
+	Blocks     []*MevBuilderPageDataBlock   `json:"blocks"`
+	TotalCount uint64                       `json:"total_count"`
+}
+
+type MevBuilderPageDataBlock struct {
+	Slot          uint64    `json:"slot"`
+	Time          time.Time `json:"time"`
+	BlockHash     []byte    `json:"block_hash"`
+	BlockNumber   uint64    `json:"block_number"`
+	ProposerIndex uint64    `json:"proposer_index"`
+	ProposerName  string    `json:"proposer_name"`
+	Proposed      uint8     `json:"proposed"`
+	TxCount       uint64    `json:"tx_count"`
+	BlockValue    uint64    `json:"block_value"`
+}
@@ -11,6 +11,7 @@ type MevBlocksPageData struct {
 	FilterMinIndex      uint64                    `json:"filter_mini"`
 	FilterMaxIndex      uint64                    `json:"filter_maxi"`
 	FilterValidatorName string                    `json:"filter_vname"`
+	FilterBuilder       string                    `json:"filter_builder"`
 	FilterRelays        map[uint8]bool            `json:"filter_relays"`
 	FilterRelayOpts     []*MevBlocksPageDataRelay `json:"filter_relay_opts"`
 	FilterProposed      map[uint8]bool            `json:"filter_proposed"`
@@ -32,6 +33,8 @@ type MevBlocksPageData struct {
 	PrevPageLink  string `json:"prev_page_link"`
 	NextPageLink  string `json:"next_page_link"`
 	LastPageLink  string `json:"last_page_link"`
+
+	PrunedBefore uint64 `json:"pruned_before"`
 }
 
 type MevBlocksPageDataBlock struct {
@@ -0,0 +1,13 @@
+package models
+
+// NetworksPageData is a struct to hold info for the network selection page
+type NetworksPageData struct {
+	Networks []*NetworksPageDataNetwork `json:"networks"`
+}
+
+type NetworksPageDataNetwork struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Url         string `json:"url"`
+	Online      bool   `json:"online"`
+}
@@ -46,3 +46,30 @@ type SearchAheadValidatorNameResult struct {
 	Name  string `json:"name,omitempty"`
 	Count string `json:"count,omitempty"`
 }
+
+// SearchResultPageData is a struct to hold info for the ranked search result page
+type SearchResultPageData struct {
+	Query       string                        `json:"query"`
+	Results     []*SearchResultPageDataResult `json:"results"`
+	ResultCount uint64                        `json:"result_count"`
+}
+
+type SearchResultPageDataResult struct {
+	Type        string `json:"type"`
+	TypeLabel   string `json:"type_label"`
+	Score       int    `json:"score"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Link        string `json:"link"`
+	Orphaned    bool   `json:"orphaned"`
+}
+
+// SearchAheadResult is a struct to hold the unified search ahead results
+type SearchAheadResult struct {
+	Type        string `json:"type"`
+	TypeLabel   string `json:"type_label"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link"`
+	Orphaned    bool   `json:"orphaned,omitempty"`
+}
@@ -6,6 +6,11 @@ import (
 	"github.com/ethpandaops/dora/types"
 )
 
+// SlotNotFoundPageData is a struct to hold info for the slot not found page
+type SlotNotFoundPageData struct {
+	NotIndexedBefore uint64 `json:"not_indexed_before"`
+}
+
 // SlotPageData is a struct to hold info for the slot details page
 type SlotPageData struct {
 	Slot                   uint64                `json:"slot"`
@@ -19,6 +24,7 @@ type SlotPageData struct {
 	Future                 bool                  `json:"future"`
 	Proposer               uint64                `json:"proposer"`
 	ProposerName           string                `json:"proposer_name"`
+	ProposerPruned         bool                  `json:"proposer_pruned"`
 	Block                  *SlotPageBlockData    `json:"block"`
 	Badges                 []*SlotPageBlockBadge `json:"badges"`
 }
@@ -65,6 +71,8 @@ type SlotPageBlockData struct {
 	DepositRequestsCount       uint64                 `json:"deposit_receipts_count"`
 	WithdrawalRequestsCount    uint64                 `json:"withdrawal_requests_count"`
 	ConsolidationRequestsCount uint64                 `json:"consolidation_requests_count"`
+	TimingsCount               uint64                 `json:"timings_count"`
+	BlobsPruned                bool                   `json:"blobs_pruned"`
 
 	ExecutionData         *SlotPageExecutionData          `json:"execution_data"`
 	Attestations          []*SlotPageAttestation          `json:"attestations"`           // Attestations included in this block
@@ -79,6 +87,15 @@ type SlotPageBlockData struct {
 	DepositRequests       []*SlotPageDepositRequest       `json:"deposit_receipts"`       // DepositRequests included in this block
 	WithdrawalRequests    []*SlotPageWithdrawalRequest    `json:"withdrawal_requests"`    // WithdrawalRequests included in this block
 	ConsolidationRequests []*SlotPageConsolidationRequest `json:"consolidation_requests"` // ConsolidationRequests included in this block
+	Timings               []*SlotPageBlockTiming          `json:"timings"`                // Arrival times of this block on the connected clients
+}
+
+type SlotPageBlockTiming struct {
+	ClientName  string `json:"client_name"`
+	SeenDelay   int64  `json:"seen_delay"`
+	HasHead     bool   `json:"has_head"`
+	HeadDelay   int64  `json:"head_delay"`
+	SwitchDelay int64  `json:"switch_delay"`
 }
 
 type SlotPageExecutionData struct {
@@ -215,6 +232,28 @@ type SlotPageTransaction struct {
 	FuncName      string  `json:"func_name"`
 	FuncSig       string  `json:"func_sig"`
 	Type          uint64  `json:"type"`
+
+	FuncArgs    []*SlotPageTransactionArg `json:"func_args"`
+	Logs        []*SlotPageTransactionLog `json:"logs"`
+	LogsLoaded  bool                      `json:"logs_loaded"`
+	ReceiptFail bool                      `json:"receipt_fail"`
+}
+
+type SlotPageTransactionArg struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Indexed bool   `json:"indexed"`
+}
+
+type SlotPageTransactionLog struct {
+	Index     uint64                    `json:"index"`
+	Address   []byte                    `json:"address"`
+	Name      string                    `json:"name"`
+	Signature string                    `json:"signature"`
+	Topics    [][]byte                  `json:"topics"`
+	Data      []byte                    `json:"data"`
+	Args      []*SlotPageTransactionArg `json:"args"`
 }
 
 type SlotPageDepositRequest struct {
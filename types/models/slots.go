@@ -22,6 +22,9 @@ type SlotsPageData struct {
 	NextPageIndex    uint64 `json:"next_page_index"`
 	NextPageSlot     uint64 `json:"next_page_slot"`
 	LastPageSlot     uint64 `json:"last_page_slot"`
+
+	NotIndexedBefore        uint64 `json:"not_indexed_before"`
+	PrunedMissedSlotsBefore uint64 `json:"pruned_missed_slots_before"`
 }
 
 type SlotsPageDataSlot struct {
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// SlotsLatePageData is a struct to hold info for the late blocks page
+type SlotsLatePageData struct {
+	MinDelay    int64                      `json:"min_delay"`
+	StatsPeriod string                     `json:"stats_period"`
+	Clients     []*SlotsLatePageDataClient `json:"clients"`
+	Blocks      []*SlotsLatePageDataBlock  `json:"blocks"`
+	BlockCount  uint64                     `json:"block_count"`
+
+	TotalPages       uint64 `json:"total_pages"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+}
+
+type SlotsLatePageDataClient struct {
+	Name           string  `json:"name"`
+	BlockCount     uint64  `json:"block_count"`
+	LateCount      uint64  `json:"late_count"`
+	LatePercent    float64 `json:"late_percent"`
+	AvgSeenDelay   int64   `json:"avg_seen_delay"`
+	MaxSeenDelay   int64   `json:"max_seen_delay"`
+	HeadCount      uint64  `json:"head_count"`
+	AvgSwitchDelay int64   `json:"avg_switch_delay"`
+}
+
+type SlotsLatePageDataBlock struct {
+	Slot         uint64    `json:"slot"`
+	Epoch        uint64    `json:"epoch"`
+	Ts           time.Time `json:"ts"`
+	BlockRoot    []byte    `json:"block_root"`
+	Status       uint8     `json:"status"`
+	Proposer     uint64    `json:"proposer"`
+	ProposerName string    `json:"proposer_name"`
+	ClientCount  uint64    `json:"client_count"`
+	MinSeenDelay int64     `json:"min_seen_delay"`
+	AvgSeenDelay int64     `json:"avg_seen_delay"`
+	MaxSeenDelay int64     `json:"max_seen_delay"`
+}
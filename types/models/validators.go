@@ -9,6 +9,7 @@ type ValidatorsPageData struct {
 	FilterPubKey     string                           `json:"filter_pubkey"`
 	FilterIndex      string                           `json:"filter_index"`
 	FilterName       string                           `json:"filter_name"`
+	FilterWithdrawal string                           `json:"filter_withdrawal"`
 	FilterStatus     string                           `json:"filter_status"`
 	FilterStatusOpts []ValidatorsPageDataStatusOption `json:"filter_status_opts"`
 
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// consensus client types as stored in the slots table (0 = unknown)
+const (
+	ClClientUnknown uint8 = iota
+	ClClientLighthouse
+	ClClientPrysm
+	ClClientTeku
+	ClClientNimbus
+	ClClientLodestar
+	ClClientGrandine
+)
+
+// execution client types as stored in the slots table (0 = unknown)
+const (
+	ElClientUnknown uint8 = iota
+	ElClientGeth
+	ElClientNethermind
+	ElClientBesu
+	ElClientErigon
+	ElClientReth
+	ElClientEthereumJS
+	ElClientNimbus
+)
+
+var ConsensusClientNames = []string{"Unknown", "Lighthouse", "Prysm", "Teku", "Nimbus", "Lodestar", "Grandine"}
+var ExecutionClientNames = []string{"Unknown", "Geth", "Nethermind", "Besu", "Erigon", "Reth", "EthereumJS", "Nimbus"}
+
+// client codes of the graffiti client version convention: <EL code>[<EL commit>]<CL code>[<CL commit>]
+var graffitiClientCodeRE = regexp.MustCompile(`(?:^|[^A-Za-z0-9])(GE|NM|BU|EG|RH|EJ|NB)(?:[0-9a-f]{4}|[0-9a-f]{8})?(LH|PM|TK|NB|LS|GD)(?:[0-9a-f]{4}|[0-9a-f]{8})?(?:$|[^A-Za-z0-9])`)
+
+var graffitiClClientCodes = map[string]uint8{
+	"LH": ClClientLighthouse,
+	"PM": ClClientPrysm,
+	"TK": ClClientTeku,
+	"NB": ClClientNimbus,
+	"LS": ClClientLodestar,
+	"GD": ClClientGrandine,
+}
+
+var graffitiElClientCodes = map[string]uint8{
+	"GE": ElClientGeth,
+	"NM": ElClientNethermind,
+	"BU": ElClientBesu,
+	"EG": ElClientErigon,
+	"RH": ElClientReth,
+	"EJ": ElClientEthereumJS,
+	"NB": ElClientNimbus,
+}
+
+type clientNamePattern struct {
+	pattern *regexp.Regexp
+	client  uint8
+}
+
+var clClientNamePatterns = []clientNamePattern{
+	{regexp.MustCompile(`(?i)lighthouse`), ClClientLighthouse},
+	{regexp.MustCompile(`(?i)prysm`), ClClientPrysm},
+	{regexp.MustCompile(`(?i)teku`), ClClientTeku},
+	{regexp.MustCompile(`(?i)nimbus`), ClClientNimbus},
+	{regexp.MustCompile(`(?i)lodestar`), ClClientLodestar},
+	{regexp.MustCompile(`(?i)grandine`), ClClientGrandine},
+}
+
+// the patterns are checked in order and the first match wins, so graffitis naming several execution clients
+// (e.g. "nethermind/geth fallback") are attributed to the first client in this list
+var elClientNamePatterns = []clientNamePattern{
+	{regexp.MustCompile(`(?i)nethermind`), ElClientNethermind},
+	{regexp.MustCompile(`(?i)\bgeth\b|go-ethereum`), ElClientGeth},
+	{regexp.MustCompile(`(?i)\bbesu\b`), ElClientBesu},
+	{regexp.MustCompile(`(?i)erigon`), ElClientErigon},
+	{regexp.MustCompile(`(?i)\breth\b`), ElClientReth},
+	{regexp.MustCompile(`(?i)ethereumjs`), ElClientEthereumJS},
+	{regexp.MustCompile(`(?i)nimbus-eth1`), ElClientNimbus},
+}
+
+// DetectProposerClients infers the consensus & execution client of a block proposer from the block graffiti
+// and the execution payload extra data.
+// the client version codes in the graffiti take precedence over client names, the extra data is only used for
+// the execution client as it is usually overwritten by external block builders.
+func DetectProposerClients(graffiti string, extraData string) (clClient uint8, elClient uint8) {
+	if match := graffitiClientCodeRE.FindStringSubmatch(graffiti); match != nil {
+		return graffitiClClientCodes[match[2]], graffitiElClientCodes[match[1]]
+	}
+
+	clClient = matchClientName(clClientNamePatterns, graffiti)
+	elClient = matchClientName(elClientNamePatterns, graffiti)
+	if elClient == ElClientUnknown {
+		elClient = matchClientName(elClientNamePatterns, extraData)
+	}
+
+	return clClient, elClient
+}
+
+func matchClientName(patterns []clientNamePattern, text string) uint8 {
+	if strings.TrimSpace(text) == "" {
+		return 0
+	}
+
+	for _, pattern := range patterns {
+		if pattern.pattern.MatchString(text) {
+			return pattern.client
+		}
+	}
+
+	return 0
+}
+
+// GetConsensusClientName returns the display name of a consensus client type.
+func GetConsensusClientName(client uint8) string {
+	if int(client) >= len(ConsensusClientNames) {
+		return ConsensusClientNames[ClClientUnknown]
+	}
+	return ConsensusClientNames[client]
+}
+
+// GetExecutionClientName returns the display name of an execution client type.
+func GetExecutionClientName(client uint8) string {
+	if int(client) >= len(ExecutionClientNames) {
+		return ExecutionClientNames[ElClientUnknown]
+	}
+	return ExecutionClientNames[client]
+}
@@ -0,0 +1,39 @@
+package utils
+
+import "testing"
+
+func TestDetectProposerClients(t *testing.T) {
+	tests := []struct {
+		graffiti  string
+		extraData string
+		clClient  uint8
+		elClient  uint8
+	}{
+		{"", "", ClClientUnknown, ElClientUnknown},
+		{"GEa1b2LHc3d4", "", ClClientLighthouse, ElClientGeth},
+		{"pool NMTK", "", ClClientTeku, ElClientNethermind},
+		{"NBab12NBcd34 nimbus", "reth/v1.0", ClClientNimbus, ElClientNimbus},
+		{"Lighthouse/v5.1.0", "", ClClientLighthouse, ElClientUnknown},
+		{"Lighthouse/v5.1.0", "reth/v1.0.0", ClClientLighthouse, ElClientReth},
+		{"prysm + besu", "geth", ClClientPrysm, ElClientBesu},
+		{"nethermind/geth fallback", "", ClClientUnknown, ElClientNethermind},
+		{"together", "", ClClientUnknown, ElClientUnknown},
+		{"", "Nethermind v1.25", ClClientUnknown, ElClientNethermind},
+	}
+
+	for _, test := range tests {
+		clClient, elClient := DetectProposerClients(test.graffiti, test.extraData)
+		if clClient != test.clClient || elClient != test.elClient {
+			t.Errorf("clients of %q / %q: expected %v/%v, got %v/%v", test.graffiti, test.extraData, test.clClient, test.elClient, clClient, elClient)
+		}
+	}
+}
+
+func TestGetClientNames(t *testing.T) {
+	if name := GetConsensusClientName(ClClientTeku); name != "Teku" {
+		t.Errorf("expected Teku, got %v", name)
+	}
+	if name := GetExecutionClientName(200); name != "Unknown" {
+		t.Errorf("expected Unknown for out of range client types, got %v", name)
+	}
+}
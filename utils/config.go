@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
@@ -24,6 +27,37 @@ func ReadConfig(cfg *types.Config, path string) error {
 
 	readConfigEnv(cfg)
 
+	if len(cfg.Networks) > 0 {
+		// multi network mode, each network is configured in its own config file
+		for idx, network := range cfg.Networks {
+			if network.Name == "" {
+				return fmt.Errorf("missing name for network %v", idx+1)
+			}
+			if network.ConfigPath == "" {
+				return fmt.Errorf("missing config path for network %v", network.Name)
+			}
+			if path != "" && !filepath.IsAbs(network.ConfigPath) {
+				// relative network config paths are relative to the gateway config file
+				cfg.Networks[idx].ConfigPath = filepath.Join(filepath.Dir(path), network.ConfigPath)
+			}
+			if network.DisplayName == "" {
+				cfg.Networks[idx].DisplayName = network.Name
+			}
+			if network.PathPrefix == "" && network.Host == "" {
+				cfg.Networks[idx].PathPrefix = "/" + network.Name
+			} else if network.PathPrefix != "" && !strings.HasPrefix(network.PathPrefix, "/") {
+				cfg.Networks[idx].PathPrefix = "/" + network.PathPrefix
+			}
+		}
+		return nil
+	}
+
+	// frontend base path, normalized to a leading slash without trailing slash
+	cfg.Frontend.BasePath = strings.TrimSuffix(cfg.Frontend.BasePath, "/")
+	if cfg.Frontend.BasePath != "" && !strings.HasPrefix(cfg.Frontend.BasePath, "/") {
+		cfg.Frontend.BasePath = "/" + cfg.Frontend.BasePath
+	}
+
 	// default validator names
 	if cfg.Frontend.ValidatorNamesYaml == "" && cfg.Frontend.ValidatorNamesInventory == "" {
 		switch cfg.Chain.Name {
@@ -45,15 +79,10 @@ func ReadConfig(cfg *types.Config, path string) error {
 	}
 	for idx, endpoint := range cfg.BeaconApi.Endpoints {
 		if endpoint.Name == "" {
-			url, _ := url.Parse(endpoint.Url)
-			if url != nil {
-				cfg.BeaconApi.Endpoints[idx].Name = url.Hostname()
-			} else {
-				cfg.BeaconApi.Endpoints[idx].Name = fmt.Sprintf("endpoint-%v", idx+1)
-			}
+			cfg.BeaconApi.Endpoints[idx].Name = GetDefaultEndpointName(endpoint.Url, idx)
 		}
 	}
-	if len(cfg.BeaconApi.Endpoints) == 0 {
+	if len(cfg.BeaconApi.Endpoints) == 0 && cfg.EndpointsFile.Path == "" && len(cfg.EndpointDiscovery.Sources) == 0 {
 		return fmt.Errorf("missing beacon node endpoints (need at least 1 endpoint to run the explorer)")
 	}
 
@@ -68,18 +97,68 @@ func ReadConfig(cfg *types.Config, path string) error {
 	}
 	for idx, endpoint := range cfg.ExecutionApi.Endpoints {
 		if endpoint.Name == "" {
-			url, _ := url.Parse(endpoint.Url)
-			if url != nil {
-				cfg.ExecutionApi.Endpoints[idx].Name = url.Hostname()
-			} else {
-				cfg.ExecutionApi.Endpoints[idx].Name = fmt.Sprintf("endpoint-%v", idx+1)
+			cfg.ExecutionApi.Endpoints[idx].Name = GetDefaultEndpointName(endpoint.Url, idx)
+		}
+	}
+
+	// endpoints file
+	if cfg.EndpointsFile.Path != "" && cfg.EndpointsFile.WatchInterval == 0 {
+		cfg.EndpointsFile.WatchInterval = 30 * time.Second
+	}
+
+	// endpoint discovery
+	if cfg.EndpointDiscovery.RefreshInterval == 0 {
+		cfg.EndpointDiscovery.RefreshInterval = 1 * time.Minute
+	}
+	for idx, source := range cfg.EndpointDiscovery.Sources {
+		switch source.Type {
+		case "inventory":
+			if source.Url == "" {
+				return fmt.Errorf("missing url for endpoint discovery source %v", idx+1)
+			}
+		case "srv":
+			if source.BeaconRecord == "" && source.ExecutionRecord == "" {
+				return fmt.Errorf("missing dns records for endpoint discovery source %v", idx+1)
+			}
+			if source.Scheme == "" {
+				cfg.EndpointDiscovery.Sources[idx].Scheme = "http"
 			}
+		case "directory":
+			if source.Path == "" {
+				return fmt.Errorf("missing path for endpoint discovery source %v", idx+1)
+			}
+		default:
+			return fmt.Errorf("unknown type '%v' for endpoint discovery source %v", source.Type, idx+1)
+		}
+
+		if source.Name == "" {
+			cfg.EndpointDiscovery.Sources[idx].Name = fmt.Sprintf("%v-%v", source.Type, idx+1)
 		}
 	}
 
+	// admin api
+	if cfg.AdminApi.Enabled && cfg.AdminApi.AuthToken == "" {
+		return fmt.Errorf("missing auth token for admin api")
+	}
+
 	return nil
 }
 
+// GetBasePath returns the path prefix the frontend is served under, or an empty string when served from the root.
+func GetBasePath() string {
+	return Config.Frontend.BasePath
+}
+
+// GetDefaultEndpointName returns the name for an endpoint without configured name.
+func GetDefaultEndpointName(endpointUrl string, idx int) string {
+	url, _ := url.Parse(endpointUrl)
+	if url != nil {
+		return url.Hostname()
+	}
+
+	return fmt.Sprintf("endpoint-%v", idx+1)
+}
+
 func readConfigFile(cfg *types.Config, path string) error {
 	if path == "" {
 		return yaml.Unmarshal([]byte(config.DefaultConfigYml), cfg)
@@ -23,6 +23,7 @@ func GetTemplateFuncs() template.FuncMap {
 
 	customFuncs := template.FuncMap{
 		"includeHTML": IncludeHTML,
+		"basePath":    GetBasePath,
 		"html":        func(x string) template.HTML { return template.HTML(x) },
 		"bigIntCmp":   func(i *big.Int, j int) int { return i.Cmp(big.NewInt(int64(j))) },
 		"mod":         func(i, j int) bool { return i%j == 0 },